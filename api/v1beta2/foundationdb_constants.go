@@ -32,4 +32,8 @@ const (
 
 	// NoneFaultDomainKey represents the none fault domain, where every Pod is a fault domain.
 	NoneFaultDomainKey = "foundationdb.org/none"
+
+	// BackupFinalizer is the finalizer the operator adds to backups to stop the backup and optionally delete the
+	// backup data before the backup resource is removed.
+	BackupFinalizer = "foundationdb.org/backup-cleanup"
 )
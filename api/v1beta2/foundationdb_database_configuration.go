@@ -53,6 +53,28 @@ type DatabaseConfiguration struct {
 	// +kubebuilder:validation:MaxItems=1024
 	ExcludedServers []ExcludedServers `json:"excluded_servers,omitempty"`
 
+	// PerpetualStorageWiggle defines how many storage servers per zone the perpetual storage wiggle recreates at the
+	// same time, a value of 0 disables the perpetual storage wiggle. If this is unset, the operator will not change
+	// the setting of the database. This requires FoundationDB 7.0 or newer.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	PerpetualStorageWiggle *int `json:"perpetual_storage_wiggle,omitempty"`
+
+	// PerpetualStorageWiggleLocality limits the perpetual storage wiggle to the storage servers that match this
+	// locality, in the format `<locality key>:<locality value>`. A value of 0 means that all storage servers are
+	// wiggled. If this is unset, the operator will not change the setting of the database. This requires
+	// FoundationDB 7.0 or newer.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=256
+	PerpetualStorageWiggleLocality *string `json:"perpetual_storage_wiggle_locality,omitempty"`
+
+	// StorageMigrationType defines how storage servers with a different storage engine than the configured
+	// storage engine are migrated. If this is unset, the operator will not change the setting of the database.
+	// This requires FoundationDB 7.0 or newer.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=disabled;aggressive;gradual
+	StorageMigrationType *StorageMigrationType `json:"storage_migration_type,omitempty"`
+
 	// RoleCounts defines how many processes the database should recruit for
 	// each role.
 	RoleCounts `json:""`
@@ -561,6 +583,24 @@ func (configuration DatabaseConfiguration) GetNextConfigurationChange(finalConfi
 	return finalConfiguration
 }
 
+// maxConfigurationChanges defines the upper limit of configuration changes that GetConfigurationChanges returns.
+const maxConfigurationChanges = 100
+
+// GetConfigurationChanges produces the sequence of configuration changes that
+// transforms this configuration into the final configuration, by applying
+// GetNextConfigurationChange repeatedly. The last entry is the final
+// configuration. If the configurations are equal no changes will be returned.
+func (configuration DatabaseConfiguration) GetConfigurationChanges(finalConfiguration DatabaseConfiguration) []DatabaseConfiguration {
+	changes := make([]DatabaseConfiguration, 0)
+	current := configuration
+	for len(changes) < maxConfigurationChanges && !reflect.DeepEqual(current, finalConfiguration) {
+		current = current.GetNextConfigurationChange(finalConfiguration)
+		changes = append(changes, current)
+	}
+
+	return changes
+}
+
 func (configuration DatabaseConfiguration) getRegionPriorities() map[string]int {
 	priorities := make(map[string]int, len(configuration.Regions))
 
@@ -662,6 +702,20 @@ func (configuration DatabaseConfiguration) GetConfigurationString(version string
 
 	configurationString += " regions=" + regionString
 
+	if fdbVersion.SupportsStorageMigrationConfiguration() {
+		if configuration.StorageMigrationType != nil {
+			configurationString += fmt.Sprintf(" storage_migration_type=%s", *configuration.StorageMigrationType)
+		}
+
+		if configuration.PerpetualStorageWiggle != nil {
+			configurationString += fmt.Sprintf(" perpetual_storage_wiggle=%d", *configuration.PerpetualStorageWiggle)
+		}
+
+		if configuration.PerpetualStorageWiggleLocality != nil {
+			configurationString += fmt.Sprintf(" perpetual_storage_wiggle_locality=%s", *configuration.PerpetualStorageWiggleLocality)
+		}
+	}
+
 	return configurationString, nil
 }
 
@@ -757,6 +811,8 @@ const (
 	StorageEngineSSD2 StorageEngine = "ssd-2"
 	// StorageEngineMemory defines the storage engine memory.
 	StorageEngineMemory StorageEngine = "memory"
+	// StorageEngineMemory1 defines the storage engine memory-1.
+	StorageEngineMemory1 StorageEngine = "memory-1"
 	// StorageEngineMemory2 defines the storage engine memory-2.
 	StorageEngineMemory2 StorageEngine = "memory-2"
 	// StorageEngineRocksDbExperimental defines the storage engine ssd-rocksdb-experimental.
@@ -767,6 +823,36 @@ const (
 	StorageEngineShardedRocksDB StorageEngine = "ssd-sharded-rocksdb"
 	// StorageEngineRedwood1Experimental defines the storage engine ssd-redwood-1-experimental.
 	StorageEngineRedwood1Experimental StorageEngine = "ssd-redwood-1-experimental"
+	// StorageEngineUnknown is reported for storage servers that don't report their storage engine.
+	StorageEngineUnknown StorageEngine = "unknown"
+)
+
+// GetStorageServerStorageEngine returns the storage engine that the storage servers report in their storage metadata
+// for this configured storage engine. The storage servers only report the type of their key value store, e.g.
+// memory for memory-2 and ssd-2 for ssd.
+func (storageEngine StorageEngine) GetStorageServerStorageEngine() StorageEngine {
+	switch storageEngine {
+	case StorageEngineSSD:
+		return StorageEngineSSD2
+	case StorageEngineMemory, StorageEngineMemory1, StorageEngineMemory2:
+		return StorageEngineMemory
+	}
+
+	return storageEngine
+}
+
+// StorageMigrationType defines how FoundationDB migrates storage servers that use a different storage engine than
+// the configured storage engine.
+type StorageMigrationType string
+
+const (
+	// StorageMigrationTypeDisabled disables the migration of storage servers.
+	StorageMigrationTypeDisabled StorageMigrationType = "disabled"
+	// StorageMigrationTypeAggressive replaces all storage servers with a different storage engine directly.
+	StorageMigrationTypeAggressive StorageMigrationType = "aggressive"
+	// StorageMigrationTypeGradual replaces the storage servers with a different storage engine with the perpetual
+	// storage wiggle.
+	StorageMigrationTypeGradual StorageMigrationType = "gradual"
 )
 
 // RoleCounts represents the roles whose counts can be customized.
@@ -29,6 +29,10 @@ const (
 	// config map.
 	LastConfigMapKey = "foundationdb.org/last-applied-config-map"
 
+	// LastBlobCredentialsKey provides the annotation name we use to store the
+	// hash of the blob credentials secrets of a backup.
+	LastBlobCredentialsKey = "foundationdb.org/last-applied-blob-credentials"
+
 	// OutdatedConfigMapKey provides the annotation name we use to store the
 	// timestamp when we saw an outdated config map.
 	OutdatedConfigMapKey = "foundationdb.org/outdated-config-map-seen"
@@ -40,6 +44,22 @@ const (
 	// BackupDeploymentPodLabel provides the label to select Pods for a specific Backup deployment.
 	BackupDeploymentPodLabel = "foundationdb.org/deployment-name"
 
+	// DisasterRecoveryDeploymentLabel provides the label we use to connect DR
+	// agent deployments to a disaster recovery resource.
+	DisasterRecoveryDeploymentLabel = "foundationdb.org/dr-for"
+
+	// RestoreValidationLabel provides the label we use to connect the
+	// resources of a restore validation to a backup.
+	RestoreValidationLabel = "foundationdb.org/restore-validation-for"
+
+	// ApprovedConfigurationPlanAnnotation is an annotation key that contains the
+	// hash of the approved configuration plan of a cluster.
+	ApprovedConfigurationPlanAnnotation = "foundationdb.org/approved-configuration-plan"
+
+	// SkipBackupCleanupAnnotation is an annotation key that can be set to "true" on a backup to remove the backup
+	// finalizer without stopping the backup or deleting the backup data, e.g. if the cluster is unavailable.
+	SkipBackupCleanupAnnotation = "foundationdb.org/skip-backup-cleanup"
+
 	// PublicIPSourceAnnotation is an annotation key that specifies where a pod
 	// gets its public IP from.
 	PublicIPSourceAnnotation = "foundationdb.org/public-ip-source"
@@ -64,6 +84,17 @@ const (
 	// NodeSelectorNoScheduleLabel is a label used when adding node selectors to block scheduling.
 	NodeSelectorNoScheduleLabel = "foundationdb.org/no-schedule-allowed"
 
+	// FDBDistributionKeyLabel represents the label that is used to represent the logical fault domain of a process
+	// group, if the fault domain distribution is enabled.
+	FDBDistributionKeyLabel = "foundationdb.org/distribution-key"
+
+	// SuspendedLabel is a label that is added to the resources of suspended process groups.
+	SuspendedLabel = "foundationdb.org/suspended"
+
+	// SuspensionExpiryAnnotation defines the annotation that contains the time after which the resources of a
+	// suspended process group will be removed.
+	SuspensionExpiryAnnotation = "foundationdb.org/suspension-expiry"
+
 	// FDBLocalityInstanceIDKey represents the key in the locality map that
 	// holds the instance ID.
 	FDBLocalityInstanceIDKey = "instance_id"
@@ -129,6 +129,48 @@ type FoundationDBStatusClusterInfo struct {
 
 	// Messages represents the possible messages that are part of the cluster information.
 	Messages []FoundationDBStatusMessage `json:"messages,omitempty"`
+
+	// StorageWiggler provides information about the progress of the perpetual storage wiggle.
+	StorageWiggler FoundationDBStatusStorageWiggler `json:"storage_wiggler,omitempty"`
+}
+
+// FoundationDBStatusStorageWiggler provides information about the progress of the perpetual storage wiggle.
+type FoundationDBStatusStorageWiggler struct {
+	// Primary provides the wiggle statistics of the primary region.
+	Primary FoundationDBStatusStorageWigglerStats `json:"primary,omitempty"`
+
+	// Remote provides the wiggle statistics of the remote region.
+	Remote FoundationDBStatusStorageWigglerStats `json:"remote,omitempty"`
+
+	// WiggleServerAddresses contains the addresses of the storage servers that are currently wiggled.
+	WiggleServerAddresses []string `json:"wiggle_server_addresses,omitempty"`
+}
+
+// FoundationDBStatusStorageWigglerStats provides the statistics of the perpetual storage wiggle for one region.
+type FoundationDBStatusStorageWigglerStats struct {
+	// FinishedRound is the number of rounds in which all storage servers were wiggled.
+	FinishedRound int `json:"finished_round,omitempty"`
+
+	// FinishedWiggle is the number of storage servers that were wiggled.
+	FinishedWiggle int `json:"finished_wiggle,omitempty"`
+
+	// LastRoundStartTimestamp is the unix timestamp when the last round was started.
+	LastRoundStartTimestamp float64 `json:"last_round_start_timestamp,omitempty"`
+
+	// LastRoundFinishTimestamp is the unix timestamp when the last round was finished.
+	LastRoundFinishTimestamp float64 `json:"last_round_finish_timestamp,omitempty"`
+
+	// LastWiggleStartTimestamp is the unix timestamp when the last wiggle of a storage server was started.
+	LastWiggleStartTimestamp float64 `json:"last_wiggle_start_timestamp,omitempty"`
+
+	// LastWiggleFinishTimestamp is the unix timestamp when the last wiggle of a storage server was finished.
+	LastWiggleFinishTimestamp float64 `json:"last_wiggle_finish_timestamp,omitempty"`
+
+	// SmoothedRoundSeconds is the smoothed duration of a round in seconds.
+	SmoothedRoundSeconds float64 `json:"smoothed_round_seconds,omitempty"`
+
+	// SmoothedWiggleSeconds is the smoothed duration of the wiggle of a storage server in seconds.
+	SmoothedWiggleSeconds float64 `json:"smoothed_wiggle_seconds,omitempty"`
 }
 
 // FaultTolerance provides information about the fault tolerance status
@@ -193,6 +235,14 @@ type FoundationDBStatusProcessRoleInfo struct {
 	StoredBytes int `json:"stored_bytes,omitempty"`
 	// ID represent the role ID.
 	ID string `json:"id,omitempty"`
+	// StorageMetadata provides the metadata of a storage server.
+	StorageMetadata FoundationDBStatusStorageMetadata `json:"storage_metadata,omitempty"`
+}
+
+// FoundationDBStatusStorageMetadata provides the metadata of a storage server.
+type FoundationDBStatusStorageMetadata struct {
+	// StorageEngine defines the storage engine the storage server uses.
+	StorageEngine StorageEngine `json:"storage_engine,omitempty"`
 }
 
 // FoundationDBStatusDataStatistics provides information about the data in
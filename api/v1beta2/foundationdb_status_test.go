@@ -28,6 +28,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("FoundationDBStatus", func() {
@@ -507,6 +508,7 @@ var _ = Describe("FoundationDBStatus", func() {
 	})
 
 	When("parsing the status json with a 7.1.0-rc1 cluster", func() {
+		storageMigrationTypeDisabled := StorageMigrationTypeDisabled
 		status := FoundationDBStatusClusterInfo{
 			Messages:                []FoundationDBStatusMessage{},
 			IncompatibleConnections: []string{},
@@ -519,6 +521,10 @@ var _ = Describe("FoundationDBStatus", func() {
 				ExcludedServers: make([]ExcludedServers, 0),
 				RoleCounts:      RoleCounts{Storage: 0, Logs: 3, Proxies: 3, CommitProxies: 2, GrvProxies: 1, Resolvers: 1, LogRouters: -1, RemoteLogs: -1},
 				VersionFlags:    VersionFlags{LogSpill: 2, LogVersion: 0},
+
+				PerpetualStorageWiggle:         pointer.Int(0),
+				PerpetualStorageWiggleLocality: pointer.String("0"),
+				StorageMigrationType:           &storageMigrationTypeDisabled,
 			},
 			Processes: map[ProcessGroupID]FoundationDBStatusProcessInfo{
 				"eb48ada3a682e86363f06aa89e1041fa": {
@@ -919,4 +925,39 @@ var _ = Describe("FoundationDBStatus", func() {
 			Expect(statusParsed.Cluster).To(Equal(status))
 		})
 	})
+
+	When("parsing the storage wiggler status", func() {
+		It("should parse the progress of the perpetual storage wiggle", func() {
+			statusParsed := FoundationDBStatusClusterInfo{}
+			err := json.Unmarshal([]byte(`{
+				"storage_wiggler": {
+					"primary": {
+						"finished_round": 2,
+						"finished_wiggle": 14,
+						"last_round_finish_timestamp": 1684569600.52,
+						"last_round_start_timestamp": 1684566000.13,
+						"last_wiggle_finish_timestamp": 1684569600.52,
+						"last_wiggle_start_timestamp": 1684569300.4,
+						"smoothed_round_seconds": 3600.39,
+						"smoothed_wiggle_seconds": 300.12
+					},
+					"wiggle_server_addresses": ["10.1.18.254:4501"]
+				}
+			}`), &statusParsed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusParsed.StorageWiggler).To(Equal(FoundationDBStatusStorageWiggler{
+				Primary: FoundationDBStatusStorageWigglerStats{
+					FinishedRound:             2,
+					FinishedWiggle:            14,
+					LastRoundStartTimestamp:   1684566000.13,
+					LastRoundFinishTimestamp:  1684569600.52,
+					LastWiggleStartTimestamp:  1684569300.4,
+					LastWiggleFinishTimestamp: 1684569600.52,
+					SmoothedRoundSeconds:      3600.39,
+					SmoothedWiggleSeconds:     300.12,
+				},
+				WiggleServerAddresses: []string{"10.1.18.254:4501"},
+			}))
+		})
+	})
 })
@@ -214,6 +214,12 @@ func (version Version) SupportsDNSInClusterFile() bool {
 	return version.IsAtLeast(Versions.SupportsDNSInClusterFile)
 }
 
+// SupportsStorageMigrationConfiguration returns true if the version of FDB supports the storage migration type and the
+// perpetual storage wiggle.
+func (version Version) SupportsStorageMigrationConfiguration() bool {
+	return version.IsAtLeast(Versions.SupportsStorageMigrationConfiguration)
+}
+
 // SupportsVersionChange returns true if the current version can be downgraded or upgraded to provided other version.
 func (version Version) SupportsVersionChange(other Version) bool {
 	return version.IsProtocolCompatible(other) || other.IsAtLeast(version)
@@ -233,18 +239,20 @@ var Versions = struct {
 	PreviousPatchVersion,
 	SupportsRecoveryState,
 	SupportsDNSInClusterFile,
+	SupportsStorageMigrationConfiguration,
 	Default Version
 }{
-	Default:                      Version{Major: 6, Minor: 2, Patch: 21},
-	IncompatibleVersion:          Version{Major: 6, Minor: 1, Patch: 0},
-	PreviousPatchVersion:         Version{Major: 6, Minor: 2, Patch: 20},
-	NextPatchVersion:             Version{Major: 6, Minor: 2, Patch: 22},
-	NextMajorVersion:             Version{Major: 7, Minor: 0, Patch: 0},
-	MinimumVersion:               Version{Major: 6, Minor: 2, Patch: 20},
-	SupportsRocksDBV1:            Version{Major: 7, Minor: 1, Patch: 0, ReleaseCandidate: 4},
-	SupportsIsPresent:            Version{Major: 7, Minor: 1, Patch: 4},
-	SupportsShardedRocksDB:       Version{Major: 7, Minor: 2, Patch: 0},
-	SupportsRedwood1Experimental: Version{Major: 7, Minor: 0, Patch: 0},
-	SupportsRecoveryState:        Version{Major: 7, Minor: 1, Patch: 22},
-	SupportsDNSInClusterFile:     Version{Major: 7, Minor: 0, Patch: 0},
+	Default:                               Version{Major: 6, Minor: 2, Patch: 21},
+	IncompatibleVersion:                   Version{Major: 6, Minor: 1, Patch: 0},
+	PreviousPatchVersion:                  Version{Major: 6, Minor: 2, Patch: 20},
+	NextPatchVersion:                      Version{Major: 6, Minor: 2, Patch: 22},
+	NextMajorVersion:                      Version{Major: 7, Minor: 0, Patch: 0},
+	MinimumVersion:                        Version{Major: 6, Minor: 2, Patch: 20},
+	SupportsRocksDBV1:                     Version{Major: 7, Minor: 1, Patch: 0, ReleaseCandidate: 4},
+	SupportsIsPresent:                     Version{Major: 7, Minor: 1, Patch: 4},
+	SupportsShardedRocksDB:                Version{Major: 7, Minor: 2, Patch: 0},
+	SupportsRedwood1Experimental:          Version{Major: 7, Minor: 0, Patch: 0},
+	SupportsRecoveryState:                 Version{Major: 7, Minor: 1, Patch: 22},
+	SupportsDNSInClusterFile:              Version{Major: 7, Minor: 0, Patch: 0},
+	SupportsStorageMigrationConfiguration: Version{Major: 7, Minor: 0, Patch: 0},
 }
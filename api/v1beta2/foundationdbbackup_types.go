@@ -19,7 +19,9 @@ package v1beta2
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
@@ -68,6 +70,11 @@ type FoundationDBBackupSpec struct {
 	// The default is run 2 agents.
 	AgentCount *int `json:"agentCount,omitempty"`
 
+	// AgentAutoscaling defines a policy to scale the number of backup agents
+	// based on the lag and the throughput of the backup. If defined, the
+	// AgentCount is only used as the initial number of agents.
+	AgentAutoscaling *BackupAgentAutoscaling `json:"agentAutoscaling,omitempty"`
+
 	// The time window between new snapshots.
 	// This is measured in seconds. The default is 864,000, or 10 days.
 	SnapshotPeriodSeconds *int `json:"snapshotPeriodSeconds,omitempty"`
@@ -93,14 +100,205 @@ type FoundationDBBackupSpec struct {
 	AllowTagOverride *bool `json:"allowTagOverride,omitempty"`
 
 	// This is the configuration of the target blobstore for this backup.
+	// If Destinations are defined this setting will be ignored.
 	BlobStoreConfiguration *BlobStoreConfiguration `json:"blobStoreConfiguration,omitempty"`
 
+	// Destinations defines the destinations of this backup. Every destination
+	// will be written by a separate backup with the destination name as the
+	// backup tag. If no destinations are defined the BlobStoreConfiguration
+	// will be used as destination with the default tag.
+	// +kubebuilder:validation:MaxItems=10
+	Destinations []BackupDestination `json:"destinations,omitempty"`
+
 	// MainContainer defines customization for the foundationdb container.
 	MainContainer ContainerOverrides `json:"mainContainer,omitempty"`
 
 	// SidecarContainer defines customization for the
 	// foundationdb-kubernetes-sidecar container.
 	SidecarContainer ContainerOverrides `json:"sidecarContainer,omitempty"`
+
+	// SnapshotSchedule defines a schedule for forcing snapshots in addition
+	// to the continuous snapshots defined by the snapshot period.
+	SnapshotSchedule *BackupSnapshotSchedule `json:"snapshotSchedule,omitempty"`
+
+	// RetentionPolicy defines how long the backup data is kept in the
+	// destination. If not set the backup data will never be expired.
+	RetentionPolicy *BackupRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// BlobCredentials defines the secrets that contain the credentials for the
+	// blob store. The operator will mount the credentials into the backup agents
+	// and restart the backup agents if the secrets are changed.
+	BlobCredentials *BackupBlobCredentials `json:"blobCredentials,omitempty"`
+
+	// RestoreValidation defines a schedule to periodically restore the backup
+	// into a temporary cluster and verify the restored data.
+	RestoreValidation *BackupRestoreValidation `json:"restoreValidation,omitempty"`
+
+	// DeletionPolicy defines what happens with the backup data in the
+	// destinations when the backup resource is deleted. The backup will
+	// always be stopped before the resource is released. The default is
+	// Retain.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	DeletionPolicy *BackupDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// BackupRestoreValidation defines how the operator validates that a backup
+// can be restored. For every validation the operator creates a temporary
+// cluster, restores the backup into this cluster, runs the verification job
+// and deletes the temporary cluster afterwards.
+type BackupRestoreValidation struct {
+	// Schedule is the schedule in the cron format, e.g. "0 4 * * 0" to
+	// validate the backup every Sunday at 4am UTC.
+	// +kubebuilder:validation:MaxLength=100
+	Schedule string `json:"schedule"`
+
+	// Destination is the name of the destination that should be restored.
+	// The destination must use a blob store. If empty the first destination
+	// will be restored.
+	// +kubebuilder:validation:MaxLength=50
+	Destination string `json:"destination,omitempty"`
+
+	// ClusterTemplate defines the spec of the temporary cluster. If the
+	// version is empty the version of the backup will be used. The template
+	// is not validated by the API server, as the full schema would exceed
+	// the size limit of the CRD.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ClusterTemplate FoundationDBClusterSpec `json:"clusterTemplate"`
+
+	// VerificationJob defines an optional job that verifies the restored
+	// data. The operator mounts the cluster file of the temporary cluster into
+	// all containers and sets the FDB_CLUSTER_FILE environment variable. If
+	// the job fails the validation fails. The job spec is not validated by the
+	// API server.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	VerificationJob *batchv1.JobSpec `json:"verificationJob,omitempty"`
+
+	// TimeoutSeconds defines how long a validation can take before it is
+	// marked as failed. The default is 21600, or 6 hours.
+	// +kubebuilder:validation:Minimum=60
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+}
+
+// BackupAgentAutoscaling defines the policy to scale the number of backup
+// agents between a minimum and a maximum count.
+type BackupAgentAutoscaling struct {
+	// MinAgentCount defines the minimum number of backup agents.
+	// The default is 1.
+	// +kubebuilder:validation:Minimum=1
+	MinAgentCount *int `json:"minAgentCount,omitempty"`
+
+	// MaxAgentCount defines the maximum number of backup agents.
+	// +kubebuilder:validation:Minimum=1
+	MaxAgentCount int `json:"maxAgentCount"`
+
+	// TargetLagSeconds defines the lag of the latest restorable point at
+	// which the operator will add backup agents. If the lag is below half of
+	// the target and no throughput target is defined, the operator will
+	// remove backup agents. The default is 60.
+	// +kubebuilder:validation:Minimum=1
+	TargetLagSeconds *int `json:"targetLagSeconds,omitempty"`
+
+	// TargetBytesPerSecondPerAgent defines the throughput that a single
+	// backup agent should handle. If defined, the operator will derive the
+	// number of backup agents from the throughput of the backup.
+	// +kubebuilder:validation:Minimum=1
+	TargetBytesPerSecondPerAgent *int64 `json:"targetBytesPerSecondPerAgent,omitempty"`
+
+	// ScaleDownDelaySeconds defines how long the operator waits after the
+	// last scaling before removing backup agents. The default is 600, or 10
+	// minutes.
+	// +kubebuilder:validation:Minimum=0
+	ScaleDownDelaySeconds *int `json:"scaleDownDelaySeconds,omitempty"`
+}
+
+// BackupBlobCredentials defines the secrets that are used by the backup agents
+// to access the blob store.
+type BackupBlobCredentials struct {
+	// SecretName is the name of the secret that contains the blob credentials
+	// file, the secret must be in the same namespace as the backup.
+	// +kubebuilder:validation:MaxLength=253
+	SecretName string `json:"secretName"`
+
+	// CredentialsKey is the key in the secret that contains the credentials in
+	// the JSON format expected by the --blob_credentials flag.
+	// The default is credentials.json.
+	CredentialsKey *string `json:"credentialsKey,omitempty"`
+
+	// CASecretName is the name of an optional secret that contains the CA
+	// bundle to verify the TLS certificate of the blob store endpoint.
+	// +kubebuilder:validation:MaxLength=253
+	CASecretName *string `json:"caSecretName,omitempty"`
+
+	// CAKey is the key in the CA secret that contains the CA bundle.
+	// The default is ca.crt.
+	CAKey *string `json:"caKey,omitempty"`
+}
+
+// BackupDestination describes a single destination of a backup. Exactly one
+// configuration must be defined.
+type BackupDestination struct {
+	// Name of the destination, this name will be used as backup tag.
+	// +kubebuilder:validation:MaxLength=50
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
+
+	// BlobStoreConfiguration defines a blob store as destination.
+	BlobStoreConfiguration *BlobStoreConfiguration `json:"blobStoreConfiguration,omitempty"`
+
+	// FileSystemConfiguration defines a directory on a persistent volume as
+	// destination.
+	FileSystemConfiguration *BackupFileSystemConfiguration `json:"fileSystemConfiguration,omitempty"`
+}
+
+// BackupFileSystemConfiguration describes a directory on a persistent volume
+// that is used as backup destination. The persistent volume will be mounted
+// into all backup agents, so it must support the ReadWriteMany access mode if
+// more than one backup agent is used.
+type BackupFileSystemConfiguration struct {
+	// PersistentVolumeClaimName is the name of the persistent volume claim
+	// that should be mounted into the backup agents.
+	// +kubebuilder:validation:MaxLength=253
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+
+	// The name for the backup, this will be the directory on the volume.
+	// If empty defaults to .metadata.name.
+	// +kubebuilder:validation:MaxLength=1024
+	BackupName string `json:"backupName,omitempty"`
+}
+
+// BackupSnapshotSchedule defines a schedule for forcing snapshots.
+type BackupSnapshotSchedule struct {
+	// Schedule is the schedule in the cron format, e.g. "0 2 * * *" to force
+	// a snapshot every day at 2am UTC.
+	// +kubebuilder:validation:MaxLength=100
+	Schedule string `json:"schedule"`
+
+	// SnapshotDurationSeconds defines in which time a forced snapshot should
+	// be completed. The default is 3600, or 1 hour.
+	// +kubebuilder:validation:Minimum=1
+	SnapshotDurationSeconds *int `json:"snapshotDurationSeconds,omitempty"`
+}
+
+// BackupRetentionPolicy defines how long the backup data is kept in the
+// destination. If multiple limits are defined, only the data that exceeds
+// all limits will be expired.
+type BackupRetentionPolicy struct {
+	// RetentionDays defines for how many days the backup must be restorable.
+	// +kubebuilder:validation:Minimum=1
+	RetentionDays *int `json:"retentionDays,omitempty"`
+
+	// MaxSnapshots defines how many restorable snapshots should be kept.
+	// +kubebuilder:validation:Minimum=1
+	MaxSnapshots *int `json:"maxSnapshots,omitempty"`
+
+	// ExpirationIntervalSeconds defines how often the operator will expire
+	// the backup data. The default is 3600, or 1 hour.
+	// +kubebuilder:validation:Minimum=60
+	ExpirationIntervalSeconds *int `json:"expirationIntervalSeconds,omitempty"`
 }
 
 // FoundationDBBackupStatus describes the current status of the backup for a cluster.
@@ -114,12 +312,180 @@ type FoundationDBBackupStatus struct {
 	DeploymentConfigured bool `json:"deploymentConfigured,omitempty"`
 
 	// BackupDetails provides information about the state of the backup in the
-	// cluster.
+	// cluster. If multiple destinations are defined, only Running and Paused
+	// are set and the details of every destination are reported in
+	// Destinations.
 	BackupDetails *FoundationDBBackupStatusBackupDetails `json:"backupDetails,omitempty"`
 
 	// Generations provides information about the latest generation to be
 	// reconciled, or to reach other stages in reconciliation.
 	Generations BackupGenerationStatus `json:"generations,omitempty"`
+
+	// RestorableRange provides the range of versions to which the backup
+	// can be restored. If multiple destinations are defined, this is not set
+	// and the range of every destination is reported in Destinations.
+	RestorableRange *BackupRestorableRange `json:"restorableRange,omitempty"`
+
+	// Destinations provides information about the state of the backup for
+	// every destination.
+	Destinations []BackupDestinationStatus `json:"destinations,omitempty"`
+
+	// LastScheduledSnapshot is the last time the operator forced a snapshot
+	// based on the snapshot schedule.
+	LastScheduledSnapshot *metav1.Time `json:"lastScheduledSnapshot,omitempty"`
+
+	// LastExpiration is the last time the operator expired the backup data
+	// based on the retention policy.
+	LastExpiration *metav1.Time `json:"lastExpiration,omitempty"`
+
+	// AgentAutoscaling provides information about the autoscaling of the
+	// backup agents.
+	AgentAutoscaling *BackupAgentAutoscalingStatus `json:"agentAutoscaling,omitempty"`
+
+	// RestoreValidation provides information about the validations of the
+	// backup.
+	RestoreValidation *BackupRestoreValidationStatus `json:"restoreValidation,omitempty"`
+}
+
+// BackupRestoreValidationStatus provides information about the validations of
+// the backup.
+type BackupRestoreValidationStatus struct {
+	// Phase is the phase of the current validation, if no validation is
+	// running the phase is empty.
+	Phase BackupRestoreValidationPhase `json:"phase,omitempty"`
+
+	// ClusterName is the name of the temporary cluster of the current
+	// validation.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// StartTimestamp is the time when the current validation was started.
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// LastScheduledValidation is the last time a validation was due based on
+	// the schedule.
+	LastScheduledValidation *metav1.Time `json:"lastScheduledValidation,omitempty"`
+
+	// LastResult is the result of the last finished validation.
+	LastResult *BackupRestoreValidationResult `json:"lastResult,omitempty"`
+}
+
+// BackupRestoreValidationResult provides the result of a finished validation.
+type BackupRestoreValidationResult struct {
+	// Succeeded is true if the backup was restored and verified.
+	Succeeded bool `json:"succeeded,omitempty"`
+
+	// Message provides details about the result.
+	Message string `json:"message,omitempty"`
+
+	// StartTimestamp is the time when the validation was started.
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// FinishTimestamp is the time when the validation was finished.
+	FinishTimestamp *metav1.Time `json:"finishTimestamp,omitempty"`
+}
+
+// BackupRestoreValidationPhase represents the phase of a restore validation.
+// +kubebuilder:validation:MaxLength=32
+type BackupRestoreValidationPhase string
+
+const (
+	// BackupRestoreValidationPhaseCreatingCluster represents a validation
+	// that waits for the temporary cluster to be reconciled.
+	BackupRestoreValidationPhaseCreatingCluster BackupRestoreValidationPhase = "CreatingCluster"
+	// BackupRestoreValidationPhaseRestoring represents a validation that
+	// waits for the restore to complete.
+	BackupRestoreValidationPhaseRestoring BackupRestoreValidationPhase = "Restoring"
+	// BackupRestoreValidationPhaseVerifying represents a validation that waits
+	// for the verification job to complete.
+	BackupRestoreValidationPhaseVerifying BackupRestoreValidationPhase = "Verifying"
+)
+
+// BackupAgentAutoscalingStatus provides information about the autoscaling of
+// the backup agents.
+type BackupAgentAutoscalingStatus struct {
+	// DesiredAgentCount is the number of backup agents chosen by the
+	// autoscaling.
+	DesiredAgentCount int `json:"desiredAgentCount,omitempty"`
+
+	// LagSeconds is the highest lag of the latest restorable point over all
+	// destinations at the last sample.
+	LagSeconds int64 `json:"lagSeconds,omitempty"`
+
+	// BytesWritten is the number of bytes written by all destinations at the
+	// last sample.
+	BytesWritten int64 `json:"bytesWritten,omitempty"`
+
+	// BytesPerSecond is the throughput of the backup between the last two
+	// samples.
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty"`
+
+	// LastSampleTimestamp is the last time the operator sampled the progress
+	// of the backup.
+	LastSampleTimestamp *metav1.Time `json:"lastSampleTimestamp,omitempty"`
+
+	// LastScaleTimestamp is the last time the operator changed the number of
+	// backup agents.
+	LastScaleTimestamp *metav1.Time `json:"lastScaleTimestamp,omitempty"`
+}
+
+// BackupDestinationStatus provides information about the state of the
+// backup for a single destination.
+type BackupDestinationStatus struct {
+	// Name of the destination.
+	Name string `json:"name"`
+
+	// BackupDetails provides information about the state of the backup for
+	// this destination.
+	BackupDetails FoundationDBBackupStatusBackupDetails `json:"backupDetails,omitempty"`
+
+	// RestorableRange provides the range of versions to which the backup
+	// for this destination can be restored. The operator has no access to
+	// the volumes of file system destinations, so the range is only reported
+	// for blob store destinations.
+	RestorableRange *BackupRestorableRange `json:"restorableRange,omitempty"`
+
+	// Progress provides information about the progress of the backup for
+	// this destination.
+	Progress *BackupProgress `json:"progress,omitempty"`
+}
+
+// BackupProgress provides information about the progress of a running
+// backup.
+type BackupProgress struct {
+	// LogBytesWritten is the number of bytes of mutation logs written to the
+	// destination.
+	LogBytesWritten int64 `json:"logBytesWritten,omitempty"`
+
+	// RangeBytesWritten is the number of bytes of snapshot ranges written to
+	// the destination.
+	RangeBytesWritten int64 `json:"rangeBytesWritten,omitempty"`
+
+	// LagSeconds is the number of seconds the latest restorable point is
+	// behind the cluster.
+	LagSeconds int64 `json:"lagSeconds,omitempty"`
+}
+
+// BackupRestorableRange provides the range of versions to which the backup
+// can be restored.
+type BackupRestorableRange struct {
+	// MinVersion is the oldest version to which the backup can be restored.
+	MinVersion int64 `json:"minVersion,omitempty"`
+
+	// MinTimestamp is the timestamp of the oldest restorable version.
+	MinTimestamp *metav1.Time `json:"minTimestamp,omitempty"`
+
+	// MaxVersion is the latest version to which the backup can be restored.
+	MaxVersion int64 `json:"maxVersion,omitempty"`
+
+	// MaxTimestamp is the timestamp of the latest restorable version.
+	MaxTimestamp *metav1.Time `json:"maxTimestamp,omitempty"`
+
+	// Snapshots is the number of restorable snapshots in the backup.
+	Snapshots int `json:"snapshots,omitempty"`
+
+	// LatestSnapshotTimestamp is the timestamp at which the latest restorable
+	// snapshot was completed.
+	LatestSnapshotTimestamp *metav1.Time `json:"latestSnapshotTimestamp,omitempty"`
 }
 
 // FoundationDBBackupStatusBackupDetails provides information about the state
@@ -162,6 +528,10 @@ type BackupGenerationStatus struct {
 // BackupState defines the desired state of a backup
 type BackupState string
 
+// DefaultBackupTag is the backup tag that is used if no destinations are
+// defined.
+const DefaultBackupTag = "default"
+
 const (
 	// BackupStateRunning defines the running state
 	BackupStateRunning BackupState = "Running"
@@ -171,6 +541,17 @@ const (
 	BackupStateStopped BackupState = "Stopped"
 )
 
+// BackupDeletionPolicy defines what happens with the backup data when the
+// backup is deleted.
+type BackupDeletionPolicy string
+
+const (
+	// BackupDeletionPolicyRetain keeps the backup data in the destinations.
+	BackupDeletionPolicyRetain BackupDeletionPolicy = "Retain"
+	// BackupDeletionPolicyDelete deletes the backup data in the destinations.
+	BackupDeletionPolicyDelete BackupDeletionPolicy = "Delete"
+)
+
 // URLParameter defines a single URL parameter to pass to the blobstore.
 // +kubebuilder:validation:MaxLength=1024
 type URLParameter string
@@ -203,6 +584,16 @@ func (backup *FoundationDBBackup) ShouldRun() bool {
 	return backup.Spec.BackupState == "" || backup.Spec.BackupState == BackupStateRunning || backup.Spec.BackupState == BackupStatePaused
 }
 
+// GetDeletionPolicy returns the deletion policy of the backup. If no policy is
+// defined the backup data will be retained.
+func (backup *FoundationDBBackup) GetDeletionPolicy() BackupDeletionPolicy {
+	if backup.Spec.DeletionPolicy == nil {
+		return BackupDeletionPolicyRetain
+	}
+
+	return *backup.Spec.DeletionPolicy
+}
+
 // ShouldBePaused determines whether the backups should be paused.
 func (backup *FoundationDBBackup) ShouldBePaused() bool {
 	return backup.Spec.BackupState == BackupStatePaused
@@ -228,16 +619,115 @@ func (backup *FoundationDBBackup) BackupName() string {
 	return backup.Spec.BlobStoreConfiguration.BackupName
 }
 
-// BackupURL gets the destination url of the backup.
+// BackupURL gets the destination url of the backup. If multiple destinations
+// are defined, this is the url of the first destination.
 func (backup *FoundationDBBackup) BackupURL() string {
+	if len(backup.Spec.Destinations) > 0 {
+		return backup.DestinationURL(backup.Spec.Destinations[0])
+	}
+
 	return backup.Spec.BlobStoreConfiguration.getURL(backup.BackupName(), backup.Bucket())
 }
 
+// GetDestinations returns the destinations of the backup. If no destinations
+// are defined, the BlobStoreConfiguration will be returned as destination with
+// the default tag.
+func (backup *FoundationDBBackup) GetDestinations() []BackupDestination {
+	if len(backup.Spec.Destinations) > 0 {
+		return backup.Spec.Destinations
+	}
+
+	if backup.Spec.BlobStoreConfiguration == nil {
+		return nil
+	}
+
+	return []BackupDestination{
+		{
+			Name:                   DefaultBackupTag,
+			BlobStoreConfiguration: backup.Spec.BlobStoreConfiguration,
+		},
+	}
+}
+
+// DestinationURL gets the url of the provided destination.
+func (backup *FoundationDBBackup) DestinationURL(destination BackupDestination) string {
+	if destination.FileSystemConfiguration != nil {
+		return destination.FileSystemConfiguration.getURL(destination.Name, backup.ObjectMeta.Name)
+	}
+
+	if destination.BlobStoreConfiguration != nil {
+		backupName := destination.BlobStoreConfiguration.BackupName
+		if backupName == "" {
+			backupName = backup.ObjectMeta.Name
+		}
+
+		return destination.BlobStoreConfiguration.getURL(backupName, destination.BlobStoreConfiguration.BucketName())
+	}
+
+	return ""
+}
+
+// ValidateDestinations validates that the destinations have unique names and
+// define exactly one configuration. The operator has no access to the volumes
+// of file system destinations, so those can't be combined with a retention
+// policy or the deletion of the backup data.
+func (backup *FoundationDBBackup) ValidateDestinations() error {
+	names := make(map[string]None, len(backup.Spec.Destinations))
+	for _, destination := range backup.Spec.Destinations {
+		if _, ok := names[destination.Name]; ok {
+			return fmt.Errorf("destination name %s is used multiple times", destination.Name)
+		}
+		names[destination.Name] = None{}
+
+		if (destination.BlobStoreConfiguration == nil) == (destination.FileSystemConfiguration == nil) {
+			return fmt.Errorf("destination %s must define either a blobStoreConfiguration or a fileSystemConfiguration", destination.Name)
+		}
+
+		if destination.FileSystemConfiguration == nil {
+			continue
+		}
+
+		if backup.Spec.RetentionPolicy != nil {
+			return fmt.Errorf("destination %s uses a fileSystemConfiguration, which doesn't support a retentionPolicy", destination.Name)
+		}
+
+		if backup.GetDeletionPolicy() == BackupDeletionPolicyDelete {
+			return fmt.Errorf("destination %s uses a fileSystemConfiguration, which doesn't support the deletionPolicy %s", destination.Name, BackupDeletionPolicyDelete)
+		}
+	}
+
+	return nil
+}
+
+// GetDestinationStatus returns the status of the destination with the
+// provided name or nil if the status has no entry for this destination.
+func (backupStatus *FoundationDBBackupStatus) GetDestinationStatus(name string) *BackupDestinationStatus {
+	for idx := range backupStatus.Destinations {
+		if backupStatus.Destinations[idx].Name == name {
+			return &backupStatus.Destinations[idx]
+		}
+	}
+
+	return nil
+}
+
 // SnapshotPeriodSeconds gets the period between snapshots for a backup.
 func (backup *FoundationDBBackup) SnapshotPeriodSeconds() int {
 	return pointer.IntDeref(backup.Spec.SnapshotPeriodSeconds, 864000)
 }
 
+// GetSnapshotDurationSeconds gets the duration in which a forced snapshot
+// should be completed.
+func (schedule *BackupSnapshotSchedule) GetSnapshotDurationSeconds() int {
+	return pointer.IntDeref(schedule.SnapshotDurationSeconds, 3600)
+}
+
+// GetExpirationInterval gets the interval between two expirations of the
+// backup data.
+func (policy *BackupRetentionPolicy) GetExpirationInterval() time.Duration {
+	return time.Duration(pointer.IntDeref(policy.ExpirationIntervalSeconds, 3600)) * time.Second
+}
+
 // FoundationDBLiveBackupStatus describes the live status of the backup for a
 // cluster, as provided by the backup status command.
 type FoundationDBLiveBackupStatus struct {
@@ -252,6 +742,32 @@ type FoundationDBLiveBackupStatus struct {
 
 	// BackupAgentsPaused describes whether the backup agents are paused.
 	BackupAgentsPaused bool `json:"BackupAgentsPaused,omitempty"`
+
+	// LogBytesWritten provides the number of bytes of mutation logs written
+	// to the destination.
+	LogBytesWritten int64 `json:"LogBytesWritten,omitempty"`
+
+	// RangeBytesWritten provides the number of bytes of snapshot ranges
+	// written to the destination.
+	RangeBytesWritten int64 `json:"RangeBytesWritten,omitempty"`
+
+	// LatestRestorablePoint provides the latest version to which the backup
+	// can be restored.
+	LatestRestorablePoint *FoundationDBLiveBackupStatusRestorablePoint `json:"LatestRestorablePoint,omitempty"`
+}
+
+// FoundationDBLiveBackupStatusRestorablePoint provides the latest restorable
+// point of a backup in the backup status.
+type FoundationDBLiveBackupStatusRestorablePoint struct {
+	// Version provides the latest restorable version.
+	Version int64 `json:"Version,omitempty"`
+
+	// Timestamp provides the time of the latest restorable version.
+	Timestamp string `json:"Timestamp,omitempty"`
+
+	// LagSeconds provides the number of seconds the latest restorable version
+	// is behind the cluster.
+	LagSeconds float64 `json:"LagSeconds,omitempty"`
 }
 
 // FoundationDBLiveBackupStatusState provides the state of a backup in the
@@ -261,10 +777,141 @@ type FoundationDBLiveBackupStatusState struct {
 	Running bool `json:"Running,omitempty"`
 }
 
+// FoundationDBBackupDescription describes the data of a backup in the
+// destination, as provided by the backup describe command.
+type FoundationDBBackupDescription struct {
+	// URL provides the URL of the backup.
+	URL string `json:"URL,omitempty"`
+
+	// Restorable describes whether the backup can be restored.
+	Restorable bool `json:"Restorable,omitempty"`
+
+	// MinRestorablePoint provides the oldest version to which the backup can
+	// be restored.
+	MinRestorablePoint *FoundationDBBackupVersion `json:"MinRestorablePoint,omitempty"`
+
+	// MaxRestorablePoint provides the latest version to which the backup can
+	// be restored.
+	MaxRestorablePoint *FoundationDBBackupVersion `json:"MaxRestorablePoint,omitempty"`
+
+	// Snapshots provides the snapshots of the backup.
+	Snapshots []FoundationDBBackupSnapshot `json:"Snapshots,omitempty"`
+}
+
+// FoundationDBBackupSnapshot describes a single snapshot of a backup.
+type FoundationDBBackupSnapshot struct {
+	// Restorable describes whether the snapshot can be restored.
+	Restorable bool `json:"Restorable,omitempty"`
+
+	// BeginVersion provides the version at which the snapshot was started.
+	BeginVersion FoundationDBBackupVersion `json:"Start,omitempty"`
+
+	// EndVersion provides the version at which the snapshot was completed.
+	EndVersion FoundationDBBackupVersion `json:"End,omitempty"`
+}
+
+// FoundationDBBackupVersion describes a version in a backup.
+type FoundationDBBackupVersion struct {
+	// Version provides the version.
+	Version int64 `json:"Version,omitempty"`
+
+	// EpochSeconds provides the time of the version as seconds since the
+	// epoch. This is only reported if the backup was described with the
+	// version timestamps.
+	EpochSeconds int64 `json:"EpochSeconds,omitempty"`
+}
+
 // GetDesiredAgentCount determines how many backup agents we should run
 // for a cluster.
 func (backup *FoundationDBBackup) GetDesiredAgentCount() int {
-	return pointer.IntDeref(backup.Spec.AgentCount, 2)
+	agentCount := pointer.IntDeref(backup.Spec.AgentCount, 2)
+
+	autoscaling := backup.Spec.AgentAutoscaling
+	if autoscaling == nil {
+		return agentCount
+	}
+
+	if backup.Status.AgentAutoscaling != nil && backup.Status.AgentAutoscaling.DesiredAgentCount > 0 {
+		agentCount = backup.Status.AgentAutoscaling.DesiredAgentCount
+	}
+
+	return autoscaling.LimitAgentCount(agentCount)
+}
+
+// GetTimeout gets the duration after which a validation is marked as failed.
+func (validation *BackupRestoreValidation) GetTimeout() time.Duration {
+	return time.Duration(pointer.IntDeref(validation.TimeoutSeconds, 21600)) * time.Second
+}
+
+// GetRestoreValidationDestination returns the destination that should be
+// restored by the restore validation.
+func (backup *FoundationDBBackup) GetRestoreValidationDestination() (BackupDestination, error) {
+	destinations := backup.GetDestinations()
+	if len(destinations) == 0 {
+		return BackupDestination{}, fmt.Errorf("backup defines no destination")
+	}
+
+	destination := destinations[0]
+	if backup.Spec.RestoreValidation != nil && backup.Spec.RestoreValidation.Destination != "" {
+		found := false
+		for _, current := range destinations {
+			if current.Name == backup.Spec.RestoreValidation.Destination {
+				destination = current
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return BackupDestination{}, fmt.Errorf("restore validation destination %s is not defined", backup.Spec.RestoreValidation.Destination)
+		}
+	}
+
+	if destination.BlobStoreConfiguration == nil {
+		return BackupDestination{}, fmt.Errorf("restore validation destination %s must use a blob store", destination.Name)
+	}
+
+	return destination, nil
+}
+
+// GetMinAgentCount gets the minimum number of backup agents.
+func (autoscaling *BackupAgentAutoscaling) GetMinAgentCount() int {
+	return pointer.IntDeref(autoscaling.MinAgentCount, 1)
+}
+
+// GetMaxAgentCount gets the maximum number of backup agents. The maximum is
+// never lower than the minimum.
+func (autoscaling *BackupAgentAutoscaling) GetMaxAgentCount() int {
+	if autoscaling.MaxAgentCount < autoscaling.GetMinAgentCount() {
+		return autoscaling.GetMinAgentCount()
+	}
+
+	return autoscaling.MaxAgentCount
+}
+
+// LimitAgentCount limits the provided number of backup agents to the range
+// defined by the minimum and the maximum.
+func (autoscaling *BackupAgentAutoscaling) LimitAgentCount(agentCount int) int {
+	if agentCount < autoscaling.GetMinAgentCount() {
+		return autoscaling.GetMinAgentCount()
+	}
+
+	if agentCount > autoscaling.GetMaxAgentCount() {
+		return autoscaling.GetMaxAgentCount()
+	}
+
+	return agentCount
+}
+
+// GetTargetLagSeconds gets the lag at which backup agents will be added.
+func (autoscaling *BackupAgentAutoscaling) GetTargetLagSeconds() int64 {
+	return int64(pointer.IntDeref(autoscaling.TargetLagSeconds, 60))
+}
+
+// GetScaleDownDelay gets the duration the operator waits after the last
+// scaling before removing backup agents.
+func (autoscaling *BackupAgentAutoscaling) GetScaleDownDelay() time.Duration {
+	return time.Duration(pointer.IntDeref(autoscaling.ScaleDownDelaySeconds, 600)) * time.Second
 }
 
 // CheckReconciliation compares the spec and the status to determine if
@@ -296,11 +943,47 @@ func (backup *FoundationDBBackup) CheckReconciliation() (bool, error) {
 		reconciled = false
 	}
 
-	if isRunning && backup.SnapshotPeriodSeconds() != backup.Status.BackupDetails.SnapshotPeriodSeconds {
+	if len(backup.Spec.Destinations) == 0 && isRunning && backup.SnapshotPeriodSeconds() != backup.Status.BackupDetails.SnapshotPeriodSeconds {
 		backup.Status.Generations.NeedsBackupReconfiguration = backup.ObjectMeta.Generation
 		reconciled = false
 	}
 
+	// If destinations are defined, the BackupDetails only summarize the state of all destinations, so every
+	// destination must be checked separately.
+	destinations := make(map[string]None, len(backup.Spec.Destinations))
+	for _, destination := range backup.Spec.Destinations {
+		destinations[destination.Name] = None{}
+		destinationStatus := backup.Status.GetDestinationStatus(destination.Name)
+		destinationRunning := destinationStatus != nil && destinationStatus.BackupDetails.Running
+
+		if backup.ShouldRun() && !destinationRunning {
+			backup.Status.Generations.NeedsBackupStart = backup.ObjectMeta.Generation
+			reconciled = false
+		}
+
+		if !backup.ShouldRun() && destinationRunning {
+			backup.Status.Generations.NeedsBackupStop = backup.ObjectMeta.Generation
+			reconciled = false
+		}
+
+		if destinationRunning && backup.SnapshotPeriodSeconds() != destinationStatus.BackupDetails.SnapshotPeriodSeconds {
+			backup.Status.Generations.NeedsBackupReconfiguration = backup.ObjectMeta.Generation
+			reconciled = false
+		}
+	}
+
+	// Backups for destinations that were removed from the spec must be stopped.
+	for _, destinationStatus := range backup.Status.Destinations {
+		if _, ok := destinations[destinationStatus.Name]; ok || len(backup.Spec.Destinations) == 0 {
+			continue
+		}
+
+		if destinationStatus.BackupDetails.Running {
+			backup.Status.Generations.NeedsBackupStop = backup.ObjectMeta.Generation
+			reconciled = false
+		}
+	}
+
 	if reconciled {
 		backup.Status.Generations = BackupGenerationStatus{
 			Reconciled: backup.ObjectMeta.Generation,
@@ -330,6 +1013,22 @@ func (configuration *BlobStoreConfiguration) getURL(backup string, bucket string
 	return fmt.Sprintf("blobstore://%s/%s?bucket=%s%s", configuration.AccountName, backup, bucket, sb.String())
 }
 
+// getURL returns the file URL for the specific configuration.
+func (configuration *BackupFileSystemConfiguration) getURL(destination string, backup string) string {
+	backupName := configuration.BackupName
+	if backupName == "" {
+		backupName = backup
+	}
+
+	return fmt.Sprintf("file://%s/%s", GetBackupDestinationMountPath(destination), backupName)
+}
+
+// GetBackupDestinationMountPath returns the path where the volume of a file
+// system destination will be mounted in the backup agents.
+func GetBackupDestinationMountPath(destination string) string {
+	return fmt.Sprintf("/var/fdb/backups/%s", destination)
+}
+
 // BucketName gets the bucket this backup will use.
 // This will fill in a default value if the bucket in the spec is empty.
 func (configuration *BlobStoreConfiguration) BucketName() string {
@@ -343,3 +1042,13 @@ func (configuration *BlobStoreConfiguration) BucketName() string {
 func init() {
 	SchemeBuilder.Register(&FoundationDBBackup{}, &FoundationDBBackupList{})
 }
+
+// GetCredentialsKey returns the key in the secret that contains the blob credentials.
+func (credentials *BackupBlobCredentials) GetCredentialsKey() string {
+	return pointer.StringDeref(credentials.CredentialsKey, "credentials.json")
+}
+
+// GetCAKey returns the key in the CA secret that contains the CA bundle.
+func (credentials *BackupBlobCredentials) GetCAKey() string {
+	return pointer.StringDeref(credentials.CAKey, "ca.crt")
+}
@@ -21,9 +21,12 @@
 package v1beta2
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("[api] FoundationDBBackup", func() {
@@ -301,6 +304,274 @@ var _ = Describe("[api] FoundationDBBackup", func() {
 					},
 				},
 				"blobstore://account@account/mybackup?bucket=fdb-backups&secure_connection=0"),
+			Entry("A Backup with a file system destination",
+				FoundationDBBackup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "mybackup",
+					},
+					Spec: FoundationDBBackupSpec{
+						Destinations: []BackupDestination{
+							{
+								Name: "local",
+								FileSystemConfiguration: &BackupFileSystemConfiguration{
+									PersistentVolumeClaimName: "backup-data",
+								},
+							},
+						},
+					},
+				},
+				"file:///var/fdb/backups/local/mybackup"),
+			Entry("A Backup with multiple destinations",
+				FoundationDBBackup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "mybackup",
+					},
+					Spec: FoundationDBBackupSpec{
+						BlobStoreConfiguration: &BlobStoreConfiguration{
+							AccountName: "ignored@account",
+						},
+						Destinations: []BackupDestination{
+							{
+								Name: "primary",
+								BlobStoreConfiguration: &BlobStoreConfiguration{
+									AccountName: "account@account",
+									Bucket:      "my-bucket",
+								},
+							},
+							{
+								Name: "local",
+								FileSystemConfiguration: &BackupFileSystemConfiguration{
+									PersistentVolumeClaimName: "backup-data",
+									BackupName:                "test",
+								},
+							},
+						},
+					},
+				},
+				"blobstore://account@account/mybackup?bucket=my-bucket"),
 		)
 	})
+
+	When("getting the destinations", func() {
+		It("should use the blob store configuration with the default tag", func() {
+			backup.Spec.BlobStoreConfiguration = &BlobStoreConfiguration{
+				AccountName: "account@account",
+			}
+
+			destinations := backup.GetDestinations()
+			Expect(destinations).To(HaveLen(1))
+			Expect(destinations[0].Name).To(Equal(DefaultBackupTag))
+			Expect(backup.DestinationURL(destinations[0])).To(Equal("blobstore://account@account/sample-cluster?bucket=fdb-backups"))
+		})
+
+		It("should return the defined destinations", func() {
+			backup.Spec.Destinations = []BackupDestination{
+				{
+					Name: "local",
+					FileSystemConfiguration: &BackupFileSystemConfiguration{
+						PersistentVolumeClaimName: "backup-data",
+						BackupName:                "test",
+					},
+				},
+			}
+
+			destinations := backup.GetDestinations()
+			Expect(destinations).To(HaveLen(1))
+			Expect(backup.DestinationURL(destinations[0])).To(Equal("file:///var/fdb/backups/local/test"))
+		})
+	})
+
+	DescribeTable("validating the destinations",
+		func(destinations []BackupDestination, expected error) {
+			backup.Spec.Destinations = destinations
+			err := backup.ValidateDestinations()
+			if expected == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(expected))
+			}
+		},
+		Entry("no destinations", nil, nil),
+		Entry("valid destinations",
+			[]BackupDestination{
+				{Name: "primary", BlobStoreConfiguration: &BlobStoreConfiguration{AccountName: "account@account"}},
+				{Name: "local", FileSystemConfiguration: &BackupFileSystemConfiguration{PersistentVolumeClaimName: "backup-data"}},
+			},
+			nil,
+		),
+		Entry("duplicate names",
+			[]BackupDestination{
+				{Name: "primary", BlobStoreConfiguration: &BlobStoreConfiguration{AccountName: "account@account"}},
+				{Name: "primary", FileSystemConfiguration: &BackupFileSystemConfiguration{PersistentVolumeClaimName: "backup-data"}},
+			},
+			fmt.Errorf("destination name primary is used multiple times"),
+		),
+		Entry("no configuration",
+			[]BackupDestination{
+				{Name: "primary"},
+			},
+			fmt.Errorf("destination primary must define either a blobStoreConfiguration or a fileSystemConfiguration"),
+		),
+		Entry("multiple configurations",
+			[]BackupDestination{
+				{
+					Name:                    "primary",
+					BlobStoreConfiguration:  &BlobStoreConfiguration{AccountName: "account@account"},
+					FileSystemConfiguration: &BackupFileSystemConfiguration{PersistentVolumeClaimName: "backup-data"},
+				},
+			},
+			fmt.Errorf("destination primary must define either a blobStoreConfiguration or a fileSystemConfiguration"),
+		),
+	)
+
+	When("a file system destination is defined", func() {
+		BeforeEach(func() {
+			backup.Spec.Destinations = []BackupDestination{
+				{Name: "primary", BlobStoreConfiguration: &BlobStoreConfiguration{AccountName: "account@account"}},
+				{Name: "local", FileSystemConfiguration: &BackupFileSystemConfiguration{PersistentVolumeClaimName: "backup-data"}},
+			}
+		})
+
+		It("should reject a retention policy", func() {
+			backup.Spec.RetentionPolicy = &BackupRetentionPolicy{MaxSnapshots: pointer.Int(2)}
+			Expect(backup.ValidateDestinations()).To(MatchError("destination local uses a fileSystemConfiguration, which doesn't support a retentionPolicy"))
+		})
+
+		It("should reject the deletion of the backup data", func() {
+			policy := BackupDeletionPolicyDelete
+			backup.Spec.DeletionPolicy = &policy
+			Expect(backup.ValidateDestinations()).To(MatchError("destination local uses a fileSystemConfiguration, which doesn't support the deletionPolicy Delete"))
+		})
+
+		It("should allow to retain the backup data", func() {
+			policy := BackupDeletionPolicyRetain
+			backup.Spec.DeletionPolicy = &policy
+			Expect(backup.ValidateDestinations()).NotTo(HaveOccurred())
+		})
+	})
+
+	When("checking reconciliation for multiple destinations", func() {
+		BeforeEach(func() {
+			backup.ObjectMeta.Generation = 2
+			backup.Spec.Destinations = []BackupDestination{
+				{Name: "primary", BlobStoreConfiguration: &BlobStoreConfiguration{AccountName: "account@account"}},
+				{Name: "secondary", BlobStoreConfiguration: &BlobStoreConfiguration{AccountName: "other@account"}},
+			}
+			backup.Status = FoundationDBBackupStatus{
+				AgentCount:           2,
+				DeploymentConfigured: true,
+				BackupDetails: &FoundationDBBackupStatusBackupDetails{
+					Running:               true,
+					SnapshotPeriodSeconds: 864000,
+				},
+				Destinations: []BackupDestinationStatus{
+					{
+						Name: "primary",
+						BackupDetails: FoundationDBBackupStatusBackupDetails{
+							Running:               true,
+							SnapshotPeriodSeconds: 864000,
+						},
+					},
+				},
+			}
+		})
+
+		It("should require a backup start for the missing destination", func() {
+			reconciled, err := backup.CheckReconciliation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciled).To(BeFalse())
+			Expect(backup.Status.Generations.NeedsBackupStart).To(Equal(int64(2)))
+		})
+
+		When("a destination was removed", func() {
+			BeforeEach(func() {
+				backup.Spec.Destinations = backup.Spec.Destinations[:1]
+				backup.Status.Destinations = append(backup.Status.Destinations, BackupDestinationStatus{
+					Name: "removed",
+					BackupDetails: FoundationDBBackupStatusBackupDetails{
+						Running: true,
+					},
+				})
+			})
+
+			It("should require a backup stop", func() {
+				reconciled, err := backup.CheckReconciliation()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reconciled).To(BeFalse())
+				Expect(backup.Status.Generations.NeedsBackupStop).To(Equal(int64(2)))
+			})
+		})
+	})
+
+	When("getting the desired agent count", func() {
+		It("should default to 2 agents", func() {
+			Expect(backup.GetDesiredAgentCount()).To(Equal(2))
+		})
+
+		When("autoscaling is enabled", func() {
+			BeforeEach(func() {
+				minAgentCount := 3
+				backup.Spec.AgentAutoscaling = &BackupAgentAutoscaling{
+					MinAgentCount: &minAgentCount,
+					MaxAgentCount: 5,
+				}
+			})
+
+			It("should limit the agent count to the minimum", func() {
+				Expect(backup.GetDesiredAgentCount()).To(Equal(3))
+			})
+
+			When("the autoscaling chose an agent count", func() {
+				BeforeEach(func() {
+					backup.Status.AgentAutoscaling = &BackupAgentAutoscalingStatus{
+						DesiredAgentCount: 4,
+					}
+				})
+
+				It("should use the chosen agent count", func() {
+					Expect(backup.GetDesiredAgentCount()).To(Equal(4))
+				})
+			})
+
+			When("the autoscaling chose an agent count above the maximum", func() {
+				BeforeEach(func() {
+					backup.Status.AgentAutoscaling = &BackupAgentAutoscalingStatus{
+						DesiredAgentCount: 10,
+					}
+				})
+
+				It("should limit the agent count to the maximum", func() {
+					Expect(backup.GetDesiredAgentCount()).To(Equal(5))
+				})
+			})
+
+			When("the maximum is below the minimum", func() {
+				BeforeEach(func() {
+					backup.Spec.AgentAutoscaling.MaxAgentCount = 1
+				})
+
+				It("should use the minimum", func() {
+					Expect(backup.Spec.AgentAutoscaling.GetMaxAgentCount()).To(Equal(3))
+					Expect(backup.GetDesiredAgentCount()).To(Equal(3))
+				})
+			})
+		})
+	})
+
+	When("getting the deletion policy", func() {
+		It("should default to retain", func() {
+			Expect(backup.GetDeletionPolicy()).To(Equal(BackupDeletionPolicyRetain))
+		})
+
+		When("the deletion policy is set", func() {
+			BeforeEach(func() {
+				policy := BackupDeletionPolicyDelete
+				backup.Spec.DeletionPolicy = &policy
+			})
+
+			It("should use the deletion policy", func() {
+				Expect(backup.GetDeletionPolicy()).To(Equal(BackupDeletionPolicyDelete))
+			})
+		})
+	})
 })
@@ -219,6 +219,10 @@ type FoundationDBClusterSpec struct {
 	// the coordinator selection process could conflict.
 	CoordinatorSelection []CoordinatorSelectionSetting `json:"coordinatorSelection,omitempty"`
 
+	// CoordinatorConstraints defines additional constraints for the coordinator selection, e.g. nodes or zones
+	// that must not host a coordinator.
+	CoordinatorConstraints CoordinatorConstraints `json:"coordinatorConstraints,omitempty"`
+
 	// LabelConfig allows customizing labels used by the operator.
 	LabelConfig LabelConfig `json:"labels,omitempty"`
 
@@ -313,6 +317,77 @@ type FoundationDBClusterStatus struct {
 
 	// ReconciledProcessGroups reflects the number of process groups that have no condition and are not marked for removal.
 	ReconciledProcessGroups int `json:"reconciledProcessGroups,omitempty"`
+
+	// NodeDrains contains the progress of nodes that are drained because
+	// they carry one of the drain taints.
+	// +kubebuilder:validation:MaxItems=100
+	NodeDrains []NodeDrainStatus `json:"nodeDrains,omitempty"`
+
+	// StorageEngineMigration contains the number of storage servers per
+	// storage engine and the progress of the latest storage engine migration.
+	StorageEngineMigration *StorageEngineMigrationStatus `json:"storageEngineMigration,omitempty"`
+
+	// StorageWiggle contains the progress of the perpetual storage wiggle in
+	// the primary region, if the perpetual storage wiggle is enabled.
+	StorageWiggle *StorageWiggleStatus `json:"storageWiggle,omitempty"`
+
+	// ConfigurationPlan contains the database configuration changes that
+	// wait for approval or are currently run, if the approval of
+	// configuration changes is required.
+	ConfigurationPlan *ConfigurationPlan `json:"configurationPlan,omitempty"`
+
+	// CoordinatorChanges contains the most recent coordinator changes, the
+	// newest change is the last entry.
+	// +kubebuilder:validation:MaxItems=10
+	CoordinatorChanges []CoordinatorChange `json:"coordinatorChanges,omitempty"`
+}
+
+// MaxCoordinatorChanges defines the number of coordinator changes that are kept in the cluster status.
+const MaxCoordinatorChanges = 10
+
+// CoordinatorChange records a change of the coordinators.
+type CoordinatorChange struct {
+	// Timestamp defines when the coordinators were changed, as a unix timestamp.
+	Timestamp int64 `json:"timestamp"`
+
+	// PreviousConnectionString defines the connection string before the change.
+	PreviousConnectionString string `json:"previousConnectionString,omitempty"`
+
+	// ConnectionString defines the connection string after the change.
+	ConnectionString string `json:"connectionString"`
+
+	// Reason describes why the coordinators were changed.
+	// +kubebuilder:validation:MaxLength=1024
+	Reason string `json:"reason,omitempty"`
+}
+
+// AddCoordinatorChange appends the change to the coordinator changes and removes the oldest changes if more than
+// MaxCoordinatorChanges are recorded.
+func (clusterStatus *FoundationDBClusterStatus) AddCoordinatorChange(change CoordinatorChange) {
+	clusterStatus.CoordinatorChanges = append(clusterStatus.CoordinatorChanges, change)
+	if len(clusterStatus.CoordinatorChanges) > MaxCoordinatorChanges {
+		clusterStatus.CoordinatorChanges = clusterStatus.CoordinatorChanges[len(clusterStatus.CoordinatorChanges)-MaxCoordinatorChanges:]
+	}
+}
+
+// ConfigurationPlan represents the configuration changes that the operator
+// runs to reach the desired database configuration.
+type ConfigurationPlan struct {
+	// Hash identifies the plan. The plan is approved by setting the
+	// foundationdb.org/approved-configuration-plan annotation to this hash.
+	// +kubebuilder:validation:MaxLength=64
+	Hash string `json:"hash,omitempty"`
+
+	// Steps contains the configuration strings of the configure commands in
+	// the order they will be run.
+	// +kubebuilder:validation:MaxItems=100
+	Steps []string `json:"steps,omitempty"`
+
+	// CompletedSteps is the number of steps that were already run.
+	CompletedSteps int `json:"completedSteps,omitempty"`
+
+	// CreationTimestamp is the timestamp when the plan was created.
+	CreationTimestamp int64 `json:"creationTimestamp,omitempty"`
 }
 
 // MaintenanceModeInfo contains information regarding the zone and process groups that are put
@@ -351,6 +426,9 @@ type ProcessGroupStatus struct {
 	ExclusionTimestamp *metav1.Time `json:"exclusionTimestamp,omitempty"`
 	// ExclusionSkipped determines if exclusion has been skipped for a process, which will allow the process group to be removed without exclusion.
 	ExclusionSkipped bool `json:"exclusionSkipped,omitempty"`
+	// SuspensionTimestamp defines when the process group was suspended. A suspended process group has no Pod running
+	// but all other resources, like the PVC, are retained until the minimum suspension duration has passed.
+	SuspensionTimestamp *metav1.Time `json:"suspensionTimestamp,omitempty"`
 	// ProcessGroupConditions represents a list of degraded conditions that the process group is in.
 	ProcessGroupConditions []*ProcessGroupCondition `json:"processGroupConditions,omitempty"`
 	// FaultDomain represents the last seen fault domain from the cluster status. This can be used if a Pod or process
@@ -467,6 +545,29 @@ func (processGroupStatus *ProcessGroupStatus) MarkForRemoval() {
 	processGroupStatus.RemovalTimestamp = &metav1.Time{Time: time.Now()}
 }
 
+// IsSuspended returns if a process group is suspended.
+func (processGroupStatus *ProcessGroupStatus) IsSuspended() bool {
+	return !processGroupStatus.SuspensionTimestamp.IsZero()
+}
+
+// SetSuspended marks a process group as suspended.
+func (processGroupStatus *ProcessGroupStatus) SetSuspended() {
+	if !processGroupStatus.SuspensionTimestamp.IsZero() {
+		return
+	}
+
+	processGroupStatus.SuspensionTimestamp = &metav1.Time{Time: time.Now()}
+}
+
+// Recover resets the removal, exclusion and suspension information of a process group, so that the process group will
+// be recreated by the operator.
+func (processGroupStatus *ProcessGroupStatus) Recover() {
+	processGroupStatus.RemovalTimestamp = nil
+	processGroupStatus.ExclusionTimestamp = nil
+	processGroupStatus.ExclusionSkipped = false
+	processGroupStatus.SuspensionTimestamp = nil
+}
+
 // GetPodName returns the Pod name for the associated Process Group.
 func (processGroupStatus *ProcessGroupStatus) GetPodName(cluster *FoundationDBCluster) string {
 	var sb strings.Builder
@@ -488,28 +589,37 @@ func (processGroupStatus *ProcessGroupStatus) GetPodName(cluster *FoundationDBCl
 // The method will return the failure condition and the timestamp. If no failure is detected an empty condition and a 0
 // will be returned.
 func (processGroupStatus *ProcessGroupStatus) NeedsReplacement(failureTime int, taintReplacementTime int) (ProcessGroupConditionType, int64) {
-	var earliestFailureTime int64 = math.MaxInt64
-	var earliestTaintReplacementTime int64 = math.MaxInt64
+	return processGroupStatus.GetFailureCondition(conditionsThatNeedReplacement, func(conditionType ProcessGroupConditionType) int {
+		if conditionType == NodeTaintReplacing {
+			return taintReplacementTime
+		}
+
+		return failureTime
+	})
+}
 
+// GetFailureCondition checks if the ProcessGroupStatus has any of the provided conditions for longer than the time
+// in seconds returned by replacementTime for this condition. If multiple conditions are exceeding their replacement
+// time, the condition with the earliest timestamp will be returned. If no failure is detected an empty condition and
+// a 0 will be returned.
+func (processGroupStatus *ProcessGroupStatus) GetFailureCondition(conditions []ProcessGroupConditionType, replacementTime func(ProcessGroupConditionType) int) (ProcessGroupConditionType, int64) {
 	// If the process group is already marked for removal we can ignore it.
 	if processGroupStatus.IsMarkedForRemoval() {
 		return "", 0
 	}
 
+	var earliestFailureTime int64 = math.MaxInt64
 	var failureCondition ProcessGroupConditionType
-	for _, conditionType := range conditionsThatNeedReplacement {
+	now := time.Now()
+	for _, conditionType := range conditions {
 		conditionTimePtr := processGroupStatus.GetConditionTime(conditionType)
 		if conditionTimePtr == nil {
 			continue
 		}
 
 		conditionTime := *conditionTimePtr
-		if conditionType == NodeTaintReplacing {
-			if earliestTaintReplacementTime > conditionTime {
-				earliestTaintReplacementTime = conditionTime
-			}
-
-			failureCondition = conditionType
+		windowStart := now.Add(-1 * time.Duration(replacementTime(conditionType)) * time.Second).Unix()
+		if conditionTime >= windowStart {
 			continue
 		}
 
@@ -519,18 +629,12 @@ func (processGroupStatus *ProcessGroupStatus) NeedsReplacement(failureTime int,
 		}
 	}
 
-	failureWindowStart := time.Now().Add(-1 * time.Duration(failureTime) * time.Second).Unix()
-	if earliestFailureTime < failureWindowStart {
-		return failureCondition, earliestFailureTime
-	}
-
-	taintWindowStart := time.Now().Add(-1 * time.Duration(taintReplacementTime) * time.Second).Unix()
-	if earliestTaintReplacementTime < taintWindowStart {
-		return failureCondition, earliestTaintReplacementTime
+	if failureCondition == "" {
+		// No failure detected.
+		return "", 0
 	}
 
-	// No failure detected.
-	return "", 0
+	return failureCondition, earliestFailureTime
 }
 
 // AddAddresses adds the new address to the ProcessGroupStatus and removes duplicates and old addresses
@@ -1064,6 +1168,15 @@ type FoundationDBClusterAutomationOptions struct {
 	// the database.
 	ConfigureDatabase *bool `json:"configureDatabase,omitempty"`
 
+	// RequireConfigurationApproval defines whether changes to the database
+	// configuration must be approved before the operator runs them. If
+	// enabled, the operator writes the planned configuration changes into
+	// the configurationPlan field of the cluster status and waits until the
+	// foundationdb.org/approved-configuration-plan annotation matches the
+	// hash of the plan.
+	// The default is false.
+	RequireConfigurationApproval *bool `json:"requireConfigurationApproval,omitempty"`
+
 	// KillProcesses defines whether the operator is allowed to bounce fdbserver
 	// processes.
 	KillProcesses *bool `json:"killProcesses,omitempty"`
@@ -1077,6 +1190,10 @@ type FoundationDBClusterAutomationOptions struct {
 	// processes.
 	Replacements AutomaticReplacementOptions `json:"replacements,omitempty"`
 
+	// NodeDrainOptions contains options for draining nodes that are
+	// tainted for planned maintenance.
+	NodeDrainOptions NodeDrainOptions `json:"nodeDrainOptions,omitempty"`
+
 	// IgnorePendingPodsDuration defines how long a Pod has to be in the Pending Phase before
 	// ignore it during reconciliation. This prevents Pod that are stuck in Pending to block
 	// further reconciliation.
@@ -1141,6 +1258,13 @@ type FoundationDBClusterAutomationOptions struct {
 	// Defaults to 60.
 	WaitBetweenRemovalsSeconds *int `json:"waitBetweenRemovalsSeconds,omitempty"`
 
+	// MinimumSuspensionDurationSeconds defines how long a process group will be suspended before the resources of the
+	// process group are removed. A suspended process group has no Pod running, but the PVC and the Service are retained
+	// and the process group can be recovered with the kubectl fdb plugin. Setting this to 0 disables the suspension of
+	// process groups. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	MinimumSuspensionDurationSeconds *int `json:"minimumSuspensionDurationSeconds,omitempty"`
+
 	// PodUpdateStrategy defines how Pod spec changes are rolled out either by replacing Pods or by deleting Pods.
 	// The default for this is ReplaceTransactionSystem.
 	// +kubebuilder:validation:Optional
@@ -1152,6 +1276,12 @@ type FoundationDBClusterAutomationOptions struct {
 	// using fdbcli to interact with the FoundationDB cluster.
 	UseManagementAPI *bool `json:"useManagementAPI,omitempty"`
 
+	// UseProcessGroupResources defines if the operator should store the process group information in dedicated
+	// FoundationDBProcessGroup resources instead of the processGroups list in the cluster status. This reduces the size
+	// of the cluster resource for large clusters.
+	// The default is false.
+	UseProcessGroupResources *bool `json:"useProcessGroupResources,omitempty"`
+
 	// MaintenanceModeOptions contains options for maintenance mode related settings.
 	MaintenanceModeOptions MaintenanceModeOptions `json:"maintenanceModeOptions,omitempty"`
 
@@ -1159,6 +1289,10 @@ type FoundationDBClusterAutomationOptions struct {
 	// The default is a list that includes "fdb-kubernetes-operator".
 	// +kubebuilder:validation:MaxItems=10
 	IgnoreLogGroupsForUpgrade []LogGroup `json:"ignoreLogGroupsForUpgrade,omitempty"`
+
+	// StorageEngineMigration defines how the operator migrates the storage servers to a new storage engine when the
+	// storage engine in the database configuration is changed.
+	StorageEngineMigration StorageEngineMigrationOptions `json:"storageEngineMigration,omitempty"`
 }
 
 // LogGroup represents a LogGroup used by a FoundationDB process to log trace events. The LogGroup can be used to filter
@@ -1200,6 +1334,39 @@ type TaintReplacementOption struct {
 	DurationInSeconds *int64 `json:"durationInSeconds,omitempty"`
 }
 
+// ReplacementConditionPolicy defines the automatic replacement policy for a specific process group condition.
+type ReplacementConditionPolicy struct {
+	// ConditionType defines the process group condition this policy applies to.
+	// +kubebuilder:validation:Enum=MissingProcesses;PodFailing;MissingPod;MissingPVC;MissingService;PodPending;NodeTaintDetected;NodeTaintReplacing;ProcessIsMarkedAsExcluded
+	ConditionType ProcessGroupConditionType `json:"conditionType"`
+
+	// FailureDetectionTimeSeconds controls how long a process group must have this condition before it is
+	// automatically replaced. If unset the failureDetectionTimeSeconds of the automatic replacement options will
+	// be used, or for the NodeTaintReplacing condition the taintReplacementTimeSeconds.
+	// +kubebuilder:validation:Minimum=0
+	FailureDetectionTimeSeconds *int `json:"failureDetectionTimeSeconds,omitempty"`
+
+	// MaxConcurrentReplacements defines a separate budget for concurrent replacements that are caused by this
+	// condition. Replacements that are caused by this condition will not be counted against the budget of the
+	// process class. If unset the replacements will be counted against the replacement bucket of the process class.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentReplacements *int `json:"maxConcurrentReplacements,omitempty"`
+}
+
+// ReplacementBucket defines the budget for concurrent automatic replacements of a specific process class.
+type ReplacementBucket struct {
+	// ProcessClass defines the process class this bucket applies to. The bucket for the general process class
+	// will be used for all process classes without a specific bucket.
+	ProcessClass ProcessClass `json:"processClass"`
+
+	// MaxConcurrentReplacements controls how many automatic replacements of this process class are allowed to take
+	// part. If unset the maxConcurrentReplacements of the automatic replacement options will be used. The sum of all
+	// replacements that are counted against the process class buckets is limited by the maxConcurrentReplacements of
+	// the automatic replacement options.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentReplacements *int `json:"maxConcurrentReplacements,omitempty"`
+}
+
 // AutomaticReplacementOptions controls options for automatically replacing
 // failed processes.
 type AutomaticReplacementOptions struct {
@@ -1230,6 +1397,211 @@ type AutomaticReplacementOptions struct {
 	// TaintReplacementOption controls which taint label the operator will react to.
 	// +kubebuilder:validation:MaxItems=32
 	TaintReplacementOptions []TaintReplacementOption `json:"taintReplacementOptions,omitempty"`
+
+	// ConditionPolicies defines per-condition replacement policies. A policy can define a different failure detection
+	// time and a separate budget for concurrent replacements for a specific process group condition. Conditions
+	// that have a policy defined will be considered for automatic replacements.
+	// +kubebuilder:validation:MaxItems=32
+	ConditionPolicies []ReplacementConditionPolicy `json:"conditionPolicies,omitempty"`
+
+	// ReplacementBuckets defines the budget for concurrent replacements per process class. If no specific bucket
+	// for a process class is defined the bucket for the general process class will be used. If no bucket for the
+	// general process class is defined the value of maxConcurrentReplacements will be used.
+	// +kubebuilder:validation:MaxItems=32
+	ReplacementBuckets []ReplacementBucket `json:"replacementBuckets,omitempty"`
+}
+
+// NodeDrainOptions controls how the operator drains nodes that are tainted
+// for planned maintenance.
+type NodeDrainOptions struct {
+	// TaintKeys defines the taint keys that mark a node for draining. All
+	// process groups running on a node with one of these taints will be
+	// excluded as one batch, and their Pods will be removed once the data
+	// has been moved off the node. If empty, node draining is disabled.
+	// +kubebuilder:validation:MaxItems=32
+	TaintKeys []string `json:"taintKeys,omitempty"`
+}
+
+// StorageEngineMigrationStrategy defines how the storage servers are migrated to a new storage engine.
+// +kubebuilder:validation:MaxLength=32
+type StorageEngineMigrationStrategy string
+
+const (
+	// StorageEngineMigrationStrategyInPlace migrates the storage servers with the perpetual storage wiggle of
+	// FoundationDB. Every storage server is excluded and recreated with the new storage engine on the same volume.
+	StorageEngineMigrationStrategyInPlace StorageEngineMigrationStrategy = "InPlace"
+
+	// StorageEngineMigrationStrategyReplaceProcessGroups migrates the storage servers by replacing the storage
+	// process groups, so the new storage servers get new volumes.
+	StorageEngineMigrationStrategyReplaceProcessGroups StorageEngineMigrationStrategy = "ReplaceProcessGroups"
+)
+
+// StorageEngineMigrationOptions controls how the operator migrates the
+// storage servers to a new storage engine.
+type StorageEngineMigrationOptions struct {
+	// Strategy defines how the storage servers are migrated. The InPlace
+	// strategy enables the gradual storage migration and the perpetual
+	// storage wiggle of FoundationDB until all storage servers are migrated,
+	// this requires FoundationDB 7.0 or newer. The ReplaceProcessGroups
+	// strategy replaces the storage process groups that use a different
+	// storage engine.
+	// The default is InPlace.
+	// +kubebuilder:validation:Enum=InPlace;ReplaceProcessGroups
+	Strategy StorageEngineMigrationStrategy `json:"strategy,omitempty"`
+
+	// MaxConcurrentMigrations defines how many storage process groups are
+	// migrated at the same time. The perpetual storage wiggle of the InPlace
+	// strategy migrates one storage server at a time, so with the InPlace
+	// strategy the operator replaces up to MaxConcurrentMigrations - 1
+	// additional storage process groups.
+	// The default is 1.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentMigrations *int `json:"maxConcurrentMigrations,omitempty"`
+}
+
+// StorageEngineMigrationStatus represents the progress of a storage engine
+// migration.
+type StorageEngineMigrationStatus struct {
+	// StorageEngine is the storage engine the storage servers are migrated
+	// to.
+	StorageEngine StorageEngine `json:"storageEngine,omitempty"`
+
+	// Strategy is the strategy that is used for the migration.
+	Strategy StorageEngineMigrationStrategy `json:"strategy,omitempty"`
+
+	// StorageServers contains the number of storage servers per storage
+	// engine. Storage servers that don't report their storage engine are
+	// counted as unknown.
+	StorageServers map[StorageEngine]int `json:"storageServers,omitempty"`
+
+	// StorageServersToMigrate is the number of storage servers that used a
+	// different storage engine when the migration was started.
+	StorageServersToMigrate int `json:"storageServersToMigrate,omitempty"`
+
+	// StartTimestamp is the timestamp when the operator started the
+	// migration.
+	StartTimestamp int64 `json:"startTimestamp,omitempty"`
+
+	// CompletionTimestamp is the timestamp when all storage servers were
+	// migrated.
+	CompletionTimestamp int64 `json:"completionTimestamp,omitempty"`
+
+	// EstimatedCompletionTimestamp is the estimated timestamp when all storage
+	// servers will be migrated, based on the progress since the start of the
+	// migration.
+	EstimatedCompletionTimestamp int64 `json:"estimatedCompletionTimestamp,omitempty"`
+
+	// PreviousStorageMigrationType is the storage migration type of the
+	// database before the InPlace strategy enabled the gradual storage
+	// migration. The operator restores it once the migration is completed.
+	PreviousStorageMigrationType *StorageMigrationType `json:"previousStorageMigrationType,omitempty"`
+
+	// PreviousPerpetualStorageWiggle is the perpetual storage wiggle setting
+	// of the database before the InPlace strategy enabled the perpetual
+	// storage wiggle. The operator restores it once the migration is
+	// completed.
+	PreviousPerpetualStorageWiggle *int `json:"previousPerpetualStorageWiggle,omitempty"`
+}
+
+// IsInProgress returns true if the migration was started and is not yet
+// completed.
+func (migrationStatus *StorageEngineMigrationStatus) IsInProgress() bool {
+	return migrationStatus != nil && migrationStatus.StartTimestamp > 0 && migrationStatus.CompletionTimestamp == 0
+}
+
+// GetRemainingStorageServers returns the number of storage servers that
+// use a different storage engine than the target of the migration. Storage
+// servers with an unknown storage engine are not counted.
+func (migrationStatus *StorageEngineMigrationStatus) GetRemainingStorageServers() int {
+	if migrationStatus == nil {
+		return 0
+	}
+
+	remaining := 0
+	for storageEngine, count := range migrationStatus.StorageServers {
+		if storageEngine == StorageEngineUnknown || storageEngine.GetStorageServerStorageEngine() == migrationStatus.StorageEngine.GetStorageServerStorageEngine() {
+			continue
+		}
+
+		remaining += count
+	}
+
+	return remaining
+}
+
+// StorageWiggleStatus represents the progress of the perpetual storage
+// wiggle.
+type StorageWiggleStatus struct {
+	// FinishedRounds is the number of rounds in which all storage servers
+	// were wiggled.
+	FinishedRounds int `json:"finishedRounds,omitempty"`
+
+	// FinishedWiggles is the number of storage servers that were wiggled.
+	FinishedWiggles int `json:"finishedWiggles,omitempty"`
+
+	// LastRoundStartTimestamp is the timestamp when the last round was
+	// started.
+	LastRoundStartTimestamp int64 `json:"lastRoundStartTimestamp,omitempty"`
+
+	// LastRoundFinishTimestamp is the timestamp when the last round was
+	// finished.
+	LastRoundFinishTimestamp int64 `json:"lastRoundFinishTimestamp,omitempty"`
+
+	// LastWiggleFinishTimestamp is the timestamp when the wiggle of the
+	// last storage server was finished.
+	LastWiggleFinishTimestamp int64 `json:"lastWiggleFinishTimestamp,omitempty"`
+
+	// SmoothedRoundSeconds is the smoothed duration of a round in seconds.
+	SmoothedRoundSeconds int64 `json:"smoothedRoundSeconds,omitempty"`
+
+	// SmoothedWiggleSeconds is the smoothed duration of the wiggle of a
+	// storage server in seconds.
+	SmoothedWiggleSeconds int64 `json:"smoothedWiggleSeconds,omitempty"`
+
+	// WiggledAddresses contains the addresses of the storage servers that are
+	// currently wiggled.
+	// +kubebuilder:validation:MaxItems=1000
+	WiggledAddresses []string `json:"wiggledAddresses,omitempty"`
+}
+
+// NodeDrainPhase represents the phase of a node drain.
+// +kubebuilder:validation:MaxLength=32
+type NodeDrainPhase string
+
+const (
+	// NodeDrainPhasePending represents a node drain that waits until the
+	// drains in another fault domain are done.
+	NodeDrainPhasePending NodeDrainPhase = "Pending"
+
+	// NodeDrainPhaseExcluding represents a node drain where the process
+	// groups on the node are being excluded.
+	NodeDrainPhaseExcluding NodeDrainPhase = "Excluding"
+
+	// NodeDrainPhaseRemoving represents a node drain where all process groups
+	// on the node are excluded and their resources are being removed.
+	NodeDrainPhaseRemoving NodeDrainPhase = "Removing"
+
+	// NodeDrainPhaseDrained represents a node drain where all process groups
+	// were removed from the node.
+	NodeDrainPhaseDrained NodeDrainPhase = "Drained"
+)
+
+// NodeDrainStatus represents the progress of draining a single node.
+type NodeDrainStatus struct {
+	// NodeName is the name of the node that is drained.
+	NodeName string `json:"nodeName"`
+
+	// Phase is the current phase of the node drain.
+	Phase NodeDrainPhase `json:"phase,omitempty"`
+
+	// ProcessGroups contains the process groups that were running on the
+	// node when the drain was started.
+	// +kubebuilder:validation:MaxItems=200
+	ProcessGroups []ProcessGroupID `json:"processGroups,omitempty"`
+
+	// StartTimestamp is the timestamp when the operator started to drain the
+	// node.
+	StartTimestamp int64 `json:"startTimestamp,omitempty"`
 }
 
 // ProcessSettings defines process-level settings.
@@ -1441,6 +1813,96 @@ func (cluster *FoundationDBCluster) MinimumFaultDomains() int {
 	return MinimumFaultDomains(cluster.Spec.DatabaseConfiguration.RedundancyMode)
 }
 
+// UseFaultDomainDistribution returns true if the process groups should be distributed across logical fault domains.
+func (cluster *FoundationDBCluster) UseFaultDomainDistribution() bool {
+	if cluster.Spec.FaultDomain.DistributionConfig == nil {
+		return false
+	}
+
+	if cluster.Spec.FaultDomain.Key == NoneFaultDomainKey || cluster.Spec.FaultDomain.Key == "foundationdb.org/kubernetes-cluster" {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.FaultDomain.DistributionConfig.Enabled, false)
+}
+
+// GetDesiredLogicalFaultDomains returns the number of logical fault domains. Per default this is
+// the number of fault domains required by the redundancy mode plus the desired fault tolerance.
+func (cluster *FoundationDBCluster) GetDesiredLogicalFaultDomains() int {
+	if cluster.Spec.FaultDomain.DistributionConfig == nil {
+		return cluster.MinimumFaultDomains() + cluster.DesiredFaultTolerance()
+	}
+
+	return pointer.IntDeref(cluster.Spec.FaultDomain.DistributionConfig.DesiredFaultDomains, cluster.MinimumFaultDomains()+cluster.DesiredFaultTolerance())
+}
+
+// GetLogicalFaultDomains returns the logical fault domains of the cluster. The logical fault domains are shared by all
+// process classes and contain the cluster name, to make sure that the zone IDs are unique across multiple clusters that
+// form a single FoundationDB cluster.
+func (cluster *FoundationDBCluster) GetLogicalFaultDomains() []FaultDomain {
+	desiredFaultDomains := cluster.GetDesiredLogicalFaultDomains()
+	faultDomains := make([]FaultDomain, 0, desiredFaultDomains)
+	for i := 0; i < desiredFaultDomains; i++ {
+		faultDomains = append(faultDomains, FaultDomain(fmt.Sprintf("%s-%d", cluster.Name, i)))
+	}
+
+	return faultDomains
+}
+
+// IsLogicalFaultDomain returns true if the fault domain of the process group is one of the logical fault domains of
+// the cluster.
+func (cluster *FoundationDBCluster) IsLogicalFaultDomain(processGroup *ProcessGroupStatus) bool {
+	if !cluster.UseFaultDomainDistribution() || processGroup.FaultDomain == "" {
+		return false
+	}
+
+	for _, faultDomain := range cluster.GetLogicalFaultDomains() {
+		if processGroup.FaultDomain == faultDomain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetLogicalFaultDomainCounts returns the number of process groups per logical fault domain for the provided process
+// class. Process groups that are marked for removal are not counted.
+func (cluster *FoundationDBCluster) GetLogicalFaultDomainCounts(processClass ProcessClass) map[FaultDomain]int {
+	counts := make(map[FaultDomain]int)
+	for _, faultDomain := range cluster.GetLogicalFaultDomains() {
+		counts[faultDomain] = 0
+	}
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != processClass || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if !cluster.IsLogicalFaultDomain(processGroup) {
+			continue
+		}
+
+		counts[processGroup.FaultDomain]++
+	}
+
+	return counts
+}
+
+// GetNextLogicalFaultDomain returns the logical fault domain with the fewest process groups based on the provided
+// counts. The counts will be updated to include the new process group.
+func (cluster *FoundationDBCluster) GetNextLogicalFaultDomain(counts map[FaultDomain]int) FaultDomain {
+	var nextFaultDomain FaultDomain
+	for _, faultDomain := range cluster.GetLogicalFaultDomains() {
+		if nextFaultDomain == "" || counts[faultDomain] < counts[nextFaultDomain] {
+			nextFaultDomain = faultDomain
+		}
+	}
+
+	counts[nextFaultDomain]++
+
+	return nextFaultDomain
+}
+
 // DesiredCoordinatorCount returns the number of coordinators to recruit for a cluster.
 func (cluster *FoundationDBCluster) DesiredCoordinatorCount() int {
 	if cluster.Spec.DatabaseConfiguration.UsableRegions > 1 || cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall {
@@ -1783,6 +2245,30 @@ type FoundationDBClusterFaultDomain struct {
 	// KCs in the data center. This is only used in the `kubernetes-cluster`
 	// fault domain strategy.
 	ZoneIndex int `json:"zoneIndex,omitempty"`
+
+	// DistributionConfig defines if the process groups should be bin packed
+	// into a fixed number of logical fault domains instead of being spread
+	// across as many fault domains as possible. Process groups of different
+	// logical fault domains will never be scheduled in the same physical fault
+	// domain. This is not supported for the `foundationdb.org/none` and the
+	// `kubernetes-cluster` fault domain strategy.
+	DistributionConfig *DistributionConfig `json:"distributionConfig,omitempty"`
+}
+
+// DistributionConfig defines the configuration for distributing the process
+// groups of a cluster across logical fault domains.
+type DistributionConfig struct {
+	// Enabled defines if the process groups should be distributed across
+	// logical fault domains.
+	// Default: false
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// DesiredFaultDomains defines the number of logical fault domains. The
+	// logical fault domains are shared by all process classes.
+	// Default: The number of fault domains required by the redundancy mode
+	// plus the desired fault tolerance.
+	// +kubebuilder:validation:Minimum=1
+	DesiredFaultDomains *int `json:"desiredFaultDomains,omitempty"`
 }
 
 // ContainerOverrides provides options for customizing a container created by
@@ -1833,14 +2319,21 @@ func (cluster *FoundationDBCluster) DesiredDatabaseConfiguration() DatabaseConfi
 		configuration.StorageEngine = StorageEngineMemory2
 	}
 
+	// The storage wiggle settings are only reported by versions that support them.
+	if !version.SupportsStorageMigrationConfiguration() {
+		configuration.PerpetualStorageWiggle = nil
+		configuration.PerpetualStorageWiggleLocality = nil
+		configuration.StorageMigrationType = nil
+	}
+
 	return configuration
 }
 
-// ClearMissingVersionFlags clears any version flags in the given configuration that are not
-// set in the configuration in the cluster spec.
+// ClearMissingVersionFlags clears any version flags and storage wiggle settings in the given
+// configuration that are not set in the configuration in the cluster spec.
 //
 // This allows us to compare the spec to the live configuration while ignoring
-// version flags that are unset in the spec.
+// settings that are unset in the spec.
 func (cluster *FoundationDBCluster) ClearMissingVersionFlags(configuration *DatabaseConfiguration) {
 	if cluster.Spec.DatabaseConfiguration.LogVersion == 0 {
 		configuration.LogVersion = 0
@@ -1848,6 +2341,21 @@ func (cluster *FoundationDBCluster) ClearMissingVersionFlags(configuration *Data
 	if cluster.Spec.DatabaseConfiguration.LogSpill == 0 {
 		configuration.LogSpill = 0
 	}
+	if cluster.Spec.DatabaseConfiguration.PerpetualStorageWiggle == nil {
+		configuration.PerpetualStorageWiggle = nil
+	}
+	if cluster.Spec.DatabaseConfiguration.PerpetualStorageWiggleLocality == nil {
+		configuration.PerpetualStorageWiggleLocality = nil
+	}
+	if cluster.Spec.DatabaseConfiguration.StorageMigrationType == nil {
+		configuration.StorageMigrationType = nil
+	}
+}
+
+// ManagesStorageMigration returns true if the storage migration type or the perpetual storage wiggle are defined
+// in the database configuration of the cluster spec.
+func (cluster *FoundationDBCluster) ManagesStorageMigration() bool {
+	return cluster.Spec.DatabaseConfiguration.PerpetualStorageWiggle != nil || cluster.Spec.DatabaseConfiguration.StorageMigrationType != nil
 }
 
 // IsBeingUpgraded determines whether the cluster has a pending upgrade.
@@ -1915,7 +2423,19 @@ func (cluster *FoundationDBCluster) ShouldUseLocks() bool {
 		return !*disabled
 	}
 
-	return cluster.Spec.FaultDomain.ZoneCount > 1 || len(cluster.Spec.DatabaseConfiguration.Regions) > 1
+	return cluster.Spec.FaultDomain.ZoneCount > 1 || len(cluster.Spec.DatabaseConfiguration.Regions) > 1 || cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall
+}
+
+// ShouldUseGlobalCoordination determines whether the operator instances in
+// the different data centers should agree on global actions before
+// performing them. Global coordination requires locks to be enabled and the
+// data center of this cluster to be defined.
+func (cluster *FoundationDBCluster) ShouldUseGlobalCoordination() bool {
+	if !cluster.ShouldUseLocks() || cluster.Spec.DataCenter == "" {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.LockOptions.UseGlobalCoordination, false)
 }
 
 // GetLockPrefix gets the prefix for the keys where we store locking
@@ -1975,8 +2495,31 @@ type LockOptions struct {
 	// DenyList manages configuration for whether an instance of the operator
 	// should be denied from taking locks.
 	DenyList []LockDenyListEntry `json:"denyList,omitempty"`
+
+	// UseGlobalCoordination determines whether the operator instances in the
+	// different data centers should agree on configuration changes and
+	// coordinator changes before one of them performs the action. Version
+	// incompatible upgrades are always coordinated through the pending
+	// upgrades if locks are enabled. This requires that all operator instances managing the cluster
+	// have this setting enabled and have the dataCenter field set.
+	// +kubebuilder:default:=false
+	UseGlobalCoordination *bool `json:"useGlobalCoordination,omitempty"`
 }
 
+// GlobalCoordinationAction defines an action that must be agreed on by all
+// operator instances managing a multi-region cluster before it is performed.
+type GlobalCoordinationAction string
+
+const (
+	// GlobalCoordinationActionConfigureDatabase represents a change of the
+	// database configuration.
+	GlobalCoordinationActionConfigureDatabase GlobalCoordinationAction = "ConfigureDatabase"
+
+	// GlobalCoordinationActionChangeCoordinators represents a change of the
+	// coordinators.
+	GlobalCoordinationActionChangeCoordinators GlobalCoordinationAction = "ChangeCoordinators"
+)
+
 // LockDenyListEntry models an entry in the deny list for the locking system.
 type LockDenyListEntry struct {
 	// The ID of the operator instance this entry is targeting.
@@ -2158,6 +2701,36 @@ type CoordinatorSelectionSetting struct {
 	Priority int `json:"priority,omitempty"`
 }
 
+// CoordinatorConstraints defines additional constraints for the coordinator selection.
+type CoordinatorConstraints struct {
+	// ExcludedNodes defines the Kubernetes nodes that must not host a coordinator. The node of a process is read
+	// from the spec.nodeName of its Pod.
+	// +kubebuilder:validation:MaxItems=1000
+	ExcludedNodes []string `json:"excludedNodes,omitempty"`
+
+	// ExcludedZones defines the zones that must not host a coordinator. The zone of a process is read from the
+	// zoneid locality.
+	// +kubebuilder:validation:MaxItems=1000
+	ExcludedZones []string `json:"excludedZones,omitempty"`
+
+	// PreferredPodLabels defines labels that mark the Pods of processes that should be preferred as coordinators,
+	// e.g. Pods that are not running on spot instances. A process is preferred if its Pod has all the labels. The
+	// preference takes precedence over the priority of the process class but not over the distribution across
+	// the fault domains.
+	PreferredPodLabels map[string]string `json:"preferredPodLabels,omitempty"`
+
+	// MaxCoordinatorsPerLocality defines the maximum number of coordinators that can be selected for a single
+	// value of a locality, e.g. "data_hall: 2" or a custom rack locality. If the operator already has a stricter
+	// limit for the locality, the stricter limit will be used.
+	MaxCoordinatorsPerLocality map[string]int `json:"maxCoordinatorsPerLocality,omitempty"`
+
+	// PinnedCoordinators defines the process groups that must be selected as coordinators, e.g. to recover a
+	// cluster in a disaster recovery scenario. If defined, all other constraints will be ignored and the number
+	// of process groups must match the desired number of coordinators.
+	// +kubebuilder:validation:MaxItems=9
+	PinnedCoordinators []ProcessGroupID `json:"pinnedCoordinators,omitempty"`
+}
+
 // IsEligibleAsCandidate checks if the given process has the right process class to be considered a valid coordinator.
 // This method will always return false for non stateful process classes.
 func (cluster *FoundationDBCluster) IsEligibleAsCandidate(pClass ProcessClass) bool {
@@ -2202,6 +2775,64 @@ func (cluster *FoundationDBCluster) GetClassCandidatePriority(pClass ProcessClas
 	return math.MinInt64
 }
 
+// HasPinnedCoordinators returns true if the coordinators are pinned to specific process groups.
+func (cluster *FoundationDBCluster) HasPinnedCoordinators() bool {
+	return len(cluster.Spec.CoordinatorConstraints.PinnedCoordinators) > 0
+}
+
+// IsPinnedCoordinator returns true if the process group is part of the pinned coordinators.
+func (cluster *FoundationDBCluster) IsPinnedCoordinator(processGroupID ProcessGroupID) bool {
+	for _, pinned := range cluster.Spec.CoordinatorConstraints.PinnedCoordinators {
+		if pinned == processGroupID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsExcludedFromCoordinators returns true if a process must not host a coordinator because the node of its Pod or
+// its zone is excluded. The node name is read from the spec.nodeName of the Pod and can be empty if the Pod is
+// unknown. Pinned coordinators are never excluded.
+func (cluster *FoundationDBCluster) IsExcludedFromCoordinators(nodeName string, localities map[string]string) bool {
+	if cluster.HasPinnedCoordinators() {
+		return false
+	}
+
+	constraints := cluster.Spec.CoordinatorConstraints
+	for _, node := range constraints.ExcludedNodes {
+		if nodeName != "" && nodeName == node {
+			return true
+		}
+	}
+
+	for _, zone := range constraints.ExcludedZones {
+		if localities[FDBLocalityZoneIDKey] == zone {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPreferredCoordinatorPod returns true if the Pod labels match all preferred Pod labels of the coordinator
+// constraints. If no preferred Pod labels are defined, no Pod is preferred.
+func (cluster *FoundationDBCluster) IsPreferredCoordinatorPod(labels map[string]string) bool {
+	preferredLabels := cluster.Spec.CoordinatorConstraints.PreferredPodLabels
+	if len(preferredLabels) == 0 {
+		return false
+	}
+
+	for key, value := range preferredLabels {
+		current, ok := labels[key]
+		if !ok || current != value {
+			return false
+		}
+	}
+
+	return true
+}
+
 // ShouldFilterOnOwnerReferences determines if we should check owner references
 // when determining if a resource is related to this cluster.
 func (cluster *FoundationDBCluster) ShouldFilterOnOwnerReferences() bool {
@@ -2282,11 +2913,45 @@ func (cluster *FoundationDBCluster) GetMaxConcurrentReplacements() int {
 	return pointer.IntDeref(cluster.Spec.AutomationOptions.MaxConcurrentReplacements, math.MaxInt64)
 }
 
+// GetStorageEngineMigrationStrategy returns the strategy for storage engine migrations or InPlace if unset.
+func (cluster *FoundationDBCluster) GetStorageEngineMigrationStrategy() StorageEngineMigrationStrategy {
+	if cluster.Spec.AutomationOptions.StorageEngineMigration.Strategy == "" {
+		return StorageEngineMigrationStrategyInPlace
+	}
+
+	return cluster.Spec.AutomationOptions.StorageEngineMigration.Strategy
+}
+
+// GetMaxConcurrentStorageEngineMigrations returns the value of MaxConcurrentMigrations or 1 if unset.
+func (cluster *FoundationDBCluster) GetMaxConcurrentStorageEngineMigrations() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.StorageEngineMigration.MaxConcurrentMigrations, 1)
+}
+
+// RequiresConfigurationApproval returns the value of RequireConfigurationApproval or false if unset.
+func (cluster *FoundationDBCluster) RequiresConfigurationApproval() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.RequireConfigurationApproval, false)
+}
+
+// IsConfigurationPlanApproved returns true if the approval annotation matches the hash of the configuration plan in
+// the cluster status.
+func (cluster *FoundationDBCluster) IsConfigurationPlanApproved() bool {
+	if cluster.Status.ConfigurationPlan == nil || cluster.Status.ConfigurationPlan.Hash == "" {
+		return false
+	}
+
+	return cluster.Annotations[ApprovedConfigurationPlanAnnotation] == cluster.Status.ConfigurationPlan.Hash
+}
+
 // UseManagementAPI returns the value of UseManagementAPI or false if unset.
 func (cluster *FoundationDBCluster) UseManagementAPI() bool {
 	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseManagementAPI, false)
 }
 
+// UseProcessGroupResources returns the value of UseProcessGroupResources or false if unset.
+func (cluster *FoundationDBCluster) UseProcessGroupResources() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseProcessGroupResources, false)
+}
+
 // PodUpdateMode defines the deletion mode for the cluster
 type PodUpdateMode string
 
@@ -2350,6 +3015,12 @@ func (cluster *FoundationDBCluster) GetWaitBetweenRemovalsSeconds() int {
 	return duration
 }
 
+// GetMinimumSuspensionDuration returns the minimum duration a process group will be suspended before the resources are
+// removed. If unset the suspension is disabled and 0 will be returned.
+func (cluster *FoundationDBCluster) GetMinimumSuspensionDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.MinimumSuspensionDurationSeconds, 0)) * time.Second
+}
+
 // UseMaintenaceMode returns true if UseMaintenanceModeChecker is set.
 func (cluster *FoundationDBCluster) UseMaintenaceMode() bool {
 	return pointer.BoolDeref(cluster.Spec.AutomationOptions.MaintenanceModeOptions.UseMaintenanceModeChecker, false)
@@ -2455,6 +3126,88 @@ func (cluster *FoundationDBCluster) GetTaintReplacementTimeSeconds() int {
 	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.TaintReplacementTimeSeconds, 1800)
 }
 
+// GetReplacementConditionPolicy returns the replacement policy for the provided condition or nil if no policy is defined.
+func (cluster *FoundationDBCluster) GetReplacementConditionPolicy(conditionType ProcessGroupConditionType) *ReplacementConditionPolicy {
+	for idx, policy := range cluster.Spec.AutomationOptions.Replacements.ConditionPolicies {
+		if policy.ConditionType == conditionType {
+			return &cluster.Spec.AutomationOptions.Replacements.ConditionPolicies[idx]
+		}
+	}
+
+	return nil
+}
+
+// GetReplacementTimeSeconds returns the time in seconds a process group must have the provided condition before it
+// will be replaced. If no policy for this condition is defined, the TaintReplacementTimeSeconds will be returned for the
+// NodeTaintReplacing condition and the FailureDetectionTimeSeconds for all other conditions.
+func (cluster *FoundationDBCluster) GetReplacementTimeSeconds(conditionType ProcessGroupConditionType) int {
+	policy := cluster.GetReplacementConditionPolicy(conditionType)
+	if policy != nil && policy.FailureDetectionTimeSeconds != nil {
+		return *policy.FailureDetectionTimeSeconds
+	}
+
+	if conditionType == NodeTaintReplacing {
+		return cluster.GetTaintReplacementTimeSeconds()
+	}
+
+	return cluster.GetFailureDetectionTimeSeconds()
+}
+
+// GetConditionsThatNeedReplacement returns all process group conditions that will be considered for automatic
+// replacements. This includes the default conditions and all conditions that have a replacement policy defined.
+func (cluster *FoundationDBCluster) GetConditionsThatNeedReplacement() []ProcessGroupConditionType {
+	conditions := make([]ProcessGroupConditionType, len(conditionsThatNeedReplacement), len(conditionsThatNeedReplacement)+len(cluster.Spec.AutomationOptions.Replacements.ConditionPolicies))
+	copy(conditions, conditionsThatNeedReplacement)
+
+	knownConditions := make(map[ProcessGroupConditionType]None, len(conditions))
+	for _, condition := range conditions {
+		knownConditions[condition] = None{}
+	}
+
+	for _, policy := range cluster.Spec.AutomationOptions.Replacements.ConditionPolicies {
+		if _, ok := knownConditions[policy.ConditionType]; ok {
+			continue
+		}
+
+		knownConditions[policy.ConditionType] = None{}
+		conditions = append(conditions, policy.ConditionType)
+	}
+
+	return conditions
+}
+
+// GetProcessGroupFailureCondition checks if the provided process group needs to be replaced based on the replacement
+// policies of the cluster. The method will return the failure condition and the timestamp. If no failure is detected
+// an empty condition and a 0 will be returned.
+func (cluster *FoundationDBCluster) GetProcessGroupFailureCondition(processGroup *ProcessGroupStatus) (ProcessGroupConditionType, int64) {
+	return processGroup.GetFailureCondition(cluster.GetConditionsThatNeedReplacement(), cluster.GetReplacementTimeSeconds)
+}
+
+// GetReplacementBuckets returns the budget for concurrent automatic replacements per process class and per
+// condition, without taking ongoing replacements into account. The returned process class buckets will always contain
+// an entry for the general process class.
+func (cluster *FoundationDBCluster) GetReplacementBuckets() (map[ProcessClass]int, map[ProcessGroupConditionType]int) {
+	maxReplacements := cluster.GetMaxConcurrentAutomaticReplacements()
+	processClassBuckets := map[ProcessClass]int{
+		ProcessClassGeneral: maxReplacements,
+	}
+
+	for _, bucket := range cluster.Spec.AutomationOptions.Replacements.ReplacementBuckets {
+		processClassBuckets[bucket.ProcessClass] = pointer.IntDeref(bucket.MaxConcurrentReplacements, maxReplacements)
+	}
+
+	conditionBuckets := map[ProcessGroupConditionType]int{}
+	for _, policy := range cluster.Spec.AutomationOptions.Replacements.ConditionPolicies {
+		if policy.MaxConcurrentReplacements == nil {
+			continue
+		}
+
+		conditionBuckets[policy.ConditionType] = *policy.MaxConcurrentReplacements
+	}
+
+	return processClassBuckets, conditionBuckets
+}
+
 // GetSidecarContainerEnableLivenessProbe returns cluster.Spec.SidecarContainer.EnableLivenessProbe or if unset the default true
 func (cluster *FoundationDBCluster) GetSidecarContainerEnableLivenessProbe() bool {
 	return pointer.BoolDeref(cluster.Spec.SidecarContainer.EnableLivenessProbe, true)
@@ -2770,6 +3523,13 @@ func (cluster *FoundationDBCluster) Validate() error {
 		}
 	}
 
+	validations = append(validations, cluster.validateCoordinatorConstraints()...)
+
+	// For the three_data_hall redundancy mode every FoundationDBCluster must define the data hall of its processes.
+	if cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall && !cluster.hasDataHallLocality() {
+		validations = append(validations, fmt.Sprintf("dataHall must be defined for the %s redundancy mode", RedundancyModeThreeDataHall))
+	}
+
 	if len(validations) == 0 {
 		return nil
 	}
@@ -2777,6 +3537,75 @@ func (cluster *FoundationDBCluster) Validate() error {
 	return fmt.Errorf(strings.Join(validations, ", "))
 }
 
+// validateCoordinatorConstraints returns the validation errors of the coordinator constraints.
+func (cluster *FoundationDBCluster) validateCoordinatorConstraints() []string {
+	var validations []string
+	constraints := cluster.Spec.CoordinatorConstraints
+
+	for key, limit := range constraints.MaxCoordinatorsPerLocality {
+		if limit < 1 {
+			validations = append(validations, fmt.Sprintf("maxCoordinatorsPerLocality for %s must be at least 1", key))
+		}
+	}
+
+	if !cluster.HasPinnedCoordinators() {
+		return validations
+	}
+
+	pinned := make(map[ProcessGroupID]None, len(constraints.PinnedCoordinators))
+	for _, processGroupID := range constraints.PinnedCoordinators {
+		if _, ok := pinned[processGroupID]; ok {
+			validations = append(validations, fmt.Sprintf("pinned coordinator %s is defined multiple times", processGroupID))
+			continue
+		}
+		pinned[processGroupID] = None{}
+	}
+
+	if len(pinned) != cluster.DesiredCoordinatorCount() {
+		validations = append(validations, fmt.Sprintf("%d pinned coordinators are defined but %d coordinators are required", len(pinned), cluster.DesiredCoordinatorCount()))
+	}
+
+	return validations
+}
+
+// hasDataHallLocality returns true if the data hall locality is defined, either with the dataHall field or as a custom
+// parameter for all process classes.
+func (cluster *FoundationDBCluster) hasDataHallLocality() bool {
+	if cluster.Spec.DataHall != "" {
+		return true
+	}
+
+	if len(cluster.Spec.Processes) == 0 {
+		return false
+	}
+
+	for _, settings := range cluster.Spec.Processes {
+		found := false
+		for _, parameter := range settings.CustomParameters {
+			if strings.HasPrefix(string(parameter), "locality_"+FDBLocalityDataHallKey+"=") {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetNodeDrainTaintKeys returns the taint keys that mark a node for draining.
+func (cluster *FoundationDBCluster) GetNodeDrainTaintKeys() []string {
+	return cluster.Spec.AutomationOptions.NodeDrainOptions.TaintKeys
+}
+
+// IsNodeDrainEnabled returns true if at least one drain taint key is defined.
+func (cluster *FoundationDBCluster) IsNodeDrainEnabled() bool {
+	return len(cluster.GetNodeDrainTaintKeys()) > 0
+}
+
 // IsTaintFeatureDisabled return true if operator is configured to not replace Pods tainted Nodes OR
 // if operator's TaintReplacementOptions is not set.
 func (cluster *FoundationDBCluster) IsTaintFeatureDisabled() bool {
@@ -690,6 +690,7 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				Status: FoundationDBLiveBackupStatusState{
 					Running: true,
 				},
+				RangeBytesWritten: 13,
 			}))
 		})
 	})
@@ -1037,6 +1038,53 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				})
 			})
 		})
+
+		When("the storage wiggle settings are defined", func() {
+			BeforeEach(func() {
+				cluster = &FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						DatabaseConfiguration: DatabaseConfiguration{
+							PerpetualStorageWiggle:         pointer.Int(1),
+							PerpetualStorageWiggleLocality: pointer.String("0"),
+						},
+						Version: "7.1.0",
+					},
+				}
+			})
+
+			It("should keep the settings", func() {
+				configuration := cluster.DesiredDatabaseConfiguration()
+				Expect(configuration.PerpetualStorageWiggle).To(HaveValue(Equal(1)))
+				Expect(configuration.PerpetualStorageWiggleLocality).To(HaveValue(Equal("0")))
+				Expect(configuration.StorageMigrationType).To(BeNil())
+				Expect(cluster.ManagesStorageMigration()).To(BeTrue())
+			})
+
+			It("should only clear the unset settings from the live configuration", func() {
+				storageMigrationType := StorageMigrationTypeDisabled
+				configuration := DatabaseConfiguration{
+					PerpetualStorageWiggle:         pointer.Int(0),
+					PerpetualStorageWiggleLocality: pointer.String("0"),
+					StorageMigrationType:           &storageMigrationType,
+				}
+				cluster.ClearMissingVersionFlags(&configuration)
+				Expect(configuration.PerpetualStorageWiggle).To(HaveValue(Equal(0)))
+				Expect(configuration.PerpetualStorageWiggleLocality).To(HaveValue(Equal("0")))
+				Expect(configuration.StorageMigrationType).To(BeNil())
+			})
+
+			When("the version doesn't support the settings", func() {
+				BeforeEach(func() {
+					cluster.Spec.Version = "6.3.24"
+				})
+
+				It("should drop the settings", func() {
+					configuration := cluster.DesiredDatabaseConfiguration()
+					Expect(configuration.PerpetualStorageWiggle).To(BeNil())
+					Expect(configuration.PerpetualStorageWiggleLocality).To(BeNil())
+				})
+			})
+		})
 	})
 
 	When("getting the configuration string", func() {
@@ -1075,6 +1123,30 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			Expect(configuration.GetConfigurationString("7.1.0-rc1")).To(Equal("double ssd usable_regions=1 logs=5 resolvers=0 log_routers=0 remote_logs=0 commit_proxies=4 grv_proxies=2 log_spill:=3 regions=[]"))
 		})
 
+		When("the storage wiggle settings are defined", func() {
+			storageMigrationType := StorageMigrationTypeGradual
+			configuration := DatabaseConfiguration{
+				RedundancyMode: RedundancyModeDouble,
+				StorageEngine:  StorageEngineSSD2,
+				UsableRegions:  1,
+				RoleCounts: RoleCounts{
+					Logs:    5,
+					Proxies: 1,
+				},
+				PerpetualStorageWiggle:         pointer.Int(1),
+				PerpetualStorageWiggleLocality: pointer.String("zoneid:storage-1"),
+				StorageMigrationType:           &storageMigrationType,
+			}
+
+			It("should add the settings for versions that support them", func() {
+				Expect(configuration.GetConfigurationString("7.1.0")).To(Equal("double ssd-2 usable_regions=1 logs=5 resolvers=0 log_routers=0 remote_logs=0 proxies=1 regions=[] storage_migration_type=gradual perpetual_storage_wiggle=1 perpetual_storage_wiggle_locality=zoneid:storage-1"))
+			})
+
+			It("should ignore the settings for versions that don't support them", func() {
+				Expect(configuration.GetConfigurationString("6.3.24")).To(Equal("double ssd-2 usable_regions=1 logs=5 resolvers=0 log_routers=0 remote_logs=0 proxies=1 regions=[]"))
+			})
+		})
+
 		When("CommitProxies and GrvProxies are not configured", func() {
 			configuration := DatabaseConfiguration{
 				RedundancyMode: RedundancyModeDouble,
@@ -1139,6 +1211,61 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 		})
 	})
 
+	When("getting all configuration changes", func() {
+		var currentConfig, finalConfig DatabaseConfiguration
+
+		BeforeEach(func() {
+			currentConfig = DatabaseConfiguration{
+				RedundancyMode: RedundancyModeDouble,
+				UsableRegions:  1,
+				Regions: []Region{
+					{
+						DataCenters: []DataCenter{
+							{
+								ID:       "dc1",
+								Priority: 1,
+							},
+						},
+					},
+				},
+			}
+
+			finalConfig = DatabaseConfiguration{
+				RedundancyMode: RedundancyModeDouble,
+				UsableRegions:  2,
+				Regions: []Region{
+					{
+						DataCenters: []DataCenter{
+							{
+								ID:       "dc1",
+								Priority: 1,
+							},
+						},
+					},
+					{
+						DataCenters: []DataCenter{
+							{
+								ID:       "dc2",
+								Priority: 0,
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should return all intermediate configurations", func() {
+			changes := currentConfig.GetConfigurationChanges(finalConfig)
+			Expect(len(changes)).To(BeNumerically(">", 1))
+			Expect(changes[0]).To(Equal(currentConfig.GetNextConfigurationChange(finalConfig)))
+			Expect(changes[len(changes)-1]).To(Equal(finalConfig))
+		})
+
+		It("should return no changes if the configuration matches", func() {
+			Expect(finalConfig.GetConfigurationChanges(finalConfig)).To(BeEmpty())
+		})
+	})
+
 	When("enabling fearless DR", func() {
 		It("should return the new fearless config", func() {
 			currentConfig := DatabaseConfiguration{
@@ -3505,12 +3632,56 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			}
 			Expect(cluster.ShouldUseLocks()).To(BeTrue())
 
+			cluster.Spec.DatabaseConfiguration.Regions = nil
+			cluster.Spec.DatabaseConfiguration.RedundancyMode = RedundancyModeThreeDataHall
+			Expect(cluster.ShouldUseLocks()).To(BeTrue())
+
 			duration := 60
 			cluster.Spec.LockOptions.LockDurationMinutes = &duration
 			Expect(cluster.GetLockDuration()).To(Equal(60 * time.Minute))
 		})
 	})
 
+	DescribeTable("should use global coordination", func(cluster *FoundationDBCluster, expected bool) {
+		Expect(cluster.ShouldUseGlobalCoordination()).To(Equal(expected))
+	},
+		Entry("global coordination is not set",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					DataCenter: "primary",
+				},
+			}, false),
+		Entry("global coordination is enabled but locks are disabled",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					DataCenter: "primary",
+					LockOptions: LockOptions{
+						DisableLocks:          pointer.Bool(true),
+						UseGlobalCoordination: pointer.Bool(true),
+					},
+				},
+			}, false),
+		Entry("global coordination is enabled but no data center is defined",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					LockOptions: LockOptions{
+						DisableLocks:          pointer.Bool(false),
+						UseGlobalCoordination: pointer.Bool(true),
+					},
+				},
+			}, false),
+		Entry("global coordination and locks are enabled",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					DataCenter: "primary",
+					LockOptions: LockOptions{
+						DisableLocks:          pointer.Bool(false),
+						UseGlobalCoordination: pointer.Bool(true),
+					},
+				},
+			}, true),
+	)
+
 	When("getting the condition timestamp", func() {
 		It("should return the correct timestamp", func() {
 			status := &ProcessGroupStatus{}
@@ -4862,6 +5033,50 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				},
 				fmt.Errorf("stateless is not a valid process class for coordinators"),
 			),
+			Entry("using three_data_hall without a data hall",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.26",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeThreeDataHall,
+						},
+					},
+				},
+				fmt.Errorf("dataHall must be defined for the three_data_hall redundancy mode"),
+			),
+			Entry("using three_data_hall with a data hall",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version:  "7.1.26",
+						DataHall: "az1",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeThreeDataHall,
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using three_data_hall with a data hall custom parameter",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.26",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeThreeDataHall,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassGeneral: {
+								CustomParameters: FoundationDBCustomParameters{
+									"locality_data_hall=$NODE_ZONE",
+								},
+							},
+						},
+					},
+				},
+				nil,
+			),
 			Entry("multiple validations",
 				&FoundationDBCluster{
 					Spec: FoundationDBClusterSpec{
@@ -4911,6 +5126,54 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				},
 				fmt.Errorf("version: 6.1.0 is not supported, minimum supported version is: 6.2.20"),
 			),
+			Entry("using valid coordinator constraints",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.26",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeDouble,
+						},
+						CoordinatorConstraints: CoordinatorConstraints{
+							ExcludedNodes:              []string{"node-1"},
+							ExcludedZones:              []string{"zone-1"},
+							PreferredPodLabels:         map[string]string{"spot": "false"},
+							MaxCoordinatorsPerLocality: map[string]int{"rack": 1},
+							PinnedCoordinators:         []ProcessGroupID{"storage-1", "storage-2", "storage-3"},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using an invalid limit per locality",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.26",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						CoordinatorConstraints: CoordinatorConstraints{
+							MaxCoordinatorsPerLocality: map[string]int{"rack": 0},
+						},
+					},
+				},
+				fmt.Errorf("maxCoordinatorsPerLocality for rack must be at least 1"),
+			),
+			Entry("using duplicate pinned coordinators",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.26",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeDouble,
+						},
+						CoordinatorConstraints: CoordinatorConstraints{
+							PinnedCoordinators: []ProcessGroupID{"storage-1", "storage-2", "storage-2"},
+						},
+					},
+				},
+				fmt.Errorf("pinned coordinator storage-2 is defined multiple times, 2 pinned coordinators are defined but 3 coordinators are required"),
+			),
 		)
 	})
 
@@ -5629,4 +5892,376 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			})
 		})
 	})
+
+	DescribeTable("getting the replacement time for a condition",
+		func(cluster *FoundationDBCluster, conditionType ProcessGroupConditionType, expected int) {
+			Expect(cluster.GetReplacementTimeSeconds(conditionType)).To(Equal(expected))
+		},
+		Entry("no policy defined",
+			&FoundationDBCluster{},
+			MissingProcesses,
+			7200,
+		),
+		Entry("no policy defined for the NodeTaintReplacing condition",
+			&FoundationDBCluster{},
+			NodeTaintReplacing,
+			1800,
+		),
+		Entry("policy defined for the condition",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					AutomationOptions: FoundationDBClusterAutomationOptions{
+						Replacements: AutomaticReplacementOptions{
+							ConditionPolicies: []ReplacementConditionPolicy{
+								{
+									ConditionType:               PodPending,
+									FailureDetectionTimeSeconds: pointer.Int(600),
+								},
+							},
+						},
+					},
+				},
+			},
+			PodPending,
+			600,
+		),
+		Entry("policy defined for a different condition",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					AutomationOptions: FoundationDBClusterAutomationOptions{
+						Replacements: AutomaticReplacementOptions{
+							FailureDetectionTimeSeconds: pointer.Int(60),
+							ConditionPolicies: []ReplacementConditionPolicy{
+								{
+									ConditionType:               PodPending,
+									FailureDetectionTimeSeconds: pointer.Int(600),
+								},
+							},
+						},
+					},
+				},
+			},
+			MissingProcesses,
+			60,
+		),
+	)
+
+	When("getting the replacement buckets", func() {
+		var cluster *FoundationDBCluster
+		var processClassBuckets map[ProcessClass]int
+		var conditionBuckets map[ProcessGroupConditionType]int
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{}
+		})
+
+		JustBeforeEach(func() {
+			processClassBuckets, conditionBuckets = cluster.GetReplacementBuckets()
+		})
+
+		When("no buckets are defined", func() {
+			It("should only return the general bucket", func() {
+				Expect(processClassBuckets).To(Equal(map[ProcessClass]int{ProcessClassGeneral: 1}))
+				Expect(conditionBuckets).To(BeEmpty())
+			})
+		})
+
+		When("buckets and condition policies are defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.Replacements = AutomaticReplacementOptions{
+					MaxConcurrentReplacements: pointer.Int(2),
+					ReplacementBuckets: []ReplacementBucket{
+						{
+							ProcessClass:              ProcessClassStorage,
+							MaxConcurrentReplacements: pointer.Int(1),
+						},
+						{
+							ProcessClass: ProcessClassLog,
+						},
+					},
+					ConditionPolicies: []ReplacementConditionPolicy{
+						{
+							ConditionType:             PodPending,
+							MaxConcurrentReplacements: pointer.Int(5),
+						},
+						{
+							ConditionType:               MissingProcesses,
+							FailureDetectionTimeSeconds: pointer.Int(60),
+						},
+						{
+							ConditionType:               SidecarUnreachable,
+							FailureDetectionTimeSeconds: pointer.Int(120),
+						},
+					},
+				}
+			})
+
+			It("should return the defined buckets", func() {
+				Expect(processClassBuckets).To(Equal(map[ProcessClass]int{
+					ProcessClassGeneral: 2,
+					ProcessClassStorage: 1,
+					ProcessClassLog:     2,
+				}))
+				Expect(conditionBuckets).To(Equal(map[ProcessGroupConditionType]int{
+					PodPending: 5,
+				}))
+			})
+
+			It("should consider the conditions with a policy for replacements", func() {
+				conditions := cluster.GetConditionsThatNeedReplacement()
+				Expect(conditions).To(ContainElement(SidecarUnreachable))
+				Expect(conditions).To(ConsistOf(append(append([]ProcessGroupConditionType{}, conditionsThatNeedReplacement...), SidecarUnreachable)))
+			})
+
+			It("should use the failure detection time of the condition policy", func() {
+				Expect(cluster.GetReplacementTimeSeconds(SidecarUnreachable)).To(Equal(120))
+				Expect(cluster.GetReplacementTimeSeconds(MissingProcesses)).To(Equal(60))
+			})
+		})
+	})
+
+	When("using the fault domain distribution", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: FoundationDBClusterSpec{
+					FaultDomain: FoundationDBClusterFaultDomain{
+						DistributionConfig: &DistributionConfig{
+							Enabled: pointer.Bool(true),
+						},
+					},
+				},
+			}
+		})
+
+		DescribeTable("checking if the fault domain distribution is used", func(key string, expected bool) {
+			cluster.Spec.FaultDomain.Key = key
+			Expect(cluster.UseFaultDomainDistribution()).To(Equal(expected))
+		},
+			Entry("default fault domain key", "", true),
+			Entry("custom fault domain key", "rack", true),
+			Entry("no fault domain", NoneFaultDomainKey, false),
+			Entry("kubernetes cluster fault domain", "foundationdb.org/kubernetes-cluster", false),
+		)
+
+		It("should derive the logical fault domains from the redundancy mode", func() {
+			cluster.Spec.DatabaseConfiguration.RedundancyMode = RedundancyModeTriple
+			Expect(cluster.GetLogicalFaultDomains()).To(Equal([]FaultDomain{"test-0", "test-1", "test-2", "test-3", "test-4"}))
+		})
+
+		It("should use the desired fault domains if defined", func() {
+			cluster.Spec.FaultDomain.DistributionConfig.DesiredFaultDomains = pointer.Int(2)
+			Expect(cluster.GetLogicalFaultDomains()).To(Equal([]FaultDomain{"test-0", "test-1"}))
+		})
+
+		It("should return the logical fault domain with the fewest process groups", func() {
+			cluster.Status.ProcessGroups = []*ProcessGroupStatus{
+				{ProcessGroupID: "storage-1", ProcessClass: ProcessClassStorage, FaultDomain: "test-0"},
+				{ProcessGroupID: "storage-2", ProcessClass: ProcessClassStorage, FaultDomain: "test-1"},
+				{ProcessGroupID: "storage-3", ProcessClass: ProcessClassStorage, FaultDomain: "test-2", RemovalTimestamp: &metav1.Time{Time: time.Now()}},
+				{ProcessGroupID: "storage-4", ProcessClass: ProcessClassStorage, FaultDomain: "node-1"},
+				{ProcessGroupID: "log-1", ProcessClass: ProcessClassLog, FaultDomain: "test-2"},
+			}
+
+			counts := cluster.GetLogicalFaultDomainCounts(ProcessClassStorage)
+			Expect(counts).To(Equal(map[FaultDomain]int{"test-0": 1, "test-1": 1, "test-2": 0}))
+			Expect(cluster.GetNextLogicalFaultDomain(counts)).To(Equal(FaultDomain("test-2")))
+			Expect(cluster.GetNextLogicalFaultDomain(counts)).To(Equal(FaultDomain("test-0")))
+		})
+	})
+
+	When("using the storage engine migration", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{}
+		})
+
+		It("should use the defaults", func() {
+			Expect(cluster.GetStorageEngineMigrationStrategy()).To(Equal(StorageEngineMigrationStrategyInPlace))
+			Expect(cluster.GetMaxConcurrentStorageEngineMigrations()).To(Equal(1))
+		})
+
+		It("should use the configured options", func() {
+			cluster.Spec.AutomationOptions.StorageEngineMigration = StorageEngineMigrationOptions{
+				Strategy:                StorageEngineMigrationStrategyReplaceProcessGroups,
+				MaxConcurrentMigrations: pointer.Int(3),
+			}
+			Expect(cluster.GetStorageEngineMigrationStrategy()).To(Equal(StorageEngineMigrationStrategyReplaceProcessGroups))
+			Expect(cluster.GetMaxConcurrentStorageEngineMigrations()).To(Equal(3))
+		})
+
+		It("should report the migration progress", func() {
+			var migrationStatus *StorageEngineMigrationStatus
+			Expect(migrationStatus.IsInProgress()).To(BeFalse())
+			Expect(migrationStatus.GetRemainingStorageServers()).To(BeZero())
+
+			migrationStatus = &StorageEngineMigrationStatus{
+				StorageEngine: StorageEngineRedwood1Experimental,
+				StorageServers: map[StorageEngine]int{
+					StorageEngineSSD2:                 3,
+					StorageEngineRedwood1Experimental: 2,
+					StorageEngineUnknown:              1,
+				},
+				StartTimestamp: time.Now().Unix(),
+			}
+			Expect(migrationStatus.IsInProgress()).To(BeTrue())
+			Expect(migrationStatus.GetRemainingStorageServers()).To(Equal(3))
+
+			migrationStatus.CompletionTimestamp = time.Now().Unix()
+			Expect(migrationStatus.IsInProgress()).To(BeFalse())
+		})
+
+		It("should compare the storage engines in the form the storage servers report them", func() {
+			migrationStatus := &StorageEngineMigrationStatus{
+				StorageEngine: StorageEngineMemory2,
+				StorageServers: map[StorageEngine]int{
+					StorageEngineMemory: 2,
+					StorageEngineSSD2:   1,
+				},
+			}
+			Expect(migrationStatus.GetRemainingStorageServers()).To(Equal(1))
+
+			migrationStatus.StorageEngine = StorageEngineSSD
+			Expect(migrationStatus.GetRemainingStorageServers()).To(Equal(2))
+		})
+
+		DescribeTable("should return the storage engine reported by the storage servers",
+			func(storageEngine StorageEngine, expected StorageEngine) {
+				Expect(storageEngine.GetStorageServerStorageEngine()).To(Equal(expected))
+			},
+			Entry("ssd", StorageEngineSSD, StorageEngineSSD2),
+			Entry("ssd-2", StorageEngineSSD2, StorageEngineSSD2),
+			Entry("memory", StorageEngineMemory, StorageEngineMemory),
+			Entry("memory-1", StorageEngineMemory1, StorageEngineMemory),
+			Entry("memory-2", StorageEngineMemory2, StorageEngineMemory),
+			Entry("redwood", StorageEngineRedwood1Experimental, StorageEngineRedwood1Experimental),
+		)
+	})
+
+	When("using the coordinator constraints", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					CoordinatorConstraints: CoordinatorConstraints{
+						ExcludedNodes:      []string{"node-1"},
+						ExcludedZones:      []string{"zone-1"},
+						PreferredPodLabels: map[string]string{"spot": "false"},
+					},
+				},
+			}
+		})
+
+		DescribeTable("checking if a process is excluded from the coordinators",
+			func(nodeName string, localities map[string]string, expected bool) {
+				Expect(cluster.IsExcludedFromCoordinators(nodeName, localities)).To(Equal(expected))
+			},
+			Entry("process on an excluded node", "node-1", map[string]string{FDBLocalityMachineIDKey: "pod-1", FDBLocalityZoneIDKey: "zone-2"}, true),
+			Entry("process in an excluded zone", "node-2", map[string]string{FDBLocalityMachineIDKey: "pod-1", FDBLocalityZoneIDKey: "zone-1"}, true),
+			Entry("process on another node and zone", "node-2", map[string]string{FDBLocalityMachineIDKey: "node-1", FDBLocalityZoneIDKey: "zone-2"}, false),
+			Entry("process with an unknown node", "", map[string]string{FDBLocalityMachineIDKey: "node-1", FDBLocalityZoneIDKey: "zone-2"}, false),
+			Entry("process without localities", "node-2", map[string]string{}, false),
+		)
+
+		DescribeTable("checking if a Pod is preferred",
+			func(labels map[string]string, expected bool) {
+				Expect(cluster.IsPreferredCoordinatorPod(labels)).To(Equal(expected))
+			},
+			Entry("Pod with the preferred labels", map[string]string{"spot": "false", "app": "fdb"}, true),
+			Entry("Pod with a different label value", map[string]string{"spot": "true"}, false),
+			Entry("Pod without labels", nil, false),
+		)
+
+		When("the coordinators are pinned", func() {
+			BeforeEach(func() {
+				cluster.Spec.CoordinatorConstraints.PinnedCoordinators = []ProcessGroupID{"storage-1"}
+			})
+
+			It("should not exclude any process", func() {
+				Expect(cluster.HasPinnedCoordinators()).To(BeTrue())
+				Expect(cluster.IsPinnedCoordinator("storage-1")).To(BeTrue())
+				Expect(cluster.IsPinnedCoordinator("storage-2")).To(BeFalse())
+				Expect(cluster.IsExcludedFromCoordinators("node-1", map[string]string{FDBLocalityZoneIDKey: "zone-1"})).To(BeFalse())
+			})
+		})
+	})
+
+	When("adding a coordinator change", func() {
+		var status FoundationDBClusterStatus
+
+		BeforeEach(func() {
+			status = FoundationDBClusterStatus{}
+			for i := 0; i < MaxCoordinatorChanges; i++ {
+				status.AddCoordinatorChange(CoordinatorChange{
+					Timestamp:        int64(i),
+					ConnectionString: fmt.Sprintf("test:test%d@127.0.0.1:4501", i),
+				})
+			}
+		})
+
+		It("should keep all changes up to the limit", func() {
+			Expect(status.CoordinatorChanges).To(HaveLen(MaxCoordinatorChanges))
+			Expect(status.CoordinatorChanges[0].Timestamp).To(BeNumerically("==", 0))
+		})
+
+		It("should remove the oldest change if the limit is exceeded", func() {
+			status.AddCoordinatorChange(CoordinatorChange{
+				Timestamp:        int64(MaxCoordinatorChanges),
+				ConnectionString: "test:new@127.0.0.1:4501",
+			})
+			Expect(status.CoordinatorChanges).To(HaveLen(MaxCoordinatorChanges))
+			Expect(status.CoordinatorChanges[0].Timestamp).To(BeNumerically("==", 1))
+			Expect(status.CoordinatorChanges[MaxCoordinatorChanges-1].ConnectionString).To(Equal("test:new@127.0.0.1:4501"))
+		})
+	})
+
+	When("using the configuration approval", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{
+				Status: FoundationDBClusterStatus{
+					ConfigurationPlan: &ConfigurationPlan{
+						Hash:  "abc",
+						Steps: []string{"triple ssd-2"},
+					},
+				},
+			}
+		})
+
+		It("should not require approval by default", func() {
+			Expect(cluster.RequiresConfigurationApproval()).To(BeFalse())
+		})
+
+		It("should require approval if enabled", func() {
+			cluster.Spec.AutomationOptions.RequireConfigurationApproval = pointer.Bool(true)
+			Expect(cluster.RequiresConfigurationApproval()).To(BeTrue())
+		})
+
+		It("should not be approved without the annotation", func() {
+			Expect(cluster.IsConfigurationPlanApproved()).To(BeFalse())
+		})
+
+		It("should be approved with the plan hash", func() {
+			cluster.Annotations = map[string]string{ApprovedConfigurationPlanAnnotation: "abc"}
+			Expect(cluster.IsConfigurationPlanApproved()).To(BeTrue())
+		})
+
+		It("should not be approved with a different hash", func() {
+			cluster.Annotations = map[string]string{ApprovedConfigurationPlanAnnotation: "def"}
+			Expect(cluster.IsConfigurationPlanApproved()).To(BeFalse())
+		})
+
+		It("should not be approved without a plan", func() {
+			cluster.Annotations = map[string]string{ApprovedConfigurationPlanAnnotation: ""}
+			cluster.Status.ConfigurationPlan = nil
+			Expect(cluster.IsConfigurationPlanApproved()).To(BeFalse())
+		})
+	})
 })
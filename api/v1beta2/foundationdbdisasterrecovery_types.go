@@ -0,0 +1,262 @@
+/*
+Copyright 2023 FoundationDB project authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fdbdr
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".metadata.generation",description="Latest generation of the spec",priority=0
+// +kubebuilder:printcolumn:name="Reconciled",type="integer",JSONPath=".status.generations.reconciled",description="Last reconciled generation of the spec",priority=0
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".status.drDetails.sourceClusterName",description="Cluster that is replicated",priority=0
+// +kubebuilder:printcolumn:name="Lag",type="integer",JSONPath=".status.drDetails.secondsBehind",description="Seconds the destination is behind the source",priority=0
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// FoundationDBDisasterRecovery is the Schema for the foundationdbdisasterrecoveries API
+type FoundationDBDisasterRecovery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FoundationDBDisasterRecoverySpec   `json:"spec,omitempty"`
+	Status FoundationDBDisasterRecoveryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FoundationDBDisasterRecoveryList contains a list of FoundationDBDisasterRecovery objects
+type FoundationDBDisasterRecoveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FoundationDBDisasterRecovery `json:"items"`
+}
+
+// FoundationDBDisasterRecoverySpec describes the desired state of the
+// replication between two clusters.
+type FoundationDBDisasterRecoverySpec struct {
+	// The version of FoundationDB that the DR agents should run.
+	Version string `json:"version"`
+
+	// SourceClusterName is the name of the cluster that is replicated, both
+	// clusters must be in the same namespace as this resource.
+	// If the source and the destination cluster are swapped while the
+	// replication is running, the operator will switch the replication.
+	// +kubebuilder:validation:MaxLength=100
+	SourceClusterName string `json:"sourceClusterName"`
+
+	// DestinationClusterName is the name of the cluster that the data is
+	// replicated to. The destination cluster will be locked while the
+	// replication is running.
+	// +kubebuilder:validation:MaxLength=100
+	DestinationClusterName string `json:"destinationClusterName"`
+
+	// +kubebuilder:validation:Enum=Running;Stopped
+	// The desired state of the replication.
+	// The default is Running.
+	DisasterRecoveryState DisasterRecoveryState `json:"disasterRecoveryState,omitempty"`
+
+	// AgentCount defines the number of DR agents to run.
+	// The default is run 2 agents.
+	AgentCount *int `json:"agentCount,omitempty"`
+
+	// DeploymentMetadata allows customizing labels and annotations on the
+	// deployment for the DR agents.
+	DeploymentMetadata *metav1.ObjectMeta `json:"deploymentMetadata,omitempty"`
+
+	// PodTemplateSpec allows customizing the pod template for the DR agents.
+	PodTemplateSpec *corev1.PodTemplateSpec `json:"podTemplateSpec,omitempty"`
+
+	// CustomParameters defines additional parameters to pass to the DR
+	// agents.
+	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
+
+	// MainContainer defines customization for the foundationdb container.
+	MainContainer ContainerOverrides `json:"mainContainer,omitempty"`
+
+	// SidecarContainer defines customization for the
+	// foundationdb-kubernetes-sidecar container.
+	SidecarContainer ContainerOverrides `json:"sidecarContainer,omitempty"`
+}
+
+// FoundationDBDisasterRecoveryStatus describes the current status of the
+// replication between two clusters.
+type FoundationDBDisasterRecoveryStatus struct {
+	// AgentCount provides the number of agents that are up-to-date, ready,
+	// and not terminated.
+	AgentCount int `json:"agentCount,omitempty"`
+
+	// DeploymentConfigured indicates whether the deployment is correctly
+	// configured.
+	DeploymentConfigured bool `json:"deploymentConfigured,omitempty"`
+
+	// DRDetails provides information about the state of the replication.
+	DRDetails *FoundationDBDisasterRecoveryStatusDetails `json:"drDetails,omitempty"`
+
+	// Generations provides information about the latest generation to be
+	// reconciled, or to reach other stages in reconciliation.
+	Generations DisasterRecoveryGenerationStatus `json:"generations,omitempty"`
+
+	// LastSwitchTimestamp is the last time the operator switched the source
+	// and the destination of the replication.
+	LastSwitchTimestamp *metav1.Time `json:"lastSwitchTimestamp,omitempty"`
+}
+
+// FoundationDBDisasterRecoveryStatusDetails provides information about the
+// state of the replication.
+type FoundationDBDisasterRecoveryStatusDetails struct {
+	// SourceClusterName is the name of the cluster that is replicated.
+	SourceClusterName string `json:"sourceClusterName,omitempty"`
+
+	// DestinationClusterName is the name of the cluster that the data is
+	// replicated to.
+	DestinationClusterName string `json:"destinationClusterName,omitempty"`
+
+	// Running determines whether the replication is running.
+	Running bool `json:"running,omitempty"`
+
+	// Restorable determines whether the destination is a complete copy of
+	// the source, which is required to switch the replication.
+	Restorable bool `json:"restorable,omitempty"`
+
+	// SecondsBehind provides the number of seconds the destination is behind
+	// the source.
+	SecondsBehind int64 `json:"secondsBehind,omitempty"`
+}
+
+// DisasterRecoveryGenerationStatus stores information on which generations
+// have reached different stages in reconciliation for the replication.
+type DisasterRecoveryGenerationStatus struct {
+	// Reconciled provides the last generation that was fully reconciled.
+	Reconciled int64 `json:"reconciled,omitempty"`
+
+	// NeedsAgentUpdate provides the last generation that could not complete
+	// reconciliation because the DR agent deployment needs to be updated.
+	NeedsAgentUpdate int64 `json:"needsAgentUpdate,omitempty"`
+
+	// NeedsStart provides the last generation that could not complete
+	// reconciliation because we need to start the replication.
+	NeedsStart int64 `json:"needsStart,omitempty"`
+
+	// NeedsStop provides the last generation that could not complete
+	// reconciliation because we need to stop the replication.
+	NeedsStop int64 `json:"needsStop,omitempty"`
+
+	// NeedsSwitch provides the last generation that could not complete
+	// reconciliation because we need to switch the source and the destination
+	// of the replication.
+	NeedsSwitch int64 `json:"needsSwitch,omitempty"`
+}
+
+// DisasterRecoveryState defines the desired state of the replication.
+type DisasterRecoveryState string
+
+const (
+	// DisasterRecoveryStateRunning defines the running state
+	DisasterRecoveryStateRunning DisasterRecoveryState = "Running"
+	// DisasterRecoveryStateStopped defines the stopped state
+	DisasterRecoveryStateStopped DisasterRecoveryState = "Stopped"
+)
+
+// ShouldRun determines whether the replication should be running.
+func (disasterRecovery *FoundationDBDisasterRecovery) ShouldRun() bool {
+	return disasterRecovery.Spec.DisasterRecoveryState == "" || disasterRecovery.Spec.DisasterRecoveryState == DisasterRecoveryStateRunning
+}
+
+// GetDesiredAgentCount determines how many DR agents we should run.
+func (disasterRecovery *FoundationDBDisasterRecovery) GetDesiredAgentCount() int {
+	return pointer.IntDeref(disasterRecovery.Spec.AgentCount, 2)
+}
+
+// IsRunning returns true if the status reports a running replication.
+func (disasterRecovery *FoundationDBDisasterRecovery) IsRunning() bool {
+	return disasterRecovery.Status.DRDetails != nil && disasterRecovery.Status.DRDetails.Running
+}
+
+// NeedsSwitch returns true if the replication is running in the opposite
+// direction of the spec, in this case the source and the destination must be
+// switched.
+func (disasterRecovery *FoundationDBDisasterRecovery) NeedsSwitch() bool {
+	if !disasterRecovery.IsRunning() {
+		return false
+	}
+
+	details := disasterRecovery.Status.DRDetails
+	return details.SourceClusterName == disasterRecovery.Spec.DestinationClusterName &&
+		details.DestinationClusterName == disasterRecovery.Spec.SourceClusterName
+}
+
+// RunsBetweenDesiredClusters returns true if the replication is running
+// between the clusters in the spec, independent of the direction.
+func (disasterRecovery *FoundationDBDisasterRecovery) RunsBetweenDesiredClusters() bool {
+	if !disasterRecovery.IsRunning() {
+		return false
+	}
+
+	details := disasterRecovery.Status.DRDetails
+	if details.SourceClusterName == disasterRecovery.Spec.SourceClusterName && details.DestinationClusterName == disasterRecovery.Spec.DestinationClusterName {
+		return true
+	}
+
+	return disasterRecovery.NeedsSwitch()
+}
+
+// CheckReconciliation compares the spec and the status to determine if
+// reconciliation is complete.
+func (disasterRecovery *FoundationDBDisasterRecovery) CheckReconciliation() (bool, error) {
+	var reconciled = true
+
+	if disasterRecovery.Status.AgentCount != disasterRecovery.GetDesiredAgentCount() || !disasterRecovery.Status.DeploymentConfigured {
+		disasterRecovery.Status.Generations.NeedsAgentUpdate = disasterRecovery.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	isRunning := disasterRecovery.IsRunning()
+
+	if disasterRecovery.ShouldRun() && !isRunning {
+		disasterRecovery.Status.Generations.NeedsStart = disasterRecovery.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	// A replication between other clusters must be stopped before the replication between the desired clusters
+	// can be started.
+	if isRunning && (!disasterRecovery.ShouldRun() || !disasterRecovery.RunsBetweenDesiredClusters()) {
+		disasterRecovery.Status.Generations.NeedsStop = disasterRecovery.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	if disasterRecovery.ShouldRun() && disasterRecovery.NeedsSwitch() {
+		disasterRecovery.Status.Generations.NeedsSwitch = disasterRecovery.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	if reconciled {
+		disasterRecovery.Status.Generations = DisasterRecoveryGenerationStatus{
+			Reconciled: disasterRecovery.ObjectMeta.Generation,
+		}
+	}
+
+	return reconciled, nil
+}
+
+func init() {
+	SchemeBuilder.Register(&FoundationDBDisasterRecovery{}, &FoundationDBDisasterRecoveryList{})
+}
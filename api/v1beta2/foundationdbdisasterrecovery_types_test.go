@@ -0,0 +1,125 @@
+/*
+ * foundationdbdisasterrecovery_types_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("[api] FoundationDBDisasterRecovery", func() {
+	When("checking the reconciliation", func() {
+		var disasterRecovery *FoundationDBDisasterRecovery
+
+		BeforeEach(func() {
+			disasterRecovery = &FoundationDBDisasterRecovery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "sample",
+					Generation: 2,
+				},
+				Spec: FoundationDBDisasterRecoverySpec{
+					SourceClusterName:      "primary",
+					DestinationClusterName: "secondary",
+					AgentCount:             pointer.Int(3),
+				},
+				Status: FoundationDBDisasterRecoveryStatus{
+					AgentCount:           3,
+					DeploymentConfigured: true,
+					DRDetails: &FoundationDBDisasterRecoveryStatusDetails{
+						SourceClusterName:      "primary",
+						DestinationClusterName: "secondary",
+						Running:                true,
+					},
+					Generations: DisasterRecoveryGenerationStatus{
+						Reconciled: 1,
+					},
+				},
+			}
+		})
+
+		When("the replication runs between the desired clusters", func() {
+			It("should be reconciled", func() {
+				Expect(disasterRecovery.CheckReconciliation()).To(BeTrue())
+				Expect(disasterRecovery.Status.Generations).To(Equal(DisasterRecoveryGenerationStatus{Reconciled: 2}))
+			})
+		})
+
+		When("the replication is not running", func() {
+			BeforeEach(func() {
+				disasterRecovery.Status.DRDetails.Running = false
+			})
+
+			It("should need a start", func() {
+				Expect(disasterRecovery.CheckReconciliation()).To(BeFalse())
+				Expect(disasterRecovery.Status.Generations).To(Equal(DisasterRecoveryGenerationStatus{Reconciled: 1, NeedsStart: 2}))
+			})
+		})
+
+		When("the replication should be stopped", func() {
+			BeforeEach(func() {
+				disasterRecovery.Spec.DisasterRecoveryState = DisasterRecoveryStateStopped
+			})
+
+			It("should need a stop", func() {
+				Expect(disasterRecovery.CheckReconciliation()).To(BeFalse())
+				Expect(disasterRecovery.Status.Generations).To(Equal(DisasterRecoveryGenerationStatus{Reconciled: 1, NeedsStop: 2}))
+			})
+		})
+
+		When("the source and the destination are swapped", func() {
+			BeforeEach(func() {
+				disasterRecovery.Spec.SourceClusterName = "secondary"
+				disasterRecovery.Spec.DestinationClusterName = "primary"
+			})
+
+			It("should need a switch", func() {
+				Expect(disasterRecovery.NeedsSwitch()).To(BeTrue())
+				Expect(disasterRecovery.CheckReconciliation()).To(BeFalse())
+				Expect(disasterRecovery.Status.Generations).To(Equal(DisasterRecoveryGenerationStatus{Reconciled: 1, NeedsSwitch: 2}))
+			})
+		})
+
+		When("the destination is changed", func() {
+			BeforeEach(func() {
+				disasterRecovery.Spec.DestinationClusterName = "tertiary"
+			})
+
+			It("should need a stop and a start", func() {
+				Expect(disasterRecovery.NeedsSwitch()).To(BeFalse())
+				Expect(disasterRecovery.CheckReconciliation()).To(BeFalse())
+				Expect(disasterRecovery.Status.Generations).To(Equal(DisasterRecoveryGenerationStatus{Reconciled: 1, NeedsStop: 2}))
+			})
+		})
+
+		When("the agents are not ready", func() {
+			BeforeEach(func() {
+				disasterRecovery.Status.AgentCount = 1
+			})
+
+			It("should need an agent update", func() {
+				Expect(disasterRecovery.CheckReconciliation()).To(BeFalse())
+				Expect(disasterRecovery.Status.Generations).To(Equal(DisasterRecoveryGenerationStatus{Reconciled: 1, NeedsAgentUpdate: 2}))
+			})
+		})
+	})
+})
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 FoundationDB project authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fdbpg
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".spec.processGroupID"
+// +kubebuilder:printcolumn:name="Class",type="string",JSONPath=".spec.processClass"
+// +kubebuilder:printcolumn:name="Fault Domain",type="string",JSONPath=".status.faultDomain"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// FoundationDBProcessGroup is the Schema for the foundationdbprocessgroups API. Every FoundationDBProcessGroup
+// represents a single process group of a FoundationDBCluster and is owned by that cluster.
+type FoundationDBProcessGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FoundationDBProcessGroupSpec   `json:"spec,omitempty"`
+	Status FoundationDBProcessGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FoundationDBProcessGroupList contains a list of FoundationDBProcessGroup objects
+type FoundationDBProcessGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FoundationDBProcessGroup `json:"items"`
+}
+
+// FoundationDBProcessGroupSpec describes the desired state of a process group.
+type FoundationDBProcessGroupSpec struct {
+	// ClusterName is the name of the FoundationDBCluster this process group belongs to.
+	ClusterName string `json:"clusterName"`
+
+	// ProcessGroupID represents the ID of the process group
+	ProcessGroupID ProcessGroupID `json:"processGroupID"`
+
+	// ProcessClass represents the class the process group has.
+	ProcessClass ProcessClass `json:"processClass"`
+}
+
+// FoundationDBProcessGroupStatus describes the observed state of a process group.
+type FoundationDBProcessGroupStatus struct {
+	// Addresses represents the list of addresses the process group has been known to have.
+	Addresses []string `json:"addresses,omitempty"`
+
+	// RemoveTimestamp if not empty defines when the process group was marked for removal.
+	RemovalTimestamp *metav1.Time `json:"removalTimestamp,omitempty"`
+
+	// ExclusionTimestamp defines when the process group has been fully excluded.
+	// This is only used within the reconciliation process, and should not be considered authoritative.
+	ExclusionTimestamp *metav1.Time `json:"exclusionTimestamp,omitempty"`
+
+	// ExclusionSkipped determines if exclusion has been skipped for a process, which will allow the process group to be removed without exclusion.
+	ExclusionSkipped bool `json:"exclusionSkipped,omitempty"`
+
+	// SuspensionTimestamp defines when the process group was suspended.
+	SuspensionTimestamp *metav1.Time `json:"suspensionTimestamp,omitempty"`
+
+	// ProcessGroupConditions represents a list of degraded conditions that the process group is in.
+	ProcessGroupConditions []*ProcessGroupCondition `json:"processGroupConditions,omitempty"`
+
+	// FaultDomain represents the last seen fault domain from the cluster status. This can be used if a Pod or process
+	// is not running and would be missing in the cluster status.
+	FaultDomain FaultDomain `json:"faultDomain,omitempty"`
+}
+
+// NewFoundationDBProcessGroup creates a new FoundationDBProcessGroup for the provided process group status. The returned
+// FoundationDBProcessGroup has no metadata set.
+func NewFoundationDBProcessGroup(cluster *FoundationDBCluster, processGroup *ProcessGroupStatus) *FoundationDBProcessGroup {
+	result := &FoundationDBProcessGroup{
+		Spec: FoundationDBProcessGroupSpec{
+			ClusterName:    cluster.Name,
+			ProcessGroupID: processGroup.ProcessGroupID,
+			ProcessClass:   processGroup.ProcessClass,
+		},
+	}
+
+	result.SetFromProcessGroupStatus(processGroup)
+
+	return result
+}
+
+// SetFromProcessGroupStatus updates the status of the FoundationDBProcessGroup to match the provided process group
+// status.
+func (processGroup *FoundationDBProcessGroup) SetFromProcessGroupStatus(processGroupStatus *ProcessGroupStatus) {
+	status := processGroupStatus.DeepCopy()
+	processGroup.Status = FoundationDBProcessGroupStatus{
+		Addresses:              status.Addresses,
+		RemovalTimestamp:       status.RemovalTimestamp,
+		ExclusionTimestamp:     status.ExclusionTimestamp,
+		ExclusionSkipped:       status.ExclusionSkipped,
+		SuspensionTimestamp:    status.SuspensionTimestamp,
+		ProcessGroupConditions: status.ProcessGroupConditions,
+		FaultDomain:            status.FaultDomain,
+	}
+}
+
+// GetProcessGroupStatus returns the ProcessGroupStatus representation of this process group.
+func (processGroup *FoundationDBProcessGroup) GetProcessGroupStatus() *ProcessGroupStatus {
+	status := processGroup.Status.DeepCopy()
+
+	return &ProcessGroupStatus{
+		ProcessGroupID:         processGroup.Spec.ProcessGroupID,
+		ProcessClass:           processGroup.Spec.ProcessClass,
+		Addresses:              status.Addresses,
+		RemovalTimestamp:       status.RemovalTimestamp,
+		ExclusionTimestamp:     status.ExclusionTimestamp,
+		ExclusionSkipped:       status.ExclusionSkipped,
+		SuspensionTimestamp:    status.SuspensionTimestamp,
+		ProcessGroupConditions: status.ProcessGroupConditions,
+		FaultDomain:            status.FaultDomain,
+	}
+}
+
+func init() {
+	SchemeBuilder.Register(&FoundationDBProcessGroup{}, &FoundationDBProcessGroupList{})
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package v1beta2
 
 import (
+	"fmt"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=fdbrestore
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase of the restore",priority=0
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
 
@@ -59,12 +63,161 @@ type FoundationDBRestoreSpec struct {
 	// CustomParameters defines additional parameters to pass to the backup
 	// agents.
 	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
+
+	// TargetVersion defines the version of the database that should be
+	// restored. If neither the TargetVersion nor the TargetTimestamp is set,
+	// the restore will restore to the end of the backup. This setting is
+	// mutually exclusive with the TargetTimestamp.
+	// +kubebuilder:validation:Minimum=0
+	TargetVersion *int64 `json:"targetVersion,omitempty"`
+
+	// TargetTimestamp defines the point in time that should be restored. The
+	// timestamp will be converted to a version with the metadata of the
+	// cluster that was backed up, so this setting requires the
+	// SourceClusterName. This setting is mutually exclusive with the
+	// TargetVersion.
+	TargetTimestamp *metav1.Time `json:"targetTimestamp,omitempty"`
+
+	// SourceClusterName provides the name of the cluster that was backed up.
+	// The cluster must be in the same namespace as the restore. This is only
+	// required when the TargetTimestamp is set.
+	SourceClusterName string `json:"sourceClusterName,omitempty"`
+
+	// AddPrefix defines the prefix that will be added to the restored keys.
+	// +kubebuilder:validation:Pattern:=^[A-Za-z0-9\/\\-]*$
+	AddPrefix string `json:"addPrefix,omitempty"`
+
+	// RemovePrefix defines the prefix that will be removed from the restored
+	// keys. All restored key ranges must start with this prefix.
+	// +kubebuilder:validation:Pattern:=^[A-Za-z0-9\/\\-]*$
+	RemovePrefix string `json:"removePrefix,omitempty"`
+
+	// DestinationPolicy defines how the restore handles existing data in the
+	// destination key ranges. The default is RequireEmpty, which lets the
+	// restore fail if the destination is not empty.
+	// +kubebuilder:validation:Enum=RequireEmpty;ClearDestination
+	DestinationPolicy *RestoreDestinationPolicy `json:"destinationPolicy,omitempty"`
 }
 
+// RestoreDestinationPolicy defines how a restore handles existing data in the
+// destination key ranges.
+// +kubebuilder:validation:MaxLength=32
+type RestoreDestinationPolicy string
+
+const (
+	// RestoreDestinationPolicyRequireEmpty lets the restore fail if the
+	// destination key ranges contain any data.
+	RestoreDestinationPolicyRequireEmpty RestoreDestinationPolicy = "RequireEmpty"
+
+	// RestoreDestinationPolicyClearDestination clears the destination key
+	// ranges before the restore is started.
+	RestoreDestinationPolicyClearDestination RestoreDestinationPolicy = "ClearDestination"
+)
+
 // FoundationDBRestoreStatus describes the current status of the restore for a cluster.
 type FoundationDBRestoreStatus struct {
 	// Running describes whether the restore is currently running.
 	Running bool `json:"running,omitempty"`
+
+	// Phase describes the phase of the restore as reported by fdbrestore.
+	Phase RestorePhase `json:"phase,omitempty"`
+
+	// Progress describes the progress of the restore as reported by
+	// fdbrestore.
+	Progress *FoundationDBRestoreProgress `json:"progress,omitempty"`
+
+	// StartTimestamp is the time when the operator started the restore.
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// FinishTimestamp is the time when the operator observed that the
+	// restore was completed or aborted.
+	FinishTimestamp *metav1.Time `json:"finishTimestamp,omitempty"`
+
+	// DestinationCleared is set once the operator has cleared the destination
+	// key ranges, this ensures that the destination is only cleared once.
+	DestinationCleared bool `json:"destinationCleared,omitempty"`
+
+	// Conditions represents the latest observations of the restore.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// RestorePhase represents the phase of a restore.
+// +kubebuilder:validation:MaxLength=32
+type RestorePhase string
+
+const (
+	// RestorePhaseQueued represents a restore that is queued and waiting for
+	// the backup agents.
+	RestorePhaseQueued RestorePhase = "Queued"
+
+	// RestorePhaseStarting represents a restore that is being started.
+	RestorePhaseStarting RestorePhase = "Starting"
+
+	// RestorePhaseRunning represents a restore that is applying data.
+	RestorePhaseRunning RestorePhase = "Running"
+
+	// RestorePhaseCompleted represents a restore that is completed.
+	RestorePhaseCompleted RestorePhase = "Completed"
+
+	// RestorePhaseAborted represents a restore that was aborted.
+	RestorePhaseAborted RestorePhase = "Aborted"
+
+	// RestorePhaseUnknown represents a restore where the state reported by
+	// fdbrestore is unknown to the operator.
+	RestorePhaseUnknown RestorePhase = "Unknown"
+)
+
+// IsFinished returns true if the restore phase is a terminal phase.
+func (phase RestorePhase) IsFinished() bool {
+	return phase == RestorePhaseCompleted || phase == RestorePhaseAborted
+}
+
+const (
+	// RestoreConditionRunning is set to true while the restore is running.
+	RestoreConditionRunning = "Running"
+
+	// RestoreConditionCompleted is set to true once the restore is completed.
+	RestoreConditionCompleted = "Completed"
+
+	// RestoreConditionFailed is set to true if the restore was aborted or
+	// reported an error.
+	RestoreConditionFailed = "Failed"
+)
+
+// FoundationDBRestoreProgress describes the progress of a restore.
+type FoundationDBRestoreProgress struct {
+	// BlocksCompleted is the number of blocks that were restored.
+	BlocksCompleted int64 `json:"blocksCompleted,omitempty"`
+
+	// BlocksTotal is the total number of blocks of the restore.
+	BlocksTotal int64 `json:"blocksTotal,omitempty"`
+
+	// BlocksInProgress is the number of blocks that are currently restored.
+	BlocksInProgress int64 `json:"blocksInProgress,omitempty"`
+
+	// Files is the number of backup files that will be restored.
+	Files int64 `json:"files,omitempty"`
+
+	// BytesWritten is the number of bytes that were written to the
+	// destination cluster.
+	BytesWritten int64 `json:"bytesWritten,omitempty"`
+
+	// CurrentVersion is the version up to which the restore has applied the
+	// mutation logs.
+	CurrentVersion int64 `json:"currentVersion,omitempty"`
+
+	// FirstConsistentVersion is the first version at which the restored data
+	// is consistent.
+	FirstConsistentVersion int64 `json:"firstConsistentVersion,omitempty"`
+
+	// ApplyVersionLag is the lag between the version that was read from the
+	// backup and the version that was applied.
+	ApplyVersionLag int64 `json:"applyVersionLag,omitempty"`
+
+	// LastError is the last error reported by the restore.
+	LastError string `json:"lastError,omitempty"`
 }
 
 // FoundationDBKeyRange describes a range of keys for a command.
@@ -97,6 +250,67 @@ func (restore *FoundationDBRestore) BackupURL() string {
 	return restore.Spec.BlobStoreConfiguration.getURL(restore.BackupName(), restore.Spec.BlobStoreConfiguration.BucketName())
 }
 
+// GetDestinationPolicy returns the destination policy of the restore or if
+// unset the default RequireEmpty.
+func (restore *FoundationDBRestore) GetDestinationPolicy() RestoreDestinationPolicy {
+	if restore.Spec.DestinationPolicy == nil {
+		return RestoreDestinationPolicyRequireEmpty
+	}
+
+	return *restore.Spec.DestinationPolicy
+}
+
+// GetDestinationKeyRanges returns the key ranges that will be written by the
+// restore, after the prefixes were removed and added.
+func (restore *FoundationDBRestore) GetDestinationKeyRanges() []FoundationDBKeyRange {
+	keyRanges := restore.Spec.KeyRanges
+	if len(keyRanges) == 0 {
+		keyRanges = []FoundationDBKeyRange{
+			{
+				Start: "",
+				End:   "\\xff",
+			},
+		}
+	}
+
+	destinationKeyRanges := make([]FoundationDBKeyRange, 0, len(keyRanges))
+	for _, keyRange := range keyRanges {
+		destinationKeyRanges = append(destinationKeyRanges, FoundationDBKeyRange{
+			Start: restore.Spec.AddPrefix + strings.TrimPrefix(keyRange.Start, restore.Spec.RemovePrefix),
+			End:   restore.Spec.AddPrefix + strings.TrimPrefix(keyRange.End, restore.Spec.RemovePrefix),
+		})
+	}
+
+	return destinationKeyRanges
+}
+
+// ValidateTarget checks that the restore target and the prefixes are valid.
+func (restore *FoundationDBRestore) ValidateTarget() error {
+	if restore.Spec.TargetVersion != nil && restore.Spec.TargetTimestamp != nil {
+		return fmt.Errorf("targetVersion and targetTimestamp are mutually exclusive")
+	}
+
+	if restore.Spec.TargetTimestamp != nil && restore.Spec.SourceClusterName == "" {
+		return fmt.Errorf("targetTimestamp requires the sourceClusterName")
+	}
+
+	if restore.Spec.RemovePrefix == "" {
+		return nil
+	}
+
+	if len(restore.Spec.KeyRanges) == 0 {
+		return fmt.Errorf("removePrefix requires keyRanges that start with the prefix %s", restore.Spec.RemovePrefix)
+	}
+
+	for _, keyRange := range restore.Spec.KeyRanges {
+		if !strings.HasPrefix(keyRange.Start, restore.Spec.RemovePrefix) || !strings.HasPrefix(keyRange.End, restore.Spec.RemovePrefix) {
+			return fmt.Errorf("key range %s - %s does not start with the removePrefix %s", keyRange.Start, keyRange.End, restore.Spec.RemovePrefix)
+		}
+	}
+
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&FoundationDBRestore{}, &FoundationDBRestoreList{})
 }
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("[api] FoundationDBRestore", func() {
@@ -118,4 +119,110 @@ var _ = Describe("[api] FoundationDBRestore", func() {
 				"blobstore://account@account/mybackup?bucket=fdb-backups&secure_connection=0"),
 		)
 	})
+
+	When("getting the destination policy", func() {
+		DescribeTable("should return the correct destination policy",
+			func(restore FoundationDBRestore, expected RestoreDestinationPolicy) {
+				Expect(restore.GetDestinationPolicy()).To(Equal(expected))
+			},
+			Entry("no destination policy is set",
+				FoundationDBRestore{},
+				RestoreDestinationPolicyRequireEmpty),
+			Entry("the destination policy is set",
+				FoundationDBRestore{
+					Spec: FoundationDBRestoreSpec{
+						DestinationPolicy: &[]RestoreDestinationPolicy{RestoreDestinationPolicyClearDestination}[0],
+					},
+				},
+				RestoreDestinationPolicyClearDestination),
+		)
+	})
+
+	When("getting the destination key ranges", func() {
+		DescribeTable("should return the key ranges that will be written",
+			func(spec FoundationDBRestoreSpec, expected []FoundationDBKeyRange) {
+				restore := FoundationDBRestore{Spec: spec}
+				Expect(restore.GetDestinationKeyRanges()).To(Equal(expected))
+			},
+			Entry("restoring the whole database",
+				FoundationDBRestoreSpec{},
+				[]FoundationDBKeyRange{{Start: "", End: "\\xff"}}),
+			Entry("restoring the whole database with a prefix",
+				FoundationDBRestoreSpec{
+					AddPrefix: "new/",
+				},
+				[]FoundationDBKeyRange{{Start: "new/", End: "new/\\xff"}}),
+			Entry("restoring key ranges with prefixes",
+				FoundationDBRestoreSpec{
+					KeyRanges: []FoundationDBKeyRange{
+						{Start: "old/a", End: "old/b"},
+						{Start: "old/c", End: "old/d"},
+					},
+					AddPrefix:    "new/",
+					RemovePrefix: "old/",
+				},
+				[]FoundationDBKeyRange{{Start: "new/a", End: "new/b"}, {Start: "new/c", End: "new/d"}}),
+		)
+	})
+
+	When("validating the restore target", func() {
+		DescribeTable("should validate the target",
+			func(spec FoundationDBRestoreSpec, expectedError string) {
+				restore := FoundationDBRestore{Spec: spec}
+				err := restore.ValidateTarget()
+				if expectedError == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(expectedError))
+			},
+			Entry("no target is set",
+				FoundationDBRestoreSpec{},
+				""),
+			Entry("a target version is set",
+				FoundationDBRestoreSpec{
+					TargetVersion: pointer.Int64(1000),
+				},
+				""),
+			Entry("a target version and a target timestamp are set",
+				FoundationDBRestoreSpec{
+					TargetVersion:   pointer.Int64(1000),
+					TargetTimestamp: &metav1.Time{},
+				},
+				"targetVersion and targetTimestamp are mutually exclusive"),
+			Entry("a target timestamp is set without a source cluster",
+				FoundationDBRestoreSpec{
+					TargetTimestamp: &metav1.Time{},
+				},
+				"targetTimestamp requires the sourceClusterName"),
+			Entry("a target timestamp is set with a source cluster",
+				FoundationDBRestoreSpec{
+					TargetTimestamp:   &metav1.Time{},
+					SourceClusterName: "source",
+				},
+				""),
+			Entry("a remove prefix is set without key ranges",
+				FoundationDBRestoreSpec{
+					RemovePrefix: "old/",
+				},
+				"removePrefix requires keyRanges that start with the prefix old/"),
+			Entry("a remove prefix is set with a key range that doesn't start with the prefix",
+				FoundationDBRestoreSpec{
+					RemovePrefix: "old/",
+					KeyRanges: []FoundationDBKeyRange{
+						{Start: "old/a", End: "new/b"},
+					},
+				},
+				"key range old/a - new/b does not start with the removePrefix old/"),
+			Entry("a remove prefix is set with matching key ranges",
+				FoundationDBRestoreSpec{
+					RemovePrefix: "old/",
+					KeyRanges: []FoundationDBKeyRange{
+						{Start: "old/a", End: "old/b"},
+					},
+				},
+				""),
+		)
+	})
 })
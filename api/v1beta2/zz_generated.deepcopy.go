@@ -22,6 +22,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -58,6 +59,20 @@ func (in *AutomaticReplacementOptions) DeepCopyInto(out *AutomaticReplacementOpt
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConditionPolicies != nil {
+		in, out := &in.ConditionPolicies, &out.ConditionPolicies
+		*out = make([]ReplacementConditionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReplacementBuckets != nil {
+		in, out := &in.ReplacementBuckets, &out.ReplacementBuckets
+		*out = make([]ReplacementBucket, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticReplacementOptions.
@@ -70,6 +85,160 @@ func (in *AutomaticReplacementOptions) DeepCopy() *AutomaticReplacementOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupAgentAutoscaling) DeepCopyInto(out *BackupAgentAutoscaling) {
+	*out = *in
+	if in.MinAgentCount != nil {
+		in, out := &in.MinAgentCount, &out.MinAgentCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.TargetLagSeconds != nil {
+		in, out := &in.TargetLagSeconds, &out.TargetLagSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.TargetBytesPerSecondPerAgent != nil {
+		in, out := &in.TargetBytesPerSecondPerAgent, &out.TargetBytesPerSecondPerAgent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ScaleDownDelaySeconds != nil {
+		in, out := &in.ScaleDownDelaySeconds, &out.ScaleDownDelaySeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupAgentAutoscaling.
+func (in *BackupAgentAutoscaling) DeepCopy() *BackupAgentAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupAgentAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupAgentAutoscalingStatus) DeepCopyInto(out *BackupAgentAutoscalingStatus) {
+	*out = *in
+	if in.LastSampleTimestamp != nil {
+		in, out := &in.LastSampleTimestamp, &out.LastSampleTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScaleTimestamp != nil {
+		in, out := &in.LastScaleTimestamp, &out.LastScaleTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupAgentAutoscalingStatus.
+func (in *BackupAgentAutoscalingStatus) DeepCopy() *BackupAgentAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupAgentAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupBlobCredentials) DeepCopyInto(out *BackupBlobCredentials) {
+	*out = *in
+	if in.CredentialsKey != nil {
+		in, out := &in.CredentialsKey, &out.CredentialsKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.CASecretName != nil {
+		in, out := &in.CASecretName, &out.CASecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.CAKey != nil {
+		in, out := &in.CAKey, &out.CAKey
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupBlobCredentials.
+func (in *BackupBlobCredentials) DeepCopy() *BackupBlobCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupBlobCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupDestination) DeepCopyInto(out *BackupDestination) {
+	*out = *in
+	if in.BlobStoreConfiguration != nil {
+		in, out := &in.BlobStoreConfiguration, &out.BlobStoreConfiguration
+		*out = new(BlobStoreConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FileSystemConfiguration != nil {
+		in, out := &in.FileSystemConfiguration, &out.FileSystemConfiguration
+		*out = new(BackupFileSystemConfiguration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestination.
+func (in *BackupDestination) DeepCopy() *BackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupDestinationStatus) DeepCopyInto(out *BackupDestinationStatus) {
+	*out = *in
+	out.BackupDetails = in.BackupDetails
+	if in.RestorableRange != nil {
+		in, out := &in.RestorableRange, &out.RestorableRange
+		*out = new(BackupRestorableRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(BackupProgress)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestinationStatus.
+func (in *BackupDestinationStatus) DeepCopy() *BackupDestinationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupDestinationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupFileSystemConfiguration) DeepCopyInto(out *BackupFileSystemConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupFileSystemConfiguration.
+func (in *BackupFileSystemConfiguration) DeepCopy() *BackupFileSystemConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupFileSystemConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupGenerationStatus) DeepCopyInto(out *BackupGenerationStatus) {
 	*out = *in
@@ -85,6 +254,175 @@ func (in *BackupGenerationStatus) DeepCopy() *BackupGenerationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupProgress) DeepCopyInto(out *BackupProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupProgress.
+func (in *BackupProgress) DeepCopy() *BackupProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRestorableRange) DeepCopyInto(out *BackupRestorableRange) {
+	*out = *in
+	if in.MinTimestamp != nil {
+		in, out := &in.MinTimestamp, &out.MinTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.MaxTimestamp != nil {
+		in, out := &in.MaxTimestamp, &out.MaxTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LatestSnapshotTimestamp != nil {
+		in, out := &in.LatestSnapshotTimestamp, &out.LatestSnapshotTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRestorableRange.
+func (in *BackupRestorableRange) DeepCopy() *BackupRestorableRange {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRestorableRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRestoreValidation) DeepCopyInto(out *BackupRestoreValidation) {
+	*out = *in
+	in.ClusterTemplate.DeepCopyInto(&out.ClusterTemplate)
+	if in.VerificationJob != nil {
+		in, out := &in.VerificationJob, &out.VerificationJob
+		*out = new(batchv1.JobSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRestoreValidation.
+func (in *BackupRestoreValidation) DeepCopy() *BackupRestoreValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRestoreValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRestoreValidationResult) DeepCopyInto(out *BackupRestoreValidationResult) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishTimestamp != nil {
+		in, out := &in.FinishTimestamp, &out.FinishTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRestoreValidationResult.
+func (in *BackupRestoreValidationResult) DeepCopy() *BackupRestoreValidationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRestoreValidationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRestoreValidationStatus) DeepCopyInto(out *BackupRestoreValidationStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduledValidation != nil {
+		in, out := &in.LastScheduledValidation, &out.LastScheduledValidation
+		*out = (*in).DeepCopy()
+	}
+	if in.LastResult != nil {
+		in, out := &in.LastResult, &out.LastResult
+		*out = new(BackupRestoreValidationResult)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRestoreValidationStatus.
+func (in *BackupRestoreValidationStatus) DeepCopy() *BackupRestoreValidationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRestoreValidationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetentionPolicy) DeepCopyInto(out *BackupRetentionPolicy) {
+	*out = *in
+	if in.RetentionDays != nil {
+		in, out := &in.RetentionDays, &out.RetentionDays
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxSnapshots != nil {
+		in, out := &in.MaxSnapshots, &out.MaxSnapshots
+		*out = new(int)
+		**out = **in
+	}
+	if in.ExpirationIntervalSeconds != nil {
+		in, out := &in.ExpirationIntervalSeconds, &out.ExpirationIntervalSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetentionPolicy.
+func (in *BackupRetentionPolicy) DeepCopy() *BackupRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSnapshotSchedule) DeepCopyInto(out *BackupSnapshotSchedule) {
+	*out = *in
+	if in.SnapshotDurationSeconds != nil {
+		in, out := &in.SnapshotDurationSeconds, &out.SnapshotDurationSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSnapshotSchedule.
+func (in *BackupSnapshotSchedule) DeepCopy() *BackupSnapshotSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSnapshotSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BlobStoreConfiguration) DeepCopyInto(out *BlobStoreConfiguration) {
 	*out = *in
@@ -177,6 +515,26 @@ func (in *ClusterHealth) DeepCopy() *ClusterHealth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationPlan) DeepCopyInto(out *ConfigurationPlan) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationPlan.
+func (in *ConfigurationPlan) DeepCopy() *ConfigurationPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionString) DeepCopyInto(out *ConnectionString) {
 	*out = *in
@@ -227,6 +585,65 @@ func (in *ContainerOverrides) DeepCopy() *ContainerOverrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatorChange) DeepCopyInto(out *CoordinatorChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorChange.
+func (in *CoordinatorChange) DeepCopy() *CoordinatorChange {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatorChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatorConstraints) DeepCopyInto(out *CoordinatorConstraints) {
+	*out = *in
+	if in.ExcludedNodes != nil {
+		in, out := &in.ExcludedNodes, &out.ExcludedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedZones != nil {
+		in, out := &in.ExcludedZones, &out.ExcludedZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreferredPodLabels != nil {
+		in, out := &in.PreferredPodLabels, &out.PreferredPodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxCoordinatorsPerLocality != nil {
+		in, out := &in.MaxCoordinatorsPerLocality, &out.MaxCoordinatorsPerLocality
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PinnedCoordinators != nil {
+		in, out := &in.PinnedCoordinators, &out.PinnedCoordinators
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorConstraints.
+func (in *CoordinatorConstraints) DeepCopy() *CoordinatorConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatorConstraints)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoordinatorSelectionSetting) DeepCopyInto(out *CoordinatorSelectionSetting) {
 	*out = *in
@@ -292,6 +709,21 @@ func (in *DatabaseConfiguration) DeepCopyInto(out *DatabaseConfiguration) {
 		*out = make([]ExcludedServers, len(*in))
 		copy(*out, *in)
 	}
+	if in.PerpetualStorageWiggle != nil {
+		in, out := &in.PerpetualStorageWiggle, &out.PerpetualStorageWiggle
+		*out = new(int)
+		**out = **in
+	}
+	if in.PerpetualStorageWiggleLocality != nil {
+		in, out := &in.PerpetualStorageWiggleLocality, &out.PerpetualStorageWiggleLocality
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageMigrationType != nil {
+		in, out := &in.StorageMigrationType, &out.StorageMigrationType
+		*out = new(StorageMigrationType)
+		**out = **in
+	}
 	out.RoleCounts = in.RoleCounts
 	out.VersionFlags = in.VersionFlags
 }
@@ -306,6 +738,46 @@ func (in *DatabaseConfiguration) DeepCopy() *DatabaseConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisasterRecoveryGenerationStatus) DeepCopyInto(out *DisasterRecoveryGenerationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisasterRecoveryGenerationStatus.
+func (in *DisasterRecoveryGenerationStatus) DeepCopy() *DisasterRecoveryGenerationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DisasterRecoveryGenerationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DistributionConfig) DeepCopyInto(out *DistributionConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DesiredFaultDomains != nil {
+		in, out := &in.DesiredFaultDomains, &out.DesiredFaultDomains
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DistributionConfig.
+func (in *DistributionConfig) DeepCopy() *DistributionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DistributionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExcludedServers) DeepCopyInto(out *ExcludedServers) {
 	*out = *in
@@ -363,6 +835,36 @@ func (in *FoundationDBBackup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupDescription) DeepCopyInto(out *FoundationDBBackupDescription) {
+	*out = *in
+	if in.MinRestorablePoint != nil {
+		in, out := &in.MinRestorablePoint, &out.MinRestorablePoint
+		*out = new(FoundationDBBackupVersion)
+		**out = **in
+	}
+	if in.MaxRestorablePoint != nil {
+		in, out := &in.MaxRestorablePoint, &out.MaxRestorablePoint
+		*out = new(FoundationDBBackupVersion)
+		**out = **in
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]FoundationDBBackupSnapshot, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupDescription.
+func (in *FoundationDBBackupDescription) DeepCopy() *FoundationDBBackupDescription {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupDescription)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBBackupList) DeepCopyInto(out *FoundationDBBackupList) {
 	*out = *in
@@ -395,6 +897,23 @@ func (in *FoundationDBBackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupSnapshot) DeepCopyInto(out *FoundationDBBackupSnapshot) {
+	*out = *in
+	out.BeginVersion = in.BeginVersion
+	out.EndVersion = in.EndVersion
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupSnapshot.
+func (in *FoundationDBBackupSnapshot) DeepCopy() *FoundationDBBackupSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
 	*out = *in
@@ -403,6 +922,11 @@ func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.AgentAutoscaling != nil {
+		in, out := &in.AgentAutoscaling, &out.AgentAutoscaling
+		*out = new(BackupAgentAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SnapshotPeriodSeconds != nil {
 		in, out := &in.SnapshotPeriodSeconds, &out.SnapshotPeriodSeconds
 		*out = new(int)
@@ -433,8 +957,40 @@ func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
 		*out = new(BlobStoreConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]BackupDestination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.MainContainer.DeepCopyInto(&out.MainContainer)
 	in.SidecarContainer.DeepCopyInto(&out.SidecarContainer)
+	if in.SnapshotSchedule != nil {
+		in, out := &in.SnapshotSchedule, &out.SnapshotSchedule
+		*out = new(BackupSnapshotSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(BackupRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlobCredentials != nil {
+		in, out := &in.BlobCredentials, &out.BlobCredentials
+		*out = new(BackupBlobCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreValidation != nil {
+		in, out := &in.RestoreValidation, &out.RestoreValidation
+		*out = new(BackupRestoreValidation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(BackupDeletionPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupSpec.
@@ -456,6 +1012,36 @@ func (in *FoundationDBBackupStatus) DeepCopyInto(out *FoundationDBBackupStatus)
 		**out = **in
 	}
 	out.Generations = in.Generations
+	if in.RestorableRange != nil {
+		in, out := &in.RestorableRange, &out.RestorableRange
+		*out = new(BackupRestorableRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]BackupDestinationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScheduledSnapshot != nil {
+		in, out := &in.LastScheduledSnapshot, &out.LastScheduledSnapshot
+		*out = (*in).DeepCopy()
+	}
+	if in.LastExpiration != nil {
+		in, out := &in.LastExpiration, &out.LastExpiration
+		*out = (*in).DeepCopy()
+	}
+	if in.AgentAutoscaling != nil {
+		in, out := &in.AgentAutoscaling, &out.AgentAutoscaling
+		*out = new(BackupAgentAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreValidation != nil {
+		in, out := &in.RestoreValidation, &out.RestoreValidation
+		*out = new(BackupRestoreValidationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupStatus.
@@ -483,6 +1069,21 @@ func (in *FoundationDBBackupStatusBackupDetails) DeepCopy() *FoundationDBBackupS
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupVersion) DeepCopyInto(out *FoundationDBBackupVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupVersion.
+func (in *FoundationDBBackupVersion) DeepCopy() *FoundationDBBackupVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBCluster) DeepCopyInto(out *FoundationDBCluster) {
 	*out = *in
@@ -518,6 +1119,11 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBCl
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RequireConfigurationApproval != nil {
+		in, out := &in.RequireConfigurationApproval, &out.RequireConfigurationApproval
+		*out = new(bool)
+		**out = **in
+	}
 	if in.KillProcesses != nil {
 		in, out := &in.KillProcesses, &out.KillProcesses
 		*out = new(bool)
@@ -529,6 +1135,7 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBCl
 		**out = **in
 	}
 	in.Replacements.DeepCopyInto(&out.Replacements)
+	in.NodeDrainOptions.DeepCopyInto(&out.NodeDrainOptions)
 	if in.UseNonBlockingExcludes != nil {
 		in, out := &in.UseNonBlockingExcludes, &out.UseNonBlockingExcludes
 		*out = new(bool)
@@ -564,17 +1171,28 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBCl
 		*out = new(int)
 		**out = **in
 	}
+	if in.MinimumSuspensionDurationSeconds != nil {
+		in, out := &in.MinimumSuspensionDurationSeconds, &out.MinimumSuspensionDurationSeconds
+		*out = new(int)
+		**out = **in
+	}
 	if in.UseManagementAPI != nil {
 		in, out := &in.UseManagementAPI, &out.UseManagementAPI
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UseProcessGroupResources != nil {
+		in, out := &in.UseProcessGroupResources, &out.UseProcessGroupResources
+		*out = new(bool)
+		**out = **in
+	}
 	in.MaintenanceModeOptions.DeepCopyInto(&out.MaintenanceModeOptions)
 	if in.IgnoreLogGroupsForUpgrade != nil {
 		in, out := &in.IgnoreLogGroupsForUpgrade, &out.IgnoreLogGroupsForUpgrade
 		*out = make([]LogGroup, len(*in))
 		copy(*out, *in)
 	}
+	in.StorageEngineMigration.DeepCopyInto(&out.StorageEngineMigration)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterAutomationOptions.
@@ -590,6 +1208,11 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopy() *FoundationDBClusterA
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBClusterFaultDomain) DeepCopyInto(out *FoundationDBClusterFaultDomain) {
 	*out = *in
+	if in.DistributionConfig != nil {
+		in, out := &in.DistributionConfig, &out.DistributionConfig
+		*out = new(DistributionConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterFaultDomain.
@@ -647,7 +1270,7 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 	}
 	out.ProcessCounts = in.ProcessCounts
 	in.PartialConnectionString.DeepCopyInto(&out.PartialConnectionString)
-	out.FaultDomain = in.FaultDomain
+	in.FaultDomain.DeepCopyInto(&out.FaultDomain)
 	if in.ProcessGroupsToRemove != nil {
 		in, out := &in.ProcessGroupsToRemove, &out.ProcessGroupsToRemove
 		*out = make([]ProcessGroupID, len(*in))
@@ -689,6 +1312,7 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 		*out = make([]CoordinatorSelectionSetting, len(*in))
 		copy(*out, *in)
 	}
+	in.CoordinatorConstraints.DeepCopyInto(&out.CoordinatorConstraints)
 	in.LabelConfig.DeepCopyInto(&out.LabelConfig)
 	if in.UseExplicitListenAddress != nil {
 		in, out := &in.UseExplicitListenAddress, &out.UseExplicitListenAddress
@@ -752,6 +1376,33 @@ func (in *FoundationDBClusterStatus) DeepCopyInto(out *FoundationDBClusterStatus
 	}
 	in.Locks.DeepCopyInto(&out.Locks)
 	in.MaintenanceModeInfo.DeepCopyInto(&out.MaintenanceModeInfo)
+	if in.NodeDrains != nil {
+		in, out := &in.NodeDrains, &out.NodeDrains
+		*out = make([]NodeDrainStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageEngineMigration != nil {
+		in, out := &in.StorageEngineMigration, &out.StorageEngineMigration
+		*out = new(StorageEngineMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageWiggle != nil {
+		in, out := &in.StorageWiggle, &out.StorageWiggle
+		*out = new(StorageWiggleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigurationPlan != nil {
+		in, out := &in.ConfigurationPlan, &out.ConfigurationPlan
+		*out = new(ConfigurationPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CoordinatorChanges != nil {
+		in, out := &in.CoordinatorChanges, &out.CoordinatorChanges
+		*out = make([]CoordinatorChange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterStatus.
@@ -773,58 +1424,331 @@ func (in FoundationDBCustomParameters) DeepCopyInto(out *FoundationDBCustomParam
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBCustomParameters.
-func (in FoundationDBCustomParameters) DeepCopy() FoundationDBCustomParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBCustomParameters.
+func (in FoundationDBCustomParameters) DeepCopy() FoundationDBCustomParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBCustomParameters)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecovery) DeepCopyInto(out *FoundationDBDisasterRecovery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecovery.
+func (in *FoundationDBDisasterRecovery) DeepCopy() *FoundationDBDisasterRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBDisasterRecovery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecoveryList) DeepCopyInto(out *FoundationDBDisasterRecoveryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FoundationDBDisasterRecovery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecoveryList.
+func (in *FoundationDBDisasterRecoveryList) DeepCopy() *FoundationDBDisasterRecoveryList {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecoveryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBDisasterRecoveryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecoverySpec) DeepCopyInto(out *FoundationDBDisasterRecoverySpec) {
+	*out = *in
+	if in.AgentCount != nil {
+		in, out := &in.AgentCount, &out.AgentCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.DeploymentMetadata != nil {
+		in, out := &in.DeploymentMetadata, &out.DeploymentMetadata
+		*out = new(v1.ObjectMeta)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplateSpec != nil {
+		in, out := &in.PodTemplateSpec, &out.PodTemplateSpec
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomParameters != nil {
+		in, out := &in.CustomParameters, &out.CustomParameters
+		*out = make(FoundationDBCustomParameters, len(*in))
+		copy(*out, *in)
+	}
+	in.MainContainer.DeepCopyInto(&out.MainContainer)
+	in.SidecarContainer.DeepCopyInto(&out.SidecarContainer)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecoverySpec.
+func (in *FoundationDBDisasterRecoverySpec) DeepCopy() *FoundationDBDisasterRecoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecoveryStatus) DeepCopyInto(out *FoundationDBDisasterRecoveryStatus) {
+	*out = *in
+	if in.DRDetails != nil {
+		in, out := &in.DRDetails, &out.DRDetails
+		*out = new(FoundationDBDisasterRecoveryStatusDetails)
+		**out = **in
+	}
+	out.Generations = in.Generations
+	if in.LastSwitchTimestamp != nil {
+		in, out := &in.LastSwitchTimestamp, &out.LastSwitchTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecoveryStatus.
+func (in *FoundationDBDisasterRecoveryStatus) DeepCopy() *FoundationDBDisasterRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecoveryStatusDetails) DeepCopyInto(out *FoundationDBDisasterRecoveryStatusDetails) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecoveryStatusDetails.
+func (in *FoundationDBDisasterRecoveryStatusDetails) DeepCopy() *FoundationDBDisasterRecoveryStatusDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecoveryStatusDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBKeyRange) DeepCopyInto(out *FoundationDBKeyRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBKeyRange.
+func (in *FoundationDBKeyRange) DeepCopy() *FoundationDBKeyRange {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBKeyRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveBackupStatus) DeepCopyInto(out *FoundationDBLiveBackupStatus) {
+	*out = *in
+	out.Status = in.Status
+	if in.LatestRestorablePoint != nil {
+		in, out := &in.LatestRestorablePoint, &out.LatestRestorablePoint
+		*out = new(FoundationDBLiveBackupStatusRestorablePoint)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatus.
+func (in *FoundationDBLiveBackupStatus) DeepCopy() *FoundationDBLiveBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveBackupStatusRestorablePoint) DeepCopyInto(out *FoundationDBLiveBackupStatusRestorablePoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatusRestorablePoint.
+func (in *FoundationDBLiveBackupStatusRestorablePoint) DeepCopy() *FoundationDBLiveBackupStatusRestorablePoint {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveBackupStatusRestorablePoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveBackupStatusState) DeepCopyInto(out *FoundationDBLiveBackupStatusState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatusState.
+func (in *FoundationDBLiveBackupStatusState) DeepCopy() *FoundationDBLiveBackupStatusState {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveBackupStatusState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBProcessGroup) DeepCopyInto(out *FoundationDBProcessGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBProcessGroup.
+func (in *FoundationDBProcessGroup) DeepCopy() *FoundationDBProcessGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBCustomParameters)
+	out := new(FoundationDBProcessGroup)
 	in.DeepCopyInto(out)
-	return *out
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBProcessGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBKeyRange) DeepCopyInto(out *FoundationDBKeyRange) {
+func (in *FoundationDBProcessGroupList) DeepCopyInto(out *FoundationDBProcessGroupList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FoundationDBProcessGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBKeyRange.
-func (in *FoundationDBKeyRange) DeepCopy() *FoundationDBKeyRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBProcessGroupList.
+func (in *FoundationDBProcessGroupList) DeepCopy() *FoundationDBProcessGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBKeyRange)
+	out := new(FoundationDBProcessGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBProcessGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBLiveBackupStatus) DeepCopyInto(out *FoundationDBLiveBackupStatus) {
+func (in *FoundationDBProcessGroupSpec) DeepCopyInto(out *FoundationDBProcessGroupSpec) {
 	*out = *in
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatus.
-func (in *FoundationDBLiveBackupStatus) DeepCopy() *FoundationDBLiveBackupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBProcessGroupSpec.
+func (in *FoundationDBProcessGroupSpec) DeepCopy() *FoundationDBProcessGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBLiveBackupStatus)
+	out := new(FoundationDBProcessGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBLiveBackupStatusState) DeepCopyInto(out *FoundationDBLiveBackupStatusState) {
+func (in *FoundationDBProcessGroupStatus) DeepCopyInto(out *FoundationDBProcessGroupStatus) {
 	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovalTimestamp != nil {
+		in, out := &in.RemovalTimestamp, &out.RemovalTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ExclusionTimestamp != nil {
+		in, out := &in.ExclusionTimestamp, &out.ExclusionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.SuspensionTimestamp != nil {
+		in, out := &in.SuspensionTimestamp, &out.SuspensionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ProcessGroupConditions != nil {
+		in, out := &in.ProcessGroupConditions, &out.ProcessGroupConditions
+		*out = make([]*ProcessGroupCondition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ProcessGroupCondition)
+				**out = **in
+			}
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatusState.
-func (in *FoundationDBLiveBackupStatusState) DeepCopy() *FoundationDBLiveBackupStatusState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBProcessGroupStatus.
+func (in *FoundationDBProcessGroupStatus) DeepCopy() *FoundationDBProcessGroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBLiveBackupStatusState)
+	out := new(FoundationDBProcessGroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -835,7 +1759,7 @@ func (in *FoundationDBRestore) DeepCopyInto(out *FoundationDBRestore) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestore.
@@ -888,6 +1812,21 @@ func (in *FoundationDBRestoreList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBRestoreProgress) DeepCopyInto(out *FoundationDBRestoreProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestoreProgress.
+func (in *FoundationDBRestoreProgress) DeepCopy() *FoundationDBRestoreProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBRestoreProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBRestoreSpec) DeepCopyInto(out *FoundationDBRestoreSpec) {
 	*out = *in
@@ -906,6 +1845,20 @@ func (in *FoundationDBRestoreSpec) DeepCopyInto(out *FoundationDBRestoreSpec) {
 		*out = make(FoundationDBCustomParameters, len(*in))
 		copy(*out, *in)
 	}
+	if in.TargetVersion != nil {
+		in, out := &in.TargetVersion, &out.TargetVersion
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TargetTimestamp != nil {
+		in, out := &in.TargetTimestamp, &out.TargetTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.DestinationPolicy != nil {
+		in, out := &in.DestinationPolicy, &out.DestinationPolicy
+		*out = new(RestoreDestinationPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestoreSpec.
@@ -921,6 +1874,26 @@ func (in *FoundationDBRestoreSpec) DeepCopy() *FoundationDBRestoreSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBRestoreStatus) DeepCopyInto(out *FoundationDBRestoreStatus) {
 	*out = *in
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(FoundationDBRestoreProgress)
+		**out = **in
+	}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishTimestamp != nil {
+		in, out := &in.FinishTimestamp, &out.FinishTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestoreStatus.
@@ -1056,6 +2029,7 @@ func (in *FoundationDBStatusClusterInfo) DeepCopyInto(out *FoundationDBStatusClu
 		*out = make([]FoundationDBStatusMessage, len(*in))
 		copy(*out, *in)
 	}
+	in.StorageWiggler.DeepCopyInto(&out.StorageWiggler)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusClusterInfo.
@@ -1307,6 +2281,7 @@ func (in *FoundationDBStatusProcessMessage) DeepCopy() *FoundationDBStatusProces
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusProcessRoleInfo) DeepCopyInto(out *FoundationDBStatusProcessRoleInfo) {
 	*out = *in
+	out.StorageMetadata = in.StorageMetadata
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessRoleInfo.
@@ -1337,6 +2312,58 @@ func (in *FoundationDBStatusQosInfo) DeepCopy() *FoundationDBStatusQosInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusStorageMetadata) DeepCopyInto(out *FoundationDBStatusStorageMetadata) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusStorageMetadata.
+func (in *FoundationDBStatusStorageMetadata) DeepCopy() *FoundationDBStatusStorageMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusStorageMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusStorageWiggler) DeepCopyInto(out *FoundationDBStatusStorageWiggler) {
+	*out = *in
+	out.Primary = in.Primary
+	out.Remote = in.Remote
+	if in.WiggleServerAddresses != nil {
+		in, out := &in.WiggleServerAddresses, &out.WiggleServerAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusStorageWiggler.
+func (in *FoundationDBStatusStorageWiggler) DeepCopy() *FoundationDBStatusStorageWiggler {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusStorageWiggler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusStorageWigglerStats) DeepCopyInto(out *FoundationDBStatusStorageWigglerStats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusStorageWigglerStats.
+func (in *FoundationDBStatusStorageWigglerStats) DeepCopy() *FoundationDBStatusStorageWigglerStats {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusStorageWigglerStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusSupportedVersion) DeepCopyInto(out *FoundationDBStatusSupportedVersion) {
 	*out = *in
@@ -1470,6 +2497,11 @@ func (in *LockOptions) DeepCopyInto(out *LockOptions) {
 		*out = make([]LockDenyListEntry, len(*in))
 		copy(*out, *in)
 	}
+	if in.UseGlobalCoordination != nil {
+		in, out := &in.UseGlobalCoordination, &out.UseGlobalCoordination
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LockOptions.
@@ -1551,6 +2583,46 @@ func (in *MaintenanceModeOptions) DeepCopy() *MaintenanceModeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainOptions) DeepCopyInto(out *NodeDrainOptions) {
+	*out = *in
+	if in.TaintKeys != nil {
+		in, out := &in.TaintKeys, &out.TaintKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainOptions.
+func (in *NodeDrainOptions) DeepCopy() *NodeDrainOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainStatus) DeepCopyInto(out *NodeDrainStatus) {
+	*out = *in
+	if in.ProcessGroups != nil {
+		in, out := &in.ProcessGroups, &out.ProcessGroups
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainStatus.
+func (in *NodeDrainStatus) DeepCopy() *NodeDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *None) DeepCopyInto(out *None) {
 	*out = *in
@@ -1639,6 +2711,10 @@ func (in *ProcessGroupStatus) DeepCopyInto(out *ProcessGroupStatus) {
 		in, out := &in.ExclusionTimestamp, &out.ExclusionTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.SuspensionTimestamp != nil {
+		in, out := &in.SuspensionTimestamp, &out.SuspensionTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.ProcessGroupConditions != nil {
 		in, out := &in.ProcessGroupConditions, &out.ProcessGroupConditions
 		*out = make([]*ProcessGroupCondition, len(*in))
@@ -1727,6 +2803,51 @@ func (in *Region) DeepCopy() *Region {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacementBucket) DeepCopyInto(out *ReplacementBucket) {
+	*out = *in
+	if in.MaxConcurrentReplacements != nil {
+		in, out := &in.MaxConcurrentReplacements, &out.MaxConcurrentReplacements
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacementBucket.
+func (in *ReplacementBucket) DeepCopy() *ReplacementBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplacementBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacementConditionPolicy) DeepCopyInto(out *ReplacementConditionPolicy) {
+	*out = *in
+	if in.FailureDetectionTimeSeconds != nil {
+		in, out := &in.FailureDetectionTimeSeconds, &out.FailureDetectionTimeSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxConcurrentReplacements != nil {
+		in, out := &in.MaxConcurrentReplacements, &out.MaxConcurrentReplacements
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacementConditionPolicy.
+func (in *ReplacementConditionPolicy) DeepCopy() *ReplacementConditionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplacementConditionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequiredAddressSet) DeepCopyInto(out *RequiredAddressSet) {
 	*out = *in
@@ -1802,6 +2923,78 @@ func (in *RoutingConfig) DeepCopy() *RoutingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageEngineMigrationOptions) DeepCopyInto(out *StorageEngineMigrationOptions) {
+	*out = *in
+	if in.MaxConcurrentMigrations != nil {
+		in, out := &in.MaxConcurrentMigrations, &out.MaxConcurrentMigrations
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageEngineMigrationOptions.
+func (in *StorageEngineMigrationOptions) DeepCopy() *StorageEngineMigrationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageEngineMigrationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageEngineMigrationStatus) DeepCopyInto(out *StorageEngineMigrationStatus) {
+	*out = *in
+	if in.StorageServers != nil {
+		in, out := &in.StorageServers, &out.StorageServers
+		*out = make(map[StorageEngine]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PreviousStorageMigrationType != nil {
+		in, out := &in.PreviousStorageMigrationType, &out.PreviousStorageMigrationType
+		*out = new(StorageMigrationType)
+		**out = **in
+	}
+	if in.PreviousPerpetualStorageWiggle != nil {
+		in, out := &in.PreviousPerpetualStorageWiggle, &out.PreviousPerpetualStorageWiggle
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageEngineMigrationStatus.
+func (in *StorageEngineMigrationStatus) DeepCopy() *StorageEngineMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageEngineMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageWiggleStatus) DeepCopyInto(out *StorageWiggleStatus) {
+	*out = *in
+	if in.WiggledAddresses != nil {
+		in, out := &in.WiggledAddresses, &out.WiggledAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageWiggleStatus.
+func (in *StorageWiggleStatus) DeepCopy() *StorageWiggleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageWiggleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaintReplacementOption) DeepCopyInto(out *TaintReplacementOption) {
 	*out = *in
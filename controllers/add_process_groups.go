@@ -60,10 +60,21 @@ func (a addProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterR
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "AddingProcesses", fmt.Sprintf("Adding %d %s processes", newCount, processClass))
 		idNum := 1
 
+		var faultDomainCounts map[fdbv1beta2.FaultDomain]int
+		if cluster.UseFaultDomainDistribution() {
+			faultDomainCounts = cluster.GetLogicalFaultDomainCounts(processClass)
+		}
+
 		for i := 0; i < newCount; i++ {
 			var processGroupID fdbv1beta2.ProcessGroupID
 			processGroupID, idNum = cluster.GetNextProcessGroupID(processClass, processGroupIDs[processClass], idNum)
-			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus(processGroupID, processClass, nil))
+			processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, processClass, nil)
+			// If the fault domain distribution is enabled, the new process group will be added to the logical fault
+			// domain with the fewest process groups.
+			if faultDomainCounts != nil {
+				processGroup.FaultDomain = cluster.GetNextLogicalFaultDomain(faultDomainCounts)
+			}
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
 			// Increase the idNum here, since we just added a Process Group with this ID number.
 			idNum++
 		}
@@ -28,6 +28,8 @@ import (
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("add_process_groups", func() {
@@ -140,6 +142,43 @@ var _ = Describe("add_process_groups", func() {
 		})
 	})
 
+	When("the fault domain distribution is enabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.FaultDomain = fdbv1beta2.FoundationDBClusterFaultDomain{
+				Key: corev1.LabelHostname,
+				DistributionConfig: &fdbv1beta2.DistributionConfig{
+					Enabled: pointer.Bool(true),
+				},
+			}
+
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage {
+					continue
+				}
+
+				processGroup.FaultDomain = fdbv1beta2.FaultDomain(cluster.Name + "-0")
+				if processGroup.ProcessGroupID == "storage-1" {
+					processGroup.FaultDomain = fdbv1beta2.FaultDomain(cluster.Name + "-1")
+				}
+			}
+
+			cluster.Spec.ProcessCounts.Storage += 2
+		})
+
+		It("should add the new process groups to the least full logical fault domains", func() {
+			faultDomains := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FaultDomain{}
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage {
+					faultDomains[processGroup.ProcessGroupID] = processGroup.FaultDomain
+				}
+			}
+
+			Expect(faultDomains).To(HaveLen(6))
+			Expect(faultDomains).To(HaveKeyWithValue(fdbv1beta2.ProcessGroupID("storage-5"), fdbv1beta2.FaultDomain(cluster.Name+"-2")))
+			Expect(faultDomains).To(HaveKeyWithValue(fdbv1beta2.ProcessGroupID("storage-6"), fdbv1beta2.FaultDomain(cluster.Name+"-1")))
+		})
+	})
+
 	Context("with an increase to the desired storage count", func() {
 		BeforeEach(func() {
 			cluster.Spec.ProcessCounts.Storage += 2
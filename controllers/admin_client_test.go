@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
@@ -120,7 +121,16 @@ var _ = Describe("admin_client_test", func() {
 						},
 						Version:       fdbv1beta2.Versions.NextMajorVersion.String(),
 						UptimeSeconds: 60000,
-						Roles:         nil,
+						// The mock reports the storage role with the storage engine of the storage server, like
+						// FoundationDB does in the storage_metadata.
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{
+								Role: string(fdbv1beta2.ProcessRoleStorage),
+								StorageMetadata: fdbv1beta2.FoundationDBStatusStorageMetadata{
+									StorageEngine: fdbv1beta2.StorageEngineSSD2,
+								},
+							},
+						},
 					}))
 				})
 			})
@@ -162,7 +172,16 @@ var _ = Describe("admin_client_test", func() {
 						},
 						Version:       cluster.Spec.Version,
 						UptimeSeconds: 60000,
-						Roles:         nil,
+						// The mock reports the storage role with the storage engine of the storage server, like
+						// FoundationDB does in the storage_metadata.
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{
+								Role: string(fdbv1beta2.ProcessRoleStorage),
+								StorageMetadata: fdbv1beta2.FoundationDBStatusStorageMetadata{
+									StorageEngine: fdbv1beta2.StorageEngineSSD2,
+								},
+							},
+						},
 					}))
 				})
 			})
@@ -224,7 +243,7 @@ var _ = Describe("admin_client_test", func() {
 
 		Context("with a backup running", func() {
 			BeforeEach(func() {
-				err = mockAdminClient.StartBackup("blobstore://test@test-service/test-backup", 10)
+				err = mockAdminClient.StartBackup("default", "blobstore://test@test-service/test-backup", 10)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -265,7 +284,7 @@ var _ = Describe("admin_client_test", func() {
 
 			Context("with a stopped backup", func() {
 				BeforeEach(func() {
-					err = mockAdminClient.StopBackup("blobstore://test@test-service/test-backup")
+					err = mockAdminClient.StopBackup("default")
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -285,7 +304,7 @@ var _ = Describe("admin_client_test", func() {
 	Describe("backup status", func() {
 		var status *fdbv1beta2.FoundationDBLiveBackupStatus
 		JustBeforeEach(func() {
-			status, err = mockAdminClient.GetBackupStatus()
+			status, err = mockAdminClient.GetBackupStatus("default")
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -299,7 +318,7 @@ var _ = Describe("admin_client_test", func() {
 
 		Context("with a backup running", func() {
 			BeforeEach(func() {
-				err = mockAdminClient.StartBackup("blobstore://test@test-service/test-backup", 10)
+				err = mockAdminClient.StartBackup("default", "blobstore://test@test-service/test-backup", 10)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -336,7 +355,7 @@ var _ = Describe("admin_client_test", func() {
 
 			Context("with a stopped backup", func() {
 				BeforeEach(func() {
-					err = mockAdminClient.StopBackup("blobstore://test@test-service/test-backup")
+					err = mockAdminClient.StopBackup("default")
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -347,7 +366,7 @@ var _ = Describe("admin_client_test", func() {
 
 			Context("with a modification to the snapshot time", func() {
 				BeforeEach(func() {
-					err = mockAdminClient.ModifyBackup(20)
+					err = mockAdminClient.ModifyBackup("default", 20)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -374,7 +393,7 @@ var _ = Describe("admin_client_test", func() {
 
 		Context("with a restore running", func() {
 			BeforeEach(func() {
-				err = mockAdminClient.StartRestore("blobstore://test@test-service/test-backup", nil)
+				err = mockAdminClient.StartRestore("blobstore://test@test-service/test-backup", fdbadminclient.RestoreOptions{})
 				Expect(err).NotTo(HaveOccurred())
 
 				status, err = mockAdminClient.GetRestoreStatus()
@@ -382,7 +401,8 @@ var _ = Describe("admin_client_test", func() {
 			})
 
 			It("should contain the backup URL", func() {
-				Expect(status).To(Equal("blobstore://test@test-service/test-backup\n"))
+				Expect(status).To(ContainSubstring("State: running"))
+				Expect(status).To(ContainSubstring("URL: blobstore://test@test-service/test-backup  Range: ''-'\\xff'"))
 			})
 		})
 	})
@@ -0,0 +1,151 @@
+/*
+ * autoscale_backup_agents.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// backupAgentAutoscalingInterval defines how often the progress of a backup is sampled to scale the backup agents.
+const backupAgentAutoscalingInterval = 1 * time.Minute
+
+// autoscaleBackupAgents provides a reconciliation step for scaling the backup agents based on the progress of the
+// backup.
+type autoscaleBackupAgents struct{}
+
+// reconcile runs the reconciler's work.
+func (s autoscaleBackupAgents) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	policy := backup.Spec.AgentAutoscaling
+	if policy == nil {
+		if backup.Status.AgentAutoscaling == nil {
+			return nil
+		}
+
+		backup.Status.AgentAutoscaling = nil
+		err := r.updateOrApply(ctx, backup)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		return nil
+	}
+
+	now := time.Now()
+	previous := backup.Status.AgentAutoscaling
+	if previous != nil && previous.LastSampleTimestamp != nil && now.Sub(previous.LastSampleTimestamp.Time) < backupAgentAutoscalingInterval {
+		return nil
+	}
+
+	status, running := sampleBackupProgress(backup, previous, now)
+	currentAgentCount := backup.GetDesiredAgentCount()
+	status.DesiredAgentCount = currentAgentCount
+	if previous != nil {
+		status.LastScaleTimestamp = previous.LastScaleTimestamp
+	}
+
+	// The agent count of a backup that is not running is kept, the progress doesn't say anything about the required
+	// agents.
+	if running {
+		status.DesiredAgentCount = getAutoscaledBackupAgentCount(policy, status, currentAgentCount, now)
+	}
+
+	if status.DesiredAgentCount != currentAgentCount {
+		status.LastScaleTimestamp = &metav1.Time{Time: now}
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupAgentsScaled", fmt.Sprintf("Scaled backup agents from %d to %d", currentAgentCount, status.DesiredAgentCount))
+	}
+
+	backup.Status.AgentAutoscaling = status
+	err := r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// sampleBackupProgress creates a new sample of the progress of all destinations. The throughput is calculated based on
+// the previous sample. The returned bool is true if at least one destination reported progress.
+func sampleBackupProgress(backup *fdbv1beta2.FoundationDBBackup, previous *fdbv1beta2.BackupAgentAutoscalingStatus, now time.Time) (*fdbv1beta2.BackupAgentAutoscalingStatus, bool) {
+	status := &fdbv1beta2.BackupAgentAutoscalingStatus{
+		LastSampleTimestamp: &metav1.Time{Time: now},
+	}
+
+	running := false
+	for _, destination := range backup.Status.Destinations {
+		if !destination.BackupDetails.Running || destination.Progress == nil {
+			continue
+		}
+
+		running = true
+		status.BytesWritten += destination.Progress.LogBytesWritten + destination.Progress.RangeBytesWritten
+		if destination.Progress.LagSeconds > status.LagSeconds {
+			status.LagSeconds = destination.Progress.LagSeconds
+		}
+	}
+
+	// If the bytes written decreased a backup was restarted, in this case the throughput can't be calculated until
+	// the next sample.
+	if previous != nil && previous.LastSampleTimestamp != nil && status.BytesWritten >= previous.BytesWritten {
+		elapsed := now.Sub(previous.LastSampleTimestamp.Time).Seconds()
+		if elapsed > 0 {
+			status.BytesPerSecond = int64(float64(status.BytesWritten-previous.BytesWritten) / elapsed)
+		}
+	}
+
+	return status, running
+}
+
+// getAutoscaledBackupAgentCount returns the number of backup agents that should run for the sampled progress.
+func getAutoscaledBackupAgentCount(policy *fdbv1beta2.BackupAgentAutoscaling, status *fdbv1beta2.BackupAgentAutoscalingStatus, currentAgentCount int, now time.Time) int {
+	desiredAgentCount := currentAgentCount
+	targetLagSeconds := policy.GetTargetLagSeconds()
+
+	if policy.TargetBytesPerSecondPerAgent != nil && *policy.TargetBytesPerSecondPerAgent > 0 && status.BytesPerSecond > 0 {
+		desiredAgentCount = int(math.Ceil(float64(status.BytesPerSecond) / float64(*policy.TargetBytesPerSecondPerAgent)))
+	} else if policy.TargetBytesPerSecondPerAgent == nil && status.LagSeconds*2 < targetLagSeconds {
+		desiredAgentCount = currentAgentCount - 1
+	}
+
+	// If the backup falls behind, at least one additional agent is required independent of the throughput.
+	if status.LagSeconds > targetLagSeconds && desiredAgentCount <= currentAgentCount {
+		desiredAgentCount = currentAgentCount + 1
+	}
+
+	desiredAgentCount = policy.LimitAgentCount(desiredAgentCount)
+	if desiredAgentCount >= currentAgentCount {
+		return desiredAgentCount
+	}
+
+	// Removing agents directly after a scaling could result in flapping, so the agents are only removed after the
+	// scale down delay.
+	if status.LastScaleTimestamp != nil && now.Sub(status.LastScaleTimestamp.Time) < policy.GetScaleDownDelay() {
+		return currentAgentCount
+	}
+
+	return desiredAgentCount
+}
@@ -0,0 +1,153 @@
+/*
+ * autoscale_backup_agents_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package controllers
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("autoscale_backup_agents", func() {
+	now := time.Date(2023, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable("getting the autoscaled agent count",
+		func(policy *fdbv1beta2.BackupAgentAutoscaling, status *fdbv1beta2.BackupAgentAutoscalingStatus, expected int) {
+			Expect(getAutoscaledBackupAgentCount(policy, status, 3, now)).To(Equal(expected))
+		},
+		Entry("the lag is within the target",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 5},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{LagSeconds: 45},
+			3,
+		),
+		Entry("the lag exceeds the target",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 5},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{LagSeconds: 120},
+			4,
+		),
+		Entry("the lag exceeds the target at the maximum",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 3},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{LagSeconds: 120},
+			3,
+		),
+		Entry("the lag is below half of the target",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 5},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{LagSeconds: 10},
+			2,
+		),
+		Entry("the lag is below half of the target at the minimum",
+			&fdbv1beta2.BackupAgentAutoscaling{MinAgentCount: pointer.Int(3), MaxAgentCount: 5},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{LagSeconds: 10},
+			3,
+		),
+		Entry("the lag is below half of the target after a recent scaling",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 5},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{LagSeconds: 10, LastScaleTimestamp: &metav1.Time{Time: now.Add(-5 * time.Minute)}},
+			3,
+		),
+		Entry("the lag is below half of the target after the scale down delay",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 5},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{LagSeconds: 10, LastScaleTimestamp: &metav1.Time{Time: now.Add(-15 * time.Minute)}},
+			2,
+		),
+		Entry("the throughput requires more agents",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 10, TargetBytesPerSecondPerAgent: pointer.Int64(1000)},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{BytesPerSecond: 4500},
+			5,
+		),
+		Entry("the throughput requires less agents",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 10, TargetBytesPerSecondPerAgent: pointer.Int64(1000)},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{BytesPerSecond: 1500},
+			2,
+		),
+		Entry("the throughput is unknown",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 10, TargetBytesPerSecondPerAgent: pointer.Int64(1000)},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{LagSeconds: 10},
+			3,
+		),
+		Entry("the throughput requires less agents but the lag exceeds the target",
+			&fdbv1beta2.BackupAgentAutoscaling{MaxAgentCount: 10, TargetBytesPerSecondPerAgent: pointer.Int64(1000)},
+			&fdbv1beta2.BackupAgentAutoscalingStatus{BytesPerSecond: 1500, LagSeconds: 120},
+			4,
+		),
+	)
+
+	When("sampling the backup progress", func() {
+		var backup *fdbv1beta2.FoundationDBBackup
+
+		BeforeEach(func() {
+			backup = &fdbv1beta2.FoundationDBBackup{
+				Status: fdbv1beta2.FoundationDBBackupStatus{
+					Destinations: []fdbv1beta2.BackupDestinationStatus{
+						{
+							Name:          "primary",
+							BackupDetails: fdbv1beta2.FoundationDBBackupStatusBackupDetails{Running: true},
+							Progress:      &fdbv1beta2.BackupProgress{LogBytesWritten: 4000, RangeBytesWritten: 2000, LagSeconds: 30},
+						},
+						{
+							Name:          "secondary",
+							BackupDetails: fdbv1beta2.FoundationDBBackupStatusBackupDetails{Running: true},
+							Progress:      &fdbv1beta2.BackupProgress{LogBytesWritten: 4000, LagSeconds: 90},
+						},
+					},
+				},
+			}
+		})
+
+		It("should sum up the bytes and use the highest lag", func() {
+			status, running := sampleBackupProgress(backup, nil, now)
+			Expect(running).To(BeTrue())
+			Expect(status.BytesWritten).To(Equal(int64(10000)))
+			Expect(status.LagSeconds).To(Equal(int64(90)))
+			Expect(status.BytesPerSecond).To(BeZero())
+			Expect(status.LastSampleTimestamp).To(Equal(&metav1.Time{Time: now}))
+		})
+
+		It("should calculate the throughput based on the previous sample", func() {
+			status, _ := sampleBackupProgress(backup, &fdbv1beta2.BackupAgentAutoscalingStatus{
+				BytesWritten:        4000,
+				LastSampleTimestamp: &metav1.Time{Time: now.Add(-1 * time.Minute)},
+			}, now)
+			Expect(status.BytesPerSecond).To(Equal(int64(100)))
+		})
+
+		It("should not calculate the throughput if the backup was restarted", func() {
+			status, _ := sampleBackupProgress(backup, &fdbv1beta2.BackupAgentAutoscalingStatus{
+				BytesWritten:        40000,
+				LastSampleTimestamp: &metav1.Time{Time: now.Add(-1 * time.Minute)},
+			}, now)
+			Expect(status.BytesPerSecond).To(BeZero())
+		})
+
+		It("should ignore destinations that are not running", func() {
+			for idx := range backup.Status.Destinations {
+				backup.Status.Destinations[idx].BackupDetails.Running = false
+			}
+
+			status, running := sampleBackupProgress(backup, nil, now)
+			Expect(running).To(BeFalse())
+			Expect(status.BytesWritten).To(BeZero())
+		})
+	})
+})
@@ -22,6 +22,16 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -40,6 +50,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// blobCredentialsSecretIndex is the name of the index for the secrets that are referenced in the blob credentials of
+// a backup.
+const blobCredentialsSecretIndex = "spec.blobCredentials.secretNames"
+
 // FoundationDBBackupReconciler reconciles a FoundationDBCluster object
 type FoundationDBBackupReconciler struct {
 	client.Client
@@ -48,11 +62,15 @@ type FoundationDBBackupReconciler struct {
 	InSimulation           bool
 	DatabaseClientProvider fdbadminclient.DatabaseClientProvider
 	ServerSideApply        bool
+	// StatusRefreshInterval defines how often the status of a running backup is refreshed, the status is used for the
+	// backup metrics. If the interval is 0 the status will only be refreshed when the backup is reconciled.
+	StatusRefreshInterval time.Duration
 }
 
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbbackups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbbackups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile runs the reconciliation logic.
@@ -75,13 +93,26 @@ func (r *FoundationDBBackupReconciler) Reconcile(ctx context.Context, request ct
 
 	backupLog := globalControllerLogger.WithValues("namespace", backup.Namespace, "backup", backup.Name)
 
+	if !backup.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalizeBackup(ctx, backup, backupLog)
+	}
+
+	err = r.ensureBackupFinalizer(ctx, backup)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	subReconcilers := []backupSubReconciler{
 		updateBackupStatus{},
+		autoscaleBackupAgents{},
 		updateBackupAgents{},
 		startBackup{},
 		stopBackup{},
 		toggleBackupPaused{},
 		modifyBackup{},
+		forceBackupSnapshot{},
+		expireBackup{},
+		validateBackupRestore{},
 		updateBackupStatus{},
 	}
 
@@ -101,9 +132,166 @@ func (r *FoundationDBBackupReconciler) Reconcile(ctx context.Context, request ct
 
 	backupLog.Info("Reconciliation complete")
 
+	// The backup controller only reacts to spec changes, so the status of a running backup and the next scheduled
+	// snapshot or expiration must be polled.
+	requeueAfter := getBackupScheduleRequeueDelay(backup, time.Now())
+	if backup.ShouldRun() && r.StatusRefreshInterval > 0 && (requeueAfter == 0 || requeueAfter > r.StatusRefreshInterval) {
+		requeueAfter = r.StatusRefreshInterval
+	}
+
+	if backup.ShouldRun() && backup.Spec.AgentAutoscaling != nil && requeueAfter > backupAgentAutoscalingInterval {
+		requeueAfter = backupAgentAutoscalingInterval
+	}
+
+	// The progress of the restore validation is reported by the resources of the validation, which are not watched.
+	if backup.Status.RestoreValidation != nil && backup.Status.RestoreValidation.Phase != "" && (requeueAfter == 0 || requeueAfter > restoreValidationPollInterval) {
+		requeueAfter = restoreValidationPollInterval
+	}
+
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// getBackupDestinationDetails returns the backup details for the destination with the provided name. If the status
+// contains no details for this destination nil will be returned.
+func getBackupDestinationDetails(backup *fdbv1beta2.FoundationDBBackup, name string) *fdbv1beta2.FoundationDBBackupStatusBackupDetails {
+	destinationStatus := backup.Status.GetDestinationStatus(name)
+	if destinationStatus != nil {
+		return &destinationStatus.BackupDetails
+	}
+
+	// Backups that were reconciled before destinations were supported only have the backup details for the default tag.
+	if len(backup.Spec.Destinations) == 0 && name == fdbv1beta2.DefaultBackupTag {
+		return backup.Status.BackupDetails
+	}
+
+	return nil
+}
+
+// getBackupScheduleRequeueDelay returns the delay until the next scheduled snapshot, restore validation or expiration
+// of the backup. If nothing is scheduled 0 will be returned.
+func getBackupScheduleRequeueDelay(backup *fdbv1beta2.FoundationDBBackup, now time.Time) time.Duration {
+	var next time.Time
+
+	if backup.Spec.SnapshotSchedule != nil && backup.Status.LastScheduledSnapshot != nil {
+		schedule, err := parseSnapshotSchedule(backup.Spec.SnapshotSchedule)
+		if err == nil {
+			next = schedule.Next(backup.Status.LastScheduledSnapshot.Time)
+		}
+	}
+
+	if backup.Spec.RestoreValidation != nil && backup.Status.RestoreValidation != nil && backup.Status.RestoreValidation.LastScheduledValidation != nil {
+		schedule, err := parseRestoreValidationSchedule(backup.Spec.RestoreValidation)
+		if err == nil {
+			nextValidation := schedule.Next(backup.Status.RestoreValidation.LastScheduledValidation.Time)
+			if next.IsZero() || nextValidation.Before(next) {
+				next = nextValidation
+			}
+		}
+	}
+
+	if backup.Spec.RetentionPolicy != nil && backup.Status.LastExpiration != nil {
+		nextExpiration := backup.Status.LastExpiration.Add(backup.Spec.RetentionPolicy.GetExpirationInterval())
+		if next.IsZero() || nextExpiration.Before(next) {
+			next = nextExpiration
+		}
+	}
+
+	if next.IsZero() {
+		return 0
+	}
+
+	delay := next.Sub(now)
+	if delay < time.Second {
+		return time.Second
+	}
+
+	return delay
+}
+
+// getBlobCredentialsHash returns the hash of the blob credentials secrets that are referenced by the backup. If the
+// backup references no blob credentials an empty string will be returned.
+func (r *FoundationDBBackupReconciler) getBlobCredentialsHash(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup) (string, error) {
+	blobCredentials := backup.Spec.BlobCredentials
+	if blobCredentials == nil {
+		return "", nil
+	}
+
+	credentials, err := r.getSecretValue(ctx, backup.Namespace, blobCredentials.SecretName, blobCredentials.GetCredentialsKey())
+	if err != nil {
+		return "", err
+	}
+
+	secretData := map[string][]byte{
+		blobCredentials.SecretName: credentials,
+	}
+
+	if blobCredentials.CASecretName != nil {
+		caBundle, err := r.getSecretValue(ctx, backup.Namespace, *blobCredentials.CASecretName, blobCredentials.GetCAKey())
+		if err != nil {
+			return "", err
+		}
+
+		secretData[*blobCredentials.CASecretName+"/ca"] = caBundle
+	}
+
+	return internal.GetJSONHash(secretData)
+}
+
+// getSecretValue returns the value of the key in the provided secret.
+func (r *FoundationDBBackupReconciler) getSecretValue(ctx context.Context, namespace string, name string, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no key %s", namespace, name, key)
+	}
+
+	return value, nil
+}
+
+// getBlobCredentialsSecretNames returns the names of the secrets that are referenced in the blob credentials of the
+// backup.
+func getBlobCredentialsSecretNames(backup *fdbv1beta2.FoundationDBBackup) []string {
+	blobCredentials := backup.Spec.BlobCredentials
+	if blobCredentials == nil {
+		return nil
+	}
+
+	if blobCredentials.CASecretName == nil || *blobCredentials.CASecretName == blobCredentials.SecretName {
+		return []string{blobCredentials.SecretName}
+	}
+
+	return []string{blobCredentials.SecretName, *blobCredentials.CASecretName}
+}
+
+// findBackupsForSecret returns the reconcile requests for all backups in the namespace of the secret that reference the
+// secret in their blob credentials.
+func (r *FoundationDBBackupReconciler) findBackupsForSecret(selector labels.Selector) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		backups := &fdbv1beta2.FoundationDBBackupList{}
+		err := r.List(context.Background(), backups, client.InNamespace(object.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}, client.MatchingFields{blobCredentialsSecretIndex: object.GetName()})
+		if err != nil {
+			globalControllerLogger.Error(err, "could not list backups for secret", "namespace", object.GetNamespace(), "secret", object.GetName())
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(backups.Items))
+		for _, backup := range backups.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name}})
+		}
+
+		return requests
+	}
+}
+
 // getDatabaseClientProvider gets the client provider for a reconciler.
 func (r *FoundationDBBackupReconciler) getDatabaseClientProvider() fdbadminclient.DatabaseClientProvider {
 	if r.DatabaseClientProvider != nil {
@@ -114,8 +302,7 @@ func (r *FoundationDBBackupReconciler) getDatabaseClientProvider() fdbadminclien
 
 // adminClientForBackup provides an admin client for a backup reconciler.
 func (r *FoundationDBBackupReconciler) adminClientForBackup(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup) (fdbadminclient.AdminClient, error) {
-	cluster := &fdbv1beta2.FoundationDBCluster{}
-	err := r.Get(ctx, types.NamespacedName{Namespace: backup.ObjectMeta.Namespace, Name: backup.Spec.ClusterName}, cluster)
+	cluster, err := internal.GetCluster(ctx, r, types.NamespacedName{Namespace: backup.ObjectMeta.Namespace, Name: backup.Spec.ClusterName})
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +314,19 @@ func (r *FoundationDBBackupReconciler) adminClientForBackup(ctx context.Context,
 
 	adminClient.SetKnobs(backup.Spec.CustomParameters.GetKnobsForCLI())
 
+	blobCredentials := backup.Spec.BlobCredentials
+	if blobCredentials != nil {
+		credentials, err := r.getSecretValue(ctx, backup.Namespace, blobCredentials.SecretName, blobCredentials.GetCredentialsKey())
+		if err != nil {
+			return nil, err
+		}
+
+		err = adminClient.SetBlobCredentials(credentials)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return adminClient, nil
 }
 
@@ -138,27 +338,51 @@ func (r *FoundationDBBackupReconciler) SetupWithManager(mgr ctrl.Manager, maxCon
 	if err != nil {
 		return err
 	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &fdbv1beta2.FoundationDBBackup{}, blobCredentialsSecretIndex, func(o client.Object) []string {
+		return getBlobCredentialsSecretNames(o.(*fdbv1beta2.FoundationDBBackup))
+	})
+	if err != nil {
+		return err
+	}
+
 	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
 	if err != nil {
 		return err
 	}
 
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector)
+	if err != nil {
+		return err
+	}
+
+	// Only react on generation changes or annotation changes and only watch
+	// resources with the provided label selector.
+	changePredicate := builder.WithPredicates(
+		predicate.And(
+			labelSelectorPredicate,
+			predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+			),
+		))
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles},
 		).
-		For(&fdbv1beta2.FoundationDBBackup{}).
-		Owns(&appsv1.Deployment{}).
-		// Only react on generation changes or annotation changes and only watch
-		// resources with the provided label selector.
-		WithEventFilter(
-			predicate.And(
-				labelSelectorPredicate,
-				predicate.Or(
-					predicate.GenerationChangedPredicate{},
-					predicate.AnnotationChangedPredicate{},
-				),
-			)).
+		For(&fdbv1beta2.FoundationDBBackup{}, changePredicate).
+		Owns(&appsv1.Deployment{}, changePredicate).
+		// Secrets have no generation, so every change of a referenced blob
+		// credentials secret will trigger a reconciliation of the backup.
+		// Only the metadata of the secrets is watched and only secrets that
+		// are referenced by a backup will be mapped to a reconcile request.
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.findBackupsForSecret(labelSelector)),
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Complete(r)
 }
 
@@ -30,11 +30,17 @@ import (
 	. "github.com/onsi/gomega"
 
 	"context"
+	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func reloadBackup(backup *fdbv1beta2.FoundationDBBackup) (int64, error) {
@@ -141,11 +147,21 @@ var _ = Describe("backup_controller", func() {
 					Generations: fdbv1beta2.BackupGenerationStatus{
 						Reconciled: 1,
 					},
+					Destinations: []fdbv1beta2.BackupDestinationStatus{
+						{
+							Name: fdbv1beta2.DefaultBackupTag,
+							BackupDetails: fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+								URL:                   "blobstore://test@test-service/test-backup?bucket=fdb-backups",
+								Running:               true,
+								SnapshotPeriodSeconds: 864000,
+							},
+						},
+					},
 				}))
 			})
 
 			It("should start a backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(fdbv1beta2.DefaultBackupTag)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.DestinationURL).To(Equal("blobstore://test@test-service/test-backup?bucket=fdb-backups"))
 				Expect(status.Status.Running).To(BeTrue())
@@ -193,7 +209,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should stop the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(fdbv1beta2.DefaultBackupTag)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.Status.Running).To(BeFalse())
 			})
@@ -207,7 +223,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should pause the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(fdbv1beta2.DefaultBackupTag)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.BackupAgentsPaused).To(BeTrue())
 			})
@@ -224,7 +240,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should resume the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(fdbv1beta2.DefaultBackupTag)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.BackupAgentsPaused).To(BeFalse())
 			})
@@ -239,7 +255,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should modify the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(fdbv1beta2.DefaultBackupTag)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.SnapshotIntervalSeconds).To(Equal(100000))
 			})
@@ -297,6 +313,589 @@ var _ = Describe("backup_controller", func() {
 			})
 		})
 
+		When("defining a snapshot schedule", func() {
+			BeforeEach(func() {
+				backup.Spec.SnapshotSchedule = &fdbv1beta2.BackupSnapshotSchedule{
+					Schedule: "0 2 * * *",
+				}
+				Expect(k8sClient.Update(context.TODO(), backup)).To(Succeed())
+			})
+
+			It("should start the schedule without forcing a snapshot", func() {
+				Expect(backup.Status.LastScheduledSnapshot).NotTo(BeNil())
+				Expect(adminClient.ForcedSnapshots).To(BeEmpty())
+			})
+
+			When("a scheduled snapshot is due", func() {
+				var lastScheduledSnapshot time.Time
+
+				BeforeEach(func() {
+					lastScheduledSnapshot = time.Now().Add(-25 * time.Hour)
+					backup.Status.LastScheduledSnapshot = &metav1.Time{Time: lastScheduledSnapshot}
+					Expect(k8sClient.Status().Update(context.TODO(), backup)).To(Succeed())
+				})
+
+				It("should force a snapshot", func() {
+					Expect(adminClient.ForcedSnapshots).To(HaveKeyWithValue(fdbv1beta2.DefaultBackupTag, 3600))
+					Expect(backup.Status.LastScheduledSnapshot).NotTo(BeNil())
+					Expect(backup.Status.LastScheduledSnapshot.After(lastScheduledSnapshot)).To(BeTrue())
+				})
+			})
+		})
+
+		When("defining a retention policy", func() {
+			BeforeEach(func() {
+				adminClient.BackupDescription = &fdbv1beta2.FoundationDBBackupDescription{
+					Restorable:         true,
+					MinRestorablePoint: &fdbv1beta2.FoundationDBBackupVersion{Version: 200, EpochSeconds: 1684310400},
+					MaxRestorablePoint: &fdbv1beta2.FoundationDBBackupVersion{Version: 1000, EpochSeconds: 1684569600},
+					Snapshots: []fdbv1beta2.FoundationDBBackupSnapshot{
+						{
+							Restorable:   true,
+							BeginVersion: fdbv1beta2.FoundationDBBackupVersion{Version: 100},
+							EndVersion:   fdbv1beta2.FoundationDBBackupVersion{Version: 200, EpochSeconds: 1684310400},
+						},
+						{
+							Restorable:   true,
+							BeginVersion: fdbv1beta2.FoundationDBBackupVersion{Version: 300},
+							EndVersion:   fdbv1beta2.FoundationDBBackupVersion{Version: 400, EpochSeconds: 1684396800},
+						},
+						{
+							Restorable:   true,
+							BeginVersion: fdbv1beta2.FoundationDBBackupVersion{Version: 500},
+							EndVersion:   fdbv1beta2.FoundationDBBackupVersion{Version: 600, EpochSeconds: 1684483200},
+						},
+					},
+				}
+
+				backup.Spec.RetentionPolicy = &fdbv1beta2.BackupRetentionPolicy{
+					MaxSnapshots: pointer.Int(2),
+				}
+				Expect(k8sClient.Update(context.TODO(), backup)).To(Succeed())
+			})
+
+			It("should expire the oldest snapshot", func() {
+				Expect(adminClient.ExpiredBackupVersions).To(HaveKeyWithValue(backup.BackupURL(), int64(300)))
+				Expect(backup.Status.LastExpiration).NotTo(BeNil())
+			})
+
+			It("should report the restorable range", func() {
+				Expect(backup.Status.RestorableRange).To(Equal(&fdbv1beta2.BackupRestorableRange{
+					MinVersion:              400,
+					MinTimestamp:            &metav1.Time{Time: time.Unix(1684396800, 0)},
+					MaxVersion:              1000,
+					MaxTimestamp:            &metav1.Time{Time: time.Unix(1684569600, 0)},
+					Snapshots:               2,
+					LatestSnapshotTimestamp: &metav1.Time{Time: time.Unix(1684483200, 0)},
+				}))
+			})
+
+			When("the backup data was expired recently", func() {
+				BeforeEach(func() {
+					backup.Status.LastExpiration = &metav1.Time{Time: time.Now().Add(-1 * time.Minute)}
+					Expect(k8sClient.Status().Update(context.TODO(), backup)).To(Succeed())
+				})
+
+				It("should not expire the backup data", func() {
+					Expect(adminClient.ExpiredBackupVersions).To(BeEmpty())
+					Expect(backup.Status.RestorableRange.Snapshots).To(Equal(3))
+				})
+			})
+		})
+
+		When("adding a file system destination", func() {
+			BeforeEach(func() {
+				backup.Spec.Destinations = []fdbv1beta2.BackupDestination{
+					{
+						Name:                   fdbv1beta2.DefaultBackupTag,
+						BlobStoreConfiguration: backup.Spec.BlobStoreConfiguration,
+					},
+					{
+						Name: "local",
+						FileSystemConfiguration: &fdbv1beta2.BackupFileSystemConfiguration{
+							PersistentVolumeClaimName: "backup-data",
+						},
+					},
+				}
+				err = k8sClient.Update(context.TODO(), backup)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should start a backup for every destination", func() {
+				Expect(adminClient.Backups).To(HaveLen(2))
+				Expect(adminClient.Backups).To(HaveKey(fdbv1beta2.DefaultBackupTag))
+				Expect(adminClient.Backups).To(HaveKey("local"))
+				Expect(adminClient.Backups["local"].URL).To(Equal(fmt.Sprintf("file:///var/fdb/backups/local/%s", backup.Name)))
+			})
+
+			It("should report the status of every destination", func() {
+				Expect(backup.Status.Destinations).To(HaveLen(2))
+				Expect(backup.Status.GetDestinationStatus("local").BackupDetails.Running).To(BeTrue())
+				Expect(backup.Status.GetDestinationStatus(fdbv1beta2.DefaultBackupTag).BackupDetails.Running).To(BeTrue())
+			})
+
+			It("should only summarize the destinations at the top level of the status", func() {
+				Expect(backup.Status.BackupDetails).To(Equal(&fdbv1beta2.FoundationDBBackupStatusBackupDetails{Running: true}))
+				Expect(backup.Status.RestorableRange).To(BeNil())
+			})
+
+			It("should mount the volume claim in the backup deployment", func() {
+				deployment := &appsv1.Deployment{}
+				deploymentName := fmt.Sprintf("%s-backup-agents", cluster.Name)
+
+				err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: deploymentName}, deployment)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "backup-local")))
+			})
+
+			When("removing the file system destination", func() {
+				JustBeforeEach(func() {
+					backup.Spec.Destinations = backup.Spec.Destinations[:1]
+					err = k8sClient.Update(context.TODO(), backup)
+					Expect(err).NotTo(HaveOccurred())
+
+					result, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+
+					_, err = reloadBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should stop the backup for the removed destination", func() {
+					Expect(adminClient.Backups["local"].Running).To(BeFalse())
+					Expect(adminClient.Backups[fdbv1beta2.DefaultBackupTag].Running).To(BeTrue())
+					Expect(backup.Status.GetDestinationStatus("local")).To(BeNil())
+				})
+			})
+		})
+
+		When("blob credentials are defined", func() {
+			var secret *corev1.Secret
+			var previousHash string
+
+			BeforeEach(func() {
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: backup.Namespace,
+						Name:      "blob-credentials",
+					},
+					Data: map[string][]byte{
+						"credentials.json": []byte(`{"accounts":{"test@test-service":{"secret":"first"}}}`),
+					},
+				}
+				Expect(k8sClient.Create(context.TODO(), secret)).NotTo(HaveOccurred())
+
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: fmt.Sprintf("%s-backup-agents", backup.Name)}, deployment)).NotTo(HaveOccurred())
+				previousHash = deployment.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]
+
+				backup.Spec.BlobCredentials = &fdbv1beta2.BackupBlobCredentials{
+					SecretName: secret.Name,
+				}
+				err = k8sClient.Update(context.TODO(), backup)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should mount the credentials into the backup agents", func() {
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: fmt.Sprintf("%s-backup-agents", backup.Name)}, deployment)).NotTo(HaveOccurred())
+				Expect(deployment.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]).NotTo(Equal(previousHash))
+				Expect(deployment.Spec.Template.ObjectMeta.Annotations).To(HaveKey(fdbv1beta2.LastBlobCredentialsKey))
+				Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--blob_credentials"))
+				Expect(backup.Status.DeploymentConfigured).To(BeTrue())
+			})
+
+			It("should pass the credentials to the admin client", func() {
+				Expect(adminClient.BlobCredentials).To(Equal(secret.Data["credentials.json"]))
+			})
+
+			When("the secret is changed", func() {
+				var previousCredentialsHash string
+
+				JustBeforeEach(func() {
+					deployment := &appsv1.Deployment{}
+					Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: fmt.Sprintf("%s-backup-agents", backup.Name)}, deployment)).NotTo(HaveOccurred())
+					previousCredentialsHash = deployment.Spec.Template.ObjectMeta.Annotations[fdbv1beta2.LastBlobCredentialsKey]
+
+					secret.Data["credentials.json"] = []byte(`{"accounts":{"test@test-service":{"secret":"second"}}}`)
+					Expect(k8sClient.Update(context.TODO(), secret)).NotTo(HaveOccurred())
+
+					result, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+				})
+
+				It("should update the credentials hash to restart the backup agents", func() {
+					deployment := &appsv1.Deployment{}
+					Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: fmt.Sprintf("%s-backup-agents", backup.Name)}, deployment)).NotTo(HaveOccurred())
+					Expect(deployment.Spec.Template.ObjectMeta.Annotations[fdbv1beta2.LastBlobCredentialsKey]).NotTo(Equal(previousCredentialsHash))
+				})
+
+				It("should pass the new credentials to the admin client", func() {
+					Expect(adminClient.BlobCredentials).To(Equal([]byte(`{"accounts":{"test@test-service":{"secret":"second"}}}`)))
+				})
+			})
+		})
+
+		When("enabling the agent autoscaling", func() {
+			BeforeEach(func() {
+				backup.Spec.AgentAutoscaling = &fdbv1beta2.BackupAgentAutoscaling{
+					MinAgentCount: pointer.Int(2),
+					MaxAgentCount: 5,
+				}
+				Expect(k8sClient.Update(context.TODO(), backup)).To(Succeed())
+			})
+
+			When("the backup falls behind", func() {
+				BeforeEach(func() {
+					adminClient.MockBackupProgress(fdbv1beta2.DefaultBackupTag, 1000, 120.5)
+				})
+
+				It("should add a backup agent", func() {
+					deployment := &appsv1.Deployment{}
+					deploymentName := fmt.Sprintf("%s-backup-agents", cluster.Name)
+					Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: backup.Namespace, Name: deploymentName}, deployment)).To(Succeed())
+					Expect(deployment.Spec.Replicas).To(HaveValue(Equal(int32(4))))
+
+					Expect(backup.Status.AgentCount).To(Equal(4))
+					Expect(backup.Status.Destinations[0].Progress).To(Equal(&fdbv1beta2.BackupProgress{
+						LogBytesWritten: 1000,
+						LagSeconds:      121,
+					}))
+					Expect(backup.Status.AgentAutoscaling).NotTo(BeNil())
+					Expect(backup.Status.AgentAutoscaling.DesiredAgentCount).To(Equal(4))
+					Expect(backup.Status.AgentAutoscaling.LagSeconds).To(Equal(int64(121)))
+					Expect(backup.Status.AgentAutoscaling.LastScaleTimestamp).NotTo(BeNil())
+				})
+			})
+
+			When("the backup is caught up", func() {
+				BeforeEach(func() {
+					adminClient.MockBackupProgress(fdbv1beta2.DefaultBackupTag, 1000, 5)
+				})
+
+				It("should remove a backup agent", func() {
+					Expect(backup.Status.AgentCount).To(Equal(2))
+					Expect(backup.Status.AgentAutoscaling.DesiredAgentCount).To(Equal(2))
+				})
+			})
+
+			When("disabling the agent autoscaling", func() {
+				BeforeEach(func() {
+					adminClient.MockBackupProgress(fdbv1beta2.DefaultBackupTag, 1000, 120)
+					result, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+					_, err = reloadBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(backup.Status.AgentCount).To(Equal(4))
+
+					backup.Spec.AgentAutoscaling = nil
+					Expect(k8sClient.Update(context.TODO(), backup)).To(Succeed())
+					generationGap = 2
+				})
+
+				It("should use the static agent count", func() {
+					Expect(backup.Status.AgentCount).To(Equal(3))
+					Expect(backup.Status.AgentAutoscaling).To(BeNil())
+				})
+			})
+		})
+
+		When("defining a restore validation", func() {
+			var validationKey types.NamespacedName
+
+			BeforeEach(func() {
+				adminClient.BackupDescription = &fdbv1beta2.FoundationDBBackupDescription{
+					Restorable:         true,
+					MinRestorablePoint: &fdbv1beta2.FoundationDBBackupVersion{Version: 200, EpochSeconds: 1684310400},
+					MaxRestorablePoint: &fdbv1beta2.FoundationDBBackupVersion{Version: 1000, EpochSeconds: 1684569600},
+				}
+
+				backup.Spec.RestoreValidation = &fdbv1beta2.BackupRestoreValidation{
+					Schedule:        "0 4 * * 0",
+					ClusterTemplate: *cluster.Spec.DeepCopy(),
+					VerificationJob: &batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "verify", Image: "verify:latest"}},
+							},
+						},
+					},
+				}
+				Expect(k8sClient.Update(context.TODO(), backup)).To(Succeed())
+				validationKey = types.NamespacedName{Namespace: backup.Namespace, Name: fmt.Sprintf("%s-validation", backup.Name)}
+			})
+
+			It("should start the schedule without validating the backup", func() {
+				Expect(backup.Status.RestoreValidation).NotTo(BeNil())
+				Expect(backup.Status.RestoreValidation.LastScheduledValidation).NotTo(BeNil())
+				Expect(backup.Status.RestoreValidation.Phase).To(BeEmpty())
+
+				validationCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), validationKey, validationCluster))).To(BeTrue())
+			})
+
+			When("a validation is due", func() {
+				BeforeEach(func() {
+					result, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+					_, err = reloadBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+
+					backup.Status.RestoreValidation.LastScheduledValidation = &metav1.Time{Time: time.Now().Add(-8 * 24 * time.Hour)}
+					Expect(k8sClient.Status().Update(context.TODO(), backup)).To(Succeed())
+				})
+
+				It("should create the temporary cluster", func() {
+					Expect(backup.Status.RestoreValidation.Phase).To(Equal(fdbv1beta2.BackupRestoreValidationPhaseCreatingCluster))
+					Expect(backup.Status.RestoreValidation.ClusterName).To(Equal(validationKey.Name))
+					Expect(backup.Status.RestoreValidation.StartTimestamp).NotTo(BeNil())
+
+					validationCluster := &fdbv1beta2.FoundationDBCluster{}
+					Expect(k8sClient.Get(context.TODO(), validationKey, validationCluster)).To(Succeed())
+					Expect(validationCluster.Labels).To(HaveKeyWithValue(fdbv1beta2.RestoreValidationLabel, string(backup.UID)))
+
+					validationBackup := &fdbv1beta2.FoundationDBBackup{}
+					Expect(k8sClient.Get(context.TODO(), validationKey, validationBackup)).To(Succeed())
+					Expect(validationBackup.Spec.ClusterName).To(Equal(validationKey.Name))
+					Expect(validationBackup.Spec.BackupState).To(Equal(fdbv1beta2.BackupStateStopped))
+				})
+
+				When("the temporary cluster is reconciled", func() {
+					JustBeforeEach(func() {
+						validationCluster := &fdbv1beta2.FoundationDBCluster{}
+						Expect(k8sClient.Get(context.TODO(), validationKey, validationCluster)).To(Succeed())
+						result, err := reconcileCluster(validationCluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Requeue).To(BeFalse())
+
+						result, err = reconcileBackup(backup)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Requeue).To(BeFalse())
+						_, err = reloadBackup(backup)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("should start the restore", func() {
+						Expect(backup.Status.RestoreValidation.Phase).To(Equal(fdbv1beta2.BackupRestoreValidationPhaseRestoring))
+
+						restore := &fdbv1beta2.FoundationDBRestore{}
+						Expect(k8sClient.Get(context.TODO(), validationKey, restore)).To(Succeed())
+						Expect(restore.Spec.DestinationClusterName).To(Equal(validationKey.Name))
+						Expect(restore.BackupURL()).To(Equal(backup.BackupURL()))
+					})
+
+					When("the restore is completed", func() {
+						JustBeforeEach(func() {
+							restore := &fdbv1beta2.FoundationDBRestore{}
+							Expect(k8sClient.Get(context.TODO(), validationKey, restore)).To(Succeed())
+							restore.Status.Phase = fdbv1beta2.RestorePhaseCompleted
+							Expect(k8sClient.Status().Update(context.TODO(), restore)).To(Succeed())
+
+							result, err := reconcileBackup(backup)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result.Requeue).To(BeFalse())
+							_, err = reloadBackup(backup)
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("should start the verification job", func() {
+							Expect(backup.Status.RestoreValidation.Phase).To(Equal(fdbv1beta2.BackupRestoreValidationPhaseVerifying))
+
+							job := &batchv1.Job{}
+							Expect(k8sClient.Get(context.TODO(), validationKey, job)).To(Succeed())
+							Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "FDB_CLUSTER_FILE", Value: "/var/dynamic-conf/fdb.cluster"}))
+						})
+
+						When("the verification job succeeds", func() {
+							JustBeforeEach(func() {
+								job := &batchv1.Job{}
+								Expect(k8sClient.Get(context.TODO(), validationKey, job)).To(Succeed())
+								job.Status.Succeeded = 1
+								Expect(k8sClient.Status().Update(context.TODO(), job)).To(Succeed())
+
+								result, err := reconcileBackup(backup)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(result.Requeue).To(BeFalse())
+								_, err = reloadBackup(backup)
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("should record the result and delete the temporary resources", func() {
+								Expect(backup.Status.RestoreValidation.Phase).To(BeEmpty())
+								Expect(backup.Status.RestoreValidation.LastResult).NotTo(BeNil())
+								Expect(backup.Status.RestoreValidation.LastResult.Succeeded).To(BeTrue())
+								Expect(backup.Status.RestoreValidation.LastResult.Message).To(Equal("Backup was restored and verified"))
+
+								for _, object := range []client.Object{&batchv1.Job{}, &fdbv1beta2.FoundationDBRestore{}, &fdbv1beta2.FoundationDBBackup{}, &fdbv1beta2.FoundationDBCluster{}} {
+									Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), validationKey, object))).To(BeTrue())
+								}
+							})
+						})
+
+						When("the verification job fails", func() {
+							JustBeforeEach(func() {
+								job := &batchv1.Job{}
+								Expect(k8sClient.Get(context.TODO(), validationKey, job)).To(Succeed())
+								job.Status.Conditions = []batchv1.JobCondition{
+									{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"},
+								}
+								Expect(k8sClient.Status().Update(context.TODO(), job)).To(Succeed())
+
+								result, err := reconcileBackup(backup)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(result.Requeue).To(BeFalse())
+								_, err = reloadBackup(backup)
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("should record the failure", func() {
+								Expect(backup.Status.RestoreValidation.Phase).To(BeEmpty())
+								Expect(backup.Status.RestoreValidation.LastResult.Succeeded).To(BeFalse())
+								Expect(backup.Status.RestoreValidation.LastResult.Message).To(Equal("Verification job failed: Job has reached the specified backoff limit"))
+							})
+						})
+					})
+				})
+
+				When("the backup is not restorable", func() {
+					BeforeEach(func() {
+						adminClient.BackupDescription = &fdbv1beta2.FoundationDBBackupDescription{}
+					})
+
+					It("should record the failure", func() {
+						Expect(backup.Status.RestoreValidation.Phase).To(BeEmpty())
+						Expect(backup.Status.RestoreValidation.LastResult.Succeeded).To(BeFalse())
+						Expect(backup.Status.RestoreValidation.LastResult.Message).To(Equal("Backup for destination default is not restorable"))
+					})
+				})
+
+				When("the restore validation is removed", func() {
+					JustBeforeEach(func() {
+						backup.Spec.RestoreValidation = nil
+						Expect(k8sClient.Update(context.TODO(), backup)).To(Succeed())
+
+						result, err := reconcileBackup(backup)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Requeue).To(BeFalse())
+						_, err = reloadBackup(backup)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("should delete the temporary resources", func() {
+						Expect(backup.Status.RestoreValidation).To(BeNil())
+
+						validationCluster := &fdbv1beta2.FoundationDBCluster{}
+						Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), validationKey, validationCluster))).To(BeTrue())
+					})
+				})
+			})
+		})
+
+		When("deleting the backup", func() {
+			BeforeEach(func() {
+				generationGap = 0
+			})
+
+			It("should add the finalizer", func() {
+				Expect(backup.Finalizers).To(ContainElement(fdbv1beta2.BackupFinalizer))
+			})
+
+			When("the deletion policy is not set", func() {
+				JustBeforeEach(func() {
+					Expect(k8sClient.Delete(context.TODO(), backup)).To(Succeed())
+					_, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should stop the backup and retain the data", func() {
+					Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(backup), backup))).To(BeTrue())
+					Expect(adminClient.Backups[fdbv1beta2.DefaultBackupTag].Running).To(BeFalse())
+					Expect(adminClient.DeletedBackups).To(BeEmpty())
+				})
+			})
+
+			When("the deletion policy is Delete", func() {
+				BeforeEach(func() {
+					deletionPolicy := fdbv1beta2.BackupDeletionPolicyDelete
+					backup.Spec.DeletionPolicy = &deletionPolicy
+					Expect(k8sClient.Update(context.TODO(), backup)).To(Succeed())
+					generationGap = 1
+				})
+
+				JustBeforeEach(func() {
+					Expect(k8sClient.Delete(context.TODO(), backup)).To(Succeed())
+					_, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should stop the backup and delete the data", func() {
+					Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(backup), backup))).To(BeTrue())
+					Expect(adminClient.Backups[fdbv1beta2.DefaultBackupTag].Running).To(BeFalse())
+					Expect(adminClient.DeletedBackups).To(HaveKey(backup.BackupURL()))
+				})
+			})
+
+			When("the cluster is unavailable", func() {
+				JustBeforeEach(func() {
+					adminClient.MockError(fmt.Errorf("cluster unavailable"))
+					Expect(k8sClient.Delete(context.TODO(), backup)).To(Succeed())
+				})
+
+				It("should keep the finalizer", func() {
+					_, err := reconcileBackup(backup)
+					Expect(err).To(HaveOccurred())
+					Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(backup), backup)).To(Succeed())
+					Expect(backup.Finalizers).To(ContainElement(fdbv1beta2.BackupFinalizer))
+				})
+
+				When("the skip annotation is set", func() {
+					BeforeEach(func() {
+						backup.Annotations = map[string]string{
+							fdbv1beta2.SkipBackupCleanupAnnotation: "true",
+						}
+						Expect(k8sClient.Update(context.TODO(), backup)).To(Succeed())
+					})
+
+					It("should remove the finalizer without stopping the backup", func() {
+						_, err := reconcileBackup(backup)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(backup), backup))).To(BeTrue())
+						Expect(adminClient.Backups[fdbv1beta2.DefaultBackupTag].Running).To(BeTrue())
+					})
+				})
+
+				When("the cluster is being deleted", func() {
+					BeforeEach(func() {
+						Expect(k8sClient.MockStuckTermination(cluster, true)).To(Succeed())
+					})
+
+					It("should remove the finalizer without stopping the backup", func() {
+						_, err := reconcileBackup(backup)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(backup), backup))).To(BeTrue())
+						Expect(adminClient.Backups[fdbv1beta2.DefaultBackupTag].Running).To(BeTrue())
+					})
+				})
+			})
+
+			When("the cluster was already deleted", func() {
+				JustBeforeEach(func() {
+					Expect(k8sClient.Delete(context.TODO(), cluster)).To(Succeed())
+					Expect(k8sClient.Delete(context.TODO(), backup)).To(Succeed())
+					_, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should remove the finalizer", func() {
+					Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(backup), backup))).To(BeTrue())
+				})
+			})
+		})
+
 		When("providing custom parameters", func() {
 			BeforeEach(func() {
 				backup.Spec.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{
@@ -311,5 +910,55 @@ var _ = Describe("backup_controller", func() {
 				Expect(adminClient.Knobs).To(HaveKey("--knob_http_verbose_level=3"))
 			})
 		})
+
+		When("a status refresh interval is defined", func() {
+			BeforeEach(func() {
+				generationGap = 0
+				backupReconciler.StatusRefreshInterval = 2 * time.Minute
+				DeferCleanup(func() {
+					backupReconciler.StatusRefreshInterval = 0
+				})
+			})
+
+			It("should requeue the running backup after the interval", func() {
+				result, err := reconcileBackup(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(2 * time.Minute))
+			})
+
+			When("the backup is stopped", func() {
+				BeforeEach(func() {
+					generationGap = 1
+					backup.Spec.BackupState = fdbv1beta2.BackupStateStopped
+					Expect(k8sClient.Update(context.TODO(), backup)).To(Succeed())
+				})
+
+				It("should not requeue the backup", func() {
+					result, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+				})
+			})
+		})
 	})
+
+	DescribeTable("getting the names of the blob credentials secrets",
+		func(blobCredentials *fdbv1beta2.BackupBlobCredentials, expected []string) {
+			backup.Spec.BlobCredentials = blobCredentials
+			Expect(getBlobCredentialsSecretNames(backup)).To(Equal(expected))
+		},
+		Entry("no blob credentials", nil, nil),
+		Entry("only the credentials secret",
+			&fdbv1beta2.BackupBlobCredentials{SecretName: "credentials"},
+			[]string{"credentials"},
+		),
+		Entry("a separate CA secret",
+			&fdbv1beta2.BackupBlobCredentials{SecretName: "credentials", CASecretName: pointer.String("ca")},
+			[]string{"credentials", "ca"},
+		),
+		Entry("the same secret for the credentials and the CA",
+			&fdbv1beta2.BackupBlobCredentials{SecretName: "credentials", CASecretName: pointer.String("credentials")},
+			[]string{"credentials"},
+		),
+	)
 })
@@ -23,11 +23,16 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/locality"
 	"github.com/go-logr/logr"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
@@ -61,7 +66,12 @@ func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBCluste
 		coordinatorStatus[coordinator.Address.String()] = false
 	}
 
-	hasValidCoordinators, allAddressesValid, err := locality.CheckCoordinatorValidity(logger, cluster, status, coordinatorStatus)
+	pods, err := getCoordinatorConstraintPods(ctx, r, cluster)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	hasValidCoordinators, allAddressesValid, err := locality.CheckCoordinatorValidity(logger, cluster, status, coordinatorStatus, getNodeNamesPerProcessGroup(cluster, pods))
 	if err != nil {
 		return &requeue{curError: err, delayedRequeue: true}
 	}
@@ -70,12 +80,19 @@ func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBCluste
 		return nil
 	}
 
+	reason := getCoordinatorChangeReason(coordinatorStatus)
+
 	if !allAddressesValid {
 		logger.Info("Deferring coordinator change")
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "DeferringCoordinatorChange", "Deferring coordinator change until all processes have consistent address TLS settings")
 		return nil
 	}
 
+	req := r.checkGlobalCoordination(logger, cluster, status, fdbv1beta2.GlobalCoordinationActionChangeCoordinators, cluster.Status.ConnectionString)
+	if req != nil {
+		return req
+	}
+
 	hasLock, err := r.takeLock(logger, cluster, "changing coordinators")
 	if !hasLock {
 		return &requeue{curError: err, delayedRequeue: true}
@@ -84,10 +101,12 @@ func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBCluste
 	logger.Info("Changing coordinators")
 	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ChangingCoordinators", "Choosing new coordinators")
 
-	coordinators, err := selectCoordinators(logger, cluster, status)
+	coordinators, err := selectCoordinators(logger, cluster, status, pods)
 	if err != nil {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "CoordinatorSelectionFailed", fmt.Sprintf("Could not select coordinators: %s", err.Error()))
 		return &requeue{curError: err, delayedRequeue: true}
 	}
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "CoordinatorsSelected", getCoordinatorSelectionMessage(cluster, coordinators))
 
 	coordinatorAddresses := make([]fdbv1beta2.ProcessAddress, len(coordinators))
 	for index, process := range coordinators {
@@ -99,23 +118,92 @@ func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBCluste
 	if err != nil {
 		return &requeue{curError: err, delayedRequeue: true}
 	}
+	cluster.Status.AddCoordinatorChange(fdbv1beta2.CoordinatorChange{
+		Timestamp:                time.Now().Unix(),
+		PreviousConnectionString: cluster.Status.ConnectionString,
+		ConnectionString:         connectionString,
+		Reason:                   reason,
+	})
 	cluster.Status.ConnectionString = connectionString
 	err = r.updateOrApply(ctx, cluster)
 	if err != nil {
 		return &requeue{curError: err, delayedRequeue: true}
 	}
 
+	err = r.clearGlobalCoordination(cluster, fdbv1beta2.GlobalCoordinationActionChangeCoordinators)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
 	return nil
 }
 
+// getCoordinatorChangeReason returns the reason for a coordinator change based on the coordinator status. The
+// coordinator status contains false for all coordinators that are not healthy.
+func getCoordinatorChangeReason(coordinatorStatus map[string]bool) string {
+	unhealthy := make([]string, 0, len(coordinatorStatus))
+	for address, healthy := range coordinatorStatus {
+		if !healthy {
+			unhealthy = append(unhealthy, address)
+		}
+	}
+
+	if len(unhealthy) == 0 {
+		return "Coordinators do not fulfill the fault tolerance requirements or the coordinator constraints"
+	}
+
+	sort.Strings(unhealthy)
+	return fmt.Sprintf("Coordinators are unhealthy or excluded: %s", strings.Join(unhealthy, ", "))
+}
+
+// getCoordinatorConstraintPods returns the Pods of the cluster if the coordinator constraints require information
+// from the Pods, e.g. the node of a Pod for the excluded nodes or the labels for the preferred Pod labels. Otherwise
+// no Pods will be returned.
+func getCoordinatorConstraintPods(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) ([]*corev1.Pod, error) {
+	constraints := cluster.Spec.CoordinatorConstraints
+	if len(constraints.ExcludedNodes) == 0 && len(constraints.PreferredPodLabels) == 0 {
+		return nil, nil
+	}
+
+	return r.PodLifecycleManager.GetPods(ctx, r, cluster, internal.GetPodListOptions(cluster, "", "")...)
+}
+
+// getNodeNamesPerProcessGroup returns the node name of the Pod for each process group.
+func getNodeNamesPerProcessGroup(cluster *fdbv1beta2.FoundationDBCluster, pods []*corev1.Pod) map[fdbv1beta2.ProcessGroupID]string {
+	nodeNames := make(map[fdbv1beta2.ProcessGroupID]string, len(pods))
+	for _, pod := range pods {
+		nodeNames[internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta)] = pod.Spec.NodeName
+	}
+
+	return nodeNames
+}
+
 // selectCandidates is a helper for Reconcile that picks non-excluded, not-being-removed class-matching process groups.
-func selectCandidates(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) ([]locality.Info, error) {
+// Processes on nodes or zones that are excluded by the coordinator constraints will be ignored and processes whose
+// Pods match the preferred Pod labels will be marked as preferred.
+func selectCandidates(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, pods []*corev1.Pod) ([]locality.Info, error) {
+	preferredProcessGroups := make(map[string]bool)
+	for _, pod := range pods {
+		if cluster.IsPreferredCoordinatorPod(pod.Labels) {
+			preferredProcessGroups[pod.Labels[cluster.GetProcessGroupIDLabel()]] = true
+		}
+	}
+	nodeNames := getNodeNamesPerProcessGroup(cluster, pods)
+
 	candidates := make([]locality.Info, 0, len(status.Cluster.Processes))
 	for _, process := range status.Cluster.Processes {
 		if process.Excluded || process.UnderMaintenance {
 			continue
 		}
 
+		if cluster.IsExcludedFromCoordinators(nodeNames[fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])], process.Locality) {
+			continue
+		}
+
+		if cluster.HasPinnedCoordinators() && !cluster.IsPinnedCoordinator(fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])) {
+			continue
+		}
+
 		if !cluster.IsEligibleAsCandidate(process.ProcessClass) {
 			continue
 		}
@@ -125,6 +213,14 @@ func selectCandidates(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta
 			continue
 		}
 
+		// For the three_data_hall redundancy mode the coordinators must be spread across the data halls, so we have to
+		// ignore processes where the data hall locality is missing.
+		if cluster.Spec.DatabaseConfiguration.RedundancyMode == fdbv1beta2.RedundancyModeThreeDataHall {
+			if process.Locality[fdbv1beta2.FDBLocalityDataHallKey] == "" {
+				continue
+			}
+		}
+
 		// If the cluster should be using DNS in the cluster file we should make sure the locality is set.
 		if cluster.UseDNSInClusterFile() {
 			_, ok := process.Locality[fdbv1beta2.FDBLocalityDNSNameKey]
@@ -141,6 +237,7 @@ func selectCandidates(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta
 		if err != nil {
 			return candidates, err
 		}
+		currentLocality.Preferred = preferredProcessGroups[currentLocality.ID]
 
 		candidates = append(candidates, currentLocality)
 	}
@@ -148,18 +245,25 @@ func selectCandidates(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta
 	return candidates, nil
 }
 
-func selectCoordinators(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) ([]locality.Info, error) {
+// selectCoordinators chooses the new coordinators from the candidates. If the coordinators are pinned, the pinned
+// process groups will be used as coordinators.
+func selectCoordinators(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, pods []*corev1.Pod) ([]locality.Info, error) {
 	var err error
 	coordinatorCount := cluster.DesiredCoordinatorCount()
 
-	candidates, err := selectCandidates(cluster, status)
+	candidates, err := selectCandidates(cluster, status, pods)
 	if err != nil {
 		return []locality.Info{}, err
 	}
 
-	coordinators, err := locality.ChooseDistributedProcesses(cluster, candidates, coordinatorCount, locality.ProcessSelectionConstraint{
-		HardLimits: locality.GetHardLimits(cluster),
-	})
+	var coordinators []locality.Info
+	if cluster.HasPinnedCoordinators() {
+		coordinators, err = selectPinnedCoordinators(cluster, candidates)
+	} else {
+		coordinators, err = locality.ChooseDistributedProcesses(cluster, candidates, coordinatorCount, locality.ProcessSelectionConstraint{
+			HardLimits: locality.GetHardLimits(cluster),
+		})
+	}
 
 	logger.Info("Current coordinators", "coordinators", coordinators, "error", err)
 	if err != nil {
@@ -171,7 +275,7 @@ func selectCoordinators(logger logr.Logger, cluster *fdbv1beta2.FoundationDBClus
 		coordinatorStatus[getCoordinatorAddress(cluster, coordinator).String()] = false
 	}
 
-	hasValidCoordinators, allAddressesValid, err := locality.CheckCoordinatorValidity(logger, cluster, status, coordinatorStatus)
+	hasValidCoordinators, allAddressesValid, err := locality.CheckCoordinatorValidity(logger, cluster, status, coordinatorStatus, getNodeNamesPerProcessGroup(cluster, pods))
 	if err != nil {
 		return coordinators, err
 	}
@@ -187,6 +291,82 @@ func selectCoordinators(logger logr.Logger, cluster *fdbv1beta2.FoundationDBClus
 	return coordinators, nil
 }
 
+// selectPinnedCoordinators returns the candidates of the pinned coordinators. An error is returned if any of the
+// pinned process groups is not an eligible candidate.
+func selectPinnedCoordinators(cluster *fdbv1beta2.FoundationDBCluster, candidates []locality.Info) ([]locality.Info, error) {
+	candidatesByID := make(map[string]locality.Info, len(candidates))
+	for _, candidate := range candidates {
+		candidatesByID[candidate.ID] = candidate
+	}
+
+	coordinators := make([]locality.Info, 0, len(cluster.Spec.CoordinatorConstraints.PinnedCoordinators))
+	var missing []string
+	for _, processGroupID := range cluster.Spec.CoordinatorConstraints.PinnedCoordinators {
+		candidate, ok := candidatesByID[string(processGroupID)]
+		if !ok {
+			missing = append(missing, string(processGroupID))
+			continue
+		}
+
+		coordinators = append(coordinators, candidate)
+	}
+
+	if len(missing) > 0 {
+		return coordinators, fmt.Errorf("pinned coordinators are not eligible: %s", strings.Join(missing, ", "))
+	}
+
+	return coordinators, nil
+}
+
+// getCoordinatorSelectionMessage returns a message that explains which coordinators were selected and which
+// constraints were applied.
+func getCoordinatorSelectionMessage(cluster *fdbv1beta2.FoundationDBCluster, coordinators []locality.Info) string {
+	selected := make([]string, 0, len(coordinators))
+	for _, coordinator := range coordinators {
+		description := fmt.Sprintf("%s (%s=%s", coordinator.ID, fdbv1beta2.FDBLocalityZoneIDKey, coordinator.LocalityData[fdbv1beta2.FDBLocalityZoneIDKey])
+		if coordinator.Preferred {
+			description += ", preferred"
+		}
+		selected = append(selected, description+")")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Selected coordinators %s", strings.Join(selected, ", ")))
+
+	constraints := cluster.Spec.CoordinatorConstraints
+	if cluster.HasPinnedCoordinators() {
+		sb.WriteString(" based on the pinned coordinators")
+		return sb.String()
+	}
+
+	hardLimits := locality.GetHardLimits(cluster)
+	fields := make([]string, 0, len(hardLimits))
+	for field := range hardLimits {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	limits := make([]string, 0, len(fields))
+	for _, field := range fields {
+		limits = append(limits, fmt.Sprintf("%s=%d", field, hardLimits[field]))
+	}
+	sb.WriteString(fmt.Sprintf(" with at most %s coordinators per locality", strings.Join(limits, ", ")))
+
+	if len(constraints.ExcludedNodes) > 0 {
+		sb.WriteString(fmt.Sprintf(", excluded nodes: %s", strings.Join(constraints.ExcludedNodes, ", ")))
+	}
+
+	if len(constraints.ExcludedZones) > 0 {
+		sb.WriteString(fmt.Sprintf(", excluded zones: %s", strings.Join(constraints.ExcludedZones, ", ")))
+	}
+
+	if len(constraints.PreferredPodLabels) > 0 {
+		sb.WriteString(fmt.Sprintf(", preferred Pod labels: %s", labels.SelectorFromSet(constraints.PreferredPodLabels).String()))
+	}
+
+	return sb.String()
+}
+
 func getCoordinatorAddress(cluster *fdbv1beta2.FoundationDBCluster, locality locality.Info) fdbv1beta2.ProcessAddress {
 	dnsName := locality.LocalityData[fdbv1beta2.FDBLocalityDNSNameKey]
 
@@ -34,6 +34,8 @@ import (
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
@@ -70,16 +72,125 @@ var _ = Describe("Change coordinators", func() {
 		Context("with a single FDB cluster", func() {
 			var status *fdbv1beta2.FoundationDBStatus
 			var candidates []locality.Info
+			var pods []*corev1.Pod
+
+			BeforeEach(func() {
+				pods = nil
+			})
 
 			JustBeforeEach(func() {
 				var err error
 				status, err = adminClient.GetStatus()
 				Expect(err).NotTo(HaveOccurred())
 
-				candidates, err = selectCoordinators(logr.Discard(), cluster, status)
+				candidates, err = selectCoordinators(logr.Discard(), cluster, status, pods)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			When("a node is excluded from the coordinators", func() {
+				BeforeEach(func() {
+					pods = append(pods, &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								cluster.GetProcessGroupIDLabel(): "storage-1",
+							},
+						},
+						Spec: corev1.PodSpec{
+							NodeName: "node-1",
+						},
+					})
+					cluster.Spec.CoordinatorConstraints.ExcludedNodes = []string{"node-1"}
+				})
+
+				It("should not select the process on the excluded node", func() {
+					Expect(len(candidates)).To(BeNumerically("==", cluster.DesiredCoordinatorCount()))
+					for _, candidate := range candidates {
+						Expect(candidate.ID).NotTo(Equal("storage-1"))
+						Expect(candidate.Class).To(Equal(fdbv1beta2.ProcessClassStorage))
+					}
+				})
+			})
+
+			When("a zone is excluded from the coordinators", func() {
+				BeforeEach(func() {
+					adminClient.MockLocalityInfo("storage-2", map[string]string{fdbv1beta2.FDBLocalityZoneIDKey: "zone-2"})
+					cluster.Spec.CoordinatorConstraints.ExcludedZones = []string{"zone-2"}
+				})
+
+				It("should not select the process in the excluded zone", func() {
+					Expect(len(candidates)).To(BeNumerically("==", cluster.DesiredCoordinatorCount()))
+					for _, candidate := range candidates {
+						Expect(candidate.ID).NotTo(Equal("storage-2"))
+					}
+				})
+			})
+
+			When("the Pods of the log processes are preferred", func() {
+				BeforeEach(func() {
+					cluster.Spec.CoordinatorConstraints.PreferredPodLabels = map[string]string{"spot": "false"}
+					for _, processGroupID := range []string{"log-1", "log-2", "log-3", "log-4"} {
+						pods = append(pods, &corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels: map[string]string{
+									cluster.GetProcessGroupIDLabel(): processGroupID,
+									"spot":                           "false",
+								},
+							},
+						})
+					}
+				})
+
+				It("should only select log processes", func() {
+					Expect(len(candidates)).To(BeNumerically("==", cluster.DesiredCoordinatorCount()))
+					for _, candidate := range candidates {
+						Expect(candidate.Class).To(Equal(fdbv1beta2.ProcessClassLog))
+						Expect(candidate.Preferred).To(BeTrue())
+					}
+				})
+			})
+
+			When("at most one coordinator per rack is allowed", func() {
+				BeforeEach(func() {
+					for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2"} {
+						adminClient.MockLocalityInfo(processGroupID, map[string]string{"rack": "rack-1"})
+					}
+					for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-3", "storage-4"} {
+						adminClient.MockLocalityInfo(processGroupID, map[string]string{"rack": "rack-2"})
+					}
+					for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"log-1", "log-2", "log-3", "log-4"} {
+						adminClient.MockLocalityInfo(processGroupID, map[string]string{"rack": "rack-3"})
+					}
+					cluster.Spec.CoordinatorConstraints.MaxCoordinatorsPerLocality = map[string]int{"rack": 1}
+				})
+
+				It("should select one process per rack", func() {
+					Expect(len(candidates)).To(BeNumerically("==", cluster.DesiredCoordinatorCount()))
+					racks := map[string]int{}
+					for _, candidate := range candidates {
+						racks[candidate.LocalityData["rack"]]++
+					}
+					Expect(racks).To(Equal(map[string]int{
+						"rack-1": 1,
+						"rack-2": 1,
+						"rack-3": 1,
+					}))
+				})
+			})
+
+			When("the coordinators are pinned", func() {
+				BeforeEach(func() {
+					cluster.Spec.CoordinatorConstraints.PinnedCoordinators = []fdbv1beta2.ProcessGroupID{"log-1", "log-2", "storage-4"}
+				})
+
+				It("should select the pinned process groups", func() {
+					ids := make([]string, 0, len(candidates))
+					for _, candidate := range candidates {
+						ids = append(ids, candidate.ID)
+					}
+					Expect(ids).To(ConsistOf("log-1", "log-2", "storage-4"))
+				})
+			})
+
 			When("all processes are healthy", func() {
 				It("should only select storage processes", func() {
 					Expect(cluster.DesiredCoordinatorCount()).To(BeNumerically("==", 3))
@@ -176,7 +287,7 @@ var _ = Describe("Change coordinators", func() {
 					initialCandidates := candidates
 
 					for i := 0; i < 100; i++ {
-						newCandidates, err := selectCoordinators(logr.Discard(), cluster, status)
+						newCandidates, err := selectCoordinators(logr.Discard(), cluster, status, nil)
 						Expect(err).NotTo(HaveOccurred())
 						Expect(newCandidates).To(Equal(initialCandidates))
 					}
@@ -250,7 +361,7 @@ var _ = Describe("Change coordinators", func() {
 				Expect(err).NotTo(HaveOccurred())
 				status.Cluster.Processes = generateProcessInfoForMultiRegion(dcCnt, satCnt, excludes)
 
-				candidates, err = selectCoordinators(testLogger, cluster, status)
+				candidates, err = selectCoordinators(testLogger, cluster, status, nil)
 				if shouldFail {
 					Expect(err).To(HaveOccurred())
 				} else {
@@ -422,7 +533,7 @@ var _ = Describe("Change coordinators", func() {
 						initialCandidates := candidates
 
 						for i := 0; i < 100; i++ {
-							newCandidates, err := selectCoordinators(logr.Discard(), cluster, status)
+							newCandidates, err := selectCoordinators(logr.Discard(), cluster, status, nil)
 							Expect(err).NotTo(HaveOccurred())
 							Expect(newCandidates).To(Equal(initialCandidates))
 						}
@@ -620,7 +731,7 @@ var _ = Describe("Change coordinators", func() {
 						initialCandidates := candidates
 
 						for i := 0; i < 100; i++ {
-							newCandidates, err := selectCoordinators(logr.Discard(), cluster, status)
+							newCandidates, err := selectCoordinators(logr.Discard(), cluster, status, nil)
 							Expect(err).NotTo(HaveOccurred())
 							Expect(newCandidates).To(Equal(initialCandidates))
 						}
@@ -632,6 +743,7 @@ var _ = Describe("Change coordinators", func() {
 		When("using a FDB cluster with three_data_hall", func() {
 			var status *fdbv1beta2.FoundationDBStatus
 			var candidates []locality.Info
+			var missingDataHall []fdbv1beta2.ProcessGroupID
 
 			JustBeforeEach(func() {
 				cluster.Spec.DataHall = "az1"
@@ -642,11 +754,42 @@ var _ = Describe("Change coordinators", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				status.Cluster.Processes = generateProcessInfoForThreeDataHall(3, nil)
+				for _, processGroupID := range missingDataHall {
+					process := status.Cluster.Processes[processGroupID]
+					delete(process.Locality, fdbv1beta2.FDBLocalityDataHallKey)
+					status.Cluster.Processes[processGroupID] = process
+				}
 
-				candidates, err = selectCoordinators(logr.Discard(), cluster, status)
+				candidates, err = selectCoordinators(logr.Discard(), cluster, status, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			BeforeEach(func() {
+				missingDataHall = nil
+			})
+
+			When("some processes have no data hall locality", func() {
+				BeforeEach(func() {
+					missingDataHall = []fdbv1beta2.ProcessGroupID{"datahall0-storage-0", "datahall1-storage-0"}
+				})
+
+				It("should not select the processes without a data hall locality", func() {
+					Expect(len(candidates)).To(BeNumerically("==", cluster.DesiredCoordinatorCount()))
+
+					dataHallCount := map[string]int{}
+					for _, candidate := range candidates {
+						Expect(candidate.ID).NotTo(BeElementOf("datahall0-storage-0", "datahall1-storage-0"))
+						dataHallCount[candidate.LocalityData[fdbv1beta2.FDBLocalityDataHallKey]]++
+					}
+
+					Expect(dataHallCount).To(Equal(map[string]int{
+						"datahall0": 3,
+						"datahall1": 3,
+						"datahall2": 3,
+					}))
+				})
+			})
+
 			When("all processes are healthy", func() {
 				It("should only select storage processes", func() {
 					Expect(cluster.DesiredCoordinatorCount()).To(BeNumerically("==", 9))
@@ -698,6 +841,19 @@ var _ = Describe("Change coordinators", func() {
 		})
 	})
 
+	DescribeTable("getting the coordinator change reason", func(coordinatorStatus map[string]bool, expected string) {
+		Expect(getCoordinatorChangeReason(coordinatorStatus)).To(Equal(expected))
+	},
+		Entry("all coordinators are healthy",
+			map[string]bool{"1.1.1.1:4501": true, "1.1.1.2:4501": true},
+			"Coordinators do not fulfill the fault tolerance requirements or the coordinator constraints",
+		),
+		Entry("some coordinators are unhealthy",
+			map[string]bool{"1.1.1.1:4501": true, "1.1.1.3:4501": false, "1.1.1.2:4501": false},
+			"Coordinators are unhealthy or excluded: 1.1.1.2:4501, 1.1.1.3:4501",
+		),
+	)
+
 	Describe("reconcile", func() {
 		var requeue *requeue
 		var originalConnectionString string
@@ -761,6 +917,63 @@ var _ = Describe("Change coordinators", func() {
 			})
 		})
 
+		When("one coordinator is running on an excluded node", func() {
+			var excludedCoordinator fdbv1beta2.FoundationDBStatusProcessInfo
+
+			BeforeEach(func() {
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+
+				status, err := adminClient.GetStatus()
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, process := range status.Cluster.Processes {
+					for _, role := range process.Roles {
+						if role.Role != "coordinator" {
+							continue
+						}
+
+						excludedCoordinator = process
+					}
+				}
+
+				processGroupID := fdbv1beta2.ProcessGroupID(excludedCoordinator.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+				pod := &corev1.Pod{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID).GetPodName(cluster)}, pod)).To(Succeed())
+				pod.Spec.NodeName = "node-1"
+				Expect(k8sClient.Update(context.TODO(), pod)).To(Succeed())
+				cluster.Spec.CoordinatorConstraints.ExcludedNodes = []string{"node-1"}
+			})
+
+			It("should change the coordinators to not include the coordinator on the excluded node", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.ConnectionString).NotTo(Equal(originalConnectionString))
+				Expect(cluster.Status.ConnectionString).NotTo(ContainSubstring(excludedCoordinator.Address.IPAddress.String()))
+			})
+
+			It("should record the coordinator change", func() {
+				// The first change is the initial coordinator selection.
+				Expect(cluster.Status.CoordinatorChanges).To(HaveLen(2))
+				change := cluster.Status.CoordinatorChanges[1]
+				Expect(change.PreviousConnectionString).To(Equal(originalConnectionString))
+				Expect(change.ConnectionString).To(Equal(cluster.Status.ConnectionString))
+				Expect(change.Reason).To(Equal("Coordinators do not fulfill the fault tolerance requirements or the coordinator constraints"))
+				Expect(change.Timestamp).To(BeNumerically(">", 0))
+			})
+		})
+
+		When("the pinned coordinators are not eligible", func() {
+			BeforeEach(func() {
+				cluster.Spec.CoordinatorConstraints.PinnedCoordinators = []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "storage-42"}
+			})
+
+			It("should not change the coordinators", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.curError).To(MatchError("pinned coordinators are not eligible: storage-42"))
+				Expect(cluster.Status.ConnectionString).To(Equal(originalConnectionString))
+			})
+		})
+
 		When("one coordinator is missing localities", func() {
 			var badCoordinator fdbv1beta2.FoundationDBStatusProcessInfo
 
@@ -31,7 +31,10 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -76,14 +79,14 @@ func NewFoundationDBClusterReconciler(podLifecycleManager podmanager.PodLifecycl
 
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbprocessgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbprocessgroups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=pods;configmaps;persistentvolumeclaims;events;secrets;services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile runs the reconciliation logic.
 func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
-	cluster := &fdbv1beta2.FoundationDBCluster{}
-
-	err := r.Get(ctx, request.NamespacedName, cluster)
+	cluster, err := internal.GetCluster(ctx, r, request.NamespacedName)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			return ctrl.Result{}, nil
@@ -148,6 +151,7 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 		deletePodsForBuggification{},
 		replaceMisconfiguredProcessGroups{},
 		replaceFailedProcessGroups{},
+		drainNodes{},
 		addProcessGroups{},
 		addServices{},
 		addPVCs{},
@@ -158,12 +162,14 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 		updatePodConfig{},
 		updateMetadata{},
 		updateDatabaseConfiguration{},
+		migrateStorageEngine{},
 		chooseRemovals{},
 		excludeProcesses{},
 		changeCoordinators{},
 		bounceProcesses{},
 		maintenanceModeChecker{},
 		updatePods{},
+		suspendProcessGroups{},
 		removeProcessGroups{},
 		removeServices{},
 		updateStatus{},
@@ -172,6 +178,7 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 	originalGeneration := cluster.ObjectMeta.Generation
 	normalizedSpec := cluster.Spec.DeepCopy()
 	delayedRequeue := false
+	var delayedRequeueAfter time.Duration
 
 	for _, subReconciler := range subReconcilers {
 		// We have to set the normalized spec here again otherwise any call to Update() for the status of the cluster
@@ -188,6 +195,10 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 				"subReconciler", fmt.Sprintf("%T", subReconciler),
 				"message", requeue.message,
 				"error", requeue.curError)
+			// Keep track of the shortest delay, a delayed requeue without a delay will requeue directly.
+			if !delayedRequeue || requeue.delay < delayedRequeueAfter {
+				delayedRequeueAfter = requeue.delay
+			}
 			delayedRequeue = true
 			continue
 		}
@@ -200,6 +211,10 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 			"CurrentGeneration", cluster.Status.Generations.Reconciled,
 			"OriginalGeneration", originalGeneration, "DelayedRequeue", delayedRequeue)
 
+		if cluster.Status.Generations.Reconciled >= originalGeneration && delayedRequeueAfter > 0 {
+			return ctrl.Result{RequeueAfter: delayedRequeueAfter}, nil
+		}
+
 		return ctrl.Result{Requeue: true}, nil
 	}
 
@@ -237,6 +252,13 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 		if err != nil {
 			return err
 		}
+
+		err = mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+			return []string{o.(*corev1.Pod).Spec.NodeName}
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	err = mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Service{}, "metadata.name", func(o client.Object) []string {
@@ -257,31 +279,42 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 		return err
 	}
 
-	builder := ctrl.NewControllerManagedBy(mgr).
+	// Only react on generation changes or annotation changes and only watch
+	// resources with the provided label selector.
+	changePredicate := builder.WithPredicates(
+		predicate.And(
+			labelSelectorPredicate,
+			predicate.Or(
+				predicate.LabelChangedPredicate{},
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+			),
+		))
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles},
 		).
-		For(&fdbv1beta2.FoundationDBCluster{}).
-		Owns(&corev1.Pod{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Service{}).
-		// Only react on generation changes or annotation changes and only watch
-		// resources with the provided label selector.
-		WithEventFilter(
-			predicate.And(
-				labelSelectorPredicate,
-				predicate.Or(
-					predicate.LabelChangedPredicate{},
-					predicate.GenerationChangedPredicate{},
-					predicate.AnnotationChangedPredicate{},
-				),
-			))
+		For(&fdbv1beta2.FoundationDBCluster{}, changePredicate).
+		Owns(&corev1.Pod{}, changePredicate).
+		Owns(&corev1.PersistentVolumeClaim{}, changePredicate).
+		Owns(&corev1.ConfigMap{}, changePredicate).
+		Owns(&corev1.Service{}, changePredicate)
+
+	// Nodes have no generation, so the taints of the nodes are watched to start a node drain without waiting for the
+	// next reconciliation. Watching nodes requires the same permissions as the node index.
+	if enableNodeIndex {
+		controllerBuilder.Watches(
+			&source.Kind{Type: &corev1.Node{}},
+			handler.EnqueueRequestsFromMapFunc(r.findClustersForNode),
+			builder.WithPredicates(nodeTaintsChangedPredicate()),
+		)
+	}
 
 	for _, object := range watchedObjects {
-		builder.Owns(object)
+		controllerBuilder.Owns(object, changePredicate)
 	}
-	return builder.Complete(r)
+	return controllerBuilder.Complete(r)
 }
 
 func (r *FoundationDBClusterReconciler) updatePodDynamicConf(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) (bool, error) {
@@ -422,8 +455,23 @@ func (r *FoundationDBClusterReconciler) newFdbPodClient(cluster *fdbv1beta2.Foun
 	return internal.NewFdbPodClient(cluster, pod, globalControllerLogger.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "pod", pod.Name), r.GetTimeout, r.PostTimeout)
 }
 
-// updateOrApply updates the status either with server-side apply or if disabled with the normal update call.
+// updateOrApply updates the status either with server-side apply or if disabled with the normal update call. If the
+// cluster makes use of FoundationDBProcessGroup resources, the process groups will be stored in those resources instead
+// of the cluster status.
 func (r *FoundationDBClusterReconciler) updateOrApply(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster) error {
+	if cluster.UseProcessGroupResources() {
+		err := r.updateProcessGroupResources(ctx, cluster)
+		if err != nil {
+			return err
+		}
+
+		processGroups := cluster.Status.ProcessGroups
+		cluster.Status.ProcessGroups = nil
+		defer func() {
+			cluster.Status.ProcessGroups = processGroups
+		}()
+	}
+
 	if r.ServerSideApply {
 		// TODO(johscheuer): We have to set the TypeMeta otherwise the Patch command will fail. This is the rudimentary
 		// support for server side apply which should be enough for the status use case. The controller runtime will
@@ -469,6 +517,12 @@ func (r *FoundationDBClusterReconciler) getStatusFromClusterOrDummyStatus(logger
 	if cluster.Status.ConnectionString != connectionString && connectionString != "" {
 		logger.Info("Updating out-of-date connection string", "previousConnectionString", cluster.Status.ConnectionString, "newConnectionString", connectionString)
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpdatingConnectionString", fmt.Sprintf("Setting connection string to %s", connectionString))
+		cluster.Status.AddCoordinatorChange(fdbv1beta2.CoordinatorChange{
+			Timestamp:                time.Now().Unix(),
+			PreviousConnectionString: cluster.Status.ConnectionString,
+			ConnectionString:         connectionString,
+			Reason:                   "Connection string was changed outside of the operator",
+		})
 		cluster.Status.ConnectionString = connectionString
 	}
 
@@ -1187,6 +1187,118 @@ var _ = Describe("cluster_controller", func() {
 				})
 			})
 
+			Context("with configuration approval required", func() {
+				BeforeEach(func() {
+					shouldCompleteReconciliation = false
+					cluster.Spec.AutomationOptions.RequireConfigurationApproval = pointer.Bool(true)
+					err = k8sClient.Update(context.TODO(), cluster)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should not configure the database", func() {
+					Expect(adminClient.DatabaseConfiguration.RedundancyMode).To(Equal(fdbv1beta2.RedundancyModeDouble))
+				})
+
+				It("should store the plan in the status", func() {
+					_, err = reloadCluster(cluster)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cluster.Status.ConfigurationPlan).NotTo(BeNil())
+					Expect(cluster.Status.ConfigurationPlan.Hash).NotTo(BeEmpty())
+					Expect(cluster.Status.ConfigurationPlan.Steps).To(HaveLen(1))
+					Expect(cluster.Status.ConfigurationPlan.Steps[0]).To(HavePrefix("triple ssd-2"))
+					Expect(cluster.Status.ConfigurationPlan.CompletedSteps).To(BeZero())
+				})
+
+				When("the plan is approved", func() {
+					JustBeforeEach(func() {
+						_, err = reloadCluster(cluster)
+						Expect(err).NotTo(HaveOccurred())
+						if cluster.Annotations == nil {
+							cluster.Annotations = map[string]string{}
+						}
+						cluster.Annotations[fdbv1beta2.ApprovedConfigurationPlanAnnotation] = cluster.Status.ConfigurationPlan.Hash
+						Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+
+						result, err := reconcileCluster(cluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Requeue).To(BeFalse())
+
+						_, err = reloadCluster(cluster)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("should configure the database and remove the plan", func() {
+						Expect(adminClient.DatabaseConfiguration.RedundancyMode).To(Equal(fdbv1beta2.RedundancyModeTriple))
+						Expect(cluster.Status.ConfigurationPlan).To(BeNil())
+						Expect(cluster.Status.Generations.Reconciled).To(Equal(cluster.Generation))
+					})
+
+					When("the same configuration change is required again", func() {
+						var approvedHash string
+
+						JustBeforeEach(func() {
+							approvedHash = cluster.Annotations[fdbv1beta2.ApprovedConfigurationPlanAnnotation]
+							configuration := cluster.DesiredDatabaseConfiguration()
+							configuration.RedundancyMode = fdbv1beta2.RedundancyModeDouble
+							Expect(adminClient.ConfigureDatabase(configuration, false, cluster.Spec.Version)).To(Succeed())
+
+							_, err := reconcileCluster(cluster)
+							Expect(err).NotTo(HaveOccurred())
+
+							_, err = reloadCluster(cluster)
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("should require a new approval", func() {
+							Expect(adminClient.DatabaseConfiguration.RedundancyMode).To(Equal(fdbv1beta2.RedundancyModeDouble))
+							Expect(cluster.Status.ConfigurationPlan).NotTo(BeNil())
+							Expect(cluster.Status.ConfigurationPlan.Hash).NotTo(Equal(approvedHash))
+						})
+					})
+				})
+
+				When("a different plan is approved", func() {
+					JustBeforeEach(func() {
+						_, err = reloadCluster(cluster)
+						Expect(err).NotTo(HaveOccurred())
+						if cluster.Annotations == nil {
+							cluster.Annotations = map[string]string{}
+						}
+						cluster.Annotations[fdbv1beta2.ApprovedConfigurationPlanAnnotation] = "outdated"
+						Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+
+						_, err := reconcileCluster(cluster)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("should not configure the database", func() {
+						Expect(adminClient.DatabaseConfiguration.RedundancyMode).To(Equal(fdbv1beta2.RedundancyModeDouble))
+					})
+				})
+			})
+
+			Context("with a change to the perpetual storage wiggle", func() {
+				BeforeEach(func() {
+					cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeDouble
+					cluster.Spec.DatabaseConfiguration.PerpetualStorageWiggle = pointer.Int(1)
+					cluster.Spec.DatabaseConfiguration.PerpetualStorageWiggleLocality = pointer.String("zoneid:operator-test-1-storage-1")
+					cluster.Spec.Version = fdbv1beta2.Versions.NextMajorVersion.String()
+					err = k8sClient.Update(context.TODO(), cluster)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should configure the database", func() {
+					Expect(adminClient.DatabaseConfiguration.PerpetualStorageWiggle).To(HaveValue(Equal(1)))
+					Expect(adminClient.DatabaseConfiguration.PerpetualStorageWiggleLocality).To(HaveValue(Equal("zoneid:operator-test-1-storage-1")))
+					Expect(adminClient.DatabaseConfiguration.StorageMigrationType).To(BeNil())
+				})
+
+				It("should report the storage wiggle in the status", func() {
+					Expect(cluster.Status.DatabaseConfiguration.PerpetualStorageWiggle).To(HaveValue(Equal(1)))
+					Expect(cluster.Status.StorageWiggle).NotTo(BeNil())
+				})
+			})
+
 			Context("with changes disabled", func() {
 				BeforeEach(func() {
 					shouldCompleteReconciliation = false
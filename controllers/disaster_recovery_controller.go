@@ -0,0 +1,207 @@
+/*
+ * disaster_recovery_controller.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// disasterRecoveryStatusRefreshInterval defines how often the status of a running replication is refreshed, the
+// status is used to report the lag of the destination cluster.
+const disasterRecoveryStatusRefreshInterval = 1 * time.Minute
+
+// FoundationDBDisasterRecoveryReconciler reconciles a FoundationDBDisasterRecovery object
+type FoundationDBDisasterRecoveryReconciler struct {
+	client.Client
+	Recorder               record.EventRecorder
+	Log                    logr.Logger
+	InSimulation           bool
+	DatabaseClientProvider fdbadminclient.DatabaseClientProvider
+	ServerSideApply        bool
+}
+
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbdisasterrecoveries,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbdisasterrecoveries/status,verbs=get;update;patch
+
+// Reconcile runs the reconciliation logic.
+func (r *FoundationDBDisasterRecoveryReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	disasterRecovery := &fdbv1beta2.FoundationDBDisasterRecovery{}
+
+	err := r.Get(ctx, request.NamespacedName, disasterRecovery)
+
+	originalGeneration := disasterRecovery.ObjectMeta.Generation
+
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	disasterRecoveryLog := globalControllerLogger.WithValues("namespace", disasterRecovery.Namespace, "disasterRecovery", disasterRecovery.Name)
+
+	subReconcilers := []disasterRecoverySubReconciler{
+		updateDisasterRecoveryStatus{},
+		updateDisasterRecoveryAgents{},
+		stopDisasterRecovery{},
+		switchDisasterRecovery{},
+		startDisasterRecovery{},
+		updateDisasterRecoveryStatus{},
+	}
+
+	for _, subReconciler := range subReconcilers {
+		requeue := subReconciler.reconcile(ctx, r, disasterRecovery)
+		if requeue == nil {
+			continue
+		}
+
+		return processRequeue(requeue, subReconciler, disasterRecovery, r.Recorder, disasterRecoveryLog)
+	}
+
+	if disasterRecovery.Status.Generations.Reconciled < originalGeneration {
+		disasterRecoveryLog.Info("Disaster recovery was not fully reconciled by reconciliation process")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	disasterRecoveryLog.Info("Reconciliation complete")
+
+	// The controller only reacts to spec changes, so the lag of a running replication must be polled.
+	if disasterRecovery.ShouldRun() {
+		return ctrl.Result{RequeueAfter: disasterRecoveryStatusRefreshInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getDatabaseClientProvider gets the client provider for a reconciler.
+func (r *FoundationDBDisasterRecoveryReconciler) getDatabaseClientProvider() fdbadminclient.DatabaseClientProvider {
+	if r.DatabaseClientProvider != nil {
+		return r.DatabaseClientProvider
+	}
+	panic("Disaster recovery reconciler does not have a DatabaseClientProvider defined")
+}
+
+// adminClientForDisasterRecovery provides an admin client for the destination cluster of a replication and the source
+// cluster of the replication. fdbdr is always run against the destination cluster.
+func (r *FoundationDBDisasterRecoveryReconciler) adminClientForDisasterRecovery(ctx context.Context, disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery, sourceClusterName string, destinationClusterName string) (fdbadminclient.AdminClient, *fdbv1beta2.FoundationDBCluster, error) {
+	source, err := internal.GetCluster(ctx, r, types.NamespacedName{Namespace: disasterRecovery.Namespace, Name: sourceClusterName})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	destination, err := internal.GetCluster(ctx, r, types.NamespacedName{Namespace: disasterRecovery.Namespace, Name: destinationClusterName})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(destination, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adminClient.SetKnobs(disasterRecovery.Spec.CustomParameters.GetKnobsForCLI())
+
+	return adminClient, source, nil
+}
+
+// SetupWithManager prepares a reconciler for use.
+func (r *FoundationDBDisasterRecoveryReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int, selector metav1.LabelSelector) error {
+	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
+	if err != nil {
+		return err
+	}
+
+	// Only react on generation changes or annotation changes and only watch
+	// resources with the provided label selector.
+	changePredicate := builder.WithPredicates(
+		predicate.And(
+			labelSelectorPredicate,
+			predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+			),
+		))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles},
+		).
+		For(&fdbv1beta2.FoundationDBDisasterRecovery{}, changePredicate).
+		Owns(&appsv1.Deployment{}, changePredicate).
+		Complete(r)
+}
+
+// disasterRecoverySubReconciler describes a class that does part of the work of
+// reconciliation for a disaster recovery.
+type disasterRecoverySubReconciler interface {
+	/**
+	reconcile runs the reconciler's work.
+
+	If reconciliation can continue, this should return nil.
+
+	If reconciliation encounters an error, this should return a requeue object
+	with an `Error` field.
+
+	If reconciliation cannot proceed, this should return a requeue object with a
+	`Message` field.
+	*/
+	reconcile(ctx context.Context, r *FoundationDBDisasterRecoveryReconciler, disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) *requeue
+}
+
+// updateOrApply updates the status either with server-side apply or if disabled with the normal update call.
+func (r *FoundationDBDisasterRecoveryReconciler) updateOrApply(ctx context.Context, disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) error {
+	if r.ServerSideApply {
+		patch := &fdbv1beta2.FoundationDBDisasterRecovery{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       disasterRecovery.Kind,
+				APIVersion: disasterRecovery.APIVersion,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      disasterRecovery.Name,
+				Namespace: disasterRecovery.Namespace,
+			},
+			Status: disasterRecovery.Status,
+		}
+
+		return r.Status().Patch(ctx, patch, client.Apply, client.FieldOwner("fdb-operator"))
+	}
+
+	return r.Status().Update(ctx, disasterRecovery)
+}
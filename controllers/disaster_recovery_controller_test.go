@@ -0,0 +1,234 @@
+/*
+ * disaster_recovery_controller_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+)
+
+func reloadDisasterRecovery(disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) error {
+	return k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: disasterRecovery.Namespace, Name: disasterRecovery.Name}, disasterRecovery)
+}
+
+func getDisasterRecoveryEventReasons(disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) []string {
+	events := &corev1.EventList{}
+	Expect(k8sClient.List(context.TODO(), events)).To(Succeed())
+
+	var reasons []string
+	for _, event := range events.Items {
+		if event.InvolvedObject.UID == disasterRecovery.ObjectMeta.UID {
+			reasons = append(reasons, event.Reason)
+		}
+	}
+
+	return reasons
+}
+
+var _ = Describe("disaster_recovery_controller", func() {
+	var source *fdbv1beta2.FoundationDBCluster
+	var destination *fdbv1beta2.FoundationDBCluster
+	var disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery
+	var sourceAdminClient *mock.AdminClient
+	var destinationAdminClient *mock.AdminClient
+	var err error
+
+	BeforeEach(func() {
+		source = internal.CreateDefaultCluster()
+		destination = internal.CreateDefaultCluster()
+		destination.Name = "operator-test-2"
+		disasterRecovery = internal.CreateDefaultDisasterRecovery(source, destination)
+
+		sourceAdminClient, err = mock.NewMockAdminClientUncast(source, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		destinationAdminClient, err = mock.NewMockAdminClientUncast(destination, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Reconciliation", func() {
+		var expectRequeue bool
+
+		BeforeEach(func() {
+			for _, cluster := range []*fdbv1beta2.FoundationDBCluster{source, destination} {
+				Expect(k8sClient.Create(context.TODO(), cluster)).To(Succeed())
+
+				result, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeFalse())
+
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(k8sClient.Create(context.TODO(), disasterRecovery)).To(Succeed())
+
+			result, err := reconcileDisasterRecovery(disasterRecovery)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+			Expect(reloadDisasterRecovery(disasterRecovery)).To(Succeed())
+
+			expectRequeue = false
+		})
+
+		JustBeforeEach(func() {
+			result, err := reconcileDisasterRecovery(disasterRecovery)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(Equal(expectRequeue))
+			Expect(reloadDisasterRecovery(disasterRecovery)).To(Succeed())
+		})
+
+		When("reconciling a new disaster recovery", func() {
+			It("should create the DR agent deployment", func() {
+				deployment := &appsv1.Deployment{}
+				deploymentName := fmt.Sprintf("%s-dr-agents", disasterRecovery.Name)
+
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: disasterRecovery.Namespace, Name: deploymentName}, deployment)).To(Succeed())
+				Expect(deployment.Spec.Replicas).To(HaveValue(Equal(int32(3))))
+				Expect(deployment.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"dr_agent"}))
+			})
+
+			It("should update the status on the resource", func() {
+				Expect(disasterRecovery.Status).To(Equal(fdbv1beta2.FoundationDBDisasterRecoveryStatus{
+					AgentCount:           3,
+					DeploymentConfigured: true,
+					DRDetails: &fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+						SourceClusterName:      source.Name,
+						DestinationClusterName: destination.Name,
+						Running:                true,
+					},
+					Generations: fdbv1beta2.DisasterRecoveryGenerationStatus{
+						Reconciled: 1,
+					},
+				}))
+			})
+
+			It("should start the replication", func() {
+				status, err := destinationAdminClient.GetDisasterRecoveryStatus(source)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(ContainSubstring("is NOT a complete copy"))
+			})
+		})
+
+		When("the destination catches up", func() {
+			BeforeEach(func() {
+				destinationAdminClient.MockDisasterRecoveryStatus(true, 2.5)
+			})
+
+			It("should report the lag", func() {
+				Expect(disasterRecovery.Status.DRDetails).To(Equal(&fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+					SourceClusterName:      source.Name,
+					DestinationClusterName: destination.Name,
+					Running:                true,
+					Restorable:             true,
+					SecondsBehind:          3,
+				}))
+			})
+		})
+
+		When("the agent count is changed to 0", func() {
+			BeforeEach(func() {
+				disasterRecovery.Spec.AgentCount = pointer.Int(0)
+				Expect(k8sClient.Update(context.TODO(), disasterRecovery)).To(Succeed())
+			})
+
+			It("should remove the deployment", func() {
+				deployment := &appsv1.Deployment{}
+				deploymentName := fmt.Sprintf("%s-dr-agents", disasterRecovery.Name)
+				err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: disasterRecovery.Namespace, Name: deploymentName}, deployment)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("stopping the replication", func() {
+			BeforeEach(func() {
+				disasterRecovery.Spec.DisasterRecoveryState = fdbv1beta2.DisasterRecoveryStateStopped
+				Expect(k8sClient.Update(context.TODO(), disasterRecovery)).To(Succeed())
+			})
+
+			It("should stop the replication", func() {
+				status, err := destinationAdminClient.GetDisasterRecoveryStatus(source)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).NotTo(ContainSubstring("complete copy"))
+				Expect(disasterRecovery.Status.DRDetails.Running).To(BeFalse())
+				Expect(disasterRecovery.Status.Generations.Reconciled).To(Equal(disasterRecovery.ObjectMeta.Generation))
+			})
+		})
+
+		When("swapping the source and the destination", func() {
+			BeforeEach(func() {
+				disasterRecovery.Spec.SourceClusterName = destination.Name
+				disasterRecovery.Spec.DestinationClusterName = source.Name
+				Expect(k8sClient.Update(context.TODO(), disasterRecovery)).To(Succeed())
+			})
+
+			When("the destination is a complete copy", func() {
+				BeforeEach(func() {
+					destinationAdminClient.MockDisasterRecoveryStatus(true, 0)
+				})
+
+				It("should switch the replication", func() {
+					status, err := sourceAdminClient.GetDisasterRecoveryStatus(destination)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status).To(ContainSubstring("is a complete copy"))
+
+					status, err = destinationAdminClient.GetDisasterRecoveryStatus(source)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status).NotTo(ContainSubstring("complete copy"))
+				})
+
+				It("should update the status", func() {
+					Expect(disasterRecovery.Status.DRDetails.SourceClusterName).To(Equal(destination.Name))
+					Expect(disasterRecovery.Status.DRDetails.DestinationClusterName).To(Equal(source.Name))
+					Expect(disasterRecovery.Status.DRDetails.Running).To(BeTrue())
+					Expect(disasterRecovery.Status.LastSwitchTimestamp).NotTo(BeNil())
+					Expect(disasterRecovery.Status.Generations.Reconciled).To(Equal(disasterRecovery.ObjectMeta.Generation))
+					Expect(getDisasterRecoveryEventReasons(disasterRecovery)).To(ContainElement("DisasterRecoverySwitched"))
+				})
+			})
+
+			When("the destination is not a complete copy", func() {
+				BeforeEach(func() {
+					expectRequeue = true
+				})
+
+				It("should not switch the replication", func() {
+					status, err := destinationAdminClient.GetDisasterRecoveryStatus(source)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status).To(ContainSubstring("is NOT a complete copy"))
+					Expect(disasterRecovery.Status.DRDetails.SourceClusterName).To(Equal(source.Name))
+					Expect(disasterRecovery.Status.LastSwitchTimestamp).To(BeNil())
+					Expect(disasterRecovery.Status.Generations.NeedsSwitch).To(Equal(disasterRecovery.ObjectMeta.Generation))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,360 @@
+/*
+ * drain_nodes.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// drainNodes provides a reconciliation step for draining nodes that carry one of the drain taints. All process groups
+// on the drained nodes of a single fault domain will be marked for removal as one batch, the following reconcilers will
+// exclude the processes, wait until the data has been moved and then remove the Pods. Drains in other fault domains
+// will wait until the current batch is removed.
+type drainNodes struct{}
+
+// reconcile runs the reconciler's work.
+func (d drainNodes) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	if !cluster.IsNodeDrainEnabled() {
+		if len(cluster.Status.NodeDrains) == 0 {
+			return nil
+		}
+
+		cluster.Status.NodeDrains = nil
+		err := r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		return nil
+	}
+
+	originalStatus := cluster.Status.DeepCopy()
+	drains := make(map[string]*fdbv1beta2.NodeDrainStatus, len(cluster.Status.NodeDrains))
+	nodes := map[string]bool{}
+
+	// Check if the previously drained nodes still carry a drain taint, if not the drain will be removed from the status.
+	for idx := range cluster.Status.NodeDrains {
+		drain := cluster.Status.NodeDrains[idx]
+		tainted, err := isNodeTaintedForDrain(ctx, r, cluster, drain.NodeName, nodes)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		if !tainted {
+			logger.Info("Node drain taint was removed", "node", drain.NodeName)
+			continue
+		}
+
+		drains[drain.NodeName] = &drain
+	}
+
+	pods, err := r.PodLifecycleManager.GetPods(ctx, r, cluster, internal.GetPodListOptions(cluster, "", "")...)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	podNodes := make(map[fdbv1beta2.ProcessGroupID]string, len(pods))
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+
+		podNodes[fdbv1beta2.ProcessGroupID(pod.Labels[cluster.GetProcessGroupIDLabel()])] = pod.Spec.NodeName
+	}
+
+	processGroups := make(map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.ProcessGroupStatus, len(cluster.Status.ProcessGroups))
+	candidates := map[fdbv1beta2.FaultDomain][]*fdbv1beta2.ProcessGroupStatus{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		processGroups[processGroup.ProcessGroupID] = processGroup
+
+		nodeName, ok := podNodes[processGroup.ProcessGroupID]
+		if !ok {
+			continue
+		}
+
+		tainted, err := isNodeTaintedForDrain(ctx, r, cluster, nodeName, nodes)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		if !tainted {
+			continue
+		}
+
+		drain, ok := drains[nodeName]
+		if !ok {
+			logger.Info("Start draining node", "node", nodeName)
+			drain = &fdbv1beta2.NodeDrainStatus{
+				NodeName:       nodeName,
+				StartTimestamp: time.Now().Unix(),
+			}
+			drains[nodeName] = drain
+		}
+
+		if !containsProcessGroupID(drain.ProcessGroups, processGroup.ProcessGroupID) {
+			drain.ProcessGroups = append(drain.ProcessGroups, processGroup.ProcessGroupID)
+		}
+
+		if !processGroup.IsMarkedForRemoval() {
+			faultDomain := getDrainFaultDomain(processGroup, nodeName)
+			candidates[faultDomain] = append(candidates[faultDomain], processGroup)
+		}
+	}
+
+	// Only a single fault domain will be drained at a time. If process groups of a drain are still marked for removal,
+	// only the process groups in the same fault domain will be marked for removal.
+	activeFaultDomains := map[fdbv1beta2.FaultDomain]fdbv1beta2.None{}
+	for _, drain := range drains {
+		for _, processGroupID := range drain.ProcessGroups {
+			processGroup, ok := processGroups[processGroupID]
+			if !ok || !processGroup.IsMarkedForRemoval() {
+				continue
+			}
+
+			activeFaultDomains[getDrainFaultDomain(processGroup, drain.NodeName)] = fdbv1beta2.None{}
+		}
+	}
+
+	if len(activeFaultDomains) == 0 && len(candidates) > 0 {
+		faultDomains := make([]fdbv1beta2.FaultDomain, 0, len(candidates))
+		for faultDomain := range candidates {
+			faultDomains = append(faultDomains, faultDomain)
+		}
+
+		sort.SliceStable(faultDomains, func(i, j int) bool {
+			return faultDomains[i] < faultDomains[j]
+		})
+
+		activeFaultDomains[faultDomains[0]] = fdbv1beta2.None{}
+	}
+
+	for faultDomain, faultDomainCandidates := range candidates {
+		if _, ok := activeFaultDomains[faultDomain]; !ok {
+			logger.Info("Deferring node drain until the current fault domain is drained", "faultDomain", faultDomain)
+			continue
+		}
+
+		for _, processGroup := range faultDomainCandidates {
+			logger.Info("Marking process group for removal because of node drain", "faultDomain", faultDomain, "processGroupID", processGroup.ProcessGroupID)
+			processGroup.MarkForRemoval()
+		}
+	}
+
+	nodeDrains := make([]fdbv1beta2.NodeDrainStatus, 0, len(drains))
+	pendingDrains := make([]string, 0, len(drains))
+	for _, drain := range drains {
+		drain.Phase = getNodeDrainPhase(drain, processGroups)
+		if drain.Phase != fdbv1beta2.NodeDrainPhaseDrained {
+			pendingDrains = append(pendingDrains, drain.NodeName)
+		}
+
+		sort.SliceStable(drain.ProcessGroups, func(i, j int) bool {
+			return drain.ProcessGroups[i] < drain.ProcessGroups[j]
+		})
+		nodeDrains = append(nodeDrains, *drain)
+	}
+
+	sort.SliceStable(nodeDrains, func(i, j int) bool {
+		return nodeDrains[i].NodeName < nodeDrains[j].NodeName
+	})
+
+	if len(nodeDrains) == 0 {
+		nodeDrains = nil
+	}
+	cluster.Status.NodeDrains = nodeDrains
+
+	if !equality.Semantic.DeepEqual(cluster.Status, *originalStatus) {
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	if len(pendingDrains) > 0 {
+		sort.Strings(pendingDrains)
+		return &requeue{message: fmt.Sprintf("Waiting for nodes to be drained: %v", pendingDrains), delayedRequeue: true}
+	}
+
+	return nil
+}
+
+// isNodeTaintedForDrain checks if the node carries one of the drain taints. The result will be cached in the provided
+// map to reduce the number of requests. If the node doesn't exist anymore it will be reported as not tainted.
+func isNodeTaintedForDrain(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, nodeName string, nodes map[string]bool) (bool, error) {
+	if tainted, ok := nodes[nodeName]; ok {
+		return tainted, nil
+	}
+
+	node := &corev1.Node{}
+	err := r.Get(ctx, client.ObjectKey{Name: nodeName}, node)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			nodes[nodeName] = false
+			return false, nil
+		}
+
+		return false, fmt.Errorf("get node %s fails with error: %w", nodeName, err)
+	}
+
+	tainted := hasDrainTaint(cluster.GetNodeDrainTaintKeys(), node)
+	nodes[nodeName] = tainted
+
+	return tainted, nil
+}
+
+// hasDrainTaint returns true if the node carries one of the provided taint keys.
+func hasDrainTaint(taintKeys []string, node *corev1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		for _, key := range taintKeys {
+			if taint.Key == key {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getDrainFaultDomain returns the fault domain of the process group, if the fault domain is unknown the node name will
+// be used.
+func getDrainFaultDomain(processGroup *fdbv1beta2.ProcessGroupStatus, nodeName string) fdbv1beta2.FaultDomain {
+	if processGroup.FaultDomain != "" {
+		return processGroup.FaultDomain
+	}
+
+	return fdbv1beta2.FaultDomain(nodeName)
+}
+
+// getNodeDrainPhase returns the phase of the node drain based on the process groups that were running on the node.
+func getNodeDrainPhase(drain *fdbv1beta2.NodeDrainStatus, processGroups map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.ProcessGroupStatus) fdbv1beta2.NodeDrainPhase {
+	phase := fdbv1beta2.NodeDrainPhaseDrained
+	for _, processGroupID := range drain.ProcessGroups {
+		processGroup, ok := processGroups[processGroupID]
+		if !ok {
+			continue
+		}
+
+		if !processGroup.IsMarkedForRemoval() {
+			return fdbv1beta2.NodeDrainPhasePending
+		}
+
+		if !processGroup.IsExcluded() {
+			return fdbv1beta2.NodeDrainPhaseExcluding
+		}
+
+		phase = fdbv1beta2.NodeDrainPhaseRemoving
+	}
+
+	return phase
+}
+
+// containsProcessGroupID returns true if the slice contains the process group ID.
+func containsProcessGroupID(processGroupIDs []fdbv1beta2.ProcessGroupID, processGroupID fdbv1beta2.ProcessGroupID) bool {
+	for _, id := range processGroupIDs {
+		if id == processGroupID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nodeTaintsChangedPredicate returns a predicate that only accepts updates of nodes that changed their taints.
+func nodeTaintsChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(updateEvent event.UpdateEvent) bool {
+			oldNode, ok := updateEvent.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+
+			newNode, ok := updateEvent.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+
+			return !equality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints)
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// findClustersForNode returns the reconcile requests for all clusters that have the node drain enabled and have a Pod
+// running on the provided node.
+func (r *FoundationDBClusterReconciler) findClustersForNode(object client.Object) []reconcile.Request {
+	pods := &corev1.PodList{}
+	err := r.List(context.Background(), pods, client.MatchingFields{"spec.nodeName": object.GetName()})
+	if err != nil {
+		globalControllerLogger.Error(err, "could not list pods for node", "node", object.GetName())
+		return nil
+	}
+
+	clusters := map[types.NamespacedName]fdbv1beta2.None{}
+	for _, pod := range pods.Items {
+		for _, ownerReference := range pod.OwnerReferences {
+			if ownerReference.Kind != "FoundationDBCluster" {
+				continue
+			}
+
+			clusters[types.NamespacedName{Namespace: pod.Namespace, Name: ownerReference.Name}] = fdbv1beta2.None{}
+		}
+	}
+
+	requests := make([]reconcile.Request, 0, len(clusters))
+	for clusterName := range clusters {
+		cluster, err := internal.GetCluster(context.Background(), r, clusterName)
+		if err != nil {
+			globalControllerLogger.Error(err, "could not get cluster for node", "node", object.GetName(), "namespace", clusterName.Namespace, "cluster", clusterName.Name)
+			continue
+		}
+
+		if !cluster.IsNodeDrainEnabled() {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: clusterName})
+	}
+
+	return requests
+}
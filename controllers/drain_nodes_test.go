@@ -0,0 +1,306 @@
+/*
+ * drain_nodes_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	mockclient "github.com/FoundationDB/fdb-kubernetes-operator/mock-kubernetes-client/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("drain_nodes", func() {
+	drainTaintKey := "foundationdb.org/drain"
+
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var result *requeue
+	var drainedProcessGroup *fdbv1beta2.ProcessGroupStatus
+	var node *corev1.Node
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		generation, err := reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generation).To(Equal(int64(1)))
+
+		drainedProcessGroup = fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1")
+		pod, err := clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, drainedProcessGroup.GetPodName(cluster))
+		Expect(err).NotTo(HaveOccurred())
+
+		node = &corev1.Node{}
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, node)).To(Succeed())
+		node.Spec.Taints = []corev1.Taint{
+			{
+				Key:    drainTaintKey,
+				Effect: corev1.TaintEffectNoSchedule,
+			},
+		}
+		Expect(k8sClient.Update(context.TODO(), node)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		result = drainNodes{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+	})
+
+	When("the node drain is disabled", func() {
+		It("should not mark the process group for removal", func() {
+			Expect(result).To(BeNil())
+			Expect(drainedProcessGroup.IsMarkedForRemoval()).To(BeFalse())
+			Expect(cluster.Status.NodeDrains).To(BeEmpty())
+		})
+	})
+
+	When("the node drain is enabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.NodeDrainOptions.TaintKeys = []string{drainTaintKey}
+		})
+
+		It("should mark the process group on the drained node for removal", func() {
+			Expect(result).NotTo(BeNil())
+			Expect(result.message).To(Equal("Waiting for nodes to be drained: [" + node.Name + "]"))
+			Expect(drainedProcessGroup.IsMarkedForRemoval()).To(BeTrue())
+			Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+		})
+
+		It("should report the drain in the status", func() {
+			Expect(cluster.Status.NodeDrains).To(HaveLen(1))
+			drain := cluster.Status.NodeDrains[0]
+			Expect(drain.NodeName).To(Equal(node.Name))
+			Expect(drain.Phase).To(Equal(fdbv1beta2.NodeDrainPhaseExcluding))
+			Expect(drain.ProcessGroups).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+			Expect(drain.StartTimestamp).To(BeNumerically(">", 0))
+		})
+
+		When("the process group is excluded", func() {
+			BeforeEach(func() {
+				drainedProcessGroup.MarkForRemoval()
+				drainedProcessGroup.SetExclude()
+			})
+
+			It("should report the drain as removing", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(cluster.Status.NodeDrains).To(HaveLen(1))
+				Expect(cluster.Status.NodeDrains[0].Phase).To(Equal(fdbv1beta2.NodeDrainPhaseRemoving))
+			})
+		})
+
+		When("the process group was removed", func() {
+			BeforeEach(func() {
+				cluster.Status.NodeDrains = []fdbv1beta2.NodeDrainStatus{
+					{
+						NodeName:       node.Name,
+						Phase:          fdbv1beta2.NodeDrainPhaseRemoving,
+						ProcessGroups:  []fdbv1beta2.ProcessGroupID{"storage-1"},
+						StartTimestamp: 1,
+					},
+				}
+
+				processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(cluster.Status.ProcessGroups))
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if processGroup.ProcessGroupID == "storage-1" {
+						continue
+					}
+					processGroups = append(processGroups, processGroup)
+				}
+				cluster.Status.ProcessGroups = processGroups
+
+				pod, err := clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, drainedProcessGroup.GetPodName(cluster))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Delete(context.TODO(), pod)).To(Succeed())
+			})
+
+			It("should report the drain as drained", func() {
+				Expect(result).To(BeNil())
+				Expect(cluster.Status.NodeDrains).To(HaveLen(1))
+				drain := cluster.Status.NodeDrains[0]
+				Expect(drain.Phase).To(Equal(fdbv1beta2.NodeDrainPhaseDrained))
+				Expect(drain.StartTimestamp).To(BeNumerically("==", 1))
+			})
+
+			When("the taint is removed from the node", func() {
+				BeforeEach(func() {
+					node.Spec.Taints = nil
+					Expect(k8sClient.Update(context.TODO(), node)).To(Succeed())
+				})
+
+				It("should remove the drain from the status", func() {
+					Expect(result).To(BeNil())
+					Expect(cluster.Status.NodeDrains).To(BeEmpty())
+				})
+			})
+		})
+
+		When("a node in a different fault domain is tainted", func() {
+			var secondProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+			BeforeEach(func() {
+				secondProcessGroup = fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+				drainedProcessGroup.FaultDomain = "zone-a"
+				secondProcessGroup.FaultDomain = "zone-b"
+
+				pod, err := clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, secondProcessGroup.GetPodName(cluster))
+				Expect(err).NotTo(HaveOccurred())
+
+				secondNode := &corev1.Node{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, secondNode)).To(Succeed())
+				secondNode.Spec.Taints = []corev1.Taint{
+					{
+						Key:    drainTaintKey,
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+				}
+				Expect(k8sClient.Update(context.TODO(), secondNode)).To(Succeed())
+			})
+
+			It("should only mark the process groups of a single fault domain for removal", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+				Expect(cluster.Status.NodeDrains).To(HaveLen(2))
+
+				phases := map[fdbv1beta2.NodeDrainPhase]int{}
+				for _, drain := range cluster.Status.NodeDrains {
+					phases[drain.Phase]++
+				}
+				Expect(phases).To(Equal(map[fdbv1beta2.NodeDrainPhase]int{
+					fdbv1beta2.NodeDrainPhaseExcluding: 1,
+					fdbv1beta2.NodeDrainPhasePending:   1,
+				}))
+			})
+
+			When("the first fault domain is still being drained", func() {
+				BeforeEach(func() {
+					secondProcessGroup.FaultDomain = "zone-0"
+					cluster.Status.NodeDrains = []fdbv1beta2.NodeDrainStatus{
+						{
+							NodeName:       node.Name,
+							Phase:          fdbv1beta2.NodeDrainPhaseExcluding,
+							ProcessGroups:  []fdbv1beta2.ProcessGroupID{"storage-1"},
+							StartTimestamp: 1,
+						},
+					}
+					drainedProcessGroup.MarkForRemoval()
+				})
+
+				It("should not mark the process groups of the other fault domain for removal", func() {
+					Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+					Expect(secondProcessGroup.IsMarkedForRemoval()).To(BeFalse())
+				})
+			})
+
+			When("both nodes are in the same fault domain", func() {
+				BeforeEach(func() {
+					secondProcessGroup.FaultDomain = "zone-a"
+				})
+
+				It("should mark the process groups of both nodes for removal", func() {
+					Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1"), fdbv1beta2.ProcessGroupID("storage-2")))
+				})
+			})
+		})
+
+		When("the node has a different taint", func() {
+			BeforeEach(func() {
+				node.Spec.Taints = []corev1.Taint{
+					{
+						Key:    "foundationdb.org/maintenance",
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+				}
+				Expect(k8sClient.Update(context.TODO(), node)).To(Succeed())
+			})
+
+			It("should not mark the process group for removal", func() {
+				Expect(result).To(BeNil())
+				Expect(drainedProcessGroup.IsMarkedForRemoval()).To(BeFalse())
+				Expect(cluster.Status.NodeDrains).To(BeEmpty())
+			})
+		})
+	})
+
+	When("checking if the node taints changed", func() {
+		var oldNode, newNode *corev1.Node
+
+		BeforeEach(func() {
+			oldNode = &corev1.Node{}
+			oldNode.Name = "node-1"
+			newNode = oldNode.DeepCopy()
+		})
+
+		It("should accept a taint change", func() {
+			newNode.Spec.Taints = []corev1.Taint{{Key: drainTaintKey, Effect: corev1.TaintEffectNoSchedule}}
+			Expect(nodeTaintsChangedPredicate().Update(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: newNode})).To(BeTrue())
+		})
+
+		It("should ignore other changes", func() {
+			newNode.Labels = map[string]string{"test": "test"}
+			Expect(nodeTaintsChangedPredicate().Update(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: newNode})).To(BeFalse())
+		})
+	})
+
+	When("finding the clusters for a node", func() {
+		var reconciler *FoundationDBClusterReconciler
+		var indexedClient *mockclient.MockClient
+
+		BeforeEach(func() {
+			indexedClient = mockclient.NewMockClientWithHooksAndIndexes(scheme.Scheme, nil, nil, true)
+			reconciler = &FoundationDBClusterReconciler{Client: indexedClient}
+
+			drainCluster := cluster.DeepCopy()
+			drainCluster.ResourceVersion = ""
+			drainCluster.Spec.AutomationOptions.NodeDrainOptions.TaintKeys = []string{drainTaintKey}
+			Expect(indexedClient.Create(context.TODO(), drainCluster)).To(Succeed())
+
+			otherCluster := internal.CreateDefaultCluster()
+			otherCluster.Name = "other"
+			Expect(indexedClient.Create(context.TODO(), otherCluster)).To(Succeed())
+
+			for _, owner := range []*fdbv1beta2.FoundationDBCluster{drainCluster, otherCluster} {
+				pod := &corev1.Pod{}
+				pod.Name = owner.Name + "-storage-1"
+				pod.Namespace = owner.Namespace
+				pod.Spec.NodeName = "node-1"
+				pod.OwnerReferences = internal.BuildOwnerReference(owner.TypeMeta, owner.ObjectMeta)
+				Expect(indexedClient.Create(context.TODO(), pod)).To(Succeed())
+			}
+		})
+
+		It("should only return the clusters with the node drain enabled", func() {
+			node := &corev1.Node{}
+			node.Name = "node-1"
+			Expect(reconciler.findClustersForNode(node)).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}}))
+		})
+	})
+})
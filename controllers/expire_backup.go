@@ -0,0 +1,161 @@
+/*
+ * expire_backup.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// expireBackup provides a reconciliation step for expiring the backup data based on the retention policy.
+type expireBackup struct{}
+
+// reconcile runs the reconciler's work.
+func (s expireBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	policy := backup.Spec.RetentionPolicy
+	if policy == nil || (policy.RetentionDays == nil && policy.MaxSnapshots == nil) {
+		return nil
+	}
+
+	if backup.Status.BackupDetails == nil {
+		return nil
+	}
+
+	now := time.Now()
+	if backup.Status.LastExpiration != nil && now.Before(backup.Status.LastExpiration.Add(policy.GetExpirationInterval())) {
+		return nil
+	}
+
+	adminClient, err := r.adminClientForBackup(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	for _, destination := range backup.GetDestinations() {
+		details := getBackupDestinationDetails(backup, destination.Name)
+		// File destinations can't be combined with a retention policy, this is checked by ValidateDestinations.
+		if details == nil || details.URL == "" {
+			continue
+		}
+
+		description, err := adminClient.DescribeBackup(details.URL)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		version, err := getBackupExpirationVersion(description, policy, now)
+		if err != nil {
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupExpirationSkipped", fmt.Sprintf("Skipped expiration of destination %s: %s", destination.Name, err.Error()))
+			continue
+		}
+
+		if version == 0 {
+			continue
+		}
+
+		err = adminClient.ExpireBackup(details.URL, version)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupExpired", fmt.Sprintf("Expired backup data of destination %s before version %d", destination.Name, version))
+	}
+
+	backup.Status.LastExpiration = &metav1.Time{Time: now}
+	err = r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// getBackupExpirationVersion returns the version before which the backup data can be expired. The data will only be
+// expired if it exceeds all limits of the retention policy. If no data should be expired 0 will be returned. If the
+// retention days are defined and the description doesn't contain the timestamps of the snapshots, an error will be
+// returned, as the age of the snapshots is unknown.
+func getBackupExpirationVersion(description *fdbv1beta2.FoundationDBBackupDescription, policy *fdbv1beta2.BackupRetentionPolicy, now time.Time) (int64, error) {
+	snapshots := make([]fdbv1beta2.FoundationDBBackupSnapshot, 0, len(description.Snapshots))
+	for _, snapshot := range description.Snapshots {
+		if !snapshot.Restorable {
+			continue
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if len(snapshots) == 0 {
+		return 0, nil
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].BeginVersion.Version < snapshots[j].BeginVersion.Version
+	})
+
+	var version int64
+	if policy.MaxSnapshots != nil {
+		maxSnapshots := pointer.IntDeref(policy.MaxSnapshots, 0)
+		if len(snapshots) <= maxSnapshots {
+			return 0, nil
+		}
+
+		version = snapshots[len(snapshots)-maxSnapshots].BeginVersion.Version
+	}
+
+	if policy.RetentionDays != nil {
+		// The newest snapshot that was completed before the retention window is required to restore to the start of
+		// the retention window, so all data before this snapshot can be expired.
+		threshold := now.AddDate(0, 0, -pointer.IntDeref(policy.RetentionDays, 0)).Unix()
+		for _, snapshot := range snapshots {
+			if snapshot.EndVersion.EpochSeconds <= 0 {
+				return 0, fmt.Errorf("the timestamp of the snapshot at version %d is unknown", snapshot.EndVersion.Version)
+			}
+		}
+
+		var retentionVersion int64
+		for _, snapshot := range snapshots {
+			if snapshot.EndVersion.EpochSeconds > threshold {
+				break
+			}
+
+			retentionVersion = snapshot.BeginVersion.Version
+		}
+
+		if version == 0 || retentionVersion < version {
+			version = retentionVersion
+		}
+	}
+
+	// If the oldest snapshot is still needed there is nothing to expire.
+	if version <= snapshots[0].BeginVersion.Version {
+		return 0, nil
+	}
+
+	return version, nil
+}
@@ -0,0 +1,137 @@
+/*
+ * expire_backup_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("expire_backup", func() {
+	now := time.Date(2023, 5, 20, 12, 0, 0, 0, time.UTC)
+	day := int64(24 * 60 * 60)
+
+	description := &fdbv1beta2.FoundationDBBackupDescription{
+		Restorable: true,
+		Snapshots: []fdbv1beta2.FoundationDBBackupSnapshot{
+			{
+				Restorable:   true,
+				BeginVersion: fdbv1beta2.FoundationDBBackupVersion{Version: 100},
+				EndVersion:   fdbv1beta2.FoundationDBBackupVersion{Version: 200, EpochSeconds: now.Unix() - 5*day},
+			},
+			{
+				Restorable:   true,
+				BeginVersion: fdbv1beta2.FoundationDBBackupVersion{Version: 300},
+				EndVersion:   fdbv1beta2.FoundationDBBackupVersion{Version: 400, EpochSeconds: now.Unix() - 3*day},
+			},
+			{
+				Restorable:   false,
+				BeginVersion: fdbv1beta2.FoundationDBBackupVersion{Version: 450},
+				EndVersion:   fdbv1beta2.FoundationDBBackupVersion{Version: 460, EpochSeconds: now.Unix() - 2*day},
+			},
+			{
+				Restorable:   true,
+				BeginVersion: fdbv1beta2.FoundationDBBackupVersion{Version: 500},
+				EndVersion:   fdbv1beta2.FoundationDBBackupVersion{Version: 600, EpochSeconds: now.Unix() - 1*day},
+			},
+		},
+	}
+
+	DescribeTable("getting the expiration version",
+		func(policy *fdbv1beta2.BackupRetentionPolicy, expected int64) {
+			version, err := getBackupExpirationVersion(description, policy, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(expected))
+		},
+		Entry("keeping more snapshots than available",
+			&fdbv1beta2.BackupRetentionPolicy{MaxSnapshots: pointer.Int(3)},
+			int64(0),
+		),
+		Entry("keeping a single snapshot",
+			&fdbv1beta2.BackupRetentionPolicy{MaxSnapshots: pointer.Int(1)},
+			int64(500),
+		),
+		Entry("keeping the data for two days",
+			&fdbv1beta2.BackupRetentionPolicy{RetentionDays: pointer.Int(2)},
+			int64(300),
+		),
+		Entry("keeping the data for ten days",
+			&fdbv1beta2.BackupRetentionPolicy{RetentionDays: pointer.Int(10)},
+			int64(0),
+		),
+		Entry("keeping the data until all limits are exceeded",
+			&fdbv1beta2.BackupRetentionPolicy{RetentionDays: pointer.Int(2), MaxSnapshots: pointer.Int(1)},
+			int64(300),
+		),
+	)
+
+	When("the description contains no timestamps", func() {
+		var descriptionWithoutTimestamps *fdbv1beta2.FoundationDBBackupDescription
+
+		BeforeEach(func() {
+			descriptionWithoutTimestamps = description.DeepCopy()
+			for idx := range descriptionWithoutTimestamps.Snapshots {
+				descriptionWithoutTimestamps.Snapshots[idx].EndVersion.EpochSeconds = 0
+			}
+		})
+
+		It("should refuse to expire data based on the retention days", func() {
+			version, err := getBackupExpirationVersion(descriptionWithoutTimestamps, &fdbv1beta2.BackupRetentionPolicy{RetentionDays: pointer.Int(2)}, now)
+			Expect(err).To(MatchError("the timestamp of the snapshot at version 200 is unknown"))
+			Expect(version).To(BeZero())
+		})
+
+		It("should expire data based on the number of snapshots", func() {
+			version, err := getBackupExpirationVersion(descriptionWithoutTimestamps, &fdbv1beta2.BackupRetentionPolicy{MaxSnapshots: pointer.Int(1)}, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(int64(500)))
+		})
+	})
+
+	When("getting the requeue delay", func() {
+		var backup *fdbv1beta2.FoundationDBBackup
+
+		BeforeEach(func() {
+			backup = &fdbv1beta2.FoundationDBBackup{}
+		})
+
+		It("should not requeue without a schedule", func() {
+			Expect(getBackupScheduleRequeueDelay(backup, now)).To(BeZero())
+		})
+
+		It("should requeue for the next expiration", func() {
+			backup.Spec.RetentionPolicy = &fdbv1beta2.BackupRetentionPolicy{RetentionDays: pointer.Int(2)}
+			backup.Status.LastExpiration = &metav1.Time{Time: now.Add(-30 * time.Minute)}
+			Expect(getBackupScheduleRequeueDelay(backup, now)).To(Equal(30 * time.Minute))
+		})
+
+		It("should requeue for the next snapshot", func() {
+			backup.Spec.SnapshotSchedule = &fdbv1beta2.BackupSnapshotSchedule{Schedule: "0 13 * * *"}
+			backup.Status.LastScheduledSnapshot = &metav1.Time{Time: now.Add(-23 * time.Hour)}
+			Expect(getBackupScheduleRequeueDelay(backup, now)).To(Equal(1 * time.Hour))
+		})
+	})
+})
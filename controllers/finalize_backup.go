@@ -0,0 +1,139 @@
+/*
+ * finalize_backup.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ensureBackupFinalizer adds the finalizer to the backup, so the backup can be stopped before the resource is removed.
+func (r *FoundationDBBackupReconciler) ensureBackupFinalizer(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup) error {
+	if controllerutil.ContainsFinalizer(backup, fdbv1beta2.BackupFinalizer) {
+		return nil
+	}
+
+	controllerutil.AddFinalizer(backup, fdbv1beta2.BackupFinalizer)
+	return r.Update(ctx, backup)
+}
+
+// finalizeBackup stops the backup and deletes the backup data if the deletion policy requires it. Afterwards the
+// finalizer is removed so the resource can be deleted.
+func (r *FoundationDBBackupReconciler) finalizeBackup(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup, logger logr.Logger) error {
+	if !controllerutil.ContainsFinalizer(backup, fdbv1beta2.BackupFinalizer) {
+		return nil
+	}
+
+	err := r.cleanupBackup(ctx, backup, logger)
+	if err != nil {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupCleanupFailed", fmt.Sprintf("Backup cleanup failed: %s, the annotation %s can be set to skip the cleanup", err.Error(), fdbv1beta2.SkipBackupCleanupAnnotation))
+		return err
+	}
+
+	controllerutil.RemoveFinalizer(backup, fdbv1beta2.BackupFinalizer)
+	return r.Update(ctx, backup)
+}
+
+// cleanupBackup stops the backups for all destinations and deletes the backup data if the deletion policy is Delete.
+func (r *FoundationDBBackupReconciler) cleanupBackup(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup, logger logr.Logger) error {
+	// If the cluster is unavailable the cleanup can't succeed, the annotation allows to release the finalizer anyway.
+	if backup.GetAnnotations()[fdbv1beta2.SkipBackupCleanupAnnotation] == "true" {
+		logger.Info("Skipping cleanup because of the skip annotation", "annotation", fdbv1beta2.SkipBackupCleanupAnnotation)
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupCleanupSkipped", fmt.Sprintf("Annotation %s is set, the backup data was not cleaned up", fdbv1beta2.SkipBackupCleanupAnnotation))
+		return nil
+	}
+
+	cluster, err := internal.GetCluster(ctx, r, types.NamespacedName{Namespace: backup.Namespace, Name: backup.Spec.ClusterName})
+	if err != nil {
+		// Without the cluster the backup can't be running anymore. Blocking the deletion would also block the
+		// deletion of the namespace, so the finalizer is released without deleting the data.
+		if k8serrors.IsNotFound(err) {
+			logger.Info("Cluster of the backup not found, skipping cleanup", "cluster", backup.Spec.ClusterName)
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupCleanupSkipped", fmt.Sprintf("Cluster %s was not found, the backup data was not cleaned up", backup.Spec.ClusterName))
+			return nil
+		}
+
+		return err
+	}
+
+	// If the cluster is being deleted, e.g. because the namespace is deleted, the cluster might already be unavailable
+	// and the cleanup would block the deletion.
+	if !cluster.DeletionTimestamp.IsZero() {
+		logger.Info("Cluster of the backup is being deleted, skipping cleanup", "cluster", backup.Spec.ClusterName)
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupCleanupSkipped", fmt.Sprintf("Cluster %s is being deleted, the backup data was not cleaned up", backup.Spec.ClusterName))
+		return nil
+	}
+
+	adminClient, err := r.adminClientForBackup(ctx, backup)
+	if err != nil {
+		return err
+	}
+	defer adminClient.Close()
+
+	destinations := backup.GetDestinations()
+	for _, destination := range destinations {
+		status, err := adminClient.GetBackupStatus(destination.Name)
+		if err != nil {
+			return err
+		}
+
+		if !status.Status.Running {
+			continue
+		}
+
+		logger.Info("Stopping backup", "destination", destination.Name)
+		err = adminClient.StopBackup(destination.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if backup.GetDeletionPolicy() != fdbv1beta2.BackupDeletionPolicyDelete {
+		return nil
+	}
+
+	for _, destination := range destinations {
+		url := backup.DestinationURL(destination)
+		// The operator has no access to the volumes of file destinations, so those can't be deleted.
+		if url == "" || isFileBackupURL(url) {
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupDataRetained", fmt.Sprintf("Backup data of destination %s can't be deleted by the operator", destination.Name))
+			continue
+		}
+
+		logger.Info("Deleting backup data", "destination", destination.Name)
+		err = adminClient.DeleteBackup(url)
+		if err != nil {
+			return err
+		}
+
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupDataDeleted", fmt.Sprintf("Deleted backup data of destination %s", destination.Name))
+	}
+
+	return nil
+}
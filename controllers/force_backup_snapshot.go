@@ -0,0 +1,95 @@
+/*
+ * force_backup_snapshot.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// forceBackupSnapshot provides a reconciliation step for forcing snapshots based on the snapshot schedule.
+type forceBackupSnapshot struct{}
+
+// reconcile runs the reconciler's work.
+func (s forceBackupSnapshot) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	if backup.Spec.SnapshotSchedule == nil || backup.Status.BackupDetails == nil || !backup.Status.BackupDetails.Running || backup.ShouldBePaused() {
+		return nil
+	}
+
+	schedule, err := parseSnapshotSchedule(backup.Spec.SnapshotSchedule)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	now := time.Now()
+	// If no snapshot was forced before, the schedule starts now. Otherwise we would force a snapshot directly after
+	// the schedule was defined.
+	if backup.Status.LastScheduledSnapshot != nil && now.Before(schedule.Next(backup.Status.LastScheduledSnapshot.Time)) {
+		return nil
+	}
+
+	if backup.Status.LastScheduledSnapshot != nil {
+		adminClient, err := r.adminClientForBackup(ctx, backup)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+		defer adminClient.Close()
+
+		snapshotDuration := backup.Spec.SnapshotSchedule.GetSnapshotDurationSeconds()
+		for _, destination := range backup.GetDestinations() {
+			details := getBackupDestinationDetails(backup, destination.Name)
+			if details == nil || !details.Running {
+				continue
+			}
+
+			err = adminClient.ForceSnapshot(destination.Name, snapshotDuration)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+		}
+
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "SnapshotForced", fmt.Sprintf("Forced snapshot to complete in %d seconds", snapshotDuration))
+	}
+
+	backup.Status.LastScheduledSnapshot = &metav1.Time{Time: now}
+	err = r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// parseSnapshotSchedule parses the cron schedule of the snapshot schedule.
+func parseSnapshotSchedule(snapshotSchedule *fdbv1beta2.BackupSnapshotSchedule) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(snapshotSchedule.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot schedule \"%s\": %w", snapshotSchedule.Schedule, err)
+	}
+
+	return schedule, nil
+}
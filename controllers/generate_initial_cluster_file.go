@@ -23,6 +23,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -124,10 +125,24 @@ func (g generateInitialClusterFile) reconcile(ctx context.Context, r *Foundation
 			logger.Info("Pod is ineligible to be a coordinator due to missing locality information", "processGroupID", processGroupID)
 			continue
 		}
+
+		if cluster.IsExcludedFromCoordinators(pod.Spec.NodeName, currentLocality.LocalityData) {
+			logger.Info("Pod is ineligible to be a coordinator because its node or zone is excluded", "processGroupID", currentLocality.ID, "node", pod.Spec.NodeName)
+			continue
+		}
+		currentLocality.Preferred = cluster.IsPreferredCoordinatorPod(pod.Labels)
+
 		processLocality = append(processLocality, currentLocality)
 	}
 
-	coordinators, err := locality.ChooseDistributedProcesses(cluster, processLocality, count, locality.ProcessSelectionConstraint{})
+	var coordinators []locality.Info
+	if cluster.HasPinnedCoordinators() {
+		coordinators, err = selectPinnedCoordinators(cluster, processLocality)
+	} else {
+		coordinators, err = locality.ChooseDistributedProcesses(cluster, processLocality, count, locality.ProcessSelectionConstraint{
+			HardLimits: getInitialCoordinatorHardLimits(cluster),
+		})
+	}
 	if err != nil {
 		return &requeue{curError: err}
 	}
@@ -136,6 +151,11 @@ func (g generateInitialClusterFile) reconcile(ctx context.Context, r *Foundation
 		connectionString.Coordinators = append(connectionString.Coordinators, getCoordinatorAddress(cluster, currentLocality).String())
 	}
 
+	cluster.Status.AddCoordinatorChange(fdbv1beta2.CoordinatorChange{
+		Timestamp:        time.Now().Unix(),
+		ConnectionString: connectionString.String(),
+		Reason:           "Initial coordinator selection",
+	})
 	cluster.Status.ConnectionString = connectionString.String()
 
 	err = r.updateOrApply(ctx, cluster)
@@ -145,3 +165,19 @@ func (g generateInitialClusterFile) reconcile(ctx context.Context, r *Foundation
 
 	return nil
 }
+
+// getInitialCoordinatorHardLimits returns the limits of the coordinator constraints for the initial coordinator
+// selection. The default limits of the fault domains are not enforced for the initial coordinators, so a new cluster
+// can be created even if not all fault domains are available yet.
+func getInitialCoordinatorHardLimits(cluster *fdbv1beta2.FoundationDBCluster) map[string]int {
+	hardLimits := make(map[string]int, len(cluster.Spec.CoordinatorConstraints.MaxCoordinatorsPerLocality))
+	for field, limit := range cluster.Spec.CoordinatorConstraints.MaxCoordinatorsPerLocality {
+		if limit < 1 {
+			continue
+		}
+
+		hardLimits[field] = limit
+	}
+
+	return hardLimits
+}
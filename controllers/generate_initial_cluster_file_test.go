@@ -0,0 +1,110 @@
+/*
+ * generate_initial_cluster_file_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("generate_initial_cluster_file", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var result *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+		cluster.Status.ConnectionString = ""
+	})
+
+	JustBeforeEach(func() {
+		result = generateInitialClusterFile{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+	})
+
+	// getCoordinatorProcessGroups returns the process groups of the coordinators in the connection string.
+	getCoordinatorProcessGroups := func() []fdbv1beta2.ProcessGroupID {
+		connectionString, err := fdbv1beta2.ParseConnectionString(cluster.Status.ConnectionString)
+		Expect(err).NotTo(HaveOccurred())
+
+		addresses := map[string]fdbv1beta2.ProcessGroupID{}
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			addresses[fmt.Sprintf("%s:4501", processGroup.Addresses[0])] = processGroup.ProcessGroupID
+		}
+
+		processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(connectionString.Coordinators))
+		for _, coordinator := range connectionString.Coordinators {
+			processGroupIDs = append(processGroupIDs, addresses[coordinator])
+		}
+
+		return processGroupIDs
+	}
+
+	When("no coordinator constraints are defined", func() {
+		It("should select the coordinators", func() {
+			Expect(result).To(BeNil())
+			Expect(getCoordinatorProcessGroups()).To(HaveLen(cluster.DesiredCoordinatorCount()))
+		})
+
+		It("should record the initial coordinator selection", func() {
+			Expect(cluster.Status.CoordinatorChanges).NotTo(BeEmpty())
+			change := cluster.Status.CoordinatorChanges[len(cluster.Status.CoordinatorChanges)-1]
+			Expect(change.PreviousConnectionString).To(BeEmpty())
+			Expect(change.ConnectionString).To(Equal(cluster.Status.ConnectionString))
+			Expect(change.Reason).To(Equal("Initial coordinator selection"))
+		})
+	})
+
+	When("a node is excluded from the coordinators", func() {
+		BeforeEach(func() {
+			pod := &corev1.Pod{}
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: "operator-test-1-log-1"}, pod)).To(Succeed())
+			pod.Spec.NodeName = "node-1"
+			Expect(k8sClient.Update(context.TODO(), pod)).To(Succeed())
+
+			cluster.Spec.CoordinatorConstraints.ExcludedNodes = []string{"node-1"}
+		})
+
+		It("should not select the process group on the excluded node", func() {
+			Expect(result).To(BeNil())
+			coordinators := getCoordinatorProcessGroups()
+			Expect(coordinators).To(HaveLen(cluster.DesiredCoordinatorCount()))
+			Expect(coordinators).NotTo(ContainElement(fdbv1beta2.ProcessGroupID("log-1")))
+		})
+	})
+
+	When("the coordinators are pinned", func() {
+		BeforeEach(func() {
+			cluster.Spec.CoordinatorConstraints.PinnedCoordinators = []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "storage-3"}
+		})
+
+		It("should select the pinned coordinators", func() {
+			Expect(result).To(BeNil())
+			Expect(getCoordinatorProcessGroups()).To(ConsistOf(cluster.Spec.CoordinatorConstraints.PinnedCoordinators))
+		})
+	})
+})
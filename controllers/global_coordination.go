@@ -0,0 +1,140 @@
+/*
+ * global_coordination.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// registerGlobalAction stores the value for the provided global action for the data center of this operator instance.
+// The value will only be written if it differs from the stored value. The method returns all stored values for the
+// action, including the value of this operator instance.
+func (r *FoundationDBClusterReconciler) registerGlobalAction(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, action fdbv1beta2.GlobalCoordinationAction, value string) (map[string]string, error) {
+	lockClient, err := r.getLockClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingActions, err := lockClient.GetPendingActions(action)
+	if err != nil {
+		return nil, err
+	}
+
+	current, ok := pendingActions[cluster.Spec.DataCenter]
+	if ok && current == value {
+		return pendingActions, nil
+	}
+
+	logger.Info("Registering global action", "action", action, "dataCenter", cluster.Spec.DataCenter, "value", value)
+	err = lockClient.AddPendingAction(action, value)
+	if err != nil {
+		return nil, err
+	}
+	pendingActions[cluster.Spec.DataCenter] = value
+
+	return pendingActions, nil
+}
+
+// checkGlobalCoordination registers that this operator instance is ready to perform the provided global action and
+// checks if the operator instances in all data centers have registered the same value. If global coordination is
+// disabled this method will always return nil. If not all data centers agree a requeue will be returned.
+func (r *FoundationDBClusterReconciler) checkGlobalCoordination(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, action fdbv1beta2.GlobalCoordinationAction, value string) *requeue {
+	if !cluster.ShouldUseGlobalCoordination() {
+		return nil
+	}
+
+	pendingActions, err := r.registerGlobalAction(logger, cluster, action, value)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	notReady := make([]string, 0)
+	for _, dataCenter := range getGlobalCoordinationDataCenters(cluster, status) {
+		if pendingActions[dataCenter] != value {
+			notReady = append(notReady, dataCenter)
+		}
+	}
+
+	if len(notReady) > 0 {
+		logger.Info("Deferring global action until all data centers are ready", "action", action, "value", value, "notReadyDataCenters", notReady)
+		message := fmt.Sprintf("Waiting for data centers to be ready for %s: %v", action, notReady)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "GlobalCoordinationPending", message)
+		return &requeue{message: message, delayedRequeue: true}
+	}
+
+	return nil
+}
+
+// clearGlobalCoordination removes the stored values of all data centers for the provided global action after the action
+// was performed. The values of all data centers must be removed, otherwise a later action with the same value could pass
+// the check before the other operator instances are ready for it. The other operator instances don't have to perform
+// the action anymore, as the action was performed for the whole cluster.
+func (r *FoundationDBClusterReconciler) clearGlobalCoordination(cluster *fdbv1beta2.FoundationDBCluster, action fdbv1beta2.GlobalCoordinationAction) error {
+	if !cluster.ShouldUseGlobalCoordination() {
+		return nil
+	}
+
+	lockClient, err := r.getLockClient(cluster)
+	if err != nil {
+		return err
+	}
+
+	return lockClient.ClearPendingActions(action)
+}
+
+// getGlobalCoordinationDataCenters returns the sorted list of data centers that must agree on a global action. This
+// includes the data center of this cluster, all data centers defined in the regions of the database configuration and
+// all data centers that are hosting processes.
+func getGlobalCoordinationDataCenters(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) []string {
+	dataCenters := map[string]fdbv1beta2.None{
+		cluster.Spec.DataCenter: {},
+	}
+
+	for _, region := range cluster.Spec.DatabaseConfiguration.Regions {
+		for _, dataCenter := range region.DataCenters {
+			dataCenters[dataCenter.ID] = fdbv1beta2.None{}
+		}
+	}
+
+	if status != nil {
+		for _, process := range status.Cluster.Processes {
+			dataCenter, ok := process.Locality[fdbv1beta2.FDBLocalityDCIDKey]
+			if !ok || dataCenter == "" {
+				continue
+			}
+
+			dataCenters[dataCenter] = fdbv1beta2.None{}
+		}
+	}
+
+	result := make([]string, 0, len(dataCenters))
+	for dataCenter := range dataCenters {
+		result = append(result, dataCenter)
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,191 @@
+/*
+ * global_coordination_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("global_coordination", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+	var lockClient *mock.LockClient
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.DataCenter = "primary"
+		cluster.Spec.LockOptions.DisableLocks = pointer.Bool(false)
+		cluster.Spec.LockOptions.UseGlobalCoordination = pointer.Bool(true)
+		lockClient = mock.NewMockLockClientUncast(cluster)
+
+		status = &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"primary-storage-1": {
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityDCIDKey: "primary",
+						},
+					},
+					"remote-storage-1": {
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityDCIDKey: "remote",
+						},
+					},
+				},
+			},
+		}
+	})
+
+	When("getting the data centers that must agree", func() {
+		BeforeEach(func() {
+			cluster.Spec.DatabaseConfiguration.Regions = []fdbv1beta2.Region{
+				{
+					DataCenters: []fdbv1beta2.DataCenter{
+						{ID: "primary"},
+						{ID: "primary-satellite", Satellite: 1},
+					},
+				},
+			}
+		})
+
+		It("should return the data centers from the spec and the status", func() {
+			Expect(getGlobalCoordinationDataCenters(cluster, status)).To(Equal([]string{"primary", "primary-satellite", "remote"}))
+		})
+	})
+
+	When("checking the global coordination", func() {
+		var req *requeue
+
+		JustBeforeEach(func() {
+			req = clusterReconciler.checkGlobalCoordination(globalControllerLogger, cluster, status, fdbv1beta2.GlobalCoordinationActionConfigureDatabase, "triple ssd")
+		})
+
+		When("the remote data center has not registered the action", func() {
+			It("should requeue", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.curError).NotTo(HaveOccurred())
+				Expect(req.message).To(Equal("Waiting for data centers to be ready for ConfigureDatabase: [remote]"))
+			})
+
+			It("should register the action for the local data center", func() {
+				actions, err := lockClient.GetPendingActions(fdbv1beta2.GlobalCoordinationActionConfigureDatabase)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actions).To(Equal(map[string]string{"primary": "triple ssd"}))
+			})
+		})
+
+		When("the remote data center has registered a different value", func() {
+			BeforeEach(func() {
+				Expect(lockClient.AddPendingActionForDataCenter(fdbv1beta2.GlobalCoordinationActionConfigureDatabase, "remote", "double ssd")).To(Succeed())
+			})
+
+			It("should requeue", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.message).To(Equal("Waiting for data centers to be ready for ConfigureDatabase: [remote]"))
+			})
+		})
+
+		When("the remote data center has registered the same value", func() {
+			BeforeEach(func() {
+				Expect(lockClient.AddPendingActionForDataCenter(fdbv1beta2.GlobalCoordinationActionConfigureDatabase, "remote", "triple ssd")).To(Succeed())
+			})
+
+			It("should not requeue", func() {
+				Expect(req).To(BeNil())
+			})
+
+			When("the action is cleared", func() {
+				JustBeforeEach(func() {
+					Expect(clusterReconciler.clearGlobalCoordination(cluster, fdbv1beta2.GlobalCoordinationActionConfigureDatabase)).To(Succeed())
+				})
+
+				It("should remove the values of all data centers", func() {
+					actions, err := lockClient.GetPendingActions(fdbv1beta2.GlobalCoordinationActionConfigureDatabase)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(actions).To(BeEmpty())
+				})
+
+				When("the same action is checked again", func() {
+					It("should wait for the remote data center", func() {
+						req = clusterReconciler.checkGlobalCoordination(globalControllerLogger, cluster, status, fdbv1beta2.GlobalCoordinationActionConfigureDatabase, "triple ssd")
+						Expect(req).NotTo(BeNil())
+						Expect(req.message).To(Equal("Waiting for data centers to be ready for ConfigureDatabase: [remote]"))
+					})
+				})
+			})
+		})
+
+		When("global coordination is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.LockOptions.UseGlobalCoordination = pointer.Bool(false)
+			})
+
+			It("should not requeue", func() {
+				Expect(req).To(BeNil())
+			})
+
+			It("should not register the action", func() {
+				actions, err := lockClient.GetPendingActions(fdbv1beta2.GlobalCoordinationActionConfigureDatabase)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actions).To(BeEmpty())
+			})
+		})
+	})
+
+	When("changing the database configuration of a reconciled cluster", func() {
+		var req *requeue
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(context.TODO(), cluster)).To(Succeed())
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeTriple
+			Expect(lockClient.AddPendingActionForDataCenter(fdbv1beta2.GlobalCoordinationActionConfigureDatabase, "remote", "triple ssd")).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			req = updateDatabaseConfiguration{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+		})
+
+		It("should configure the database and clear the registered values", func() {
+			Expect(req).To(BeNil())
+			adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adminClient.DatabaseConfiguration.RedundancyMode).To(Equal(fdbv1beta2.RedundancyModeTriple))
+
+			actions, err := lockClient.GetPendingActions(fdbv1beta2.GlobalCoordinationActionConfigureDatabase)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actions).To(BeEmpty())
+		})
+	})
+})
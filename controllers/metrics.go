@@ -22,8 +22,10 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -100,6 +102,212 @@ var (
 		append(descClusterDefaultLabels, "process_class"),
 		nil,
 	)
+
+	descStorageWiggleFinishedRounds = prometheus.NewDesc(
+		"fdb_operator_storage_wiggle_finished_rounds_total",
+		"the count of perpetual storage wiggle rounds in which all storage servers were wiggled.",
+		descClusterDefaultLabels,
+		nil,
+	)
+
+	descStorageWiggleFinishedWiggles = prometheus.NewDesc(
+		"fdb_operator_storage_wiggle_finished_wiggles_total",
+		"the count of storage servers that were wiggled by the perpetual storage wiggle.",
+		descClusterDefaultLabels,
+		nil,
+	)
+
+	descStorageWiggleLastRoundFinishTime = prometheus.NewDesc(
+		"fdb_operator_storage_wiggle_last_round_finish_time",
+		"the time in unix timestamp when the last perpetual storage wiggle round was finished.",
+		descClusterDefaultLabels,
+		nil,
+	)
+
+	descStorageWiggleRoundDuration = prometheus.NewDesc(
+		"fdb_operator_storage_wiggle_round_duration_seconds",
+		"the smoothed duration of a perpetual storage wiggle round in seconds.",
+		descClusterDefaultLabels,
+		nil,
+	)
+
+	descStorageWiggleWiggledProcesses = prometheus.NewDesc(
+		"fdb_operator_storage_wiggle_wiggled_processes_total",
+		"the count of storage servers that are currently wiggled.",
+		descClusterDefaultLabels,
+		nil,
+	)
+)
+
+var (
+	descBackupDefaultLabels = []string{"namespace", "name"}
+
+	descBackupStatus = prometheus.NewDesc(
+		"fdb_operator_backup_status",
+		"status of the Fdb Backup.",
+		append(descBackupDefaultLabels, "status_type"),
+		nil,
+	)
+
+	descBackupReconciled = prometheus.NewDesc(
+		"fdb_operator_backup_reconciled_status",
+		"status if the Fdb Backup is reconciled.",
+		descBackupDefaultLabels,
+		nil,
+	)
+
+	descBackupAgents = prometheus.NewDesc(
+		"fdb_operator_backup_agents_total",
+		"the count of ready backup agents.",
+		descBackupDefaultLabels,
+		nil,
+	)
+
+	descBackupDesiredAgents = prometheus.NewDesc(
+		"fdb_operator_backup_desired_agents_total",
+		"the count of the desired backup agents.",
+		descBackupDefaultLabels,
+		nil,
+	)
+
+	descBackupDestinationRunning = prometheus.NewDesc(
+		"fdb_operator_backup_destination_running_status",
+		"status if the backup to the destination is running.",
+		append(descBackupDefaultLabels, "destination"),
+		nil,
+	)
+
+	descBackupRestorableVersion = prometheus.NewDesc(
+		"fdb_operator_backup_restorable_version",
+		"the latest version to which the backup can be restored.",
+		append(descBackupDefaultLabels, "destination"),
+		nil,
+	)
+
+	descBackupRestorableTime = prometheus.NewDesc(
+		"fdb_operator_backup_restorable_time",
+		"the latest time in unix timestamp to which the backup can be restored.",
+		append(descBackupDefaultLabels, "destination"),
+		nil,
+	)
+
+	descBackupRestorableSnapshots = prometheus.NewDesc(
+		"fdb_operator_backup_restorable_snapshots_total",
+		"the count of restorable snapshots in the backup.",
+		append(descBackupDefaultLabels, "destination"),
+		nil,
+	)
+
+	descBackupLatestSnapshotAge = prometheus.NewDesc(
+		"fdb_operator_backup_latest_snapshot_age_seconds",
+		"the age of the latest restorable snapshot in seconds.",
+		append(descBackupDefaultLabels, "destination"),
+		nil,
+	)
+
+	descBackupLag = prometheus.NewDesc(
+		"fdb_operator_backup_lag_seconds",
+		"the number of seconds the latest restorable point of the backup is behind the cluster.",
+		append(descBackupDefaultLabels, "destination"),
+		nil,
+	)
+
+	descBackupRestoreValidationSucceeded = prometheus.NewDesc(
+		"fdb_operator_backup_restore_validation_succeeded_status",
+		"whether the last restore validation of the backup succeeded.",
+		descBackupDefaultLabels,
+		nil,
+	)
+
+	descBackupRestoreValidationTime = prometheus.NewDesc(
+		"fdb_operator_backup_restore_validation_time",
+		"the time in unix timestamp when the last restore validation of the backup was finished.",
+		descBackupDefaultLabels,
+		nil,
+	)
+)
+
+var (
+	descRestoreDefaultLabels = []string{"namespace", "name"}
+
+	descRestorePhase = prometheus.NewDesc(
+		"fdb_operator_restore_phase",
+		"the current phase of the Fdb Restore.",
+		append(descRestoreDefaultLabels, "phase"),
+		nil,
+	)
+
+	descRestoreRunning = prometheus.NewDesc(
+		"fdb_operator_restore_running_status",
+		"status if the Fdb Restore is running.",
+		descRestoreDefaultLabels,
+		nil,
+	)
+
+	descRestoreBlocksCompleted = prometheus.NewDesc(
+		"fdb_operator_restore_blocks_completed_total",
+		"the count of restored blocks.",
+		descRestoreDefaultLabels,
+		nil,
+	)
+
+	descRestoreBlocks = prometheus.NewDesc(
+		"fdb_operator_restore_blocks_total",
+		"the count of blocks that must be restored.",
+		descRestoreDefaultLabels,
+		nil,
+	)
+
+	descRestoreBytesWritten = prometheus.NewDesc(
+		"fdb_operator_restore_bytes_written_total",
+		"the count of bytes that were written by the restore.",
+		descRestoreDefaultLabels,
+		nil,
+	)
+
+	descRestoreApplyVersionLag = prometheus.NewDesc(
+		"fdb_operator_restore_apply_version_lag",
+		"the lag between the restored and the applied versions.",
+		descRestoreDefaultLabels,
+		nil,
+	)
+
+	descDisasterRecoveryDefaultLabels = []string{"namespace", "name"}
+
+	descDisasterRecoveryRunning = prometheus.NewDesc(
+		"fdb_operator_disaster_recovery_running_status",
+		"status if the Fdb DR is running.",
+		append(descDisasterRecoveryDefaultLabels, "source", "destination"),
+		nil,
+	)
+
+	descDisasterRecoveryRestorable = prometheus.NewDesc(
+		"fdb_operator_disaster_recovery_restorable_status",
+		"status if the destination of the Fdb DR is a complete copy of the source.",
+		append(descDisasterRecoveryDefaultLabels, "source", "destination"),
+		nil,
+	)
+
+	descDisasterRecoveryLag = prometheus.NewDesc(
+		"fdb_operator_disaster_recovery_lag_seconds",
+		"the seconds the destination of the Fdb DR is behind the source.",
+		append(descDisasterRecoveryDefaultLabels, "source", "destination"),
+		nil,
+	)
+
+	descDisasterRecoveryReconciled = prometheus.NewDesc(
+		"fdb_operator_disaster_recovery_reconciled_status",
+		"status if the Fdb DR is reconciled.",
+		descDisasterRecoveryDefaultLabels,
+		nil,
+	)
+
+	descDisasterRecoveryAgents = prometheus.NewDesc(
+		"fdb_operator_disaster_recovery_agents_total",
+		"the count of DR agents that are up-to-date and ready.",
+		descDisasterRecoveryDefaultLabels,
+		nil,
+	)
 )
 
 type fdbClusterCollector struct {
@@ -118,8 +326,7 @@ func (c *fdbClusterCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect implements the prometheus.Collector interface
 func (c *fdbClusterCollector) Collect(ch chan<- prometheus.Metric) {
-	clusters := &fdbv1beta2.FoundationDBClusterList{}
-	err := c.reconciler.List(context.Background(), clusters)
+	clusters, err := internal.ListClusters(context.Background(), c.reconciler)
 	if err != nil {
 		return
 	}
@@ -147,6 +354,14 @@ func collectMetrics(ch chan<- prometheus.Metric, cluster *fdbv1beta2.FoundationD
 	addGauge(descProcessGroupsToRemove, float64(len(cluster.Spec.ProcessGroupsToRemove)))
 	addGauge(descProcessGroupsToRemoveWithoutExclusion, float64(len(cluster.Spec.ProcessGroupsToRemoveWithoutExclusion)))
 
+	if cluster.Status.StorageWiggle != nil {
+		addGauge(descStorageWiggleFinishedRounds, float64(cluster.Status.StorageWiggle.FinishedRounds))
+		addGauge(descStorageWiggleFinishedWiggles, float64(cluster.Status.StorageWiggle.FinishedWiggles))
+		addGauge(descStorageWiggleLastRoundFinishTime, float64(cluster.Status.StorageWiggle.LastRoundFinishTimestamp))
+		addGauge(descStorageWiggleRoundDuration, float64(cluster.Status.StorageWiggle.SmoothedRoundSeconds))
+		addGauge(descStorageWiggleWiggledProcesses, float64(len(cluster.Status.StorageWiggle.WiggledAddresses)))
+	}
+
 	// Calculate the process group metrics
 	conditionMap, removals, exclusions := getProcessGroupMetrics(cluster)
 
@@ -214,6 +429,200 @@ func getProcessGroupMetrics(cluster *fdbv1beta2.FoundationDBCluster) (map[fdbv1b
 	return metricMap, removals, exclusions
 }
 
+type fdbBackupCollector struct {
+	reconciler *FoundationDBBackupReconciler
+}
+
+func newFDBBackupCollector(reconciler *FoundationDBBackupReconciler) *fdbBackupCollector {
+	return &fdbBackupCollector{reconciler: reconciler}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *fdbBackupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descBackupStatus
+	ch <- descBackupReconciled
+	ch <- descBackupAgents
+	ch <- descBackupDesiredAgents
+	ch <- descBackupDestinationRunning
+	ch <- descBackupRestorableVersion
+	ch <- descBackupRestorableTime
+	ch <- descBackupRestorableSnapshots
+	ch <- descBackupLatestSnapshotAge
+	ch <- descBackupLag
+	ch <- descBackupRestoreValidationSucceeded
+	ch <- descBackupRestoreValidationTime
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *fdbBackupCollector) Collect(ch chan<- prometheus.Metric) {
+	backups := &fdbv1beta2.FoundationDBBackupList{}
+	err := c.reconciler.List(context.Background(), backups)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for _, backup := range backups.Items {
+		collectBackupMetrics(ch, &backup, now)
+	}
+}
+
+func collectBackupMetrics(ch chan<- prometheus.Metric, backup *fdbv1beta2.FoundationDBBackup, now time.Time) {
+	addGauge := func(desc *prometheus.Desc, v float64, lv ...string) {
+		lv = append([]string{backup.Namespace, backup.Name}, lv...)
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, lv...)
+	}
+
+	var running, paused bool
+	if backup.Status.BackupDetails != nil {
+		running = backup.Status.BackupDetails.Running
+		paused = backup.Status.BackupDetails.Paused
+	}
+
+	addGauge(descBackupStatus, boolFloat64(running), "running")
+	addGauge(descBackupStatus, boolFloat64(paused), "paused")
+	addGauge(descBackupStatus, boolFloat64(backup.Status.DeploymentConfigured), "deployment_configured")
+	addGauge(descBackupReconciled, boolFloat64(backup.ObjectMeta.Generation == backup.Status.Generations.Reconciled))
+	addGauge(descBackupAgents, float64(backup.Status.AgentCount))
+	addGauge(descBackupDesiredAgents, float64(backup.GetDesiredAgentCount()))
+
+	for _, destination := range backup.Status.Destinations {
+		addGauge(descBackupDestinationRunning, boolFloat64(destination.BackupDetails.Running), destination.Name)
+
+		if destination.Progress != nil {
+			addGauge(descBackupLag, float64(destination.Progress.LagSeconds), destination.Name)
+		}
+
+		restorableRange := destination.RestorableRange
+		if restorableRange == nil {
+			continue
+		}
+
+		addGauge(descBackupRestorableVersion, float64(restorableRange.MaxVersion), destination.Name)
+		addGauge(descBackupRestorableSnapshots, float64(restorableRange.Snapshots), destination.Name)
+
+		if restorableRange.MaxTimestamp != nil {
+			addGauge(descBackupRestorableTime, float64(restorableRange.MaxTimestamp.Unix()), destination.Name)
+		}
+
+		if restorableRange.LatestSnapshotTimestamp != nil {
+			addGauge(descBackupLatestSnapshotAge, now.Sub(restorableRange.LatestSnapshotTimestamp.Time).Seconds(), destination.Name)
+		}
+	}
+
+	if backup.Status.RestoreValidation == nil || backup.Status.RestoreValidation.LastResult == nil {
+		return
+	}
+
+	lastResult := backup.Status.RestoreValidation.LastResult
+	addGauge(descBackupRestoreValidationSucceeded, boolFloat64(lastResult.Succeeded))
+	if lastResult.FinishTimestamp != nil {
+		addGauge(descBackupRestoreValidationTime, float64(lastResult.FinishTimestamp.Unix()))
+	}
+}
+
+type fdbRestoreCollector struct {
+	reconciler *FoundationDBRestoreReconciler
+}
+
+func newFDBRestoreCollector(reconciler *FoundationDBRestoreReconciler) *fdbRestoreCollector {
+	return &fdbRestoreCollector{reconciler: reconciler}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *fdbRestoreCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descRestorePhase
+	ch <- descRestoreRunning
+	ch <- descRestoreBlocksCompleted
+	ch <- descRestoreBlocks
+	ch <- descRestoreBytesWritten
+	ch <- descRestoreApplyVersionLag
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *fdbRestoreCollector) Collect(ch chan<- prometheus.Metric) {
+	restores := &fdbv1beta2.FoundationDBRestoreList{}
+	err := c.reconciler.List(context.Background(), restores)
+	if err != nil {
+		return
+	}
+
+	for _, restore := range restores.Items {
+		collectRestoreMetrics(ch, &restore)
+	}
+}
+
+func collectRestoreMetrics(ch chan<- prometheus.Metric, restore *fdbv1beta2.FoundationDBRestore) {
+	addGauge := func(desc *prometheus.Desc, v float64, lv ...string) {
+		lv = append([]string{restore.Namespace, restore.Name}, lv...)
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, lv...)
+	}
+
+	addGauge(descRestoreRunning, boolFloat64(restore.Status.Running))
+	if restore.Status.Phase != "" {
+		addGauge(descRestorePhase, 1, string(restore.Status.Phase))
+	}
+
+	progress := restore.Status.Progress
+	if progress == nil {
+		return
+	}
+
+	addGauge(descRestoreBlocksCompleted, float64(progress.BlocksCompleted))
+	addGauge(descRestoreBlocks, float64(progress.BlocksTotal))
+	addGauge(descRestoreBytesWritten, float64(progress.BytesWritten))
+	addGauge(descRestoreApplyVersionLag, float64(progress.ApplyVersionLag))
+}
+
+type fdbDisasterRecoveryCollector struct {
+	reconciler *FoundationDBDisasterRecoveryReconciler
+}
+
+func newFDBDisasterRecoveryCollector(reconciler *FoundationDBDisasterRecoveryReconciler) *fdbDisasterRecoveryCollector {
+	return &fdbDisasterRecoveryCollector{reconciler: reconciler}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *fdbDisasterRecoveryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descDisasterRecoveryRunning
+	ch <- descDisasterRecoveryRestorable
+	ch <- descDisasterRecoveryLag
+	ch <- descDisasterRecoveryReconciled
+	ch <- descDisasterRecoveryAgents
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *fdbDisasterRecoveryCollector) Collect(ch chan<- prometheus.Metric) {
+	disasterRecoveries := &fdbv1beta2.FoundationDBDisasterRecoveryList{}
+	err := c.reconciler.List(context.Background(), disasterRecoveries)
+	if err != nil {
+		return
+	}
+
+	for _, disasterRecovery := range disasterRecoveries.Items {
+		collectDisasterRecoveryMetrics(ch, &disasterRecovery)
+	}
+}
+
+func collectDisasterRecoveryMetrics(ch chan<- prometheus.Metric, disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) {
+	addGauge := func(desc *prometheus.Desc, v float64, lv ...string) {
+		lv = append([]string{disasterRecovery.Namespace, disasterRecovery.Name}, lv...)
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, lv...)
+	}
+
+	addGauge(descDisasterRecoveryReconciled, boolFloat64(disasterRecovery.Status.Generations.Reconciled == disasterRecovery.ObjectMeta.Generation))
+	addGauge(descDisasterRecoveryAgents, float64(disasterRecovery.Status.AgentCount))
+
+	details := disasterRecovery.Status.DRDetails
+	if details == nil {
+		return
+	}
+
+	addGauge(descDisasterRecoveryRunning, boolFloat64(details.Running), details.SourceClusterName, details.DestinationClusterName)
+	addGauge(descDisasterRecoveryRestorable, boolFloat64(details.Restorable), details.SourceClusterName, details.DestinationClusterName)
+	addGauge(descDisasterRecoveryLag, float64(details.SecondsBehind), details.SourceClusterName, details.DestinationClusterName)
+}
+
 // InitCustomMetrics initializes the metrics collectors for the operator.
 func InitCustomMetrics(reconciler *FoundationDBClusterReconciler) {
 	metrics.Registry.MustRegister(
@@ -221,6 +630,27 @@ func InitCustomMetrics(reconciler *FoundationDBClusterReconciler) {
 	)
 }
 
+// InitBackupMetrics initializes the metrics collectors for backups.
+func InitBackupMetrics(reconciler *FoundationDBBackupReconciler) {
+	metrics.Registry.MustRegister(
+		newFDBBackupCollector(reconciler),
+	)
+}
+
+// InitRestoreMetrics initializes the metrics collectors for restores.
+func InitRestoreMetrics(reconciler *FoundationDBRestoreReconciler) {
+	metrics.Registry.MustRegister(
+		newFDBRestoreCollector(reconciler),
+	)
+}
+
+// InitDisasterRecoveryMetrics initializes the metrics collectors for disaster recoveries.
+func InitDisasterRecoveryMetrics(reconciler *FoundationDBDisasterRecoveryReconciler) {
+	metrics.Registry.MustRegister(
+		newFDBDisasterRecoveryCollector(reconciler),
+	)
+}
+
 func boolFloat64(b bool) float64 {
 	if b {
 		return 1
@@ -21,14 +21,36 @@
 package controllers
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
+// testCollector allows to test the metrics of a single resource.
+type testCollector struct {
+	collect func(ch chan<- prometheus.Metric)
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *testCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *testCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collect(ch)
+}
+
 var _ = Describe("metrics", func() {
 	var cluster *fdbv1beta2.FoundationDBCluster
 
@@ -75,4 +97,325 @@ var _ = Describe("metrics", func() {
 			Expect(exclusions[fdbv1beta2.ProcessClassStateless]).To(BeNumerically("==", 1))
 		})
 	})
+
+	Context("Collecting the storage wiggle metrics", func() {
+		BeforeEach(func() {
+			cluster.Namespace = "test"
+			cluster.Name = "cluster"
+			cluster.Status.StorageWiggle = &fdbv1beta2.StorageWiggleStatus{
+				FinishedRounds:           3,
+				FinishedWiggles:          42,
+				LastRoundFinishTimestamp: 1684569600,
+				SmoothedRoundSeconds:     7200,
+				WiggledAddresses:         []string{"192.168.0.1:4501"},
+			}
+		})
+
+		It("generates the storage wiggle metrics", func() {
+			expected := `
+# HELP fdb_operator_storage_wiggle_finished_rounds_total the count of perpetual storage wiggle rounds in which all storage servers were wiggled.
+# TYPE fdb_operator_storage_wiggle_finished_rounds_total gauge
+fdb_operator_storage_wiggle_finished_rounds_total{name="cluster",namespace="test"} 3
+# HELP fdb_operator_storage_wiggle_finished_wiggles_total the count of storage servers that were wiggled by the perpetual storage wiggle.
+# TYPE fdb_operator_storage_wiggle_finished_wiggles_total gauge
+fdb_operator_storage_wiggle_finished_wiggles_total{name="cluster",namespace="test"} 42
+# HELP fdb_operator_storage_wiggle_last_round_finish_time the time in unix timestamp when the last perpetual storage wiggle round was finished.
+# TYPE fdb_operator_storage_wiggle_last_round_finish_time gauge
+fdb_operator_storage_wiggle_last_round_finish_time{name="cluster",namespace="test"} 1.6845696e+09
+# HELP fdb_operator_storage_wiggle_round_duration_seconds the smoothed duration of a perpetual storage wiggle round in seconds.
+# TYPE fdb_operator_storage_wiggle_round_duration_seconds gauge
+fdb_operator_storage_wiggle_round_duration_seconds{name="cluster",namespace="test"} 7200
+# HELP fdb_operator_storage_wiggle_wiggled_processes_total the count of storage servers that are currently wiggled.
+# TYPE fdb_operator_storage_wiggle_wiggled_processes_total gauge
+fdb_operator_storage_wiggle_wiggled_processes_total{name="cluster",namespace="test"} 1
+`
+			collector := &testCollector{collect: func(ch chan<- prometheus.Metric) {
+				collectMetrics(ch, cluster)
+			}}
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected),
+				"fdb_operator_storage_wiggle_finished_rounds_total",
+				"fdb_operator_storage_wiggle_finished_wiggles_total",
+				"fdb_operator_storage_wiggle_last_round_finish_time",
+				"fdb_operator_storage_wiggle_round_duration_seconds",
+				"fdb_operator_storage_wiggle_wiggled_processes_total",
+			)).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Collecting the backup metrics", func() {
+		var backup *fdbv1beta2.FoundationDBBackup
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Unix(1684570000, 0)
+			backup = &fdbv1beta2.FoundationDBBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  "test",
+					Name:       "backup",
+					Generation: 2,
+				},
+				Spec: fdbv1beta2.FoundationDBBackupSpec{
+					AgentCount: pointer.Int(3),
+				},
+				Status: fdbv1beta2.FoundationDBBackupStatus{
+					AgentCount:           2,
+					DeploymentConfigured: true,
+					BackupDetails: &fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+						Running: true,
+					},
+					Generations: fdbv1beta2.BackupGenerationStatus{
+						Reconciled: 1,
+					},
+					Destinations: []fdbv1beta2.BackupDestinationStatus{
+						{
+							Name: "default",
+							BackupDetails: fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+								Running: true,
+							},
+							Progress: &fdbv1beta2.BackupProgress{
+								LagSeconds: 30,
+							},
+							RestorableRange: &fdbv1beta2.BackupRestorableRange{
+								MaxVersion:              1000,
+								MaxTimestamp:            &metav1.Time{Time: time.Unix(1684569600, 0)},
+								Snapshots:               2,
+								LatestSnapshotTimestamp: &metav1.Time{Time: time.Unix(1684566400, 0)},
+							},
+						},
+						{
+							Name: "local",
+						},
+					},
+				},
+			}
+		})
+
+		It("generates the backup metrics", func() {
+			expected := `
+# HELP fdb_operator_backup_agents_total the count of ready backup agents.
+# TYPE fdb_operator_backup_agents_total gauge
+fdb_operator_backup_agents_total{name="backup",namespace="test"} 2
+# HELP fdb_operator_backup_desired_agents_total the count of the desired backup agents.
+# TYPE fdb_operator_backup_desired_agents_total gauge
+fdb_operator_backup_desired_agents_total{name="backup",namespace="test"} 3
+# HELP fdb_operator_backup_destination_running_status status if the backup to the destination is running.
+# TYPE fdb_operator_backup_destination_running_status gauge
+fdb_operator_backup_destination_running_status{destination="default",name="backup",namespace="test"} 1
+fdb_operator_backup_destination_running_status{destination="local",name="backup",namespace="test"} 0
+# HELP fdb_operator_backup_lag_seconds the number of seconds the latest restorable point of the backup is behind the cluster.
+# TYPE fdb_operator_backup_lag_seconds gauge
+fdb_operator_backup_lag_seconds{destination="default",name="backup",namespace="test"} 30
+# HELP fdb_operator_backup_latest_snapshot_age_seconds the age of the latest restorable snapshot in seconds.
+# TYPE fdb_operator_backup_latest_snapshot_age_seconds gauge
+fdb_operator_backup_latest_snapshot_age_seconds{destination="default",name="backup",namespace="test"} 3600
+# HELP fdb_operator_backup_reconciled_status status if the Fdb Backup is reconciled.
+# TYPE fdb_operator_backup_reconciled_status gauge
+fdb_operator_backup_reconciled_status{name="backup",namespace="test"} 0
+# HELP fdb_operator_backup_restorable_snapshots_total the count of restorable snapshots in the backup.
+# TYPE fdb_operator_backup_restorable_snapshots_total gauge
+fdb_operator_backup_restorable_snapshots_total{destination="default",name="backup",namespace="test"} 2
+# HELP fdb_operator_backup_restorable_time the latest time in unix timestamp to which the backup can be restored.
+# TYPE fdb_operator_backup_restorable_time gauge
+fdb_operator_backup_restorable_time{destination="default",name="backup",namespace="test"} 1.6845696e+09
+# HELP fdb_operator_backup_restorable_version the latest version to which the backup can be restored.
+# TYPE fdb_operator_backup_restorable_version gauge
+fdb_operator_backup_restorable_version{destination="default",name="backup",namespace="test"} 1000
+# HELP fdb_operator_backup_status status of the Fdb Backup.
+# TYPE fdb_operator_backup_status gauge
+fdb_operator_backup_status{name="backup",namespace="test",status_type="deployment_configured"} 1
+fdb_operator_backup_status{name="backup",namespace="test",status_type="paused"} 0
+fdb_operator_backup_status{name="backup",namespace="test",status_type="running"} 1
+`
+			collector := &testCollector{collect: func(ch chan<- prometheus.Metric) {
+				collectBackupMetrics(ch, backup, now)
+			}}
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected))).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("generating the metrics from the description of the backup",
+			func(fileName string, expected string) {
+				// The descriptions are the output of fdbbackup describe that is used by the fdbclient tests.
+				content, err := os.ReadFile(filepath.Join("..", "fdbclient", "testdata", fileName))
+				Expect(err).NotTo(HaveOccurred())
+
+				description := &fdbv1beta2.FoundationDBBackupDescription{}
+				Expect(json.Unmarshal(content, description)).To(Succeed())
+				backup.Status.Destinations[0].RestorableRange = getBackupRestorableRange(description)
+
+				collector := &testCollector{collect: func(ch chan<- prometheus.Metric) {
+					collectBackupMetrics(ch, backup, now)
+				}}
+				Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected),
+					"fdb_operator_backup_restorable_version",
+					"fdb_operator_backup_restorable_time",
+					"fdb_operator_backup_restorable_snapshots_total",
+					"fdb_operator_backup_latest_snapshot_age_seconds",
+				)).NotTo(HaveOccurred())
+			},
+			Entry("with version timestamps",
+				"fdbbackup_describe_version_timestamps.json",
+				`
+# HELP fdb_operator_backup_latest_snapshot_age_seconds the age of the latest restorable snapshot in seconds.
+# TYPE fdb_operator_backup_latest_snapshot_age_seconds gauge
+fdb_operator_backup_latest_snapshot_age_seconds{destination="default",name="backup",namespace="test"} 172600
+# HELP fdb_operator_backup_restorable_snapshots_total the count of restorable snapshots in the backup.
+# TYPE fdb_operator_backup_restorable_snapshots_total gauge
+fdb_operator_backup_restorable_snapshots_total{destination="default",name="backup",namespace="test"} 2
+# HELP fdb_operator_backup_restorable_time the latest time in unix timestamp to which the backup can be restored.
+# TYPE fdb_operator_backup_restorable_time gauge
+fdb_operator_backup_restorable_time{destination="default",name="backup",namespace="test"} 1.6843998e+09
+# HELP fdb_operator_backup_restorable_version the latest version to which the backup can be restored.
+# TYPE fdb_operator_backup_restorable_version gauge
+fdb_operator_backup_restorable_version{destination="default",name="backup",namespace="test"} 1.9e+11
+`,
+			),
+			Entry("without version timestamps",
+				"fdbbackup_describe.json",
+				`
+# HELP fdb_operator_backup_restorable_snapshots_total the count of restorable snapshots in the backup.
+# TYPE fdb_operator_backup_restorable_snapshots_total gauge
+fdb_operator_backup_restorable_snapshots_total{destination="default",name="backup",namespace="test"} 2
+# HELP fdb_operator_backup_restorable_version the latest version to which the backup can be restored.
+# TYPE fdb_operator_backup_restorable_version gauge
+fdb_operator_backup_restorable_version{destination="default",name="backup",namespace="test"} 1.9e+11
+`,
+			),
+		)
+
+		It("generates the restore validation metrics", func() {
+			backup.Status.RestoreValidation = &fdbv1beta2.BackupRestoreValidationStatus{
+				LastResult: &fdbv1beta2.BackupRestoreValidationResult{
+					Succeeded:       true,
+					FinishTimestamp: &metav1.Time{Time: time.Unix(1684569600, 0)},
+				},
+			}
+
+			expected := `
+# HELP fdb_operator_backup_restore_validation_succeeded_status whether the last restore validation of the backup succeeded.
+# TYPE fdb_operator_backup_restore_validation_succeeded_status gauge
+fdb_operator_backup_restore_validation_succeeded_status{name="backup",namespace="test"} 1
+# HELP fdb_operator_backup_restore_validation_time the time in unix timestamp when the last restore validation of the backup was finished.
+# TYPE fdb_operator_backup_restore_validation_time gauge
+fdb_operator_backup_restore_validation_time{name="backup",namespace="test"} 1.6845696e+09
+`
+			collector := &testCollector{collect: func(ch chan<- prometheus.Metric) {
+				collectBackupMetrics(ch, backup, now)
+			}}
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected), "fdb_operator_backup_restore_validation_succeeded_status", "fdb_operator_backup_restore_validation_time")).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Collecting the restore metrics", func() {
+		var restore *fdbv1beta2.FoundationDBRestore
+
+		BeforeEach(func() {
+			restore = &fdbv1beta2.FoundationDBRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "restore",
+				},
+				Status: fdbv1beta2.FoundationDBRestoreStatus{
+					Running: true,
+					Phase:   fdbv1beta2.RestorePhaseRunning,
+					Progress: &fdbv1beta2.FoundationDBRestoreProgress{
+						BlocksCompleted: 42,
+						BlocksTotal:     100,
+						BytesWritten:    1048576,
+						ApplyVersionLag: 10,
+					},
+				},
+			}
+		})
+
+		It("generates the restore metrics", func() {
+			expected := `
+# HELP fdb_operator_restore_apply_version_lag the lag between the restored and the applied versions.
+# TYPE fdb_operator_restore_apply_version_lag gauge
+fdb_operator_restore_apply_version_lag{name="restore",namespace="test"} 10
+# HELP fdb_operator_restore_blocks_completed_total the count of restored blocks.
+# TYPE fdb_operator_restore_blocks_completed_total gauge
+fdb_operator_restore_blocks_completed_total{name="restore",namespace="test"} 42
+# HELP fdb_operator_restore_blocks_total the count of blocks that must be restored.
+# TYPE fdb_operator_restore_blocks_total gauge
+fdb_operator_restore_blocks_total{name="restore",namespace="test"} 100
+# HELP fdb_operator_restore_bytes_written_total the count of bytes that were written by the restore.
+# TYPE fdb_operator_restore_bytes_written_total gauge
+fdb_operator_restore_bytes_written_total{name="restore",namespace="test"} 1.048576e+06
+# HELP fdb_operator_restore_phase the current phase of the Fdb Restore.
+# TYPE fdb_operator_restore_phase gauge
+fdb_operator_restore_phase{name="restore",namespace="test",phase="Running"} 1
+# HELP fdb_operator_restore_running_status status if the Fdb Restore is running.
+# TYPE fdb_operator_restore_running_status gauge
+fdb_operator_restore_running_status{name="restore",namespace="test"} 1
+`
+			collector := &testCollector{collect: func(ch chan<- prometheus.Metric) {
+				collectRestoreMetrics(ch, restore)
+			}}
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected))).NotTo(HaveOccurred())
+		})
+
+		When("the restore has no progress", func() {
+			BeforeEach(func() {
+				restore.Status = fdbv1beta2.FoundationDBRestoreStatus{}
+			})
+
+			It("only generates the running status", func() {
+				collector := &testCollector{collect: func(ch chan<- prometheus.Metric) {
+					collectRestoreMetrics(ch, restore)
+				}}
+				Expect(testutil.CollectAndCount(collector)).To(Equal(1))
+			})
+		})
+	})
+
+	Context("Collecting the disaster recovery metrics", func() {
+		var disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery
+
+		BeforeEach(func() {
+			disasterRecovery = &fdbv1beta2.FoundationDBDisasterRecovery{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  "test",
+					Name:       "dr",
+					Generation: 2,
+				},
+				Status: fdbv1beta2.FoundationDBDisasterRecoveryStatus{
+					AgentCount: 2,
+					DRDetails: &fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+						SourceClusterName:      "primary",
+						DestinationClusterName: "secondary",
+						Running:                true,
+						Restorable:             true,
+						SecondsBehind:          3,
+					},
+					Generations: fdbv1beta2.DisasterRecoveryGenerationStatus{
+						Reconciled: 2,
+					},
+				},
+			}
+		})
+
+		It("generates the disaster recovery metrics", func() {
+			expected := `
+# HELP fdb_operator_disaster_recovery_agents_total the count of DR agents that are up-to-date and ready.
+# TYPE fdb_operator_disaster_recovery_agents_total gauge
+fdb_operator_disaster_recovery_agents_total{name="dr",namespace="test"} 2
+# HELP fdb_operator_disaster_recovery_lag_seconds the seconds the destination of the Fdb DR is behind the source.
+# TYPE fdb_operator_disaster_recovery_lag_seconds gauge
+fdb_operator_disaster_recovery_lag_seconds{destination="secondary",name="dr",namespace="test",source="primary"} 3
+# HELP fdb_operator_disaster_recovery_reconciled_status status if the Fdb DR is reconciled.
+# TYPE fdb_operator_disaster_recovery_reconciled_status gauge
+fdb_operator_disaster_recovery_reconciled_status{name="dr",namespace="test"} 1
+# HELP fdb_operator_disaster_recovery_restorable_status status if the destination of the Fdb DR is a complete copy of the source.
+# TYPE fdb_operator_disaster_recovery_restorable_status gauge
+fdb_operator_disaster_recovery_restorable_status{destination="secondary",name="dr",namespace="test",source="primary"} 1
+# HELP fdb_operator_disaster_recovery_running_status status if the Fdb DR is running.
+# TYPE fdb_operator_disaster_recovery_running_status gauge
+fdb_operator_disaster_recovery_running_status{destination="secondary",name="dr",namespace="test",source="primary"} 1
+`
+			collector := &testCollector{collect: func(ch chan<- prometheus.Metric) {
+				collectDisasterRecoveryMetrics(ch, disasterRecovery)
+			}}
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected))).NotTo(HaveOccurred())
+		})
+	})
 })
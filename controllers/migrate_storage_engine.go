@@ -0,0 +1,343 @@
+/*
+ * migrate_storage_engine.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/pointer"
+)
+
+// storageEngineMigrationPollInterval defines how often the operator checks the progress of a storage engine migration.
+const storageEngineMigrationPollInterval = 1 * time.Minute
+
+// migrateStorageEngine provides a reconciliation step for migrating the storage servers to the configured storage
+// engine.
+type migrateStorageEngine struct{}
+
+// reconcile runs the reconciler's work.
+func (m migrateStorageEngine) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	if !cluster.Status.Configured {
+		return nil
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+	defer adminClient.Close()
+
+	// If the status is not cached, we have to fetch it.
+	if status == nil {
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	if !status.Client.DatabaseStatus.Available {
+		logger.Info("Skipping storage engine migration because database is unavailable")
+		return nil
+	}
+
+	migrationStatus := cluster.Status.StorageEngineMigration.DeepCopy()
+	if migrationStatus == nil {
+		migrationStatus = &fdbv1beta2.StorageEngineMigrationStatus{}
+	}
+
+	now := time.Now()
+	storageEngine := cluster.DesiredDatabaseConfiguration().StorageEngine
+	if migrationStatus.StorageEngine != storageEngine {
+		// A changed storage engine replaces the previous migration. If the previous migration is still in progress,
+		// the migration continues with the new storage engine and keeps its strategy and the recorded storage
+		// migration settings, so they are restored once the storage servers are migrated.
+		previousMigration := migrationStatus
+		migrationStatus = &fdbv1beta2.StorageEngineMigrationStatus{StorageEngine: storageEngine}
+		if previousMigration.IsInProgress() {
+			migrationStatus.Strategy = previousMigration.Strategy
+			migrationStatus.StartTimestamp = now.Unix()
+			migrationStatus.PreviousStorageMigrationType = previousMigration.PreviousStorageMigrationType
+			migrationStatus.PreviousPerpetualStorageWiggle = previousMigration.PreviousPerpetualStorageWiggle
+		}
+	}
+
+	storageEngines := getStorageEnginesPerProcessGroup(status)
+	migrationStatus.StorageServers = getStorageServersPerEngine(status)
+	remaining := migrationStatus.GetRemainingStorageServers()
+
+	var req *requeue
+	hasReplacements := false
+	if remaining == 0 {
+		if migrationStatus.IsInProgress() {
+			req = finishStorageEngineMigration(logger, r, cluster, adminClient, migrationStatus)
+			if req != nil {
+				return req
+			}
+
+			migrationStatus.CompletionTimestamp = now.Unix()
+			migrationStatus.EstimatedCompletionTimestamp = 0
+			logger.Info("Storage engine migration completed", "storageEngine", storageEngine)
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "StorageEngineMigrationCompleted", fmt.Sprintf("Migrated all storage servers to %s", storageEngine))
+		}
+	} else if status.Cluster.DatabaseConfiguration.StorageEngine.GetStorageServerStorageEngine() != storageEngine.GetStorageServerStorageEngine() {
+		// The storage servers can only be migrated once the new storage engine is configured, otherwise the new
+		// storage servers would use the old storage engine.
+		req = &requeue{message: "Waiting for the storage engine to be configured", delayedRequeue: true, delay: storageEngineMigrationPollInterval}
+	} else {
+		if !migrationStatus.IsInProgress() {
+			req = startStorageEngineMigration(logger, r, cluster, adminClient, status, migrationStatus, remaining, now)
+			if req != nil {
+				return req
+			}
+
+			logger.Info("Starting storage engine migration", "storageEngine", storageEngine, "strategy", migrationStatus.Strategy, "storageServers", remaining)
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "StorageEngineMigrationStarted", fmt.Sprintf("Migrating %d storage servers to %s with the %s strategy", remaining, storageEngine, migrationStatus.Strategy))
+		}
+
+		// If storage servers were added during the migration the progress is based on the new count.
+		if remaining > migrationStatus.StorageServersToMigrate {
+			migrationStatus.StorageServersToMigrate = remaining
+		}
+		migrationStatus.EstimatedCompletionTimestamp = getStorageEngineMigrationEstimate(migrationStatus, remaining, now)
+
+		hasReplacements = replaceProcessGroupsForStorageEngine(logger, cluster, storageEngines, storageEngine, getConcurrentStorageEngineReplacements(cluster, migrationStatus))
+
+		req = &requeue{message: "Storage engine migration in progress", delayedRequeue: true, delay: storageEngineMigrationPollInterval}
+	}
+
+	if hasReplacements || !equality.Semantic.DeepEqual(cluster.Status.StorageEngineMigration, migrationStatus) {
+		cluster.Status.StorageEngineMigration = migrationStatus
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	return req
+}
+
+// startStorageEngineMigration records the start of the migration and enables the gradual storage migration for the
+// InPlace strategy. The previous storage migration settings of the database are recorded in the migration status, so
+// they can be restored once the migration is completed. If the storage migration settings are defined in the database
+// configuration of the cluster spec, the operator leaves them to the UpdateDatabaseConfiguration subreconciler.
+func startStorageEngineMigration(logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, adminClient fdbadminclient.AdminClient, status *fdbv1beta2.FoundationDBStatus, migrationStatus *fdbv1beta2.StorageEngineMigrationStatus, remaining int, now time.Time) *requeue {
+	migrationStatus.Strategy = cluster.GetStorageEngineMigrationStrategy()
+	migrationStatus.StartTimestamp = now.Unix()
+	migrationStatus.CompletionTimestamp = 0
+	migrationStatus.StorageServersToMigrate = remaining
+
+	// Versions before 7.0 always replace the storage servers with a different storage engine directly.
+	if !managesStorageMigrationForStrategy(cluster, migrationStatus) {
+		return nil
+	}
+
+	migrationStatus.PreviousStorageMigrationType = status.Cluster.DatabaseConfiguration.StorageMigrationType
+	migrationStatus.PreviousPerpetualStorageWiggle = status.Cluster.DatabaseConfiguration.PerpetualStorageWiggle
+
+	return configureStorageMigration(logger, r, cluster, adminClient, fdbv1beta2.StorageMigrationTypeGradual, 1)
+}
+
+// finishStorageEngineMigration restores the storage migration settings of the database from before the InPlace
+// strategy enabled the gradual storage migration.
+func finishStorageEngineMigration(logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, adminClient fdbadminclient.AdminClient, migrationStatus *fdbv1beta2.StorageEngineMigrationStatus) *requeue {
+	if !managesStorageMigrationForStrategy(cluster, migrationStatus) {
+		return nil
+	}
+
+	migrationType := fdbv1beta2.StorageMigrationTypeDisabled
+	if migrationStatus.PreviousStorageMigrationType != nil {
+		migrationType = *migrationStatus.PreviousStorageMigrationType
+	}
+
+	return configureStorageMigration(logger, r, cluster, adminClient, migrationType, pointer.IntDeref(migrationStatus.PreviousPerpetualStorageWiggle, 0))
+}
+
+// managesStorageMigrationForStrategy returns true if the operator changes the storage migration settings of the
+// database for the strategy of the migration.
+func managesStorageMigrationForStrategy(cluster *fdbv1beta2.FoundationDBCluster, migrationStatus *fdbv1beta2.StorageEngineMigrationStatus) bool {
+	return migrationStatus.Strategy == fdbv1beta2.StorageEngineMigrationStrategyInPlace && supportsStorageMigrationConfiguration(cluster) && !cluster.ManagesStorageMigration()
+}
+
+// getConcurrentStorageEngineReplacements returns how many process groups can be replaced at the same time for the
+// migration. With the InPlace strategy the perpetual storage wiggle migrates one storage server at a time, which
+// counts against MaxConcurrentMigrations. Versions before 7.0 migrate the storage servers in FoundationDB itself.
+func getConcurrentStorageEngineReplacements(cluster *fdbv1beta2.FoundationDBCluster, migrationStatus *fdbv1beta2.StorageEngineMigrationStatus) int {
+	maxConcurrentMigrations := cluster.GetMaxConcurrentStorageEngineMigrations()
+	if migrationStatus.Strategy != fdbv1beta2.StorageEngineMigrationStrategyInPlace {
+		return maxConcurrentMigrations
+	}
+
+	if !supportsStorageMigrationConfiguration(cluster) {
+		return 0
+	}
+
+	return maxConcurrentMigrations - 1
+}
+
+// configureStorageMigration changes the storage migration type and the perpetual storage wiggle of the database.
+func configureStorageMigration(logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, adminClient fdbadminclient.AdminClient, migrationType fdbv1beta2.StorageMigrationType, perpetualStorageWiggle int) *requeue {
+	hasLock, err := r.takeLock(logger, cluster, fmt.Sprintf("setting the storage migration type to %s", migrationType))
+	if !hasLock {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	logger.Info("Configuring storage migration", "storageMigrationType", migrationType, "perpetualStorageWiggle", perpetualStorageWiggle)
+	err = adminClient.ConfigureStorageMigration(migrationType, perpetualStorageWiggle)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// supportsStorageMigrationConfiguration returns true if the running version of the cluster supports the storage
+// migration type and the perpetual storage wiggle.
+func supportsStorageMigrationConfiguration(cluster *fdbv1beta2.FoundationDBCluster) bool {
+	version, err := fdbv1beta2.ParseFdbVersion(cluster.GetRunningVersion())
+	if err != nil {
+		return false
+	}
+
+	return version.SupportsStorageMigrationConfiguration()
+}
+
+// getStorageServersPerEngine returns the number of storage servers per storage engine in the database.
+func getStorageServersPerEngine(status *fdbv1beta2.FoundationDBStatus) map[fdbv1beta2.StorageEngine]int {
+	storageServers := map[fdbv1beta2.StorageEngine]int{}
+	for _, process := range status.Cluster.Processes {
+		for _, role := range process.Roles {
+			if role.Role != string(fdbv1beta2.ProcessRoleStorage) {
+				continue
+			}
+
+			storageEngine := role.StorageMetadata.StorageEngine
+			if storageEngine == "" {
+				storageEngine = fdbv1beta2.StorageEngineUnknown
+			}
+
+			storageServers[storageEngine]++
+		}
+	}
+
+	if len(storageServers) == 0 {
+		return nil
+	}
+
+	return storageServers
+}
+
+// getStorageEnginesPerProcessGroup returns the storage engines of the storage servers per process group.
+func getStorageEnginesPerProcessGroup(status *fdbv1beta2.FoundationDBStatus) map[fdbv1beta2.ProcessGroupID]map[fdbv1beta2.StorageEngine]fdbv1beta2.None {
+	storageEngines := map[fdbv1beta2.ProcessGroupID]map[fdbv1beta2.StorageEngine]fdbv1beta2.None{}
+	for _, process := range status.Cluster.Processes {
+		processGroupID := fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+		if processGroupID == "" {
+			continue
+		}
+
+		for _, role := range process.Roles {
+			if role.Role != string(fdbv1beta2.ProcessRoleStorage) || role.StorageMetadata.StorageEngine == "" {
+				continue
+			}
+
+			if _, ok := storageEngines[processGroupID]; !ok {
+				storageEngines[processGroupID] = map[fdbv1beta2.StorageEngine]fdbv1beta2.None{}
+			}
+
+			storageEngines[processGroupID][role.StorageMetadata.StorageEngine] = fdbv1beta2.None{}
+		}
+	}
+
+	return storageEngines
+}
+
+// replaceProcessGroupsForStorageEngine marks the process groups that run storage servers with a different storage
+// engine for removal. At most maxReplacements of those process groups will be in flight at the same time.
+func replaceProcessGroupsForStorageEngine(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, storageEngines map[fdbv1beta2.ProcessGroupID]map[fdbv1beta2.StorageEngine]fdbv1beta2.None, storageEngine fdbv1beta2.StorageEngine, maxReplacements int) bool {
+	if maxReplacements <= 0 {
+		return false
+	}
+
+	candidates := make([]*fdbv1beta2.ProcessGroupStatus, 0)
+	available := maxReplacements
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !usesDifferentStorageEngine(storageEngines[processGroup.ProcessGroupID], storageEngine) {
+			continue
+		}
+
+		if processGroup.IsMarkedForRemoval() {
+			// Process groups that are not yet excluded are still migrating their data.
+			if !processGroup.IsExcluded() {
+				available--
+			}
+
+			continue
+		}
+
+		candidates = append(candidates, processGroup)
+	}
+
+	hasReplacements := false
+	for _, processGroup := range candidates {
+		if available <= 0 {
+			break
+		}
+
+		logger.Info("Replace process group for storage engine migration", "processGroupID", processGroup.ProcessGroupID, "storageEngine", storageEngine)
+		processGroup.MarkForRemoval()
+		hasReplacements = true
+		available--
+	}
+
+	return hasReplacements
+}
+
+// usesDifferentStorageEngine returns true if any of the storage engines differs from the provided storage engine. The
+// storage engines are compared in the form the storage servers report them.
+func usesDifferentStorageEngine(storageEngines map[fdbv1beta2.StorageEngine]fdbv1beta2.None, storageEngine fdbv1beta2.StorageEngine) bool {
+	for currentStorageEngine := range storageEngines {
+		if currentStorageEngine.GetStorageServerStorageEngine() != storageEngine.GetStorageServerStorageEngine() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getStorageEngineMigrationEstimate returns the estimated completion time of the migration as unix timestamp, based
+// on the number of storage servers that were migrated since the start of the migration. If no storage server was
+// migrated yet, 0 will be returned.
+func getStorageEngineMigrationEstimate(migrationStatus *fdbv1beta2.StorageEngineMigrationStatus, remaining int, now time.Time) int64 {
+	migrated := migrationStatus.StorageServersToMigrate - remaining
+	if migrated <= 0 {
+		return 0
+	}
+
+	elapsed := now.Unix() - migrationStatus.StartTimestamp
+	return now.Unix() + elapsed*int64(remaining)/int64(migrated)
+}
@@ -0,0 +1,263 @@
+/*
+ * migrate_storage_engine_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("migrate_storage_engine", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var result *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		generation, err := reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generation).To(Equal(int64(1)))
+
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		result = migrateStorageEngine{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+	})
+
+	When("all storage servers use the configured storage engine", func() {
+		It("should report the storage servers without starting a migration", func() {
+			Expect(result).To(BeNil())
+			Expect(cluster.Status.StorageEngineMigration).NotTo(BeNil())
+			Expect(cluster.Status.StorageEngineMigration.StorageEngine).To(Equal(fdbv1beta2.StorageEngineSSD2))
+			Expect(cluster.Status.StorageEngineMigration.StorageServers).To(Equal(map[fdbv1beta2.StorageEngine]int{
+				fdbv1beta2.StorageEngineSSD2: 4,
+			}))
+			Expect(cluster.Status.StorageEngineMigration.IsInProgress()).To(BeFalse())
+			Expect(cluster.Status.StorageEngineMigration.StartTimestamp).To(BeZero())
+		})
+	})
+
+	When("the storage engine is changed", func() {
+		BeforeEach(func() {
+			cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineRedwood1Experimental
+		})
+
+		When("the new storage engine is not yet configured", func() {
+			It("should wait for the configuration", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.message).To(Equal("Waiting for the storage engine to be configured"))
+				Expect(cluster.Status.StorageEngineMigration.StorageEngine).To(Equal(fdbv1beta2.StorageEngineRedwood1Experimental))
+				Expect(cluster.Status.StorageEngineMigration.GetRemainingStorageServers()).To(Equal(4))
+				Expect(cluster.Status.StorageEngineMigration.IsInProgress()).To(BeFalse())
+			})
+		})
+
+		When("the new storage engine is configured", func() {
+			BeforeEach(func() {
+				Expect(adminClient.ConfigureDatabase(cluster.DesiredDatabaseConfiguration(), false, cluster.Spec.Version)).To(Succeed())
+			})
+
+			When("using the in-place strategy", func() {
+				BeforeEach(func() {
+					cluster.Status.RunningVersion = fdbv1beta2.Versions.NextMajorVersion.String()
+				})
+
+				It("should start the migration", func() {
+					Expect(result).NotTo(BeNil())
+					Expect(result.message).To(Equal("Storage engine migration in progress"))
+					Expect(result.delay).To(Equal(storageEngineMigrationPollInterval))
+
+					migrationStatus := cluster.Status.StorageEngineMigration
+					Expect(migrationStatus.IsInProgress()).To(BeTrue())
+					Expect(migrationStatus.Strategy).To(Equal(fdbv1beta2.StorageEngineMigrationStrategyInPlace))
+					Expect(migrationStatus.StorageServersToMigrate).To(Equal(4))
+					Expect(migrationStatus.EstimatedCompletionTimestamp).To(BeZero())
+				})
+
+				It("should enable the gradual storage migration", func() {
+					Expect(adminClient.DatabaseConfiguration.StorageMigrationType).To(HaveValue(Equal(fdbv1beta2.StorageMigrationTypeGradual)))
+					Expect(adminClient.DatabaseConfiguration.PerpetualStorageWiggle).To(HaveValue(Equal(1)))
+				})
+
+				It("should not replace any process groups", func() {
+					Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+				})
+
+				When("some storage servers are migrated", func() {
+					JustBeforeEach(func() {
+						cluster.Status.StorageEngineMigration.StartTimestamp = time.Now().Add(-1 * time.Hour).Unix()
+						adminClient.MockStorageEngine("storage-1", fdbv1beta2.StorageEngineRedwood1Experimental)
+						result = migrateStorageEngine{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+					})
+
+					It("should report the progress", func() {
+						Expect(result).NotTo(BeNil())
+						migrationStatus := cluster.Status.StorageEngineMigration
+						Expect(migrationStatus.StorageServers).To(Equal(map[fdbv1beta2.StorageEngine]int{
+							fdbv1beta2.StorageEngineSSD2:                 3,
+							fdbv1beta2.StorageEngineRedwood1Experimental: 1,
+						}))
+						Expect(migrationStatus.GetRemainingStorageServers()).To(Equal(3))
+						Expect(migrationStatus.EstimatedCompletionTimestamp).To(BeNumerically("~", time.Now().Add(3*time.Hour).Unix(), 10))
+					})
+				})
+
+				When("all storage servers are migrated", func() {
+					JustBeforeEach(func() {
+						for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "storage-3", "storage-4"} {
+							adminClient.MockStorageEngine(processGroupID, fdbv1beta2.StorageEngineRedwood1Experimental)
+						}
+						result = migrateStorageEngine{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+					})
+
+					It("should complete the migration", func() {
+						Expect(result).To(BeNil())
+						migrationStatus := cluster.Status.StorageEngineMigration
+						Expect(migrationStatus.IsInProgress()).To(BeFalse())
+						Expect(migrationStatus.CompletionTimestamp).To(BeNumerically(">", 0))
+						Expect(migrationStatus.EstimatedCompletionTimestamp).To(BeZero())
+					})
+
+					It("should disable the gradual storage migration", func() {
+						Expect(adminClient.DatabaseConfiguration.StorageMigrationType).To(HaveValue(Equal(fdbv1beta2.StorageMigrationTypeDisabled)))
+						Expect(adminClient.DatabaseConfiguration.PerpetualStorageWiggle).To(HaveValue(Equal(0)))
+					})
+
+					When("the perpetual storage wiggle was enabled before the migration", func() {
+						BeforeEach(func() {
+							Expect(adminClient.ConfigureStorageMigration(fdbv1beta2.StorageMigrationTypeDisabled, 1)).To(Succeed())
+						})
+
+						It("should restore the previous storage migration settings", func() {
+							Expect(result).To(BeNil())
+							Expect(cluster.Status.StorageEngineMigration.PreviousPerpetualStorageWiggle).To(HaveValue(Equal(1)))
+							Expect(adminClient.DatabaseConfiguration.StorageMigrationType).To(HaveValue(Equal(fdbv1beta2.StorageMigrationTypeDisabled)))
+							Expect(adminClient.DatabaseConfiguration.PerpetualStorageWiggle).To(HaveValue(Equal(1)))
+						})
+					})
+				})
+
+				When("two concurrent migrations are allowed", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.StorageEngineMigration.MaxConcurrentMigrations = pointer.Int(2)
+					})
+
+					It("should replace one process group in addition to the perpetual storage wiggle", func() {
+						Expect(result).NotTo(BeNil())
+						Expect(getRemovedProcessGroupIDs(cluster)).To(HaveLen(1))
+					})
+				})
+			})
+
+			When("the version doesn't support the storage migration configuration", func() {
+				It("should only track the migration", func() {
+					Expect(result).NotTo(BeNil())
+					Expect(cluster.Status.StorageEngineMigration.IsInProgress()).To(BeTrue())
+					Expect(adminClient.DatabaseConfiguration.StorageMigrationType).To(BeNil())
+				})
+			})
+
+			When("using the replace process groups strategy", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.StorageEngineMigration.Strategy = fdbv1beta2.StorageEngineMigrationStrategyReplaceProcessGroups
+				})
+
+				It("should replace one process group", func() {
+					Expect(result).NotTo(BeNil())
+					Expect(cluster.Status.StorageEngineMigration.Strategy).To(Equal(fdbv1beta2.StorageEngineMigrationStrategyReplaceProcessGroups))
+					Expect(getRemovedProcessGroupIDs(cluster)).To(HaveLen(1))
+					Expect(adminClient.DatabaseConfiguration.StorageMigrationType).To(BeNil())
+				})
+
+				When("two concurrent migrations are allowed", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.StorageEngineMigration.MaxConcurrentMigrations = pointer.Int(2)
+					})
+
+					It("should replace two process groups", func() {
+						Expect(result).NotTo(BeNil())
+						Expect(getRemovedProcessGroupIDs(cluster)).To(HaveLen(2))
+					})
+				})
+
+				When("a process group is already being replaced", func() {
+					BeforeEach(func() {
+						fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1").MarkForRemoval()
+					})
+
+					It("should not replace another process group", func() {
+						Expect(result).NotTo(BeNil())
+						Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+					})
+				})
+
+				When("the storage servers report an equivalent storage engine", func() {
+					BeforeEach(func() {
+						cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineMemory
+						Expect(adminClient.ConfigureDatabase(cluster.DesiredDatabaseConfiguration(), false, cluster.Spec.Version)).To(Succeed())
+					})
+
+					JustBeforeEach(func() {
+						for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "storage-3", "storage-4"} {
+							adminClient.MockStorageEngine(processGroupID, fdbv1beta2.StorageEngineMemory)
+						}
+						result = migrateStorageEngine{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+					})
+
+					It("should complete the migration", func() {
+						Expect(result).To(BeNil())
+						Expect(cluster.Status.StorageEngineMigration.StorageEngine).To(Equal(fdbv1beta2.StorageEngineMemory2))
+						Expect(cluster.Status.StorageEngineMigration.IsInProgress()).To(BeFalse())
+						Expect(cluster.Status.StorageEngineMigration.CompletionTimestamp).To(BeNumerically(">", 0))
+					})
+				})
+
+				When("the replaced process group is excluded", func() {
+					BeforeEach(func() {
+						processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1")
+						processGroup.MarkForRemoval()
+						processGroup.SetExclude()
+					})
+
+					It("should replace another process group", func() {
+						Expect(result).NotTo(BeNil())
+						Expect(getRemovedProcessGroupIDs(cluster)).To(HaveLen(2))
+					})
+				})
+			})
+		})
+	})
+})
@@ -24,6 +24,7 @@ import (
 	"context"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 )
 
 // modifyBackup provides a reconciliation step for modifying a backup's
@@ -38,14 +39,23 @@ func (s modifyBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconc
 	}
 
 	snapshotPeriod := backup.SnapshotPeriodSeconds()
-	if backup.Status.BackupDetails.SnapshotPeriodSeconds != snapshotPeriod {
-		adminClient, err := r.adminClientForBackup(ctx, backup)
-		if err != nil {
-			return &requeue{curError: err}
+	var adminClient fdbadminclient.AdminClient
+	var err error
+	for _, destination := range backup.GetDestinations() {
+		details := getBackupDestinationDetails(backup, destination.Name)
+		if details == nil || !details.Running || details.SnapshotPeriodSeconds == snapshotPeriod {
+			continue
+		}
+
+		if adminClient == nil {
+			adminClient, err = r.adminClientForBackup(ctx, backup)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+			defer adminClient.Close()
 		}
-		defer adminClient.Close()
 
-		err = adminClient.ModifyBackup(snapshotPeriod)
+		err = adminClient.ModifyBackup(destination.Name, snapshotPeriod)
 		if err != nil {
 			return &requeue{curError: err}
 		}
@@ -93,6 +93,30 @@ func (u removeProcessGroups) reconcile(ctx context.Context, r *FoundationDBClust
 		return nil
 	}
 
+	// Ensure we only remove process groups that have been suspended for at least the minimum suspension duration.
+	processGroupsToRemove, nextRemoval := removals.FilterSuspendedProcessGroups(cluster, processGroupsToRemove)
+	// The suspension expiry annotation of the PVC can be used to extend the suspension of a single process group.
+	if len(processGroupsToRemove) > 0 && cluster.GetMinimumSuspensionDuration() > 0 {
+		var nextExpiry time.Duration
+		processGroupsToRemove, nextExpiry, err = filterBySuspensionExpiry(ctx, r, cluster, processGroupsToRemove)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if nextExpiry > 0 && (nextRemoval == 0 || nextExpiry < nextRemoval) {
+			nextRemoval = nextExpiry
+		}
+	}
+
+	// If all of the process groups are filtered out we can stop doing the next steps.
+	if len(processGroupsToRemove) == 0 {
+		if nextRemoval > 0 {
+			return &requeue{message: fmt.Sprintf("waiting for suspended process groups, next removal in: %v", nextRemoval), delay: nextRemoval, delayedRequeue: true}
+		}
+
+		return nil
+	}
+
 	// We don't use the "cached" of the cluster status from the CRD to minimize the window between data loss (e.g. a node
 	// or a set of Pods is not reachable anymore). We still end up with the risk to actually query the FDB cluster and after that
 	// query the cluster gets into a degraded state.
@@ -106,6 +130,17 @@ func (u removeProcessGroups) reconcile(ctx context.Context, r *FoundationDBClust
 		}
 	}
 
+	// For three_data_hall clusters we have to make sure that the removal doesn't leave a data hall without the required
+	// number of zones or removes a coordinator.
+	err = removals.CheckDataHallRemovals(cluster, status, processGroupsToRemove)
+	if err != nil {
+		logger.Info("Removals are blocked by the data hall check", "error", err.Error())
+		return &requeue{
+			message: fmt.Sprintf("Removals cannot proceed: %s", err.Error()),
+			delay:   30 * time.Second,
+		}
+	}
+
 	// In addition to that we should add the same logic as in the exclude step
 	// to ensure we never exclude/remove more process groups than desired.
 	zonedRemovals, lastDeletion, err := removals.GetZonedRemovals(processGroupsToRemove)
@@ -375,3 +410,51 @@ func (r *FoundationDBClusterReconciler) removeProcessGroups(ctx context.Context,
 
 	return removedProcessGroups
 }
+
+// filterBySuspensionExpiry removes all process groups from the provided list whose suspended PVC has a suspension
+// expiry annotation that lies in the future. The returned duration defines the time until the next suspension expires
+// or 0 if no suspension is pending.
+func filterBySuspensionExpiry(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroups []*fdbv1beta2.ProcessGroupStatus) ([]*fdbv1beta2.ProcessGroupStatus, time.Duration, error) {
+	matchLabels := internal.GetPodMatchLabels(cluster, "", "")
+	matchLabels[fdbv1beta2.SuspendedLabel] = "true"
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	err := r.List(ctx, pvcs, client.InNamespace(cluster.Namespace), client.MatchingLabels(matchLabels))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	expiries := make(map[fdbv1beta2.ProcessGroupID]time.Time, len(pvcs.Items))
+	for _, pvc := range pvcs.Items {
+		expiry, ok := pvc.Annotations[fdbv1beta2.SuspensionExpiryAnnotation]
+		if !ok {
+			continue
+		}
+
+		expiryTime, err := time.Parse(time.RFC3339, expiry)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not parse suspension expiry of PVC %s: %w", pvc.Name, err)
+		}
+
+		expiries[internal.GetProcessGroupIDFromMeta(cluster, pvc.ObjectMeta)] = expiryTime
+	}
+
+	var nextExpiry time.Duration
+	filteredList := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroups))
+	for _, processGroup := range processGroups {
+		expiry, ok := expiries[processGroup.ProcessGroupID]
+		if ok {
+			remaining := time.Until(expiry)
+			if remaining > 0 {
+				if nextExpiry == 0 || remaining < nextExpiry {
+					nextExpiry = remaining
+				}
+
+				continue
+			}
+		}
+
+		filteredList = append(filteredList, processGroup)
+	}
+
+	return filteredList, nextExpiry, nil
+}
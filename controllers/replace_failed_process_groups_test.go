@@ -825,6 +825,143 @@ var _ = Describe("replace_failed_process_groups", func() {
 			})
 		})
 
+		Context("with another in-flight exclusion of a different process class", func() {
+			BeforeEach(func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "log-1")
+				processGroup.MarkForRemoval()
+			})
+
+			It("should not mark the process group for removal", func() {
+				Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"log-1"}))
+			})
+
+			When("a replacement bucket for the storage process class is defined", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacements = pointer.Int(2)
+					cluster.Spec.AutomationOptions.Replacements.ReplacementBuckets = []fdbv1beta2.ReplacementBucket{
+						{
+							ProcessClass:              fdbv1beta2.ProcessClassStorage,
+							MaxConcurrentReplacements: pointer.Int(1),
+						},
+						{
+							ProcessClass:              fdbv1beta2.ProcessClassGeneral,
+							MaxConcurrentReplacements: pointer.Int(1),
+						},
+					}
+				})
+
+				It("should requeue", func() {
+					Expect(result).NotTo(BeNil())
+					Expect(result.message).To(Equal("Removals have been updated in the cluster status"))
+				})
+
+				It("should mark the process group for removal", func() {
+					Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"log-1", "storage-2"}))
+				})
+
+				When("the buckets allow more replacements than max concurrent replacements", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacements = pointer.Int(1)
+					})
+
+					It("should return nil", func() {
+						Expect(result).To(BeNil())
+					})
+
+					It("should not mark the process group for removal", func() {
+						Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"log-1"}))
+					})
+				})
+			})
+		})
+
+		Context("with another in-flight replacement caused by a condition with a separate budget", func() {
+			BeforeEach(func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-3")
+				processGroup.UpdateCondition(fdbv1beta2.PodPending, true)
+				processGroup.MarkForRemoval()
+				cluster.Spec.AutomationOptions.Replacements.ConditionPolicies = []fdbv1beta2.ReplacementConditionPolicy{
+					{
+						ConditionType:             fdbv1beta2.PodPending,
+						MaxConcurrentReplacements: pointer.Int(1),
+					},
+				}
+			})
+
+			It("should requeue", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.message).To(Equal("Removals have been updated in the cluster status"))
+			})
+
+			It("should mark the process group for removal", func() {
+				Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2", "storage-3"}))
+			})
+
+			When("another process group is pending for a long time", func() {
+				BeforeEach(func() {
+					processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-4")
+					processGroup.ProcessGroupConditions = append(processGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+						ProcessGroupConditionType: fdbv1beta2.PodPending,
+						Timestamp:                 time.Now().Add(-3 * time.Hour).Unix(),
+					})
+				})
+
+				It("should only mark the process group with missing processes for removal", func() {
+					Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2", "storage-3"}))
+				})
+			})
+		})
+
+		Context("with a condition policy that defines a longer failure detection time", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.Replacements.ConditionPolicies = []fdbv1beta2.ReplacementConditionPolicy{
+					{
+						ConditionType:               fdbv1beta2.MissingProcesses,
+						FailureDetectionTimeSeconds: pointer.Int(7200),
+					},
+				}
+			})
+
+			It("should return nil", func() {
+				Expect(result).To(BeNil())
+			})
+
+			It("should not mark the process group for removal", func() {
+				Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{}))
+			})
+		})
+
+		Context("with a condition policy for a condition that is not replaced by default", func() {
+			BeforeEach(func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+				processGroup.ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.NodeTaintDetected,
+						Timestamp:                 time.Now().Add(-1 * time.Hour).Unix(),
+					},
+				}
+			})
+
+			It("should not mark the process group for removal without a policy", func() {
+				Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{}))
+			})
+
+			When("a policy for the condition is defined", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.ConditionPolicies = []fdbv1beta2.ReplacementConditionPolicy{
+						{
+							ConditionType:               fdbv1beta2.NodeTaintDetected,
+							FailureDetectionTimeSeconds: pointer.Int(1800),
+						},
+					}
+				})
+
+				It("should mark the process group for removal", func() {
+					Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2"}))
+				})
+			})
+		})
+
 		Context("with another complete exclusion", func() {
 			BeforeEach(func() {
 				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-3")
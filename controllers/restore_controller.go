@@ -22,6 +22,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -31,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/go-logr/logr"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,6 +41,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// restoreStatusRefreshInterval defines how often the progress of a running restore will be updated.
+const restoreStatusRefreshInterval = 1 * time.Minute
+
 // FoundationDBRestoreReconciler reconciles a FoundationDBRestore object
 type FoundationDBRestoreReconciler struct {
 	client.Client
@@ -84,6 +89,11 @@ func (r *FoundationDBRestoreReconciler) Reconcile(ctx context.Context, request c
 
 	restoreLog.Info("Reconciliation complete")
 
+	// The restore controller only reacts to spec changes, so the progress of a running restore must be polled.
+	if restore.Status.Running {
+		return ctrl.Result{RequeueAfter: restoreStatusRefreshInterval}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -97,8 +107,7 @@ func (r *FoundationDBRestoreReconciler) getDatabaseClientProvider() fdbadminclie
 
 // adminClientForRestore provides an admin client for a restore reconciler.
 func (r *FoundationDBRestoreReconciler) adminClientForRestore(ctx context.Context, restore *fdbv1beta2.FoundationDBRestore) (fdbadminclient.AdminClient, error) {
-	cluster := &fdbv1beta2.FoundationDBCluster{}
-	err := r.Get(ctx, types.NamespacedName{Namespace: restore.ObjectMeta.Namespace, Name: restore.Spec.DestinationClusterName}, cluster)
+	cluster, err := internal.GetCluster(ctx, r, types.NamespacedName{Namespace: restore.ObjectMeta.Namespace, Name: restore.Spec.DestinationClusterName})
 	if err != nil {
 		return nil, err
 	}
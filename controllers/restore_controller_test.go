@@ -27,15 +27,34 @@ import (
 	. "github.com/onsi/gomega"
 
 	"context"
+	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 )
 
 func reloadRestore(restore *fdbv1beta2.FoundationDBRestore) error {
 	return k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: restore.Name}, restore)
 }
 
+func getRestoreEventReasons(restore *fdbv1beta2.FoundationDBRestore) []string {
+	events := &corev1.EventList{}
+	Expect(k8sClient.List(context.TODO(), events)).To(Succeed())
+
+	var reasons []string
+	for _, event := range events.Items {
+		if event.InvolvedObject.UID == restore.ObjectMeta.UID {
+			reasons = append(reasons, event.Reason)
+		}
+	}
+
+	return reasons
+}
+
 var _ = Describe("restore_controller", func() {
 	var cluster *fdbv1beta2.FoundationDBCluster
 	var restore *fdbv1beta2.FoundationDBRestore
@@ -47,6 +66,15 @@ var _ = Describe("restore_controller", func() {
 		restore = createDefaultRestore(cluster)
 		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
 		Expect(err).NotTo(HaveOccurred())
+		adminClient.BackupDescription = &fdbv1beta2.FoundationDBBackupDescription{
+			Restorable: true,
+			MinRestorablePoint: &fdbv1beta2.FoundationDBBackupVersion{
+				Version: 100,
+			},
+			MaxRestorablePoint: &fdbv1beta2.FoundationDBBackupVersion{
+				Version: 2000,
+			},
+		}
 	})
 
 	Describe("Reconciliation", func() {
@@ -94,7 +122,79 @@ var _ = Describe("restore_controller", func() {
 			It("should start a restore", func() {
 				status, err := adminClient.GetRestoreStatus()
 				Expect(err).NotTo(HaveOccurred())
-				Expect(status).To(Equal("blobstore://test@test-service/test-backup?bucket=fdb-backups\n"))
+				Expect(status).To(ContainSubstring("URL: blobstore://test@test-service/test-backup?bucket=fdb-backups  Range:"))
+			})
+
+			It("should report the restore as running", func() {
+				Expect(restore.Status.Running).To(BeTrue())
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseRunning))
+				Expect(restore.Status.StartTimestamp).NotTo(BeNil())
+				Expect(restore.Status.FinishTimestamp).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(restore.Status.Conditions, fdbv1beta2.RestoreConditionRunning)).To(BeTrue())
+				Expect(meta.IsStatusConditionTrue(restore.Status.Conditions, fdbv1beta2.RestoreConditionCompleted)).To(BeFalse())
+			})
+		})
+
+		When("the restore makes progress", func() {
+			BeforeEach(func() {
+				adminClient.MockRestoreStatus("running", fdbv1beta2.FoundationDBRestoreProgress{
+					BlocksCompleted: 10,
+					BlocksTotal:     100,
+					BytesWritten:    4096,
+					CurrentVersion:  1000,
+				})
+			})
+
+			It("should update the progress", func() {
+				Expect(restore.Status.Running).To(BeTrue())
+				Expect(restore.Status.Progress).NotTo(BeNil())
+				Expect(restore.Status.Progress.BlocksCompleted).To(BeNumerically("==", 10))
+				Expect(restore.Status.Progress.BlocksTotal).To(BeNumerically("==", 100))
+				Expect(restore.Status.Progress.BytesWritten).To(BeNumerically("==", 4096))
+				Expect(restore.Status.Progress.CurrentVersion).To(BeNumerically("==", 1000))
+			})
+		})
+
+		When("the restore is completed", func() {
+			BeforeEach(func() {
+				adminClient.MockRestoreStatus("completed", fdbv1beta2.FoundationDBRestoreProgress{
+					BlocksCompleted: 100,
+					BlocksTotal:     100,
+				})
+			})
+
+			It("should report the restore as completed", func() {
+				Expect(restore.Status.Running).To(BeFalse())
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseCompleted))
+				Expect(restore.Status.FinishTimestamp).NotTo(BeNil())
+				Expect(meta.IsStatusConditionTrue(restore.Status.Conditions, fdbv1beta2.RestoreConditionRunning)).To(BeFalse())
+				Expect(meta.IsStatusConditionTrue(restore.Status.Conditions, fdbv1beta2.RestoreConditionCompleted)).To(BeTrue())
+				Expect(meta.IsStatusConditionTrue(restore.Status.Conditions, fdbv1beta2.RestoreConditionFailed)).To(BeFalse())
+			})
+
+			It("should emit an event", func() {
+				Expect(getRestoreEventReasons(restore)).To(ContainElement("RestoreCompleted"))
+			})
+		})
+
+		When("the restore is aborted", func() {
+			BeforeEach(func() {
+				adminClient.MockRestoreStatus("aborted", fdbv1beta2.FoundationDBRestoreProgress{
+					LastError: "'restore_missing_data' 20s ago.",
+				})
+			})
+
+			It("should report the restore as failed", func() {
+				Expect(restore.Status.Running).To(BeFalse())
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseAborted))
+				Expect(restore.Status.FinishTimestamp).NotTo(BeNil())
+				Expect(restore.Status.Progress.LastError).To(Equal("'restore_missing_data' 20s ago."))
+				Expect(meta.IsStatusConditionTrue(restore.Status.Conditions, fdbv1beta2.RestoreConditionFailed)).To(BeTrue())
+				Expect(meta.IsStatusConditionTrue(restore.Status.Conditions, fdbv1beta2.RestoreConditionCompleted)).To(BeFalse())
+			})
+
+			It("should emit events", func() {
+				Expect(getRestoreEventReasons(restore)).To(ContainElements("RestoreAborted", "RestoreError"))
 			})
 		})
 
@@ -113,4 +213,149 @@ var _ = Describe("restore_controller", func() {
 			})
 		})
 	})
+
+	When("starting a point-in-time restore", func() {
+		var req *requeue
+		var targetTimestamp time.Time
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(context.TODO(), cluster)).To(Succeed())
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+
+			targetTimestamp = time.Date(2023, 5, 17, 10, 30, 15, 0, time.UTC)
+			restore.Spec.KeyRanges = []fdbv1beta2.FoundationDBKeyRange{
+				{Start: "old/a", End: "old/b"},
+			}
+			restore.Spec.TargetTimestamp = &metav1.Time{Time: targetTimestamp}
+			restore.Spec.SourceClusterName = cluster.Name
+			restore.Spec.AddPrefix = "new/"
+			restore.Spec.RemovePrefix = "old/"
+			restore.Spec.DestinationPolicy = &[]fdbv1beta2.RestoreDestinationPolicy{fdbv1beta2.RestoreDestinationPolicyClearDestination}[0]
+		})
+
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(context.TODO(), restore)).To(Succeed())
+			req = startRestore{}.reconcile(context.TODO(), restoreReconciler, restore)
+		})
+
+		It("should pass the restore options to the admin client", func() {
+			Expect(req).To(BeNil())
+			Expect(adminClient.RestoreOptions).NotTo(BeNil())
+			Expect(adminClient.RestoreOptions.KeyRanges).To(Equal(restore.Spec.KeyRanges))
+			Expect(adminClient.RestoreOptions.TargetVersion).To(BeNil())
+			Expect(adminClient.RestoreOptions.TargetTimestamp).NotTo(BeNil())
+			Expect(adminClient.RestoreOptions.TargetTimestamp.Equal(targetTimestamp)).To(BeTrue())
+			Expect(adminClient.RestoreOptions.SourceCluster).NotTo(BeNil())
+			Expect(adminClient.RestoreOptions.SourceCluster.Name).To(Equal(cluster.Name))
+			Expect(adminClient.RestoreOptions.AddPrefix).To(Equal("new/"))
+			Expect(adminClient.RestoreOptions.RemovePrefix).To(Equal("old/"))
+		})
+
+		It("should clear only the destination key ranges and record it", func() {
+			Expect(adminClient.ClearedKeyRanges).To(Equal([]fdbv1beta2.FoundationDBKeyRange{{Start: "new/a", End: "new/b"}}))
+			Expect(restore.Status.DestinationCleared).To(BeTrue())
+		})
+
+		When("the destination was already cleared", func() {
+			BeforeEach(func() {
+				restore.Status.DestinationCleared = true
+			})
+
+			It("should not clear the destination again", func() {
+				Expect(req).To(BeNil())
+				Expect(adminClient.ClearedKeyRanges).To(BeEmpty())
+				Expect(adminClient.RestoreOptions).NotTo(BeNil())
+			})
+		})
+
+		When("the backup is not restorable", func() {
+			BeforeEach(func() {
+				adminClient.BackupDescription.Restorable = false
+			})
+
+			It("should not modify the destination", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.message).To(Equal("backup blobstore://test@test-service/test-backup?bucket=fdb-backups is not restorable"))
+				Expect(adminClient.ClearedKeyRanges).To(BeEmpty())
+				Expect(adminClient.RestoreOptions).To(BeNil())
+			})
+		})
+
+		When("a target version and a target timestamp are set", func() {
+			BeforeEach(func() {
+				restore.Spec.TargetVersion = pointer.Int64(1000)
+			})
+
+			It("should not start the restore", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.curError).To(MatchError("targetVersion and targetTimestamp are mutually exclusive"))
+				Expect(adminClient.RestoreOptions).To(BeNil())
+			})
+		})
+
+		When("the target timestamp is set without a source cluster", func() {
+			BeforeEach(func() {
+				restore.Spec.SourceClusterName = ""
+			})
+
+			It("should not start the restore", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.curError).To(MatchError("targetTimestamp requires the sourceClusterName"))
+				Expect(adminClient.ClearedKeyRanges).To(BeEmpty())
+				Expect(adminClient.RestoreOptions).To(BeNil())
+			})
+		})
+
+		When("the target version is not restorable", func() {
+			BeforeEach(func() {
+				restore.Spec.TargetTimestamp = nil
+				restore.Spec.TargetVersion = pointer.Int64(5000)
+			})
+
+			It("should not modify the destination", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.message).To(Equal("target version 5000 is outside of the restorable versions 100 - 2000 of backup blobstore://test@test-service/test-backup?bucket=fdb-backups"))
+				Expect(adminClient.ClearedKeyRanges).To(BeEmpty())
+				Expect(adminClient.RestoreOptions).To(BeNil())
+			})
+		})
+
+		When("the destination must be empty", func() {
+			BeforeEach(func() {
+				restore.Spec.DestinationPolicy = nil
+			})
+
+			It("should start the restore without clearing the destination", func() {
+				Expect(req).To(BeNil())
+				Expect(adminClient.ClearedKeyRanges).To(BeEmpty())
+				Expect(adminClient.RestoreOptions).NotTo(BeNil())
+			})
+
+			When("the destination contains data", func() {
+				BeforeEach(func() {
+					adminClient.MockNonEmptyKeyRange(fdbv1beta2.FoundationDBKeyRange{Start: "new/a1", End: "new/a2"})
+				})
+
+				It("should not start the restore", func() {
+					Expect(req).NotTo(BeNil())
+					Expect(req.message).To(Equal("destination key range new/a - new/b is not empty"))
+					Expect(adminClient.ClearedKeyRanges).To(BeEmpty())
+					Expect(adminClient.RestoreOptions).To(BeNil())
+				})
+			})
+
+			When("only keys outside of the destination exist", func() {
+				BeforeEach(func() {
+					adminClient.MockNonEmptyKeyRange(fdbv1beta2.FoundationDBKeyRange{Start: "other/a", End: "other/b"})
+				})
+
+				It("should start the restore", func() {
+					Expect(req).To(BeNil())
+					Expect(adminClient.RestoreOptions).NotTo(BeNil())
+				})
+			})
+		})
+	})
 })
@@ -24,6 +24,7 @@ import (
 	"context"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 )
 
 // startBackup provides a reconciliation step for starting a new backup.
@@ -32,19 +33,36 @@ type startBackup struct {
 
 // reconcile runs the reconciler's work.
 func (s startBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
-	if !backup.ShouldRun() || (backup.Status.BackupDetails != nil && backup.Status.BackupDetails.Running) {
+	if !backup.ShouldRun() {
 		return nil
 	}
 
-	adminClient, err := r.adminClientForBackup(ctx, backup)
+	err := backup.ValidateDestinations()
 	if err != nil {
-		return &requeue{curError: err}
+		return &requeue{curError: err, delayedRequeue: true}
 	}
-	defer adminClient.Close()
 
-	err = adminClient.StartBackup(backup.BackupURL(), backup.SnapshotPeriodSeconds())
-	if err != nil {
-		return &requeue{curError: err}
+	var adminClient fdbadminclient.AdminClient
+	for _, destination := range backup.GetDestinations() {
+		details := getBackupDestinationDetails(backup, destination.Name)
+		if details != nil && details.Running {
+			continue
+		}
+
+		if adminClient == nil {
+			adminClient, err = r.adminClientForBackup(ctx, backup)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+			defer adminClient.Close()
+		}
+
+		// For file destinations the operator only registers the backup, the directory is created and written by the
+		// backup agents that have the volume mounted.
+		err = adminClient.StartBackup(destination.Name, backup.DestinationURL(destination), backup.SnapshotPeriodSeconds())
+		if err != nil {
+			return &requeue{curError: err}
+		}
 	}
 
 	return nil
@@ -0,0 +1,50 @@
+/*
+ * start_disaster_recovery.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+// startDisasterRecovery provides a reconciliation step for starting the replication.
+type startDisasterRecovery struct{}
+
+// reconcile runs the reconciler's work.
+func (s startDisasterRecovery) reconcile(ctx context.Context, r *FoundationDBDisasterRecoveryReconciler, disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) *requeue {
+	if !disasterRecovery.ShouldRun() || disasterRecovery.IsRunning() {
+		return nil
+	}
+
+	adminClient, source, err := r.adminClientForDisasterRecovery(ctx, disasterRecovery, disasterRecovery.Spec.SourceClusterName, disasterRecovery.Spec.DestinationClusterName)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	err = adminClient.StartDisasterRecovery(source)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
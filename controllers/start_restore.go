@@ -22,17 +22,30 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
-// startRestore provides a reconciliation step for starting a new restore.
+// startRestore provides a reconciliation step for starting a new restore and for updating the progress of a running
+// restore.
 type startRestore struct {
 }
 
 // reconcile runs the reconciler's work.
 func (s startRestore) reconcile(ctx context.Context, r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore) *requeue {
+	err := restore.ValidateTarget()
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
 	adminClient, err := r.adminClientForRestore(ctx, restore)
 	if err != nil {
 		return &requeue{curError: err}
@@ -44,18 +57,114 @@ func (s startRestore) reconcile(ctx context.Context, r *FoundationDBRestoreRecon
 		return &requeue{curError: err}
 	}
 
-	if len(strings.TrimSpace(status)) == 0 {
-		err = adminClient.StartRestore(restore.BackupURL(), restore.Spec.KeyRanges)
-		if err != nil {
-			return &requeue{curError: err}
+	// If a restore exists, the output of the status command is reused to update the progress.
+	if len(strings.TrimSpace(status)) > 0 {
+		return updateRestoreProgress(ctx, r, restore, status)
+	}
+
+	// Make sure the backup can be restored before the destination is modified.
+	description, err := adminClient.DescribeBackup(restore.BackupURL())
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	err = validateRestorableBackup(restore, description)
+	if err != nil {
+		r.Recorder.Event(restore, corev1.EventTypeWarning, "BackupNotRestorable", err.Error())
+		return &requeue{message: err.Error(), delayedRequeue: true}
+	}
+
+	options, err := getRestoreOptions(ctx, r, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	destinationKeyRanges := restore.GetDestinationKeyRanges()
+	if restore.GetDestinationPolicy() == fdbv1beta2.RestoreDestinationPolicyClearDestination {
+		// The destination must only be cleared once, otherwise a retry could remove data that was already restored.
+		if !restore.Status.DestinationCleared {
+			err = adminClient.ClearKeyRanges(destinationKeyRanges)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+
+			restore.Status.DestinationCleared = true
+			err = r.updateOrApply(ctx, restore)
+			if err != nil {
+				return &requeue{curError: err}
+			}
 		}
+	} else {
+		for _, keyRange := range destinationKeyRanges {
+			empty, err := adminClient.IsKeyRangeEmpty(keyRange)
+			if err != nil {
+				return &requeue{curError: err}
+			}
 
-		restore.Status.Running = true
-		err = r.updateOrApply(ctx, restore)
-		if err != nil {
-			return &requeue{curError: err}
+			if !empty {
+				message := fmt.Sprintf("destination key range %s - %s is not empty", keyRange.Start, keyRange.End)
+				r.Recorder.Event(restore, corev1.EventTypeWarning, "DestinationNotEmpty", message)
+				return &requeue{message: message, delayedRequeue: true}
+			}
 		}
 	}
 
+	err = adminClient.StartRestore(restore.BackupURL(), options)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	restore.Status.Running = true
+	restore.Status.StartTimestamp = &metav1.Time{Time: time.Now()}
+	err = r.updateOrApply(ctx, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// validateRestorableBackup checks that the backup described by the provided description can be restored to the target
+// of the restore.
+func validateRestorableBackup(restore *fdbv1beta2.FoundationDBRestore, description *fdbv1beta2.FoundationDBBackupDescription) error {
+	if !description.Restorable || description.MinRestorablePoint == nil || description.MaxRestorablePoint == nil {
+		return fmt.Errorf("backup %s is not restorable", restore.BackupURL())
+	}
+
+	if restore.Spec.TargetVersion == nil {
+		return nil
+	}
+
+	targetVersion := *restore.Spec.TargetVersion
+	if targetVersion < description.MinRestorablePoint.Version || targetVersion > description.MaxRestorablePoint.Version {
+		return fmt.Errorf("target version %d is outside of the restorable versions %d - %d of backup %s", targetVersion, description.MinRestorablePoint.Version, description.MaxRestorablePoint.Version, restore.BackupURL())
+	}
+
 	return nil
 }
+
+// getRestoreOptions returns the options to start the restore based on the restore spec.
+func getRestoreOptions(ctx context.Context, r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore) (fdbadminclient.RestoreOptions, error) {
+	options := fdbadminclient.RestoreOptions{
+		KeyRanges:     restore.Spec.KeyRanges,
+		TargetVersion: restore.Spec.TargetVersion,
+		AddPrefix:     restore.Spec.AddPrefix,
+		RemovePrefix:  restore.Spec.RemovePrefix,
+	}
+
+	if restore.Spec.TargetTimestamp != nil {
+		targetTimestamp := restore.Spec.TargetTimestamp.Time
+		options.TargetTimestamp = &targetTimestamp
+	}
+
+	if restore.Spec.SourceClusterName != "" {
+		sourceCluster, err := internal.GetCluster(ctx, r, types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.SourceClusterName})
+		if err != nil {
+			return options, err
+		}
+
+		options.SourceCluster = sourceCluster
+	}
+
+	return options, nil
+}
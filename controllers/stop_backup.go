@@ -32,7 +32,34 @@ type stopBackup struct {
 
 // reconcile runs the reconciler's work.
 func (s stopBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
-	if backup.ShouldRun() || backup.Status.BackupDetails == nil || !backup.Status.BackupDetails.Running {
+	destinations := backup.GetDestinations()
+	tags := make([]string, 0, len(destinations))
+	if !backup.ShouldRun() {
+		for _, destination := range destinations {
+			details := getBackupDestinationDetails(backup, destination.Name)
+			if details != nil && details.Running {
+				tags = append(tags, destination.Name)
+			}
+		}
+	}
+
+	// Backups for destinations that were removed from the spec must be stopped.
+	if len(backup.Spec.Destinations) > 0 {
+		destinationNames := make(map[string]fdbv1beta2.None, len(destinations))
+		for _, destination := range destinations {
+			destinationNames[destination.Name] = fdbv1beta2.None{}
+		}
+
+		for _, destinationStatus := range backup.Status.Destinations {
+			if _, ok := destinationNames[destinationStatus.Name]; ok || !destinationStatus.BackupDetails.Running {
+				continue
+			}
+
+			tags = append(tags, destinationStatus.Name)
+		}
+	}
+
+	if len(tags) == 0 {
 		return nil
 	}
 
@@ -42,9 +69,11 @@ func (s stopBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconcil
 	}
 	defer adminClient.Close()
 
-	err = adminClient.StopBackup(backup.BackupURL())
-	if err != nil {
-		return &requeue{curError: err}
+	for _, tag := range tags {
+		err = adminClient.StopBackup(tag)
+		if err != nil {
+			return &requeue{curError: err}
+		}
 	}
 
 	return nil
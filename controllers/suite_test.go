@@ -60,6 +60,7 @@ var k8sClient *mockclient.MockClient
 var clusterReconciler *FoundationDBClusterReconciler
 var backupReconciler *FoundationDBBackupReconciler
 var restoreReconciler *FoundationDBRestoreReconciler
+var disasterRecoveryReconciler *FoundationDBDisasterRecoveryReconciler
 var requeueLimit = 20
 
 func TestAPIs(t *testing.T) {
@@ -95,6 +96,14 @@ var _ = BeforeSuite(func() {
 		Recorder:               k8sClient,
 		DatabaseClientProvider: mock.DatabaseClientProvider{},
 	}
+
+	disasterRecoveryReconciler = &FoundationDBDisasterRecoveryReconciler{
+		Client:                 k8sClient,
+		Log:                    ctrl.Log.WithName("controllers").WithName("FoundationDBDisasterRecovery"),
+		Recorder:               k8sClient,
+		InSimulation:           true,
+		DatabaseClientProvider: mock.DatabaseClientProvider{},
+	}
 })
 
 var _ = AfterSuite(func() {
@@ -106,6 +115,7 @@ var _ = AfterEach(func() {
 	k8sClient.Clear()
 	mock.ClearMockAdminClients()
 	mock.ClearMockLockClients()
+	mockpodclient.ClearMockProcessCommandLines()
 })
 
 func createDefaultRestore(cluster *fdbv1beta2.FoundationDBCluster) *fdbv1beta2.FoundationDBRestore {
@@ -142,6 +152,10 @@ func reconcileRestore(restore *fdbv1beta2.FoundationDBRestore) (reconcile.Result
 	return reconcileObject(restoreReconciler, restore.ObjectMeta, requeueLimit)
 }
 
+func reconcileDisasterRecovery(disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) (reconcile.Result, error) {
+	return reconcileObject(disasterRecoveryReconciler, disasterRecovery.ObjectMeta, requeueLimit)
+}
+
 func reconcileObject(reconciler reconcile.Reconciler, metadata metav1.ObjectMeta, requeueLimit int) (reconcile.Result, error) {
 	attempts := requeueLimit + 1
 	result := reconcile.Result{Requeue: true}
@@ -0,0 +1,202 @@
+/*
+ * suspend_process_groups.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/buggify"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/removals"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// suspendProcessGroups provides a reconciliation step for suspending process groups before they are removed. A
+// suspended process group has no Pod running, but all other resources will be retained until the minimum suspension
+// duration has passed.
+type suspendProcessGroups struct{}
+
+// reconcile runs the reconciler's work.
+func (u suspendProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	// If the suspension is disabled we can skip all further checks.
+	if cluster.GetMinimumSuspensionDuration() == 0 {
+		return nil
+	}
+
+	var hasPendingSuspension bool
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() && !processGroup.IsSuspended() {
+			hasPendingSuspension = true
+			break
+		}
+	}
+
+	if !hasPendingSuspension {
+		return nil
+	}
+
+	adminClient, err := r.DatabaseClientProvider.GetAdminClient(cluster, r)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	// If the status is not cached, we have to fetch it.
+	if status == nil {
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	remainingMap, err := removals.GetRemainingMap(logger, adminClient, cluster, status)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	coordinators := fdbstatus.GetCoordinatorsFromStatus(status)
+	_, newExclusions, processGroupsToRemove := r.getProcessGroupsToRemove(logger, cluster, remainingMap, coordinators)
+	// Update the cluster to reflect the new exclusions in our status
+	if newExclusions {
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	// Ensure we only suspend process groups that are not blocked to be removed by the buggify config.
+	processGroupsToRemove = buggify.FilterBlockedRemovals(cluster, processGroupsToRemove)
+	processGroupsToSuspend := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupsToRemove))
+	for _, processGroup := range processGroupsToRemove {
+		if processGroup.IsSuspended() {
+			continue
+		}
+
+		processGroupsToSuspend = append(processGroupsToSuspend, processGroup)
+	}
+
+	// If all of the process groups are filtered out we can stop doing the next steps.
+	if len(processGroupsToSuspend) == 0 {
+		return nil
+	}
+
+	hasDesiredFaultTolerance := fdbstatus.HasDesiredFaultToleranceFromStatus(logger, status, cluster)
+	if !hasDesiredFaultTolerance {
+		return &requeue{
+			message: "Suspensions cannot proceed because cluster has degraded fault tolerance",
+			delay:   30 * time.Second,
+		}
+	}
+
+	// The last deletion must be based on all process groups, including the already suspended process groups, to make
+	// sure we are not suspending zones faster than Kubernetes actually removes Pods.
+	_, lastDeletion, err := removals.GetZonedRemovals(processGroupsToRemove)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	zonedRemovals, _, err := removals.GetZonedRemovals(processGroupsToSuspend)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	// If the operator is allowed to suspend all process groups at the same time we don't enforce any safety checks.
+	if cluster.GetRemovalMode() != fdbv1beta2.PodUpdateModeAll {
+		waitTime, allowed := removals.RemovalAllowed(lastDeletion, time.Now().Unix(), cluster.GetWaitBetweenRemovalsSeconds())
+		if !allowed {
+			return &requeue{message: fmt.Sprintf("not allowed to suspend process groups, waiting: %v", waitTime), delay: time.Duration(waitTime) * time.Second}
+		}
+	}
+
+	zone, zoneSuspensions, err := removals.GetProcessGroupsToRemove(cluster.GetRemovalMode(), zonedRemovals)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	logger.Info("Suspending process groups", "zone", zone, "count", len(zoneSuspensions), "deletionMode", cluster.GetRemovalMode())
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "SuspendingProcesses", fmt.Sprintf("Suspending pods: %v", zoneSuspensions))
+
+	for _, processGroup := range append(zoneSuspensions, zonedRemovals[removals.TerminatingZone]...) {
+		err = suspendProcessGroup(ctx, r, cluster, processGroup)
+		if err != nil {
+			logger.Error(err, "Error during suspension of process group", "processGroupID", processGroup.ProcessGroupID)
+			continue
+		}
+
+		processGroup.SetSuspended()
+	}
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// suspendProcessGroup deletes the Pod of the process group and marks the PVC of the process group as suspended. All
+// other resources of the process group will be retained.
+func suspendProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) error {
+	pod, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil && pod.DeletionTimestamp.IsZero() {
+		err = r.PodLifecycleManager.DeletePod(ctx, r, pod)
+		if err != nil {
+			return fmt.Errorf("could not delete Pod: %w", err)
+		}
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	err = r.List(ctx, pvcs, internal.GetSinglePodListOptions(cluster, processGroup.ProcessGroupID)...)
+	if err != nil {
+		return err
+	}
+
+	if len(pvcs.Items) > 1 {
+		return fmt.Errorf("multiple PVCs found for cluster %s, processGroupID %s", cluster.Name, processGroup.ProcessGroupID)
+	}
+
+	if len(pvcs.Items) == 0 || !pvcs.Items[0].DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	pvc := pvcs.Items[0]
+	if pvc.Labels == nil {
+		pvc.Labels = map[string]string{}
+	}
+	pvc.Labels[fdbv1beta2.SuspendedLabel] = "true"
+
+	if pvc.Annotations == nil {
+		pvc.Annotations = map[string]string{}
+	}
+	pvc.Annotations[fdbv1beta2.SuspensionExpiryAnnotation] = time.Now().Add(cluster.GetMinimumSuspensionDuration()).UTC().Format(time.RFC3339)
+
+	return r.Update(ctx, &pvc)
+}
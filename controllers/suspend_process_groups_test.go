@@ -0,0 +1,168 @@
+/*
+ * suspend_process_groups_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("suspend_process_groups", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var result *requeue
+	var suspendedProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		generation, err := reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generation).To(Equal(int64(1)))
+
+		suspendedProcessGroup = fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+		suspendedProcessGroup.MarkForRemoval()
+		adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		for _, address := range suspendedProcessGroup.Addresses {
+			adminClient.ExcludedAddresses[address] = fdbv1beta2.None{}
+		}
+	})
+
+	JustBeforeEach(func() {
+		result = suspendProcessGroups{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+	})
+
+	When("the suspension is disabled", func() {
+		It("should not suspend the process group", func() {
+			Expect(result).To(BeNil())
+			Expect(suspendedProcessGroup.IsSuspended()).To(BeFalse())
+			_, err := clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, suspendedProcessGroup.GetPodName(cluster))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the suspension is enabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.MinimumSuspensionDurationSeconds = pointer.Int(3600)
+		})
+
+		It("should suspend the process group", func() {
+			Expect(result).To(BeNil())
+			Expect(suspendedProcessGroup.IsSuspended()).To(BeTrue())
+			Expect(suspendedProcessGroup.IsExcluded()).To(BeTrue())
+		})
+
+		It("should delete the Pod and retain the PVC", func() {
+			_, err := clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, suspendedProcessGroup.GetPodName(cluster))
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+			pvcs := &corev1.PersistentVolumeClaimList{}
+			Expect(k8sClient.List(context.TODO(), pvcs, internal.GetSinglePodListOptions(cluster, suspendedProcessGroup.ProcessGroupID)...)).To(Succeed())
+			Expect(pvcs.Items).To(HaveLen(1))
+			Expect(pvcs.Items[0].Labels).To(HaveKeyWithValue(fdbv1beta2.SuspendedLabel, "true"))
+			Expect(pvcs.Items[0].Annotations).To(HaveKey(fdbv1beta2.SuspensionExpiryAnnotation))
+		})
+
+		When("the process groups are removed", func() {
+			var removeResult *requeue
+
+			JustBeforeEach(func() {
+				removeResult = removeProcessGroups{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			})
+
+			When("the minimum suspension duration has not passed", func() {
+				It("should delay the removal", func() {
+					Expect(removeResult).NotTo(BeNil())
+					Expect(removeResult.delayedRequeue).To(BeTrue())
+					Expect(removeResult.delay).To(BeNumerically(">", 59*time.Minute))
+				})
+
+				It("should not remove the PVC", func() {
+					pvcs := &corev1.PersistentVolumeClaimList{}
+					Expect(k8sClient.List(context.TODO(), pvcs, internal.GetSinglePodListOptions(cluster, suspendedProcessGroup.ProcessGroupID)...)).To(Succeed())
+					Expect(pvcs.Items).To(HaveLen(1))
+				})
+			})
+
+			When("the minimum suspension duration has passed", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.MinimumSuspensionDurationSeconds = pointer.Int(60)
+					cluster.Spec.AutomationOptions.WaitBetweenRemovalsSeconds = pointer.Int(0)
+				})
+
+				var expiry time.Time
+
+				BeforeEach(func() {
+					expiry = time.Now().Add(-1 * time.Minute)
+				})
+
+				JustBeforeEach(func() {
+					suspendedProcessGroup.SuspensionTimestamp = &metav1.Time{Time: time.Now().Add(-1 * time.Hour)}
+					pvcs := &corev1.PersistentVolumeClaimList{}
+					Expect(k8sClient.List(context.TODO(), pvcs, internal.GetSinglePodListOptions(cluster, suspendedProcessGroup.ProcessGroupID)...)).To(Succeed())
+					Expect(pvcs.Items).To(HaveLen(1))
+					pvc := pvcs.Items[0]
+					pvc.Annotations[fdbv1beta2.SuspensionExpiryAnnotation] = expiry.UTC().Format(time.RFC3339)
+					Expect(k8sClient.Update(context.TODO(), &pvc)).To(Succeed())
+
+					removeResult = removeProcessGroups{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+				})
+
+				It("should remove the process group", func() {
+					Expect(removeResult).To(BeNil())
+					Expect(fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, suspendedProcessGroup.ProcessGroupID)).To(BeNil())
+
+					pvcs := &corev1.PersistentVolumeClaimList{}
+					Expect(k8sClient.List(context.TODO(), pvcs, internal.GetSinglePodListOptions(cluster, suspendedProcessGroup.ProcessGroupID)...)).To(Succeed())
+					Expect(pvcs.Items).To(BeEmpty())
+				})
+
+				When("the suspension expiry of the PVC was extended", func() {
+					BeforeEach(func() {
+						expiry = time.Now().Add(2 * time.Hour)
+					})
+
+					It("should delay the removal until the suspension expires", func() {
+						Expect(removeResult).NotTo(BeNil())
+						Expect(removeResult.delayedRequeue).To(BeTrue())
+						Expect(removeResult.delay).To(BeNumerically(">", 119*time.Minute))
+						Expect(fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, suspendedProcessGroup.ProcessGroupID)).NotTo(BeNil())
+					})
+				})
+			})
+		})
+	})
+})
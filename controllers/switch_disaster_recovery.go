@@ -0,0 +1,73 @@
+/*
+ * switch_disaster_recovery.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// switchDisasterRecovery provides a reconciliation step for switching the
+// source and the destination of the replication.
+type switchDisasterRecovery struct{}
+
+// reconcile runs the reconciler's work.
+func (s switchDisasterRecovery) reconcile(ctx context.Context, r *FoundationDBDisasterRecoveryReconciler, disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) *requeue {
+	if !disasterRecovery.ShouldRun() || !disasterRecovery.NeedsSwitch() {
+		return nil
+	}
+
+	details := disasterRecovery.Status.DRDetails
+	// fdbdr will only switch if the destination is a complete copy of the source.
+	if !details.Restorable {
+		return &requeue{
+			message: fmt.Sprintf("waiting for destination cluster %s to be a complete copy before switching", details.DestinationClusterName),
+			delay:   30 * time.Second,
+		}
+	}
+
+	adminClient, source, err := r.adminClientForDisasterRecovery(ctx, disasterRecovery, details.SourceClusterName, details.DestinationClusterName)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	err = adminClient.SwitchDisasterRecovery(source)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	r.Recorder.Event(disasterRecovery, corev1.EventTypeNormal, "DisasterRecoverySwitched", fmt.Sprintf("switched replication, %s is the new source cluster", details.DestinationClusterName))
+
+	disasterRecovery.Status.DRDetails = &fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+		SourceClusterName:      details.DestinationClusterName,
+		DestinationClusterName: details.SourceClusterName,
+		Running:                true,
+	}
+	disasterRecovery.Status.LastSwitchTimestamp = &metav1.Time{Time: time.Now()}
+
+	return nil
+}
@@ -56,7 +56,13 @@ func (u updateBackupAgents) reconcile(ctx context.Context, r *FoundationDBBackup
 		}
 	}
 
-	deployment, err := internal.GetBackupDeployment(backup)
+	credentialsHash, err := r.getBlobCredentialsHash(ctx, backup)
+	if err != nil {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "GetBlobCredentials", err.Error())
+		return &requeue{curError: err}
+	}
+
+	deployment, err := internal.GetBackupDeployment(backup, credentialsHash)
 	if err != nil {
 		r.Recorder.Event(backup, corev1.EventTypeWarning, "GetBackupDeployment", err.Error())
 		return &requeue{curError: err}
@@ -22,12 +22,17 @@ package controllers
 
 import (
 	"context"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	"k8s.io/apimachinery/pkg/api/equality"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -39,6 +44,10 @@ type updateBackupStatus struct{}
 func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
 	status := fdbv1beta2.FoundationDBBackupStatus{}
 	status.Generations.Reconciled = backup.Status.Generations.Reconciled
+	status.LastScheduledSnapshot = backup.Status.LastScheduledSnapshot
+	status.LastExpiration = backup.Status.LastExpiration
+	status.AgentAutoscaling = backup.Status.AgentAutoscaling
+	status.RestoreValidation = backup.Status.RestoreValidation
 
 	backupDeployments := &appsv1.DeploymentList{}
 	err := r.List(ctx, backupDeployments, client.InNamespace(backup.Namespace), client.MatchingLabels(map[string]string{fdbv1beta2.BackupDeploymentLabel: string(backup.ObjectMeta.UID)}))
@@ -46,7 +55,12 @@ func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackup
 		return &requeue{curError: err}
 	}
 
-	desiredBackupDeployment, err := internal.GetBackupDeployment(backup)
+	credentialsHash, err := r.getBlobCredentialsHash(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	desiredBackupDeployment, err := internal.GetBackupDeployment(backup, credentialsHash)
 	if err != nil {
 		return &requeue{curError: err}
 	}
@@ -82,18 +96,40 @@ func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackup
 	}
 	defer adminClient.Close()
 
-	liveStatus, err := adminClient.GetBackupStatus()
-	if err != nil {
-		return &requeue{curError: err}
+	destinations := backup.GetDestinations()
+	if len(destinations) == 0 {
+		destinations = []fdbv1beta2.BackupDestination{{Name: fdbv1beta2.DefaultBackupTag}}
 	}
 
-	status.BackupDetails = &fdbv1beta2.FoundationDBBackupStatusBackupDetails{
-		URL:                   liveStatus.DestinationURL,
-		Running:               liveStatus.Status.Running,
-		Paused:                liveStatus.BackupAgentsPaused,
-		SnapshotPeriodSeconds: liveStatus.SnapshotIntervalSeconds,
+	destinationNames := make(map[string]fdbv1beta2.None, len(destinations))
+	for _, destination := range destinations {
+		destinationNames[destination.Name] = fdbv1beta2.None{}
+		destinationStatus, err := getBackupDestinationStatus(adminClient, backup, destination.Name)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		status.Destinations = append(status.Destinations, *destinationStatus)
+	}
+
+	// Destinations that were removed from the spec will be kept in the status until their backup is stopped.
+	for _, previousStatus := range backup.Status.Destinations {
+		if _, ok := destinationNames[previousStatus.Name]; ok || !previousStatus.BackupDetails.Running {
+			continue
+		}
+
+		destinationStatus, err := getBackupDestinationStatus(adminClient, backup, previousStatus.Name)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if destinationStatus.BackupDetails.Running {
+			status.Destinations = append(status.Destinations, *destinationStatus)
+		}
 	}
 
+	status.BackupDetails, status.RestorableRange = getBackupSummary(backup, status.Destinations)
+
 	originalStatus := backup.Status.DeepCopy()
 
 	backup.Status = status
@@ -113,3 +149,123 @@ func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackup
 
 	return nil
 }
+
+// getBackupDestinationStatus returns the status of the backup for the destination with the provided name.
+func getBackupDestinationStatus(adminClient fdbadminclient.AdminClient, backup *fdbv1beta2.FoundationDBBackup, name string) (*fdbv1beta2.BackupDestinationStatus, error) {
+	liveStatus, err := adminClient.GetBackupStatus(name)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationStatus := &fdbv1beta2.BackupDestinationStatus{
+		Name: name,
+		BackupDetails: fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+			URL:                   liveStatus.DestinationURL,
+			Running:               liveStatus.Status.Running,
+			Paused:                liveStatus.BackupAgentsPaused,
+			SnapshotPeriodSeconds: liveStatus.SnapshotIntervalSeconds,
+		},
+	}
+
+	if liveStatus.Status.Running && (liveStatus.LatestRestorablePoint != nil || liveStatus.LogBytesWritten > 0 || liveStatus.RangeBytesWritten > 0) {
+		destinationStatus.Progress = &fdbv1beta2.BackupProgress{
+			LogBytesWritten:   liveStatus.LogBytesWritten,
+			RangeBytesWritten: liveStatus.RangeBytesWritten,
+		}
+
+		if liveStatus.LatestRestorablePoint != nil {
+			destinationStatus.Progress.LagSeconds = int64(math.Ceil(liveStatus.LatestRestorablePoint.LagSeconds))
+		}
+	}
+
+	if previousStatus := backup.Status.GetDestinationStatus(name); previousStatus != nil {
+		destinationStatus.RestorableRange = previousStatus.RestorableRange
+	}
+
+	// The operator has no access to the volumes of file destinations, so those can't be described. The API documents
+	// that the restorable range is only reported for blob store destinations.
+	url := destinationStatus.BackupDetails.URL
+	if url == "" || !destinationStatus.BackupDetails.Running || isFileBackupURL(url) {
+		return destinationStatus, nil
+	}
+
+	description, err := adminClient.DescribeBackup(url)
+	if err != nil {
+		// The restorable range is only informational, so the status update should not be blocked.
+		globalControllerLogger.Error(err, "Error describing backup", "namespace", backup.Namespace, "backup", backup.Name, "destination", name)
+		return destinationStatus, nil
+	}
+
+	destinationStatus.RestorableRange = getBackupRestorableRange(description)
+
+	return destinationStatus, nil
+}
+
+// getBackupSummary returns the backup details and the restorable range for the top level of the status. If the backup
+// defines no destinations, those reflect the single default destination. Otherwise the backup details only summarize
+// whether any destination is running or paused and no restorable range is returned, as the ranges of the destinations
+// can't be combined into a single range.
+func getBackupSummary(backup *fdbv1beta2.FoundationDBBackup, destinations []fdbv1beta2.BackupDestinationStatus) (*fdbv1beta2.FoundationDBBackupStatusBackupDetails, *fdbv1beta2.BackupRestorableRange) {
+	if len(destinations) == 0 {
+		return nil, nil
+	}
+
+	if len(backup.Spec.Destinations) == 0 {
+		return destinations[0].BackupDetails.DeepCopy(), destinations[0].RestorableRange
+	}
+
+	details := &fdbv1beta2.FoundationDBBackupStatusBackupDetails{}
+	for _, destination := range destinations {
+		details.Running = details.Running || destination.BackupDetails.Running
+		details.Paused = details.Paused || destination.BackupDetails.Paused
+	}
+
+	return details, nil
+}
+
+// isFileBackupURL returns true if the backup URL points to a local directory.
+func isFileBackupURL(url string) bool {
+	return strings.HasPrefix(url, "file://")
+}
+
+// getBackupRestorableRange returns the range of versions to which the backup can be restored. If the backup is not
+// restorable nil will be returned.
+func getBackupRestorableRange(description *fdbv1beta2.FoundationDBBackupDescription) *fdbv1beta2.BackupRestorableRange {
+	if !description.Restorable || description.MinRestorablePoint == nil || description.MaxRestorablePoint == nil {
+		return nil
+	}
+
+	restorableRange := &fdbv1beta2.BackupRestorableRange{
+		MinVersion:   description.MinRestorablePoint.Version,
+		MinTimestamp: getBackupVersionTimestamp(description.MinRestorablePoint),
+		MaxVersion:   description.MaxRestorablePoint.Version,
+		MaxTimestamp: getBackupVersionTimestamp(description.MaxRestorablePoint),
+	}
+
+	var latestSnapshot *fdbv1beta2.FoundationDBBackupVersion
+	for idx, snapshot := range description.Snapshots {
+		if !snapshot.Restorable {
+			continue
+		}
+
+		restorableRange.Snapshots++
+		if latestSnapshot == nil || snapshot.EndVersion.Version > latestSnapshot.Version {
+			latestSnapshot = &description.Snapshots[idx].EndVersion
+		}
+	}
+
+	if latestSnapshot != nil {
+		restorableRange.LatestSnapshotTimestamp = getBackupVersionTimestamp(latestSnapshot)
+	}
+
+	return restorableRange
+}
+
+// getBackupVersionTimestamp returns the timestamp of the backup version, if the timestamp is unknown nil will be returned.
+func getBackupVersionTimestamp(version *fdbv1beta2.FoundationDBBackupVersion) *metav1.Time {
+	if version.EpochSeconds <= 0 {
+		return nil
+	}
+
+	return &metav1.Time{Time: time.Unix(version.EpochSeconds, 0)}
+}
@@ -23,16 +23,21 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 
 	"k8s.io/utils/pointer"
 
 	fdbtypes "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 )
 
+// configurationPlanApprovalPollInterval defines how often the operator checks if a configuration plan was approved.
+const configurationPlanApprovalPollInterval = 1 * time.Minute
+
 // updateDatabaseConfiguration provides a reconciliation step for changing the
 // database configuration.
 type updateDatabaseConfiguration struct{}
@@ -71,7 +76,16 @@ func (u updateDatabaseConfiguration) reconcile(ctx context.Context, r *Foundatio
 	currentConfiguration.ExcludedServers = nil
 	cluster.ClearMissingVersionFlags(&currentConfiguration)
 
-	if initialConfig || !equality.Semantic.DeepEqual(desiredConfiguration, currentConfiguration) {
+	configurationMatches := equality.Semantic.DeepEqual(desiredConfiguration, currentConfiguration)
+	if cluster.Status.ConfigurationPlan != nil && (configurationMatches || !cluster.RequiresConfigurationApproval()) {
+		cluster.Status.ConfigurationPlan = nil
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	if initialConfig || !configurationMatches {
 		var nextConfiguration fdbtypes.DatabaseConfiguration
 		if initialConfig {
 			nextConfiguration = desiredConfiguration
@@ -80,6 +94,13 @@ func (u updateDatabaseConfiguration) reconcile(ctx context.Context, r *Foundatio
 		}
 		configurationString, _ := nextConfiguration.GetConfigurationString(cluster.Spec.Version)
 
+		if !initialConfig && cluster.RequiresConfigurationApproval() {
+			req := checkConfigurationPlan(ctx, r, cluster, currentConfiguration, desiredConfiguration, logger)
+			if req != nil {
+				return req
+			}
+		}
+
 		dataState := status.Cluster.Data.State
 		if !(initialConfig || dataState.Healthy) {
 			logger.Info("Waiting for data distribution to be healthy", "stateName", dataState.Name, "stateDescription", dataState.Description)
@@ -89,6 +110,11 @@ func (u updateDatabaseConfiguration) reconcile(ctx context.Context, r *Foundatio
 		}
 
 		if !initialConfig {
+			req := r.checkGlobalCoordination(logger, cluster, status, fdbtypes.GlobalCoordinationActionConfigureDatabase, configurationString)
+			if req != nil {
+				return req
+			}
+
 			hasLock, err := r.takeLock(logger, cluster,
 				fmt.Sprintf("reconfiguring the database to `%s`", configurationString))
 			if !hasLock {
@@ -114,10 +140,113 @@ func (u updateDatabaseConfiguration) reconcile(ctx context.Context, r *Foundatio
 		}
 		logger.Info("Configured database")
 
+		err = r.clearGlobalCoordination(cluster, fdbtypes.GlobalCoordinationActionConfigureDatabase)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
 		if !equality.Semantic.DeepEqual(nextConfiguration, desiredConfiguration) {
 			return &requeue{message: "Requeuing for next stage of database configuration change", delayedRequeue: true}
 		}
+
+		// The last step of the plan was executed, so the plan can be removed.
+		if cluster.Status.ConfigurationPlan != nil {
+			cluster.Status.ConfigurationPlan = nil
+			err = r.updateOrApply(ctx, cluster)
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+		}
 	}
 
 	return nil
 }
+
+// checkConfigurationPlan computes the configuration changes between the current and the desired configuration and
+// stores them as plan in the cluster status. A requeue will be returned until the plan is approved. An approved plan
+// stays approved as long as the remaining configuration changes match the end of the plan.
+func checkConfigurationPlan(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbtypes.FoundationDBCluster, currentConfiguration fdbtypes.DatabaseConfiguration, desiredConfiguration fdbtypes.DatabaseConfiguration, logger logr.Logger) *requeue {
+	changes := currentConfiguration.GetConfigurationChanges(desiredConfiguration)
+	steps := make([]string, 0, len(changes))
+	for _, change := range changes {
+		step, err := change.GetConfigurationString(cluster.Spec.Version)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+		steps = append(steps, step)
+	}
+
+	plan := cluster.Status.ConfigurationPlan
+	if cluster.IsConfigurationPlanApproved() && isRemainingConfigurationPlan(plan, steps) {
+		completedSteps := len(plan.Steps) - len(steps)
+		if plan.CompletedSteps == completedSteps {
+			return nil
+		}
+
+		plan.CompletedSteps = completedSteps
+		err := r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		return nil
+	}
+
+	// A new plan is only created if the steps changed, otherwise an approval of the pending plan would be lost.
+	if plan == nil || !equality.Semantic.DeepEqual(plan.Steps, steps) {
+		var err error
+		plan, err = newConfigurationPlan(cluster, steps)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		logger.Info("Configuration plan requires approval", "hash", plan.Hash, "steps", steps)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "ConfigurationPlanCreated",
+			fmt.Sprintf("Configuration plan %s with %d steps requires approval", plan.Hash, len(steps)))
+		cluster.Status.ConfigurationPlan = plan
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	if cluster.IsConfigurationPlanApproved() {
+		return nil
+	}
+
+	return &requeue{message: fmt.Sprintf("Waiting for approval of configuration plan %s", plan.Hash), delayedRequeue: true, delay: configurationPlanApprovalPollInterval}
+}
+
+// newConfigurationPlan creates a configuration plan for the provided steps. The hash of the plan includes the
+// generation of the cluster and the creation time of the plan, so the approval annotation of an earlier plan with the
+// same steps doesn't approve the new plan.
+func newConfigurationPlan(cluster *fdbtypes.FoundationDBCluster, steps []string) (*fdbtypes.ConfigurationPlan, error) {
+	now := time.Now()
+	hash, err := internal.GetJSONHash(struct {
+		Generation   int64
+		CreationTime int64
+		Steps        []string
+	}{
+		Generation:   cluster.Generation,
+		CreationTime: now.UnixNano(),
+		Steps:        steps,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fdbtypes.ConfigurationPlan{
+		Hash:              hash,
+		Steps:             steps,
+		CreationTimestamp: now.Unix(),
+	}, nil
+}
+
+// isRemainingConfigurationPlan returns true if the steps match the last steps of the plan.
+func isRemainingConfigurationPlan(plan *fdbtypes.ConfigurationPlan, steps []string) bool {
+	if len(steps) == 0 || len(steps) > len(plan.Steps) {
+		return false
+	}
+
+	return equality.Semantic.DeepEqual(plan.Steps[len(plan.Steps)-len(steps):], steps)
+}
@@ -0,0 +1,168 @@
+/*
+ * update_disaster_recovery_status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateDisasterRecoveryStatus provides a reconciliation step for updating the
+// status in the CRD.
+type updateDisasterRecoveryStatus struct{}
+
+// reconcile runs the reconciler's work.
+func (s updateDisasterRecoveryStatus) reconcile(ctx context.Context, r *FoundationDBDisasterRecoveryReconciler, disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) *requeue {
+	status := fdbv1beta2.FoundationDBDisasterRecoveryStatus{}
+	status.Generations.Reconciled = disasterRecovery.Status.Generations.Reconciled
+	status.LastSwitchTimestamp = disasterRecovery.Status.LastSwitchTimestamp
+
+	deployments := &appsv1.DeploymentList{}
+	err := r.List(ctx, deployments, client.InNamespace(disasterRecovery.Namespace), client.MatchingLabels(map[string]string{fdbv1beta2.DisasterRecoveryDeploymentLabel: string(disasterRecovery.ObjectMeta.UID)}))
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	desiredDeployment, err := internal.GetDisasterRecoveryDeployment(disasterRecovery)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if len(deployments.Items) == 1 && desiredDeployment != nil {
+		deployment := deployments.Items[0]
+		status.AgentCount = int(deployment.Status.ReadyReplicas)
+		if status.AgentCount > int(deployment.Status.UpdatedReplicas) {
+			status.AgentCount = int(deployment.Status.UpdatedReplicas)
+		}
+		generationsMatch := deployment.Status.ObservedGeneration == deployment.ObjectMeta.Generation
+
+		annotationChange := mergeAnnotations(&deployment.ObjectMeta, desiredDeployment.ObjectMeta)
+
+		metadataMatch := !annotationChange &&
+			equality.Semantic.DeepEqual(deployment.ObjectMeta.Labels, desiredDeployment.ObjectMeta.Labels)
+
+		status.DeploymentConfigured = generationsMatch && metadataMatch
+
+		if r.InSimulation {
+			status.AgentCount = int(*deployment.Spec.Replicas)
+			status.DeploymentConfigured = metadataMatch
+		}
+	} else if len(deployments.Items) == 0 && desiredDeployment == nil {
+		status.DeploymentConfigured = true
+	} else {
+		status.DeploymentConfigured = false
+	}
+
+	status.DRDetails, err = r.getDisasterRecoveryDetails(ctx, disasterRecovery)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	originalStatus := disasterRecovery.Status.DeepCopy()
+
+	disasterRecovery.Status = status
+
+	_, err = disasterRecovery.CheckReconciliation()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if !equality.Semantic.DeepEqual(disasterRecovery.Status, *originalStatus) {
+		err = r.updateOrApply(ctx, disasterRecovery)
+		if err != nil {
+			globalControllerLogger.Error(err, "Error updating disaster recovery status", "namespace", disasterRecovery.Namespace, "disasterRecovery", disasterRecovery.Name)
+			return &requeue{curError: err}
+		}
+	}
+
+	return nil
+}
+
+// getDisasterRecoveryDetails returns the state of the replication. The output of fdbdr contains no information about
+// the direction of the replication, so the last known direction and both directions between the desired clusters are
+// checked. If no replication is running, the details for the desired direction will be returned.
+func (r *FoundationDBDisasterRecoveryReconciler) getDisasterRecoveryDetails(ctx context.Context, disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) (*fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails, error) {
+	desired := fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+		SourceClusterName:      disasterRecovery.Spec.SourceClusterName,
+		DestinationClusterName: disasterRecovery.Spec.DestinationClusterName,
+	}
+
+	candidates := make([]fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails, 0, 3)
+	if disasterRecovery.IsRunning() {
+		candidates = append(candidates, fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+			SourceClusterName:      disasterRecovery.Status.DRDetails.SourceClusterName,
+			DestinationClusterName: disasterRecovery.Status.DRDetails.DestinationClusterName,
+		})
+	}
+
+	candidates = append(candidates,
+		desired,
+		fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+			SourceClusterName:      desired.DestinationClusterName,
+			DestinationClusterName: desired.SourceClusterName,
+		},
+	)
+
+	checked := make(map[fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails]fdbv1beta2.None, len(candidates))
+	for _, candidate := range candidates {
+		if _, ok := checked[candidate]; ok {
+			continue
+		}
+		checked[candidate] = fdbv1beta2.None{}
+
+		details, err := r.getDisasterRecoveryDetailsForClusters(ctx, disasterRecovery, candidate.SourceClusterName, candidate.DestinationClusterName)
+		if err != nil {
+			return nil, err
+		}
+
+		if details.Running {
+			return details, nil
+		}
+	}
+
+	return &desired, nil
+}
+
+// getDisasterRecoveryDetailsForClusters returns the state of the replication from the source cluster into the
+// destination cluster.
+func (r *FoundationDBDisasterRecoveryReconciler) getDisasterRecoveryDetailsForClusters(ctx context.Context, disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery, sourceClusterName string, destinationClusterName string) (*fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails, error) {
+	adminClient, source, err := r.adminClientForDisasterRecovery(ctx, disasterRecovery, sourceClusterName, destinationClusterName)
+	if err != nil {
+		return nil, err
+	}
+	defer adminClient.Close()
+
+	output, err := adminClient.GetDisasterRecoveryStatus(source)
+	if err != nil {
+		return nil, err
+	}
+
+	details := internal.ParseDisasterRecoveryStatus(output)
+	details.SourceClusterName = sourceClusterName
+	details.DestinationClusterName = destinationClusterName
+
+	return details, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * update_process_group_resources.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateProcessGroupResources creates, updates and deletes the FoundationDBProcessGroup resources of the cluster to
+// match the process groups in the cluster status. Only the resources of process groups that changed will be written.
+func (r *FoundationDBClusterReconciler) updateProcessGroupResources(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster) error {
+	existingProcessGroups, err := internal.GetProcessGroupResources(ctx, r, cluster)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.FoundationDBProcessGroup, len(existingProcessGroups))
+	for idx, processGroup := range existingProcessGroups {
+		existing[processGroup.Spec.ProcessGroupID] = &existingProcessGroups[idx]
+	}
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		desired := fdbv1beta2.NewFoundationDBProcessGroup(cluster, processGroup)
+
+		current, ok := existing[processGroup.ProcessGroupID]
+		if !ok {
+			desired.ObjectMeta = internal.GetProcessGroupResourceMetadata(cluster, processGroup)
+			desiredStatus := desired.Status
+			err = r.Create(ctx, desired)
+			if err != nil {
+				return err
+			}
+
+			// The status will be ignored during the creation of the resource, so we have to set it separately.
+			if equality.Semantic.DeepEqual(desiredStatus, fdbv1beta2.FoundationDBProcessGroupStatus{}) {
+				continue
+			}
+
+			patch := client.MergeFrom(desired.DeepCopy())
+			desired.Status = desiredStatus
+			err = r.Status().Patch(ctx, desired, patch)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		delete(existing, processGroup.ProcessGroupID)
+		if equality.Semantic.DeepEqual(current.Status, desired.Status) {
+			continue
+		}
+
+		patch := client.MergeFrom(current.DeepCopy())
+		current.Status = desired.Status
+		err = r.Status().Patch(ctx, current, patch)
+		if err != nil {
+			return err
+		}
+	}
+
+	// All remaining resources have no matching process group in the cluster status anymore.
+	for _, processGroup := range existing {
+		err = r.Delete(ctx, processGroup)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+ * update_process_group_resources_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("update_process_group_resources", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var processGroupResources []fdbv1beta2.FoundationDBProcessGroup
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.AutomationOptions.UseProcessGroupResources = pointer.Bool(true)
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		processGroupResources, err = internal.GetProcessGroupResources(context.TODO(), k8sClient, cluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not store the process groups in the cluster status", func() {
+		Expect(cluster.Status.ProcessGroups).To(BeEmpty())
+	})
+
+	It("should create a resource for every process group", func() {
+		pods := &corev1.PodList{}
+		Expect(k8sClient.List(context.TODO(), pods, getListOptions(cluster)...)).To(Succeed())
+		Expect(processGroupResources).To(HaveLen(len(pods.Items)))
+		for _, processGroup := range processGroupResources {
+			Expect(processGroup.Spec.ClusterName).To(Equal(cluster.Name))
+			Expect(processGroup.OwnerReferences).To(HaveLen(1))
+			Expect(processGroup.Status.Addresses).NotTo(BeEmpty())
+		}
+	})
+
+	When("loading the process groups from the resources", func() {
+		JustBeforeEach(func() {
+			Expect(internal.LoadProcessGroupsFromResources(context.TODO(), k8sClient, cluster)).To(Succeed())
+		})
+
+		It("should set the process groups in the cluster status", func() {
+			Expect(cluster.Status.ProcessGroups).To(HaveLen(len(processGroupResources)))
+		})
+
+		When("a process group is removed from the cluster status", func() {
+			var removedProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+			JustBeforeEach(func() {
+				removedProcessGroup = cluster.Status.ProcessGroups[0]
+				cluster.Status.ProcessGroups = cluster.Status.ProcessGroups[1:]
+				Expect(clusterReconciler.updateProcessGroupResources(context.TODO(), cluster)).To(Succeed())
+			})
+
+			It("should delete the matching resource", func() {
+				resources, err := internal.GetProcessGroupResources(context.TODO(), k8sClient, cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources).To(HaveLen(len(processGroupResources) - 1))
+				for _, processGroup := range resources {
+					Expect(processGroup.Spec.ProcessGroupID).NotTo(Equal(removedProcessGroup.ProcessGroupID))
+				}
+			})
+		})
+
+		When("no process group changed", func() {
+			It("should not write any resource", func() {
+				Expect(clusterReconciler.updateProcessGroupResources(context.TODO(), cluster)).To(Succeed())
+				resources, err := internal.GetProcessGroupResources(context.TODO(), k8sClient, cluster)
+				Expect(err).NotTo(HaveOccurred())
+
+				resourceVersions := make(map[string]string, len(processGroupResources))
+				for _, processGroup := range processGroupResources {
+					resourceVersions[processGroup.Name] = processGroup.ResourceVersion
+				}
+
+				for _, processGroup := range resources {
+					Expect(processGroup.ResourceVersion).To(Equal(resourceVersions[processGroup.Name]))
+				}
+			})
+		})
+
+		When("a process group is marked for removal", func() {
+			var markedProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+			JustBeforeEach(func() {
+				markedProcessGroup = cluster.Status.ProcessGroups[0]
+				markedProcessGroup.MarkForRemoval()
+				Expect(clusterReconciler.updateProcessGroupResources(context.TODO(), cluster)).To(Succeed())
+			})
+
+			It("should update the status of the matching resource", func() {
+				resources, err := internal.GetProcessGroupResources(context.TODO(), k8sClient, cluster)
+				Expect(err).NotTo(HaveOccurred())
+				for _, processGroup := range resources {
+					if processGroup.Spec.ProcessGroupID != markedProcessGroup.ProcessGroupID {
+						continue
+					}
+
+					Expect(processGroup.Status.RemovalTimestamp).NotTo(BeNil())
+				}
+			})
+		})
+	})
+
+	When("reading the cluster outside of the cluster reconciler", func() {
+		It("should load the process groups with the shared accessors", func() {
+			loadedCluster, err := internal.GetCluster(context.TODO(), k8sClient, ctrlClient.ObjectKeyFromObject(cluster))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loadedCluster.Status.ProcessGroups).To(HaveLen(len(processGroupResources)))
+
+			clusters, err := internal.ListClusters(context.TODO(), k8sClient, ctrlClient.InNamespace(cluster.Namespace))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters.Items).To(HaveLen(1))
+			Expect(clusters.Items[0].Status.ProcessGroups).To(HaveLen(len(processGroupResources)))
+		})
+
+		It("should provide the process groups to the backup reconciler", func() {
+			backup := &fdbv1beta2.FoundationDBBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cluster.Name,
+					Namespace: cluster.Namespace,
+				},
+				Spec: fdbv1beta2.FoundationDBBackupSpec{
+					ClusterName: cluster.Name,
+				},
+			}
+
+			adminClient, err := backupReconciler.adminClientForBackup(context.TODO(), backup)
+			Expect(err).NotTo(HaveOccurred())
+			mockAdminClient, ok := adminClient.(*mock.AdminClient)
+			Expect(ok).To(BeTrue())
+			Expect(mockAdminClient.Cluster.Status.ProcessGroups).To(HaveLen(len(processGroupResources)))
+		})
+
+		It("should report the process group metrics", func() {
+			collector := &testCollector{collect: newFDBClusterCollector(clusterReconciler).Collect}
+			Expect(testutil.CollectAndCount(collector, "fdb_operator_process_group_total")).To(BeNumerically(">", 0))
+		})
+	})
+
+	When("the cluster doesn't use process group resources", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.UseProcessGroupResources = pointer.Bool(false)
+		})
+
+		It("should not load the process groups from the resources", func() {
+			cluster.Status.ProcessGroups = nil
+			Expect(processGroupResources).NotTo(BeEmpty())
+			Expect(internal.LoadProcessGroupsFromResources(context.TODO(), k8sClient, cluster)).To(Succeed())
+			Expect(cluster.Status.ProcessGroups).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,121 @@
+/*
+ * update_restore_status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// updateRestoreProgress updates the restore progress in the status based on the output of `fdbrestore status`.
+func updateRestoreProgress(ctx context.Context, r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore, output string) *requeue {
+	phase, progress := internal.ParseRestoreStatus(output)
+	if phase == "" {
+		return nil
+	}
+
+	originalStatus := restore.Status.DeepCopy()
+	previousPhase := restore.Status.Phase
+	var previousError string
+	if restore.Status.Progress != nil {
+		previousError = restore.Status.Progress.LastError
+	}
+
+	restore.Status.Phase = phase
+	restore.Status.Progress = progress
+	restore.Status.Running = !phase.IsFinished()
+
+	if phase.IsFinished() && previousPhase != phase {
+		restore.Status.FinishTimestamp = &metav1.Time{Time: time.Now()}
+		if phase == fdbv1beta2.RestorePhaseCompleted {
+			r.Recorder.Event(restore, corev1.EventTypeNormal, "RestoreCompleted", "Restore was completed")
+		} else {
+			r.Recorder.Event(restore, corev1.EventTypeWarning, "RestoreAborted", fmt.Sprintf("Restore was aborted, last error: %s", progress.LastError))
+		}
+	}
+
+	if progress.LastError != "" && progress.LastError != previousError {
+		r.Recorder.Event(restore, corev1.EventTypeWarning, "RestoreError", progress.LastError)
+	}
+
+	setRestoreConditions(restore)
+
+	if !equality.Semantic.DeepEqual(restore.Status, *originalStatus) {
+		err := r.updateOrApply(ctx, restore)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	return nil
+}
+
+// setRestoreConditions sets the conditions of the restore based on the current phase.
+func setRestoreConditions(restore *fdbv1beta2.FoundationDBRestore) {
+	phase := restore.Status.Phase
+	failedMessage := "No error was reported"
+	if restore.Status.Progress != nil && restore.Status.Progress.LastError != "" {
+		failedMessage = fmt.Sprintf("Last error: %s", restore.Status.Progress.LastError)
+	}
+
+	conditions := []metav1.Condition{
+		{
+			Type:    fdbv1beta2.RestoreConditionRunning,
+			Status:  getConditionStatus(!phase.IsFinished()),
+			Reason:  string(phase),
+			Message: fmt.Sprintf("Restore is in phase %s", phase),
+		},
+		{
+			Type:    fdbv1beta2.RestoreConditionCompleted,
+			Status:  getConditionStatus(phase == fdbv1beta2.RestorePhaseCompleted),
+			Reason:  string(phase),
+			Message: fmt.Sprintf("Restore is in phase %s", phase),
+		},
+		{
+			Type:    fdbv1beta2.RestoreConditionFailed,
+			Status:  getConditionStatus(phase == fdbv1beta2.RestorePhaseAborted),
+			Reason:  string(phase),
+			Message: failedMessage,
+		},
+	}
+
+	for _, condition := range conditions {
+		condition.ObservedGeneration = restore.Generation
+		meta.SetStatusCondition(&restore.Status.Conditions, condition)
+	}
+}
+
+// getConditionStatus converts a boolean into a condition status.
+func getConditionStatus(value bool) metav1.ConditionStatus {
+	if value {
+		return metav1.ConditionTrue
+	}
+
+	return metav1.ConditionFalse
+}
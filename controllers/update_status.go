@@ -54,6 +54,12 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 	clusterStatus := fdbv1beta2.FoundationDBClusterStatus{}
 	// Pass through Maintenance Mode Info as the maintenance_mode_checker reconciler takes care of updating it
 	originalStatus.MaintenanceModeInfo.DeepCopyInto(&clusterStatus.MaintenanceModeInfo)
+	// Pass through the node drain status as the drain_nodes reconciler takes care of updating it
+	clusterStatus.NodeDrains = originalStatus.NodeDrains
+	// Pass through the storage engine migration status as the migrate_storage_engine reconciler takes care of updating it
+	clusterStatus.StorageEngineMigration = originalStatus.StorageEngineMigration
+	// Pass through the configuration plan as the update_database_configuration reconciler takes care of updating it
+	clusterStatus.ConfigurationPlan = originalStatus.ConfigurationPlan
 	clusterStatus.Generations.Reconciled = cluster.Status.Generations.Reconciled
 
 	// Initialize with the current desired storage servers per Pod
@@ -71,6 +77,10 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		}
 	}
 
+	// Pass through the coordinator changes as they are recorded whenever the connection string is changed. This must
+	// happen after the status was fetched, as fetching the status might record a connection string change.
+	clusterStatus.CoordinatorChanges = cluster.Status.CoordinatorChanges
+
 	versionMap := map[string]int{}
 	for _, process := range databaseStatus.Cluster.Processes {
 		versionMap[process.Version]++
@@ -103,6 +113,7 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		// Removing excluded servers as we don't want them during comparison.
 		clusterStatus.DatabaseConfiguration.ExcludedServers = nil
 		cluster.ClearMissingVersionFlags(&clusterStatus.DatabaseConfiguration)
+		clusterStatus.StorageWiggle = getStorageWiggleStatus(databaseStatus)
 	}
 
 	clusterStatus.Configured = cluster.Status.Configured || (databaseStatus.Client.DatabaseStatus.Available && databaseStatus.Cluster.Layers.Error != "configurationMissing")
@@ -148,7 +159,8 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		}
 	}
 
-	updateFaultDomains(logger, processMap, &clusterStatus)
+	updateFaultDomains(logger, cluster, processMap, &clusterStatus)
+	assignLogicalFaultDomains(logger, cluster, &clusterStatus)
 
 	pvcs, err := refreshProcessGroupStatus(ctx, r, cluster, &clusterStatus)
 	if err != nil {
@@ -209,7 +221,12 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 			coordinatorStatus[coordinator.Address.String()] = false
 		}
 
-		coordinatorsValid, _, err := locality.CheckCoordinatorValidity(logger, cluster, databaseStatus, coordinatorStatus)
+		pods, err := getCoordinatorConstraintPods(ctx, r, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		coordinatorsValid, _, err := locality.CheckCoordinatorValidity(logger, cluster, databaseStatus, coordinatorStatus, getNodeNamesPerProcessGroup(cluster, pods))
 		if err != nil {
 			return &requeue{curError: err, delayedRequeue: true}
 		}
@@ -358,6 +375,11 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 		return nil
 	}
 
+	// The unified image is able to report the command lines of the running processes. Those are only fetched if the
+	// command line from the machine-readable status doesn't match, to prevent an additional request per pod.
+	var commandLines map[int]string
+	var fetchedCommandLines bool
+
 	var excluded, correct bool
 	versionCompatibleUpgrade := cluster.VersionCompatibleUpgradeInProgress()
 	for _, process := range processStatus {
@@ -387,12 +409,32 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 			versionMatch = process.Version == cluster.Spec.Version || process.Version == fmt.Sprintf("%s-PRERELEASE", cluster.Spec.Version)
 		}
 
+		runningCommandLine := process.CommandLine
+		if commandLine != runningCommandLine {
+			if !fetchedCommandLines {
+				commandLines, err = podClient.GetProcessCommandLines()
+				if err != nil {
+					if internal.IsNetworkError(err) {
+						processGroupStatus.UpdateCondition(fdbv1beta2.SidecarUnreachable, true)
+						return nil
+					}
+
+					return err
+				}
+				fetchedCommandLines = true
+			}
+
+			if reportedCommandLine, ok := commandLines[processNumber]; ok {
+				runningCommandLine = reportedCommandLine
+			}
+		}
+
 		// If the `EmptyMonitorConf` is set, the commandline is by definition wrong since there should be no running processes.
-		correct = commandLine == process.CommandLine && versionMatch && !cluster.Spec.Buggify.EmptyMonitorConf
+		correct = commandLine == runningCommandLine && versionMatch && !cluster.Spec.Buggify.EmptyMonitorConf
 
 		if !correct {
 			logger.Info("IncorrectProcess",
-				"expected", commandLine, "got", process.CommandLine,
+				"expected", commandLine, "got", runningCommandLine,
 				"expectedVersion", cluster.Spec.Version,
 				"version", process.Version,
 				"processGroupID", processGroupStatus.ProcessGroupID,
@@ -821,6 +863,26 @@ func getRunningVersion(logger logr.Logger, versionMap map[string]int, currentRun
 	return candidateString, nil
 }
 
+// getStorageWiggleStatus returns the progress of the perpetual storage wiggle in the primary region. If the perpetual
+// storage wiggle is disabled, nil will be returned.
+func getStorageWiggleStatus(databaseStatus *fdbv1beta2.FoundationDBStatus) *fdbv1beta2.StorageWiggleStatus {
+	if pointer.IntDeref(databaseStatus.Cluster.DatabaseConfiguration.PerpetualStorageWiggle, 0) == 0 {
+		return nil
+	}
+
+	stats := databaseStatus.Cluster.StorageWiggler.Primary
+	return &fdbv1beta2.StorageWiggleStatus{
+		FinishedRounds:            stats.FinishedRound,
+		FinishedWiggles:           stats.FinishedWiggle,
+		LastRoundStartTimestamp:   int64(stats.LastRoundStartTimestamp),
+		LastRoundFinishTimestamp:  int64(stats.LastRoundFinishTimestamp),
+		LastWiggleFinishTimestamp: int64(stats.LastWiggleFinishTimestamp),
+		SmoothedRoundSeconds:      int64(stats.SmoothedRoundSeconds),
+		SmoothedWiggleSeconds:     int64(stats.SmoothedWiggleSeconds),
+		WiggledAddresses:          databaseStatus.Cluster.StorageWiggler.WiggleServerAddresses,
+	}
+}
+
 func hasExactMatchedTaintKey(taintReplacementOptions []fdbv1beta2.TaintReplacementOption, nodeTaintKey string) bool {
 	for _, configuredTaintKey := range taintReplacementOptions {
 		if *configuredTaintKey.Key == nodeTaintKey {
@@ -864,13 +926,18 @@ func getFaultDomainFromProcesses(processes []fdbv1beta2.FoundationDBStatusProces
 }
 
 // updateFaultDomains will update the process groups fault domain, based on the last seen zone id in the cluster status.
-func updateFaultDomains(logger logr.Logger, processes map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.FoundationDBStatusProcessInfo, status *fdbv1beta2.FoundationDBClusterStatus) {
+// Process groups that are assigned to a logical fault domain will keep their fault domain.
+func updateFaultDomains(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processes map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.FoundationDBStatusProcessInfo, status *fdbv1beta2.FoundationDBClusterStatus) {
 	// If the process map is empty we can skip any further steps.
 	if len(processes) == 0 {
 		return
 	}
 
 	for idx, processGroup := range status.ProcessGroups {
+		if cluster.IsLogicalFaultDomain(processGroup) {
+			continue
+		}
+
 		process, ok := processes[processGroup.ProcessGroupID]
 		if !ok || len(processes) == 0 {
 			// Fallback for multiple storage or log servers, those will contain the process information with the process number as a suffix.
@@ -890,3 +957,64 @@ func updateFaultDomains(logger logr.Logger, processes map[fdbv1beta2.ProcessGrou
 		status.ProcessGroups[idx].FaultDomain = fdbv1beta2.FaultDomain(faultDomain)
 	}
 }
+
+// assignLogicalFaultDomains assigns the process groups to the logical fault domains if the fault domain distribution
+// was enabled and no process group is assigned to a logical fault domain yet. All process groups of a physical fault
+// domain are assigned to the same logical fault domain, so the Pods can be updated in place and the process groups
+// don't have to be replaced. Process groups without a known fault domain will be replaced later.
+func assignLogicalFaultDomains(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBClusterStatus) {
+	if !cluster.UseFaultDomainDistribution() {
+		return
+	}
+
+	processGroupsPerFaultDomain := map[fdbv1beta2.FaultDomain]int{}
+	for _, processGroup := range status.ProcessGroups {
+		if cluster.IsLogicalFaultDomain(processGroup) {
+			return
+		}
+
+		if processGroup.IsMarkedForRemoval() || processGroup.FaultDomain == "" {
+			continue
+		}
+
+		processGroupsPerFaultDomain[processGroup.FaultDomain]++
+	}
+
+	physicalFaultDomains := make([]fdbv1beta2.FaultDomain, 0, len(processGroupsPerFaultDomain))
+	for faultDomain := range processGroupsPerFaultDomain {
+		physicalFaultDomains = append(physicalFaultDomains, faultDomain)
+	}
+
+	// Assign the largest physical fault domains first to get an even distribution.
+	sort.Slice(physicalFaultDomains, func(i, j int) bool {
+		if processGroupsPerFaultDomain[physicalFaultDomains[i]] != processGroupsPerFaultDomain[physicalFaultDomains[j]] {
+			return processGroupsPerFaultDomain[physicalFaultDomains[i]] > processGroupsPerFaultDomain[physicalFaultDomains[j]]
+		}
+
+		return physicalFaultDomains[i] < physicalFaultDomains[j]
+	})
+
+	logicalFaultDomainCounts := map[fdbv1beta2.FaultDomain]int{}
+	assignments := make(map[fdbv1beta2.FaultDomain]fdbv1beta2.FaultDomain, len(physicalFaultDomains))
+	for _, faultDomain := range physicalFaultDomains {
+		var logicalFaultDomain fdbv1beta2.FaultDomain
+		for _, candidate := range cluster.GetLogicalFaultDomains() {
+			if logicalFaultDomain == "" || logicalFaultDomainCounts[candidate] < logicalFaultDomainCounts[logicalFaultDomain] {
+				logicalFaultDomain = candidate
+			}
+		}
+
+		logicalFaultDomainCounts[logicalFaultDomain] += processGroupsPerFaultDomain[faultDomain]
+		assignments[faultDomain] = logicalFaultDomain
+	}
+
+	for _, processGroup := range status.ProcessGroups {
+		logicalFaultDomain, ok := assignments[processGroup.FaultDomain]
+		if !ok || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		logger.Info("assigning process group to logical fault domain", "processGroupID", processGroup.ProcessGroupID, "faultDomain", processGroup.FaultDomain, "logicalFaultDomain", logicalFaultDomain)
+		processGroup.FaultDomain = logicalFaultDomain
+	}
+}
@@ -31,6 +31,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	mockpodclient "github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient/mock"
 
 	"k8s.io/utils/pointer"
 
@@ -407,6 +408,38 @@ var _ = Describe("update_status", func() {
 				Expect(len(processGroup.ProcessGroupConditions)).To(Equal(1))
 			})
 
+			When("the pod reports the desired command line for the running process", func() {
+				BeforeEach(func() {
+					podClient, err := mockpodclient.NewMockFdbPodClient(cluster, storagePod)
+					Expect(err).NotTo(HaveOccurred())
+					commandLine, err := internal.GetStartCommand(cluster, fdbv1beta2.ProcessClassStorage, podClient, 1, 1)
+					Expect(err).NotTo(HaveOccurred())
+					mockpodclient.MockProcessCommandLines(storagePod, map[int]string{1: commandLine})
+				})
+
+				It("should prefer the reported command line", func() {
+					processGroupStatus, err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger)
+					Expect(err).NotTo(HaveOccurred())
+
+					incorrectProcesses := fdbv1beta2.FilterByCondition(processGroupStatus, fdbv1beta2.IncorrectCommandLine, false)
+					Expect(incorrectProcesses).To(BeEmpty())
+				})
+			})
+
+			When("the pod reports a different command line for the running process", func() {
+				BeforeEach(func() {
+					mockpodclient.MockProcessCommandLines(storagePod, map[int]string{1: "/usr/bin/fdbserver --class=storage"})
+				})
+
+				It("should get a condition assigned", func() {
+					processGroupStatus, err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger)
+					Expect(err).NotTo(HaveOccurred())
+
+					incorrectProcesses := fdbv1beta2.FilterByCondition(processGroupStatus, fdbv1beta2.IncorrectCommandLine, false)
+					Expect(incorrectProcesses).To(Equal([]fdbv1beta2.ProcessGroupID{storageOneProcessGroupID}))
+				})
+			})
+
 			When("the process group is marked for removal", func() {
 				BeforeEach(func() {
 					cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{storageOneProcessGroupID}
@@ -752,6 +785,45 @@ var _ = Describe("update_status", func() {
 			})
 		})
 
+		When("the connection string was changed outside of the operator and the status is not cached", func() {
+			var previousConnectionString, newConnectionString string
+
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.CacheDatabaseStatusForReconciliation = pointer.Bool(false)
+				Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+
+				previousConnectionString = cluster.Status.ConnectionString
+				connectionString, err := fdbv1beta2.ParseConnectionString(previousConnectionString)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(connectionString.GenerateNewGenerationID()).To(Succeed())
+				newConnectionString = connectionString.String()
+				adminClient.MockConnectionString(newConnectionString)
+			})
+
+			It("should record the coordinator change", func() {
+				Expect(cluster.Status.ConnectionString).To(Equal(newConnectionString))
+				Expect(cluster.Status.CoordinatorChanges).NotTo(BeEmpty())
+				change := cluster.Status.CoordinatorChanges[len(cluster.Status.CoordinatorChanges)-1]
+				Expect(change.PreviousConnectionString).To(Equal(previousConnectionString))
+				Expect(change.ConnectionString).To(Equal(newConnectionString))
+				Expect(change.Reason).To(Equal("Connection string was changed outside of the operator"))
+			})
+
+			When("the cluster is reconciled again", func() {
+				It("should keep the recorded coordinator change", func() {
+					changes := len(cluster.Status.CoordinatorChanges)
+					_, err := reconcileCluster(cluster)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = reloadCluster(cluster)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(cluster.Status.ConnectionString).To(Equal(newConnectionString))
+					Expect(cluster.Status.CoordinatorChanges).To(HaveLen(changes))
+					Expect(cluster.Status.CoordinatorChanges[changes-1].ConnectionString).To(Equal(newConnectionString))
+				})
+			})
+		})
+
 		When("testing maintenance mode functionality", func() {
 			When("maintenance mode is on", func() {
 				BeforeEach(func() {
@@ -808,6 +880,11 @@ var _ = Describe("update_status", func() {
 	When("updating the fault domains based on the cluster status", func() {
 		var processes map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.FoundationDBStatusProcessInfo
 		var status fdbv1beta2.FoundationDBClusterStatus
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+		})
 
 		JustBeforeEach(func() {
 			status = fdbv1beta2.FoundationDBClusterStatus{
@@ -824,7 +901,7 @@ var _ = Describe("update_status", func() {
 				},
 			}
 
-			updateFaultDomains(logr.Discard(), processes, &status)
+			updateFaultDomains(logr.Discard(), cluster, processes, &status)
 		})
 
 		When("storage-2 has two process information", func() {
@@ -969,5 +1046,131 @@ var _ = Describe("update_status", func() {
 				}
 			})
 		})
+
+		When("the process group is assigned to a logical fault domain", func() {
+			BeforeEach(func() {
+				cluster.Spec.FaultDomain = fdbv1beta2.FoundationDBClusterFaultDomain{
+					Key: corev1.LabelHostname,
+					DistributionConfig: &fdbv1beta2.DistributionConfig{
+						Enabled: pointer.Bool(true),
+					},
+				}
+				processes = map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"storage-1": {
+						fdbv1beta2.FoundationDBStatusProcessInfo{
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityZoneIDKey: "storage-1-zone",
+							},
+						},
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				status.ProcessGroups[0].FaultDomain = fdbv1beta2.FaultDomain(cluster.Name + "-0")
+				updateFaultDomains(logr.Discard(), cluster, processes, &status)
+			})
+
+			It("should keep the logical fault domain", func() {
+				Expect(status.ProcessGroups[0].FaultDomain).To(Equal(fdbv1beta2.FaultDomain(cluster.Name + "-0")))
+			})
+		})
+	})
+
+	When("assigning the process groups to logical fault domains", func() {
+		var status fdbv1beta2.FoundationDBClusterStatus
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			cluster.Spec.FaultDomain = fdbv1beta2.FoundationDBClusterFaultDomain{
+				Key: corev1.LabelHostname,
+				DistributionConfig: &fdbv1beta2.DistributionConfig{
+					Enabled:             pointer.Bool(true),
+					DesiredFaultDomains: pointer.Int(2),
+				},
+			}
+			status = fdbv1beta2.FoundationDBClusterStatus{
+				ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+					{ProcessGroupID: "storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage, FaultDomain: "node-1"},
+					{ProcessGroupID: "log-1", ProcessClass: fdbv1beta2.ProcessClassLog, FaultDomain: "node-1"},
+					{ProcessGroupID: "storage-2", ProcessClass: fdbv1beta2.ProcessClassStorage, FaultDomain: "node-2"},
+					{ProcessGroupID: "storage-3", ProcessClass: fdbv1beta2.ProcessClassStorage, FaultDomain: "node-3"},
+					{ProcessGroupID: "storage-4", ProcessClass: fdbv1beta2.ProcessClassStorage},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			assignLogicalFaultDomains(logr.Discard(), cluster, &status)
+		})
+
+		It("should assign all process groups of a physical fault domain to the same logical fault domain", func() {
+			faultDomains := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FaultDomain{}
+			for _, processGroup := range status.ProcessGroups {
+				faultDomains[processGroup.ProcessGroupID] = processGroup.FaultDomain
+			}
+
+			Expect(faultDomains).To(Equal(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FaultDomain{
+				"storage-1": fdbv1beta2.FaultDomain(cluster.Name + "-0"),
+				"log-1":     fdbv1beta2.FaultDomain(cluster.Name + "-0"),
+				"storage-2": fdbv1beta2.FaultDomain(cluster.Name + "-1"),
+				"storage-3": fdbv1beta2.FaultDomain(cluster.Name + "-1"),
+				"storage-4": "",
+			}))
+		})
+
+		When("a process group is already assigned to a logical fault domain", func() {
+			BeforeEach(func() {
+				status.ProcessGroups[4].FaultDomain = fdbv1beta2.FaultDomain(cluster.Name + "-1")
+			})
+
+			It("should not change the other process groups", func() {
+				Expect(status.ProcessGroups[0].FaultDomain).To(Equal(fdbv1beta2.FaultDomain("node-1")))
+				Expect(status.ProcessGroups[2].FaultDomain).To(Equal(fdbv1beta2.FaultDomain("node-2")))
+			})
+		})
+	})
+
+	When("getting the storage wiggle status", func() {
+		var databaseStatus *fdbv1beta2.FoundationDBStatus
+
+		BeforeEach(func() {
+			databaseStatus = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					StorageWiggler: fdbv1beta2.FoundationDBStatusStorageWiggler{
+						Primary: fdbv1beta2.FoundationDBStatusStorageWigglerStats{
+							FinishedRound:            2,
+							FinishedWiggle:           14,
+							LastRoundFinishTimestamp: 1684569600.52,
+							SmoothedRoundSeconds:     3600.39,
+						},
+						WiggleServerAddresses: []string{"10.1.18.254:4501"},
+					},
+				},
+			}
+		})
+
+		When("the perpetual storage wiggle is disabled", func() {
+			It("should not report the storage wiggle", func() {
+				Expect(getStorageWiggleStatus(databaseStatus)).To(BeNil())
+			})
+		})
+
+		When("the perpetual storage wiggle is enabled", func() {
+			BeforeEach(func() {
+				databaseStatus.Cluster.DatabaseConfiguration.PerpetualStorageWiggle = pointer.Int(1)
+			})
+
+			It("should report the progress of the primary region", func() {
+				Expect(getStorageWiggleStatus(databaseStatus)).To(Equal(&fdbv1beta2.StorageWiggleStatus{
+					FinishedRounds:           2,
+					FinishedWiggles:          14,
+					LastRoundFinishTimestamp: 1684569600,
+					SmoothedRoundSeconds:     3600,
+					WiggledAddresses:         []string{"10.1.18.254:4501"},
+				}))
+			})
+		})
 	})
 })
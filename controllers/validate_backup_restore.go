@@ -0,0 +1,322 @@
+/*
+ * validate_backup_restore.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// restoreValidationPollInterval defines how often the operator checks the progress of a running restore validation.
+const restoreValidationPollInterval = 1 * time.Minute
+
+// validateBackupRestore provides a reconciliation step for periodically restoring the backup into a temporary
+// cluster and verifying the restored data.
+type validateBackupRestore struct{}
+
+// reconcile runs the reconciler's work.
+func (s validateBackupRestore) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	validation := backup.Spec.RestoreValidation
+	if validation == nil {
+		if backup.Status.RestoreValidation == nil {
+			return nil
+		}
+
+		err := r.deleteRestoreValidationResources(ctx, backup)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		backup.Status.RestoreValidation = nil
+		err = r.updateOrApply(ctx, backup)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		return nil
+	}
+
+	if backup.Status.RestoreValidation == nil {
+		backup.Status.RestoreValidation = &fdbv1beta2.BackupRestoreValidationStatus{}
+	}
+
+	status := backup.Status.RestoreValidation
+	now := time.Now()
+
+	if status.Phase == "" {
+		return s.startValidation(ctx, r, backup, now)
+	}
+
+	if status.StartTimestamp != nil && now.Sub(status.StartTimestamp.Time) > validation.GetTimeout() {
+		return r.finishRestoreValidation(ctx, backup, false, fmt.Sprintf("Validation was not finished in %s", validation.GetTimeout()), now)
+	}
+
+	name := internal.GetRestoreValidationName(backup)
+	key := types.NamespacedName{Namespace: backup.Namespace, Name: name}
+
+	switch status.Phase {
+	case fdbv1beta2.BackupRestoreValidationPhaseCreatingCluster:
+		cluster, err := internal.GetCluster(ctx, r, key)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		// The restore can only be started once the temporary cluster is available.
+		if !cluster.Status.Configured || cluster.Status.Generations.Reconciled < cluster.ObjectMeta.Generation {
+			return nil
+		}
+
+		destination, err := backup.GetRestoreValidationDestination()
+		if err != nil {
+			return r.finishRestoreValidation(ctx, backup, false, err.Error(), now)
+		}
+
+		err = r.createRestoreValidationResource(ctx, backup, internal.GetRestoreValidationRestore(backup, destination))
+		if err != nil {
+			return r.finishRestoreValidation(ctx, backup, false, err.Error(), now)
+		}
+
+		status.Phase = fdbv1beta2.BackupRestoreValidationPhaseRestoring
+	case fdbv1beta2.BackupRestoreValidationPhaseRestoring:
+		restore := &fdbv1beta2.FoundationDBRestore{}
+		err := r.Get(ctx, key, restore)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if restore.Status.Phase == fdbv1beta2.RestorePhaseAborted {
+			message := "Restore was aborted"
+			if restore.Status.Progress != nil && restore.Status.Progress.LastError != "" {
+				message = fmt.Sprintf("Restore was aborted, last error: %s", restore.Status.Progress.LastError)
+			}
+
+			return r.finishRestoreValidation(ctx, backup, false, message, now)
+		}
+
+		if restore.Status.Phase != fdbv1beta2.RestorePhaseCompleted {
+			return nil
+		}
+
+		job := internal.GetRestoreValidationJob(backup)
+		if job == nil {
+			return r.finishRestoreValidation(ctx, backup, true, "Backup was restored", now)
+		}
+
+		err = r.createRestoreValidationResource(ctx, backup, job)
+		if err != nil {
+			return r.finishRestoreValidation(ctx, backup, false, err.Error(), now)
+		}
+
+		status.Phase = fdbv1beta2.BackupRestoreValidationPhaseVerifying
+	case fdbv1beta2.BackupRestoreValidationPhaseVerifying:
+		job := &batchv1.Job{}
+		err := r.Get(ctx, key, job)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if job.Status.Succeeded > 0 {
+			return r.finishRestoreValidation(ctx, backup, true, "Backup was restored and verified", now)
+		}
+
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+				return r.finishRestoreValidation(ctx, backup, false, fmt.Sprintf("Verification job failed: %s", condition.Message), now)
+			}
+		}
+
+		return nil
+	default:
+		return r.finishRestoreValidation(ctx, backup, false, fmt.Sprintf("Unknown validation phase %s", status.Phase), now)
+	}
+
+	err := r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// startValidation starts a new validation if the schedule is due.
+func (s validateBackupRestore) startValidation(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup, now time.Time) *requeue {
+	status := backup.Status.RestoreValidation
+	schedule, err := parseRestoreValidationSchedule(backup.Spec.RestoreValidation)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	// If no validation was scheduled before, the schedule starts now. Otherwise we would start a validation directly
+	// after the schedule was defined.
+	if status.LastScheduledValidation != nil && now.Before(schedule.Next(status.LastScheduledValidation.Time)) {
+		return nil
+	}
+
+	startValidation := status.LastScheduledValidation != nil
+	status.LastScheduledValidation = &metav1.Time{Time: now}
+	if !startValidation {
+		err = r.updateOrApply(ctx, backup)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		return nil
+	}
+
+	status.StartTimestamp = &metav1.Time{Time: now}
+	destination, err := backup.GetRestoreValidationDestination()
+	if err != nil {
+		return r.finishRestoreValidation(ctx, backup, false, err.Error(), now)
+	}
+
+	destinationStatus := backup.Status.GetDestinationStatus(destination.Name)
+	if destinationStatus == nil || destinationStatus.RestorableRange == nil {
+		return r.finishRestoreValidation(ctx, backup, false, fmt.Sprintf("Backup for destination %s is not restorable", destination.Name), now)
+	}
+
+	err = r.createRestoreValidationResource(ctx, backup, internal.GetRestoreValidationCluster(backup))
+	if err != nil {
+		return r.finishRestoreValidation(ctx, backup, false, err.Error(), now)
+	}
+
+	err = r.createRestoreValidationResource(ctx, backup, internal.GetRestoreValidationBackup(backup, destination))
+	if err != nil {
+		return r.finishRestoreValidation(ctx, backup, false, err.Error(), now)
+	}
+
+	status.Phase = fdbv1beta2.BackupRestoreValidationPhaseCreatingCluster
+	status.ClusterName = internal.GetRestoreValidationName(backup)
+	r.Recorder.Event(backup, corev1.EventTypeNormal, "RestoreValidationStarted", fmt.Sprintf("Started restore validation in cluster %s", status.ClusterName))
+
+	err = r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// finishRestoreValidation records the result of the current validation and deletes the resources of the validation.
+func (r *FoundationDBBackupReconciler) finishRestoreValidation(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup, succeeded bool, message string, now time.Time) *requeue {
+	err := r.deleteRestoreValidationResources(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	status := backup.Status.RestoreValidation
+	status.LastResult = &fdbv1beta2.BackupRestoreValidationResult{
+		Succeeded:       succeeded,
+		Message:         message,
+		StartTimestamp:  status.StartTimestamp,
+		FinishTimestamp: &metav1.Time{Time: now},
+	}
+	status.Phase = ""
+	status.ClusterName = ""
+	status.StartTimestamp = nil
+
+	if succeeded {
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "RestoreValidationSucceeded", message)
+	} else {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "RestoreValidationFailed", message)
+	}
+
+	err = r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// createRestoreValidationResource creates a resource of the restore validation. If the resource already exists it
+// must belong to the restore validation of this backup.
+func (r *FoundationDBBackupReconciler) createRestoreValidationResource(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup, object client.Object) error {
+	err := r.Create(ctx, object)
+	if err == nil || !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	err = r.Get(ctx, client.ObjectKeyFromObject(object), object)
+	if err != nil {
+		return err
+	}
+
+	if object.GetLabels()[fdbv1beta2.RestoreValidationLabel] != string(backup.ObjectMeta.UID) {
+		return fmt.Errorf("%s already exists and is not managed by the restore validation", object.GetName())
+	}
+
+	return nil
+}
+
+// deleteRestoreValidationResources deletes all resources that were created for the restore validation of the backup.
+// Resources that are not managed by the restore validation of this backup will not be deleted.
+func (r *FoundationDBBackupReconciler) deleteRestoreValidationResources(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup) error {
+	key := types.NamespacedName{Namespace: backup.Namespace, Name: internal.GetRestoreValidationName(backup)}
+	objects := []client.Object{
+		&batchv1.Job{},
+		&fdbv1beta2.FoundationDBRestore{},
+		&fdbv1beta2.FoundationDBBackup{},
+		&fdbv1beta2.FoundationDBCluster{},
+	}
+
+	for _, object := range objects {
+		err := r.Get(ctx, key, object)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return err
+		}
+
+		if object.GetLabels()[fdbv1beta2.RestoreValidationLabel] != string(backup.ObjectMeta.UID) {
+			continue
+		}
+
+		err = r.Delete(ctx, object, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseRestoreValidationSchedule parses the cron schedule of the restore validation.
+func parseRestoreValidationSchedule(validation *fdbv1beta2.BackupRestoreValidation) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(validation.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid restore validation schedule \"%s\": %w", validation.Schedule, err)
+	}
+
+	return schedule, nil
+}
@@ -93,6 +93,8 @@ rules:
   - foundationdbclusters
   - foundationdbbackups
   - foundationdbrestores
+  - foundationdbprocessgroups
+  - foundationdbdisasterrecoveries
   verbs:
   - get
   - list
@@ -113,6 +115,8 @@ rules:
   - foundationdbclusters/status
   - foundationdbbackups/status
   - foundationdbrestores/status
+  - foundationdbprocessgroups/status
+  - foundationdbdisasterrecoveries/status
   verbs:
   - get
   - update
@@ -142,6 +146,18 @@ rules:
   - update
   - patch
   - delete
+- apiGroups:
+  - batch
+  resources:
+  - jobs
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
 - apiGroups:
   - coordination.k8s.io
   resources:
@@ -227,7 +243,7 @@ spec:
           # This is a workaround for a change of the version schema that was never tested/supported
           args:
             - -c
-            - echo "{{ .FDBVersion.String }}" > /var/fdb/version && runuser -u fdb -g fdb -- /entrypoint.bash --copy-library {{ .FDBVersion.Compact }} --copy-binary fdbcli --copy-binary fdbbackup --copy-binary fdbrestore --output-dir /var/output-files/{{ .FDBVersion.String }} --init-mode
+            - echo "{{ .FDBVersion.String }}" > /var/fdb/version && runuser -u fdb -g fdb -- /entrypoint.bash --copy-library {{ .FDBVersion.Compact }} --copy-binary fdbcli --copy-binary fdbbackup --copy-binary fdbrestore --copy-binary fdbdr --output-dir /var/output-files/{{ .FDBVersion.String }} --init-mode
           volumeMounts:
             - name: fdb-binaries
               mountPath: /var/output-files
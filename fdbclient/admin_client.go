@@ -47,6 +47,7 @@ const (
 	fdbcliStr     = "fdbcli"
 	fdbbackupStr  = "fdbbackup"
 	fdbrestoreStr = "fdbrestore"
+	fdbdrStr      = "fdbdr"
 )
 
 var adminClientMutex sync.Mutex
@@ -79,6 +80,10 @@ type cliAdminClient struct {
 	// custom parameters that should be set.
 	knobs []string
 
+	// blobCredentialsPath is the path to the temp file containing the blob
+	// credentials for the commands that access the backup data.
+	blobCredentialsPath string
+
 	// log implementation for logging output
 	log logr.Logger
 
@@ -151,6 +156,10 @@ func (command cliCommand) getClusterFileFlag() string {
 		return "--dest_cluster_file"
 	}
 
+	if command.binary == fdbdrStr {
+		return "-d"
+	}
+
 	return "-C"
 }
 
@@ -346,6 +355,12 @@ func (client *cliAdminClient) ConfigureDatabase(configuration fdbv1beta2.Databas
 	return err
 }
 
+// ConfigureStorageMigration sets the storage migration type and the perpetual storage wiggle.
+func (client *cliAdminClient) ConfigureStorageMigration(migrationType fdbv1beta2.StorageMigrationType, perpetualStorageWiggle int) error {
+	_, err := client.runCommand(cliCommand{command: fmt.Sprintf("configure storage_migration_type=%s perpetual_storage_wiggle=%d", migrationType, perpetualStorageWiggle)})
+	return err
+}
+
 // GetMaintenanceZone gets current maintenance zone, if any. Returns empty string if maintenance mode is off
 func (client *cliAdminClient) GetMaintenanceZone() (string, error) {
 	mode, err := client.fdbLibClient.getValueFromDBUsingKey("\xff/maintenance", DefaultCLITimeout)
@@ -530,27 +545,31 @@ func (client *cliAdminClient) GetProtocolVersion(version string) (string, error)
 	return protocolVersionMatch[1], nil
 }
 
-func (client *cliAdminClient) StartBackup(url string, snapshotPeriodSeconds int) error {
+func (client *cliAdminClient) StartBackup(tag string, url string, snapshotPeriodSeconds int) error {
 	_, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
-		args: []string{
+		args: client.withBlobCredentials([]string{
 			"start",
+			"-t",
+			tag,
 			"-d",
 			url,
 			"-s",
 			fmt.Sprintf("%d", snapshotPeriodSeconds),
 			"-z",
-		},
+		}),
 	})
 	return err
 }
 
-// StopBackup stops a backup.
-func (client *cliAdminClient) StopBackup(_ string) error {
+// StopBackup stops the backup with the provided tag.
+func (client *cliAdminClient) StopBackup(tag string) error {
 	_, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"discontinue",
+			"-t",
+			tag,
 		},
 	})
 	return err
@@ -578,12 +597,14 @@ func (client *cliAdminClient) ResumeBackups() error {
 	return err
 }
 
-// ModifyBackup updates the backup parameters.
-func (client *cliAdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
+// ModifyBackup updates the parameters of the backup with the provided tag.
+func (client *cliAdminClient) ModifyBackup(tag string, snapshotPeriodSeconds int) error {
 	_, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"modify",
+			"-t",
+			tag,
 			"-s",
 			fmt.Sprintf("%d", snapshotPeriodSeconds),
 		},
@@ -591,12 +612,14 @@ func (client *cliAdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
 	return err
 }
 
-// GetBackupStatus gets the status of the current backup.
-func (client *cliAdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
+// GetBackupStatus gets the status of the backup with the provided tag.
+func (client *cliAdminClient) GetBackupStatus(tag string) (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
 	statusString, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"status",
+			"-t",
+			tag,
 			"--json",
 		},
 	})
@@ -619,17 +642,109 @@ func (client *cliAdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBac
 	return status, nil
 }
 
+// ForceSnapshot modifies the interval of the active snapshot of the backup with the provided tag, so that the snapshot
+// will be completed in the provided duration.
+func (client *cliAdminClient) ForceSnapshot(tag string, snapshotDurationSeconds int) error {
+	_, err := client.runCommand(cliCommand{
+		binary: fdbbackupStr,
+		args: []string{
+			"modify",
+			"-t",
+			tag,
+			"--active_snapshot_interval",
+			strconv.Itoa(snapshotDurationSeconds),
+		},
+	})
+	return err
+}
+
+// DescribeBackup describes the data of the backup in the destination.
+func (client *cliAdminClient) DescribeBackup(url string) (*fdbv1beta2.FoundationDBBackupDescription, error) {
+	output, err := client.runCommand(cliCommand{
+		binary: fdbbackupStr,
+		args: client.withBlobCredentials([]string{
+			"describe",
+			"-d",
+			url,
+			"--json",
+			// The version timestamps are read from the cluster and are required to map versions to points in time.
+			"--version_timestamps",
+		}),
+		timeout: MaxCliTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	descriptionBytes, err := fdbstatus.RemoveWarningsInJSON(output)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &fdbv1beta2.FoundationDBBackupDescription{}
+	err = json.Unmarshal(descriptionBytes, description)
+	if err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+// ExpireBackup deletes all backup data that is only needed to restore to a version before the provided version.
+func (client *cliAdminClient) ExpireBackup(url string, version int64) error {
+	_, err := client.runCommand(cliCommand{
+		binary: fdbbackupStr,
+		args: client.withBlobCredentials([]string{
+			"expire",
+			"-d",
+			url,
+			"--expire_before_version",
+			strconv.FormatInt(version, 10),
+		}),
+		timeout: backupExpirationTimeout,
+	})
+	return err
+}
+
+// DeleteBackup deletes all backup data in the destination.
+func (client *cliAdminClient) DeleteBackup(url string) error {
+	_, err := client.runCommand(cliCommand{
+		binary: fdbbackupStr,
+		args: client.withBlobCredentials([]string{
+			"delete",
+			"-d",
+			url,
+		}),
+		timeout: backupExpirationTimeout,
+	})
+	return err
+}
+
 // StartRestore starts a new restore.
-func (client *cliAdminClient) StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange) error {
+func (client *cliAdminClient) StartRestore(url string, options fdbadminclient.RestoreOptions) error {
+	args, err := client.getRestoreArgs(url, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.runCommand(cliCommand{
+		binary: fdbrestoreStr,
+		args:   args,
+	})
+	return err
+}
+
+// getRestoreArgs returns the arguments for fdbrestore to start a new restore.
+func (client *cliAdminClient) getRestoreArgs(url string, options fdbadminclient.RestoreOptions) ([]string, error) {
 	args := []string{
 		"start",
 		"-r",
 		url,
 	}
 
-	if keyRanges != nil {
+	if len(options.KeyRanges) > 0 {
 		keyRangeString := ""
-		for _, keyRange := range keyRanges {
+		for _, keyRange := range options.KeyRanges {
 			if keyRangeString != "" {
 				keyRangeString += ";"
 			}
@@ -637,13 +752,75 @@ func (client *cliAdminClient) StartRestore(url string, keyRanges []fdbv1beta2.Fo
 		}
 		args = append(args, "-k", keyRangeString)
 	}
-	_, err := client.runCommand(cliCommand{
-		binary: fdbrestoreStr,
-		args:   args,
-	})
+
+	if options.TargetVersion != nil {
+		args = append(args, "-v", strconv.FormatInt(*options.TargetVersion, 10))
+	}
+
+	// The timestamp will be converted to a version with the metadata of the cluster that was backed up.
+	if options.TargetTimestamp != nil {
+		if options.SourceCluster == nil {
+			return nil, fmt.Errorf("restoring to a timestamp requires the source cluster")
+		}
+
+		sourceClusterFile, err := createClusterFile(options.SourceCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args,
+			"--timestamp", options.TargetTimestamp.UTC().Format("2006/01/02.15:04:05-0700"),
+			"--orig_cluster_file", sourceClusterFile,
+		)
+	}
+
+	if options.AddPrefix != "" {
+		args = append(args, "--add_prefix", options.AddPrefix)
+	}
+
+	if options.RemovePrefix != "" {
+		args = append(args, "--remove_prefix", options.RemovePrefix)
+	}
+
+	return args, nil
+}
+
+// ClearKeyRanges clears all keys in the provided key ranges.
+func (client *cliAdminClient) ClearKeyRanges(keyRanges []fdbv1beta2.FoundationDBKeyRange) error {
+	commands := []string{"writemode on"}
+	for _, keyRange := range keyRanges {
+		commands = append(commands, fmt.Sprintf("clearrange \"%s\" \"%s\"", keyRange.Start, keyRange.End))
+	}
+
+	_, err := client.runCommand(cliCommand{command: strings.Join(commands, "; ")})
 	return err
 }
 
+// IsKeyRangeEmpty checks if the provided key range contains any keys.
+func (client *cliAdminClient) IsKeyRangeEmpty(keyRange fdbv1beta2.FoundationDBKeyRange) (bool, error) {
+	begin, err := unescapeKey(keyRange.Start)
+	if err != nil {
+		return false, err
+	}
+
+	end, err := unescapeKey(keyRange.End)
+	if err != nil {
+		return false, err
+	}
+
+	return client.fdbLibClient.isRangeEmpty(begin, end, DefaultCLITimeout)
+}
+
+// unescapeKey converts a key with `\xBB` escape sequences, as used by the CLI, into the raw key.
+func unescapeKey(key string) (string, error) {
+	unescaped, err := strconv.Unquote("\"" + key + "\"")
+	if err != nil {
+		return "", fmt.Errorf("could not parse key %s: %w", key, err)
+	}
+
+	return unescaped, nil
+}
+
 // GetRestoreStatus gets the status of the current restore.
 func (client *cliAdminClient) GetRestoreStatus() (string, error) {
 	return client.runCommand(cliCommand{
@@ -654,6 +831,49 @@ func (client *cliAdminClient) GetRestoreStatus() (string, error) {
 	})
 }
 
+// StartDisasterRecovery starts replicating the provided source cluster into the cluster of this client.
+func (client *cliAdminClient) StartDisasterRecovery(source *fdbv1beta2.FoundationDBCluster) error {
+	_, err := client.runDisasterRecoveryCommand(source, "start")
+	return err
+}
+
+// AbortDisasterRecovery stops replicating the provided source cluster into the cluster of this client.
+func (client *cliAdminClient) AbortDisasterRecovery(source *fdbv1beta2.FoundationDBCluster) error {
+	_, err := client.runDisasterRecoveryCommand(source, "abort")
+	return err
+}
+
+// SwitchDisasterRecovery switches the replication from the provided source cluster, afterwards the cluster of this
+// client will be the source of the replication.
+func (client *cliAdminClient) SwitchDisasterRecovery(source *fdbv1beta2.FoundationDBCluster) error {
+	_, err := client.runDisasterRecoveryCommand(source, "switch")
+	return err
+}
+
+// GetDisasterRecoveryStatus gets the status of the replication from the provided source cluster into the cluster of
+// this client.
+func (client *cliAdminClient) GetDisasterRecoveryStatus(source *fdbv1beta2.FoundationDBCluster) (string, error) {
+	return client.runDisasterRecoveryCommand(source, "status")
+}
+
+// runDisasterRecoveryCommand runs the provided fdbdr subcommand with the cluster of this client as destination
+// and the provided cluster as source.
+func (client *cliAdminClient) runDisasterRecoveryCommand(source *fdbv1beta2.FoundationDBCluster, subcommand string) (string, error) {
+	sourceClusterFile, err := createClusterFile(source)
+	if err != nil {
+		return "", err
+	}
+
+	return client.runCommand(cliCommand{
+		binary: fdbdrStr,
+		args: []string{
+			subcommand,
+			"-s",
+			sourceClusterFile,
+		},
+	})
+}
+
 // Close cleans up any pending resources.
 func (client *cliAdminClient) Close() error {
 	// Allow to reuse the same file.
@@ -675,6 +895,28 @@ func (client *cliAdminClient) SetKnobs(knobs []string) {
 	client.knobs = knobs
 }
 
+// SetBlobCredentials sets the blob credentials that should be used by the commands that access the backup data in the
+// destination.
+func (client *cliAdminClient) SetBlobCredentials(credentials []byte) error {
+	blobCredentialsPath, err := createBlobCredentialsFile(credentials)
+	if err != nil {
+		return err
+	}
+
+	client.blobCredentialsPath = blobCredentialsPath
+	return nil
+}
+
+// withBlobCredentials adds the blob credentials to the arguments of a command that accesses the backup data, if blob
+// credentials are set.
+func (client *cliAdminClient) withBlobCredentials(args []string) []string {
+	if client.blobCredentialsPath == "" {
+		return args
+	}
+
+	return append(args, "--blob_credentials", client.blobCredentialsPath)
+}
+
 // WithValues will update the logger used by the current AdminClient to contain the provided key value pairs. The provided
 // arguments must be even.
 func (client *cliAdminClient) WithValues(keysAndValues ...interface{}) {
@@ -29,11 +29,13 @@ import (
 	"path"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	"github.com/go-logr/logr"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -426,6 +428,56 @@ var _ = Describe("admin_client_test", func() {
 			},
 			1*time.Second,
 		),
+		// Tests for fdbdr
+		Entry("using fdbdr and trace options are disabled",
+			cliCommand{
+				binary:  fdbdrStr,
+				version: "7.1.25",
+				args:    []string{"status", "-s", "source"},
+				timeout: 1 * time.Second,
+			},
+			&cliAdminClient{
+				Cluster:         nil,
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+			},
+			"",
+			"",
+			[]string{
+				"status",
+				"-s",
+				"source",
+				"-d",
+				"test",
+			},
+			1*time.Second,
+		),
+		Entry("using fdbdr and trace options are enabled",
+			cliCommand{
+				binary:  fdbdrStr,
+				version: "7.1.25",
+				args:    []string{"status", "-s", "source"},
+				timeout: 1 * time.Second,
+			},
+			&cliAdminClient{
+				Cluster:         nil,
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+			},
+			"/tmp",
+			"",
+			[]string{
+				"status",
+				"-s",
+				"source",
+				"-d",
+				"test",
+				"--log",
+				"--logdir",
+				"/tmp",
+			},
+			1*time.Second,
+		),
 		// Tests for fdbbackup
 		Entry("using fdbbackup and trace options are disabled",
 			cliCommand{
@@ -1151,4 +1203,255 @@ protocol fdb00b071010000`,
 	})
 
 	// TODO(johscheuer): Add test case for timeout.
+
+	DescribeTable("getting the args to start a restore", func(options fdbadminclient.RestoreOptions, expectedArgs []string) {
+		client := &cliAdminClient{
+			clusterFilePath: "test",
+			log:             logr.Discard(),
+		}
+
+		args, err := client.getRestoreArgs("blobstore://test@test-service/test-backup", options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(HaveExactElements(expectedArgs))
+	},
+		Entry("restoring to the end of the backup",
+			fdbadminclient.RestoreOptions{},
+			[]string{"start", "-r", "blobstore://test@test-service/test-backup"},
+		),
+		Entry("restoring key ranges",
+			fdbadminclient.RestoreOptions{
+				KeyRanges: []fdbv1beta2.FoundationDBKeyRange{
+					{Start: "a", End: "b"},
+					{Start: "c", End: "d"},
+				},
+			},
+			[]string{"start", "-r", "blobstore://test@test-service/test-backup", "-k", "a b;c d"},
+		),
+		Entry("restoring to a version",
+			fdbadminclient.RestoreOptions{
+				TargetVersion: pointer.Int64(123456),
+			},
+			[]string{"start", "-r", "blobstore://test@test-service/test-backup", "-v", "123456"},
+		),
+		Entry("restoring to a timestamp",
+			fdbadminclient.RestoreOptions{
+				TargetTimestamp: func() *time.Time {
+					timestamp := time.Date(2023, 5, 17, 10, 30, 15, 0, time.FixedZone("test", 2*60*60))
+					return &timestamp
+				}(),
+				SourceCluster: &fdbv1beta2.FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "source",
+					},
+					Status: fdbv1beta2.FoundationDBClusterStatus{
+						ConnectionString: "source:source@127.0.0.1:4501",
+					},
+				},
+			},
+			[]string{"start", "-r", "blobstore://test@test-service/test-backup", "--timestamp", "2023/05/17.08:30:15+0000", "--orig_cluster_file", path.Join(os.TempDir(), "source")},
+		),
+		Entry("restoring with prefixes",
+			fdbadminclient.RestoreOptions{
+				KeyRanges: []fdbv1beta2.FoundationDBKeyRange{
+					{Start: "old/a", End: "old/b"},
+				},
+				AddPrefix:    "new/",
+				RemovePrefix: "old/",
+			},
+			[]string{"start", "-r", "blobstore://test@test-service/test-backup", "-k", "old/a old/b", "--add_prefix", "new/", "--remove_prefix", "old/"},
+		),
+	)
+
+	When("restoring to a timestamp without the source cluster", func() {
+		It("should return an error", func() {
+			client := &cliAdminClient{
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+			}
+
+			timestamp := time.Now()
+			_, err := client.getRestoreArgs("blobstore://test@test-service/test-backup", fdbadminclient.RestoreOptions{
+				TargetTimestamp: &timestamp,
+			})
+			Expect(err).To(MatchError("restoring to a timestamp requires the source cluster"))
+		})
+	})
+
+	When("describing a backup", func() {
+		var mockRunner *mockCommandRunner
+		var description *fdbv1beta2.FoundationDBBackupDescription
+
+		BeforeEach(func() {
+			tmpDir := GinkgoT().TempDir()
+			GinkgoT().Setenv("FDB_BINARY_DIR", tmpDir)
+
+			binaryDir := path.Join(tmpDir, fdbv1beta2.Versions.Default.GetBinaryVersion())
+			Expect(os.MkdirAll(binaryDir, 0700)).NotTo(HaveOccurred())
+
+			// fdbbackup only reports the timestamps of the versions if the version timestamps are requested.
+			output, err := os.ReadFile(path.Join("testdata", "fdbbackup_describe.json"))
+			Expect(err).NotTo(HaveOccurred())
+			outputWithTimestamps, err := os.ReadFile(path.Join("testdata", "fdbbackup_describe_version_timestamps.json"))
+			Expect(err).NotTo(HaveOccurred())
+
+			mockRunner = &mockCommandRunner{
+				mockedOutput: string(output),
+				mockedOutputPerArg: map[string]string{
+					"--version_timestamps": string(outputWithTimestamps),
+				},
+			}
+			cliClient := &cliAdminClient{
+				Cluster: &fdbv1beta2.FoundationDBCluster{
+					Spec: fdbv1beta2.FoundationDBClusterSpec{
+						Version: fdbv1beta2.Versions.Default.String(),
+					},
+				},
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+				cmdRunner:       mockRunner,
+			}
+
+			description, err = cliClient.DescribeBackup("blobstore://test@test-service/test-backup?bucket=fdb-backups")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should request the version timestamps", func() {
+			Expect(mockRunner.receivedBinary).To(HaveSuffix(fdbbackupStr))
+			Expect(mockRunner.receivedArgs).To(ContainElements("describe", "--json", "--version_timestamps"))
+		})
+
+		It("should parse the restorable range", func() {
+			Expect(description.Restorable).To(BeTrue())
+			Expect(description.MinRestorablePoint).To(Equal(&fdbv1beta2.FoundationDBBackupVersion{Version: 100600000000, EpochSeconds: 1684311000}))
+			Expect(description.MaxRestorablePoint).To(Equal(&fdbv1beta2.FoundationDBBackupVersion{Version: 190000000000, EpochSeconds: 1684399800}))
+		})
+
+		It("should parse the snapshots", func() {
+			Expect(description.Snapshots).To(Equal([]fdbv1beta2.FoundationDBBackupSnapshot{
+				{
+					Restorable:   true,
+					BeginVersion: fdbv1beta2.FoundationDBBackupVersion{Version: 100000000000, EpochSeconds: 1684310400},
+					EndVersion:   fdbv1beta2.FoundationDBBackupVersion{Version: 100600000000, EpochSeconds: 1684311000},
+				},
+				{
+					Restorable:   true,
+					BeginVersion: fdbv1beta2.FoundationDBBackupVersion{Version: 186400000000, EpochSeconds: 1684396800},
+					EndVersion:   fdbv1beta2.FoundationDBBackupVersion{Version: 187000000000, EpochSeconds: 1684397400},
+				},
+			}))
+		})
+	})
+
+	When("blob credentials are set", func() {
+		var mockRunner *mockCommandRunner
+		var credentials []byte
+
+		BeforeEach(func() {
+			tmpDir := GinkgoT().TempDir()
+			GinkgoT().Setenv("FDB_BINARY_DIR", tmpDir)
+			GinkgoT().Setenv("TMPDIR", tmpDir)
+
+			binaryDir := path.Join(tmpDir, fdbv1beta2.Versions.Default.GetBinaryVersion())
+			Expect(os.MkdirAll(binaryDir, 0700)).NotTo(HaveOccurred())
+
+			mockRunner = &mockCommandRunner{}
+			cliClient := &cliAdminClient{
+				Cluster: &fdbv1beta2.FoundationDBCluster{
+					Spec: fdbv1beta2.FoundationDBClusterSpec{
+						Version: fdbv1beta2.Versions.Default.String(),
+					},
+				},
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+				cmdRunner:       mockRunner,
+			}
+
+			credentials = []byte(`{"accounts":{"test@test-service":{"secret":"test"}}}`)
+			Expect(cliClient.SetBlobCredentials(credentials)).NotTo(HaveOccurred())
+			Expect(cliClient.ExpireBackup("blobstore://test@test-service/test-backup?bucket=fdb-backups", 100)).NotTo(HaveOccurred())
+		})
+
+		It("should pass the blob credentials to fdbbackup", func() {
+			Expect(mockRunner.receivedBinary).To(HaveSuffix(fdbbackupStr))
+			var credentialsPath string
+			for idx, arg := range mockRunner.receivedArgs {
+				if arg == "--blob_credentials" && idx+1 < len(mockRunner.receivedArgs) {
+					credentialsPath = mockRunner.receivedArgs[idx+1]
+				}
+			}
+
+			Expect(credentialsPath).To(HavePrefix(os.TempDir()))
+
+			content, err := os.ReadFile(credentialsPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(credentials))
+		})
+	})
+
+	When("clearing key ranges", func() {
+		var mockRunner *mockCommandRunner
+
+		BeforeEach(func() {
+			tmpDir := GinkgoT().TempDir()
+			GinkgoT().Setenv("FDB_BINARY_DIR", tmpDir)
+
+			binaryDir := path.Join(tmpDir, fdbv1beta2.Versions.Default.GetBinaryVersion())
+			Expect(os.MkdirAll(binaryDir, 0700)).NotTo(HaveOccurred())
+
+			mockRunner = &mockCommandRunner{}
+			cliClient := &cliAdminClient{
+				Cluster: &fdbv1beta2.FoundationDBCluster{
+					Spec: fdbv1beta2.FoundationDBClusterSpec{
+						Version: fdbv1beta2.Versions.Default.String(),
+					},
+				},
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+				cmdRunner:       mockRunner,
+			}
+
+			Expect(cliClient.ClearKeyRanges([]fdbv1beta2.FoundationDBKeyRange{
+				{Start: "a", End: "b"},
+				{Start: "new/", End: "new/\\xff"},
+			})).To(Succeed())
+		})
+
+		It("should clear only the provided key ranges", func() {
+			Expect(mockRunner.receivedBinary).To(HaveSuffix(fdbcliStr))
+			Expect(mockRunner.receivedArgs[1]).To(Equal("writemode on; clearrange \"a\" \"b\"; clearrange \"new/\" \"new/\\xff\""))
+		})
+	})
+
+	When("checking if a key range is empty", func() {
+		var mockFdbClient *mockFdbLibClient
+		var cliClient *cliAdminClient
+
+		BeforeEach(func() {
+			mockFdbClient = &mockFdbLibClient{
+				mockedKeyValues: map[string][]byte{
+					"old/a": []byte("value"),
+				},
+			}
+			cliClient = &cliAdminClient{
+				log:          logr.Discard(),
+				fdbLibClient: mockFdbClient,
+			}
+		})
+
+		DescribeTable("should read the key range", func(keyRange fdbv1beta2.FoundationDBKeyRange, expected bool) {
+			empty, err := cliClient.IsKeyRangeEmpty(keyRange)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(empty).To(Equal(expected))
+		},
+			Entry("the key range contains a key", fdbv1beta2.FoundationDBKeyRange{Start: "old/", End: "old/\\xff"}, false),
+			Entry("the key range ends before the key", fdbv1beta2.FoundationDBKeyRange{Start: "", End: "old/a"}, true),
+			Entry("the key range contains no key", fdbv1beta2.FoundationDBKeyRange{Start: "new/", End: "new/\\xff"}, true),
+		)
+
+		It("should unescape the keys", func() {
+			_, err := cliClient.IsKeyRangeEmpty(fdbv1beta2.FoundationDBKeyRange{Start: "\\x00", End: "\\xff"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockFdbClient.requestedKey).To(Equal("\x00"))
+		})
+	})
 })
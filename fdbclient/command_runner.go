@@ -84,6 +84,9 @@ type mockCommandRunner struct {
 	// mockedOutputPerBinary is the output returned if the binary is matching. This can be helpful to test the behaviour for
 	// different versions.
 	mockedOutputPerBinary map[string]string
+	// mockedOutputPerArg is the output returned if the args contain the key. This takes precedence over the output per
+	// binary and can be helpful to test the behaviour for different flags.
+	mockedOutputPerArg map[string]string
 }
 
 func (runner *mockCommandRunner) runCommand(_ context.Context, name string, arg ...string) ([]byte, error) {
@@ -97,5 +100,12 @@ func (runner *mockCommandRunner) runCommand(_ context.Context, name string, arg
 		mockedOutput = runner.mockedOutput
 	}
 
+	for _, currentArg := range arg {
+		if output, ok := runner.mockedOutputPerArg[currentArg]; ok {
+			mockedOutput = output
+			break
+		}
+	}
+
 	return []byte(mockedOutput), runner.mockedError
 }
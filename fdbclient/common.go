@@ -21,6 +21,7 @@
 package fdbclient
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -44,6 +45,10 @@ var MaxCliTimeout = 60 * time.Second
 
 const (
 	defaultTransactionTimeout = 5 * time.Second
+
+	// backupExpirationTimeout is the timeout for expiring or deleting backup data, the expiration has to delete all
+	// the files in the destination that are not needed anymore, so it can take a lot longer than other commands.
+	backupExpirationTimeout = 10 * time.Minute
 )
 
 func parseMachineReadableStatus(logger logr.Logger, contents []byte) (*fdbv1beta2.FoundationDBStatus, error) {
@@ -107,6 +112,23 @@ func createClusterFile(cluster *fdbv1beta2.FoundationDBCluster) (string, error)
 	return ensureClusterFileIsPresent(os.TempDir(), string(cluster.UID), cluster.Status.ConnectionString)
 }
 
+// createBlobCredentialsFile creates a file with the provided blob credentials in the temp directory. The file name is
+// based on the hash of the credentials, so multiple clients can share the same file.
+func createBlobCredentialsFile(credentials []byte) (string, error) {
+	blobCredentialsFileName := path.Join(os.TempDir(), fmt.Sprintf("blob-credentials-%x.json", sha256.Sum256(credentials)))
+
+	_, err := os.Stat(blobCredentialsFileName)
+	if err == nil {
+		return blobCredentialsFileName, nil
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	return blobCredentialsFileName, os.WriteFile(blobCredentialsFileName, credentials, 0600)
+}
+
 // ensureClusterFileIsPresent will ensure that the cluster file with the specified connection string is present.
 func ensureClusterFileIsPresent(dir string, uid string, connectionString string) (string, error) {
 	clusterFileName := path.Join(dir, uid)
@@ -154,8 +176,13 @@ func (p *realDatabaseClientProvider) GetLockClient(cluster *fdbv1beta2.Foundatio
 }
 
 // GetAdminClient generates a client for performing administrative actions
-// against the database.
+// against the database. If the cluster has UseManagementAPI enabled the
+// management API based client will be returned.
 func (p *realDatabaseClientProvider) GetAdminClient(cluster *fdbv1beta2.FoundationDBCluster, kubernetesClient client.Client) (fdbadminclient.AdminClient, error) {
+	if cluster.UseManagementAPI() {
+		return NewManagementAPIAdminClient(cluster, kubernetesClient, p.log)
+	}
+
 	return NewCliAdminClient(cluster, kubernetesClient, p.log)
 }
 
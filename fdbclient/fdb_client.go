@@ -23,6 +23,7 @@ package fdbclient
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
@@ -34,6 +35,25 @@ import (
 type fdbLibClient interface {
 	// getValueFromDBUsingKey returns the value of the provided key.
 	getValueFromDBUsingKey(fdbKey string, timeout time.Duration) ([]byte, error)
+
+	// getRangeFromDB returns all key value pairs with a key that starts with the provided prefix.
+	getRangeFromDB(prefix string, timeout time.Duration) (map[string][]byte, error)
+
+	// isRangeEmpty returns true if the range between begin and end doesn't contain any keys.
+	isRangeEmpty(begin string, end string, timeout time.Duration) (bool, error)
+
+	// updateManagementKeysInDB commits the provided changes to the special key space in a single transaction.
+	updateManagementKeysInDB(update managementUpdate, timeout time.Duration) error
+}
+
+// managementUpdate describes the changes to the special key space that will be committed in a single transaction.
+type managementUpdate struct {
+	// setKeys are the keys that will be set to the provided values.
+	setKeys map[string][]byte
+	// clearKeys are the keys that will be cleared.
+	clearKeys []string
+	// clearPrefixes are the prefixes for which all keys will be cleared.
+	clearPrefixes []string
 }
 
 // realFdbLibClient represents the actual FDB client that will interact with FDB.
@@ -72,16 +92,7 @@ func (fdbClient *realFdbLibClient) getValueFromDBUsingKey(fdbKey string, timeout
 	})
 
 	if err != nil {
-		var fdbError *fdb.Error
-		if errors.As(err, &fdbError) {
-			// See: https://apple.github.io/foundationdb/api-error-codes.html
-			// 1031: Operation aborted because the transaction timed out
-			if fdbError.Code == 1031 {
-				return nil, fdbv1beta2.TimeoutError{Err: err}
-			}
-		}
-
-		return nil, err
+		return nil, convertFDBError(err)
 	}
 
 	byteResult, ok := result.([]byte)
@@ -92,6 +103,188 @@ func (fdbClient *realFdbLibClient) getValueFromDBUsingKey(fdbKey string, timeout
 	return byteResult, nil
 }
 
+func (fdbClient *realFdbLibClient) getRangeFromDB(prefix string, timeout time.Duration) (map[string][]byte, error) {
+	fdbClient.logger.Info("Fetch range from FDB", "prefix", prefix)
+	defer func() {
+		fdbClient.logger.Info("Done fetching range from FDB", "prefix", prefix)
+	}()
+	database, err := getFDBDatabase(fdbClient.cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := database.Transact(func(transaction fdb.Transaction) (interface{}, error) {
+		err := transaction.Options().SetAccessSystemKeys()
+		if err != nil {
+			return nil, err
+		}
+		err = transaction.Options().SetTimeout(timeout.Milliseconds())
+		if err != nil {
+			return nil, err
+		}
+
+		keyRange, err := fdb.PrefixRange([]byte(prefix))
+		if err != nil {
+			return nil, err
+		}
+
+		keyValues := make(map[string][]byte)
+		for _, keyValue := range transaction.GetRange(keyRange, fdb.RangeOptions{}).GetSliceOrPanic() {
+			keyValues[string(keyValue.Key)] = keyValue.Value
+		}
+
+		return keyValues, nil
+	})
+
+	if err != nil {
+		return nil, convertFDBError(err)
+	}
+
+	keyValues, ok := result.(map[string][]byte)
+	if !ok {
+		return nil, fmt.Errorf("could not cast result into key value map")
+	}
+
+	return keyValues, nil
+}
+
+func (fdbClient *realFdbLibClient) isRangeEmpty(begin string, end string, timeout time.Duration) (bool, error) {
+	fdbClient.logger.Info("Check if range is empty in FDB", "begin", fdb.Printable([]byte(begin)), "end", fdb.Printable([]byte(end)))
+	database, err := getFDBDatabase(fdbClient.cluster)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := database.Transact(func(transaction fdb.Transaction) (interface{}, error) {
+		err := transaction.Options().SetTimeout(timeout.Milliseconds())
+		if err != nil {
+			return nil, err
+		}
+
+		keyValues, err := transaction.GetRange(fdb.KeyRange{Begin: fdb.Key(begin), End: fdb.Key(end)}, fdb.RangeOptions{Limit: 1}).GetSliceWithError()
+		if err != nil {
+			return nil, err
+		}
+
+		return len(keyValues) == 0, nil
+	})
+
+	if err != nil {
+		return false, convertFDBError(err)
+	}
+
+	empty, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("could not cast result into bool")
+	}
+
+	return empty, nil
+}
+
+func (fdbClient *realFdbLibClient) updateManagementKeysInDB(update managementUpdate, timeout time.Duration) error {
+	fdbClient.logger.Info("Update management keys in FDB", "setKeys", len(update.setKeys), "clearKeys", len(update.clearKeys), "clearPrefixes", len(update.clearPrefixes))
+	defer func() {
+		fdbClient.logger.Info("Done updating management keys in FDB", "setKeys", len(update.setKeys), "clearKeys", len(update.clearKeys), "clearPrefixes", len(update.clearPrefixes))
+	}()
+	database, err := getFDBDatabase(fdbClient.cluster)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := database.CreateTransaction()
+	if err != nil {
+		return err
+	}
+
+	// The transaction is retried manually, a rejected change must be read from the same transaction to get the
+	// reason why the special key space rejected the change.
+	for {
+		err = commitManagementUpdate(transaction, update, timeout)
+		if err == nil {
+			return nil
+		}
+
+		var fdbError fdb.Error
+		if !errors.As(err, &fdbError) {
+			return err
+		}
+
+		// See: https://apple.github.io/foundationdb/api-error-codes.html
+		// 2117: The special key space API call failed, the reason is stored in the error message key.
+		if fdbError.Code == 2117 {
+			message, messageErr := transaction.Get(fdb.Key(errorMessageKey)).Get()
+			if messageErr != nil {
+				return convertFDBError(err)
+			}
+
+			return fmt.Errorf("management API rejected the change: %s", string(message))
+		}
+
+		err = transaction.OnError(fdbError).Get()
+		if err != nil {
+			return convertFDBError(err)
+		}
+	}
+}
+
+// commitManagementUpdate applies the update to the provided transaction and commits it.
+func commitManagementUpdate(transaction fdb.Transaction, update managementUpdate, timeout time.Duration) error {
+	err := enableSpecialKeySpaceWrites(transaction)
+	if err != nil {
+		return err
+	}
+	err = transaction.Options().SetPrioritySystemImmediate()
+	if err != nil {
+		return err
+	}
+	err = transaction.Options().SetLockAware()
+	if err != nil {
+		return err
+	}
+	err = transaction.Options().SetTimeout(timeout.Milliseconds())
+	if err != nil {
+		return err
+	}
+
+	for _, prefix := range update.clearPrefixes {
+		keyRange, err := fdb.PrefixRange([]byte(prefix))
+		if err != nil {
+			return err
+		}
+
+		transaction.ClearRange(keyRange)
+	}
+
+	for _, key := range update.clearKeys {
+		transaction.Clear(fdb.Key(key))
+	}
+
+	for key, value := range update.setKeys {
+		transaction.Set(fdb.Key(key), value)
+	}
+
+	return transaction.Commit().Get()
+}
+
+// convertFDBError converts FDB errors that have a representation in the operator, e.g. timeouts, into the according
+// operator error.
+func convertFDBError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var fdbError *fdb.Error
+	if errors.As(err, &fdbError) {
+		// See: https://apple.github.io/foundationdb/api-error-codes.html
+		// 1031: Operation aborted because the transaction timed out
+		if fdbError.Code == 1031 {
+			return fdbv1beta2.TimeoutError{Err: err}
+		}
+	}
+
+	return err
+}
+
 // mockFdbLibClient is a mock for unit testing.
 type mockFdbLibClient struct {
 	// mockedOutput is the output returned by getValueFromDBUsingKey.
@@ -100,6 +293,8 @@ type mockFdbLibClient struct {
 	mockedError error
 	// requestedKey will be the key that was used to call getValueFromDBUsingKey.
 	requestedKey string
+	// mockedKeyValues are the key values used by getRangeFromDB and updateManagementKeysInDB.
+	mockedKeyValues map[string][]byte
 }
 
 func (fdbClient *mockFdbLibClient) getValueFromDBUsingKey(fdbKey string, _ time.Duration) ([]byte, error) {
@@ -107,3 +302,62 @@ func (fdbClient *mockFdbLibClient) getValueFromDBUsingKey(fdbKey string, _ time.
 
 	return fdbClient.mockedOutput, fdbClient.mockedError
 }
+
+func (fdbClient *mockFdbLibClient) getRangeFromDB(prefix string, _ time.Duration) (map[string][]byte, error) {
+	fdbClient.requestedKey = prefix
+	if fdbClient.mockedError != nil {
+		return nil, fdbClient.mockedError
+	}
+
+	keyValues := make(map[string][]byte)
+	for key, value := range fdbClient.mockedKeyValues {
+		if strings.HasPrefix(key, prefix) {
+			keyValues[key] = value
+		}
+	}
+
+	return keyValues, nil
+}
+
+func (fdbClient *mockFdbLibClient) isRangeEmpty(begin string, end string, _ time.Duration) (bool, error) {
+	fdbClient.requestedKey = begin
+	if fdbClient.mockedError != nil {
+		return false, fdbClient.mockedError
+	}
+
+	for key := range fdbClient.mockedKeyValues {
+		if key >= begin && key < end {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (fdbClient *mockFdbLibClient) updateManagementKeysInDB(update managementUpdate, _ time.Duration) error {
+	if fdbClient.mockedError != nil {
+		return fdbClient.mockedError
+	}
+
+	if fdbClient.mockedKeyValues == nil {
+		fdbClient.mockedKeyValues = make(map[string][]byte)
+	}
+
+	for _, prefix := range update.clearPrefixes {
+		for key := range fdbClient.mockedKeyValues {
+			if strings.HasPrefix(key, prefix) {
+				delete(fdbClient.mockedKeyValues, key)
+			}
+		}
+	}
+
+	for _, key := range update.clearKeys {
+		delete(fdbClient.mockedKeyValues, key)
+	}
+
+	for key, value := range update.setKeys {
+		fdbClient.mockedKeyValues[key] = value
+	}
+
+	return nil
+}
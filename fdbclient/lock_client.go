@@ -22,6 +22,7 @@ package fdbclient
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -301,6 +302,82 @@ func (client *realLockClient) UpdateDenyList(locks []fdbv1beta2.LockDenyListEntr
 	return err
 }
 
+// AddPendingAction registers that the operator instance for the data
+// center of this cluster is ready to perform the provided global action.
+func (client *realLockClient) AddPendingAction(action fdbv1beta2.GlobalCoordinationAction, value string) error {
+	_, err := client.database.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		err := tr.Options().SetAccessSystemKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		key := fdb.Key(fmt.Sprintf("%s/globalCoordination/%s/%s", client.cluster.GetLockPrefix(), action, client.cluster.Spec.DataCenter))
+		tr.Set(key, []byte(value))
+		return nil, nil
+	})
+
+	return err
+}
+
+// GetPendingActions returns the stored values for the provided global
+// action, keyed by the data center of the operator instance that registered
+// them.
+func (client *realLockClient) GetPendingActions(action fdbv1beta2.GlobalCoordinationAction) (map[string]string, error) {
+	actions, err := client.database.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		err := tr.Options().SetReadSystemKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		keyPrefix := fmt.Sprintf("%s/globalCoordination/%s/", client.cluster.GetLockPrefix(), action)
+		keyRange, err := fdb.PrefixRange([]byte(keyPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		results := tr.GetRange(keyRange, fdb.RangeOptions{}).GetSliceOrPanic()
+		actions := make(map[string]string, len(results))
+		for _, result := range results {
+			actions[strings.TrimPrefix(string(result.Key), keyPrefix)] = string(result.Value)
+		}
+
+		return actions, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	actionMap, isMap := actions.(map[string]string)
+	if !isMap {
+		return nil, fmt.Errorf("invalid return value from transaction in GetPendingActions: %v", actions)
+	}
+
+	return actionMap, nil
+}
+
+// ClearPendingActions removes the values stored by the operator instances
+// of all data centers for the provided global action.
+func (client *realLockClient) ClearPendingActions(action fdbv1beta2.GlobalCoordinationAction) error {
+	_, err := client.database.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		err := tr.Options().SetAccessSystemKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		keyPrefix := []byte(fmt.Sprintf("%s/globalCoordination/%s/", client.cluster.GetLockPrefix(), action))
+		keyRange, err := fdb.PrefixRange(keyPrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		tr.ClearRange(keyRange)
+		return nil, nil
+	})
+
+	return err
+}
+
 // getDenyListKeyRange defines a key range containing the full deny list.
 func (client *realLockClient) getDenyListKeyRange() (fdb.KeyRange, error) {
 	keyPrefix := []byte(fmt.Sprintf("%s/denyList/", client.cluster.GetLockPrefix()))
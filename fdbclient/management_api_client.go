@@ -0,0 +1,248 @@
+/*
+ * management_api_client.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fdbclient
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// excludedServersPrefix is the prefix of the management API for excluded server addresses.
+	excludedServersPrefix = "\xff\xff/management/excluded/"
+	// excludedLocalitiesPrefix is the prefix of the management API for excluded localities.
+	excludedLocalitiesPrefix = "\xff\xff/management/excluded_locality/"
+	// failedServersPrefix is the prefix of the management API for server addresses that are excluded as failed.
+	failedServersPrefix = "\xff\xff/management/failed/"
+	// failedLocalitiesPrefix is the prefix of the management API for localities that are excluded as failed.
+	failedLocalitiesPrefix = "\xff\xff/management/failed_locality/"
+	// maintenancePrefix is the prefix of the management API for the maintenance zone. The value of the key is the
+	// remaining duration of the maintenance in seconds.
+	maintenancePrefix = "\xff\xff/management/maintenance/"
+	// coordinatorsProcessesKey is the key of the management API for the addresses of the coordinators.
+	coordinatorsProcessesKey = "\xff\xff/configuration/coordinators/processes"
+	// errorMessageKey is the key that contains the reason why the special key space rejected a change.
+	errorMessageKey = "\xff\xff/error_message"
+	// localityPrefix is the prefix that locality based exclusions have.
+	localityPrefix = "locality_"
+)
+
+// managementAPIAdminClient provides an implementation of the admin interface that makes use of the management API in
+// the special key space (\xff\xff/management/) to manage exclusions, the maintenance zone and the coordinators instead
+// of running fdbcli commands. All other commands, e.g. killing processes, configuring the database or managing
+// backups, have no representation in the management API and will be executed by the embedded cliAdminClient.
+//
+// All management API transactions use MaxCliTimeout, as the exclusion of processes or the change of coordinators can
+// take some time until the change is committed.
+type managementAPIAdminClient struct {
+	*cliAdminClient
+}
+
+// NewManagementAPIAdminClient generates an Admin client for a cluster that makes use of the management API for
+// supported operations. The management API requires at least API version 700.
+func NewManagementAPIAdminClient(cluster *fdbv1beta2.FoundationDBCluster, kubernetesClient client.Client, log logr.Logger) (fdbadminclient.AdminClient, error) {
+	apiVersion, err := fdb.GetAPIVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	if apiVersion < minimumManagementAPIVersion {
+		return nil, fmt.Errorf("the management API requires at least API version %d but the operator uses API version %d", minimumManagementAPIVersion, apiVersion)
+	}
+
+	cliClient, err := NewCliAdminClient(cluster, kubernetesClient, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &managementAPIAdminClient{
+		cliAdminClient: cliClient.(*cliAdminClient),
+	}, nil
+}
+
+// getExclusionKey returns the key in the management API that represents the exclusion of the provided address.
+func getExclusionKey(address fdbv1beta2.ProcessAddress) string {
+	if isLocality(address) {
+		return excludedLocalitiesPrefix + address.StringAddress
+	}
+
+	return excludedServersPrefix + address.StringWithoutFlags()
+}
+
+// getFailedKey returns the key in the management API that represents the exclusion of the provided address as failed.
+func getFailedKey(address fdbv1beta2.ProcessAddress) string {
+	if isLocality(address) {
+		return failedLocalitiesPrefix + address.StringAddress
+	}
+
+	return failedServersPrefix + address.StringWithoutFlags()
+}
+
+// isLocality returns true if the address is a locality based exclusion.
+func isLocality(address fdbv1beta2.ProcessAddress) bool {
+	return address.StringAddress != "" && strings.HasPrefix(address.StringAddress, localityPrefix)
+}
+
+// ExcludeProcesses starts evacuating processes so that they can be removed from the database. All addresses will be
+// excluded in a single transaction. The management API performs the same safety checks as fdbcli and doesn't wait
+// until the data is moved away from the excluded processes.
+func (client *managementAPIAdminClient) ExcludeProcesses(addresses []fdbv1beta2.ProcessAddress) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	setKeys := make(map[string][]byte, len(addresses))
+	for _, address := range addresses {
+		setKeys[getExclusionKey(address)] = []byte{}
+	}
+
+	client.log.Info("Excluding processes with management API", "addresses", fdbv1beta2.ProcessAddressesString(addresses, " "))
+	return client.fdbLibClient.updateManagementKeysInDB(managementUpdate{setKeys: setKeys}, MaxCliTimeout)
+}
+
+// IncludeProcesses removes processes from the exclusion list and allows them to take on roles again. Like the include
+// command of fdbcli this removes the addresses from the excluded and the failed servers. All addresses will be
+// included in a single transaction.
+func (client *managementAPIAdminClient) IncludeProcesses(addresses []fdbv1beta2.ProcessAddress) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	clearKeys := make([]string, 0, 2*len(addresses))
+	for _, address := range addresses {
+		clearKeys = append(clearKeys, getExclusionKey(address), getFailedKey(address))
+	}
+
+	client.log.Info("Including processes with management API", "addresses", fdbv1beta2.ProcessAddressesString(addresses, " "))
+	return client.fdbLibClient.updateManagementKeysInDB(managementUpdate{clearKeys: clearKeys}, MaxCliTimeout)
+}
+
+// GetExclusions gets a list of the addresses currently excluded from the database, including the servers and
+// localities that are excluded as failed.
+func (client *managementAPIAdminClient) GetExclusions() ([]fdbv1beta2.ProcessAddress, error) {
+	exclusions := make([]fdbv1beta2.ProcessAddress, 0)
+	// The same address can be excluded and marked as failed, only report it once.
+	seen := map[string]fdbv1beta2.None{}
+
+	for _, prefix := range []string{excludedServersPrefix, failedServersPrefix, excludedLocalitiesPrefix, failedLocalitiesPrefix} {
+		keyValues, err := client.fdbLibClient.getRangeFromDB(prefix, MaxCliTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		for key := range keyValues {
+			exclusion := strings.TrimPrefix(key, prefix)
+			if _, ok := seen[exclusion]; ok {
+				continue
+			}
+			seen[exclusion] = fdbv1beta2.None{}
+
+			if prefix == excludedLocalitiesPrefix || prefix == failedLocalitiesPrefix {
+				exclusions = append(exclusions, fdbv1beta2.ProcessAddress{StringAddress: exclusion})
+				continue
+			}
+
+			address, err := fdbv1beta2.ParseProcessAddress(exclusion)
+			if err != nil {
+				return nil, err
+			}
+
+			exclusions = append(exclusions, address)
+		}
+	}
+
+	// Sort the exclusions to return a stable result.
+	sort.Slice(exclusions, func(i, j int) bool {
+		return exclusions[i].String() < exclusions[j].String()
+	})
+
+	return exclusions, nil
+}
+
+// CanSafelyRemove checks whether it is safe to remove processes from the cluster
+//
+// The list returned by this method will be the addresses that are *not* safe to remove.
+func (client *managementAPIAdminClient) CanSafelyRemove(addresses []fdbv1beta2.ProcessAddress) ([]fdbv1beta2.ProcessAddress, error) {
+	status, err := client.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	return fdbstatus.CanSafelyRemoveFromStatus(client.log, client, addresses, status)
+}
+
+// GetMaintenanceZone gets current maintenance zone, if any. Returns empty string if maintenance mode is off.
+func (client *managementAPIAdminClient) GetMaintenanceZone() (string, error) {
+	keyValues, err := client.fdbLibClient.getRangeFromDB(maintenancePrefix, MaxCliTimeout)
+	if err != nil {
+		return "", err
+	}
+
+	// FDB only allows a single maintenance zone.
+	for key := range keyValues {
+		return strings.TrimPrefix(key, maintenancePrefix), nil
+	}
+
+	return "", nil
+}
+
+// SetMaintenanceZone places zone into maintenance mode.
+func (client *managementAPIAdminClient) SetMaintenanceZone(zone string, timeoutSeconds int) error {
+	client.log.Info("Setting maintenance zone with management API", "zone", zone, "timeoutSeconds", timeoutSeconds)
+	return client.fdbLibClient.updateManagementKeysInDB(managementUpdate{
+		setKeys: map[string][]byte{
+			maintenancePrefix + zone: []byte(strconv.Itoa(timeoutSeconds)),
+		},
+	}, MaxCliTimeout)
+}
+
+// ResetMaintenanceMode switches of maintenance mode.
+func (client *managementAPIAdminClient) ResetMaintenanceMode() error {
+	client.log.Info("Resetting maintenance mode with management API")
+	return client.fdbLibClient.updateManagementKeysInDB(managementUpdate{
+		clearPrefixes: []string{maintenancePrefix},
+	}, MaxCliTimeout)
+}
+
+// ChangeCoordinators changes the coordinator set and returns the new connection string.
+func (client *managementAPIAdminClient) ChangeCoordinators(addresses []fdbv1beta2.ProcessAddress) (string, error) {
+	client.log.Info("Changing coordinators with management API", "addresses", fdbv1beta2.ProcessAddressesString(addresses, " "))
+	err := client.fdbLibClient.updateManagementKeysInDB(managementUpdate{
+		setKeys: map[string][]byte{
+			coordinatorsProcessesKey: []byte(fdbv1beta2.ProcessAddressesString(addresses, ",")),
+		},
+	}, MaxCliTimeout)
+	if err != nil {
+		return "", err
+	}
+
+	return client.GetConnectionString()
+}
@@ -0,0 +1,207 @@
+/*
+ * management_api_client_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fdbclient
+
+import (
+	"fmt"
+	"net"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("management_api_client_test", func() {
+	var mockFdbClient *mockFdbLibClient
+	var mockRunner *mockCommandRunner
+	var managementClient *managementAPIAdminClient
+
+	BeforeEach(func() {
+		mockFdbClient = &mockFdbLibClient{}
+		mockRunner = &mockCommandRunner{}
+		managementClient = &managementAPIAdminClient{
+			cliAdminClient: &cliAdminClient{
+				Cluster: &fdbv1beta2.FoundationDBCluster{
+					Spec: fdbv1beta2.FoundationDBClusterSpec{
+						Version: fdbv1beta2.Versions.Default.String(),
+					},
+				},
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+				cmdRunner:       mockRunner,
+				fdbLibClient:    mockFdbClient,
+			},
+		}
+	})
+
+	DescribeTable("getting the exclusion key",
+		func(address fdbv1beta2.ProcessAddress, expected string) {
+			Expect(getExclusionKey(address)).To(Equal(expected))
+		},
+		Entry("IP address without port",
+			fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP("192.168.0.1")},
+			"\xff\xff/management/excluded/192.168.0.1",
+		),
+		Entry("IP address with port and tls flag",
+			fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP("192.168.0.1"), Port: 4500, Flags: map[string]bool{"tls": true}},
+			"\xff\xff/management/excluded/192.168.0.1:4500",
+		),
+		Entry("locality",
+			fdbv1beta2.ProcessAddress{StringAddress: "locality_instance_id:storage-1"},
+			"\xff\xff/management/excluded_locality/locality_instance_id:storage-1",
+		),
+	)
+
+	When("excluding processes", func() {
+		var err error
+		var addresses []fdbv1beta2.ProcessAddress
+
+		BeforeEach(func() {
+			addresses = []fdbv1beta2.ProcessAddress{
+				{IPAddress: net.ParseIP("192.168.0.1"), Port: 4500},
+				{StringAddress: "locality_instance_id:storage-2"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			err = managementClient.ExcludeProcesses(addresses)
+		})
+
+		It("should only set the management API keys without calling fdbcli", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockFdbClient.mockedKeyValues).To(HaveLen(2))
+			Expect(mockFdbClient.mockedKeyValues).To(HaveKey("\xff\xff/management/excluded/192.168.0.1:4500"))
+			Expect(mockFdbClient.mockedKeyValues).To(HaveKey("\xff\xff/management/excluded_locality/locality_instance_id:storage-2"))
+			Expect(mockRunner.receivedBinary).To(BeEmpty())
+		})
+
+		It("should return the exclusions", func() {
+			exclusions, err := managementClient.GetExclusions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exclusions).To(ConsistOf(addresses))
+		})
+
+		When("the processes are included again", func() {
+			JustBeforeEach(func() {
+				Expect(managementClient.IncludeProcesses(addresses[:1])).To(Succeed())
+			})
+
+			It("should only keep the remaining exclusion", func() {
+				exclusions, err := managementClient.GetExclusions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exclusions).To(ConsistOf(addresses[1]))
+			})
+		})
+
+		When("one of the processes is also excluded as failed", func() {
+			BeforeEach(func() {
+				mockFdbClient.mockedKeyValues = map[string][]byte{
+					"\xff\xff/management/failed/192.168.0.1:4500": {},
+					"\xff\xff/management/failed/192.168.0.3:4500": {},
+				}
+			})
+
+			It("should return every exclusion only once", func() {
+				exclusions, err := managementClient.GetExclusions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exclusions).To(ConsistOf(append(addresses, fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP("192.168.0.3"), Port: 4500})))
+			})
+
+			When("the process is included again", func() {
+				JustBeforeEach(func() {
+					Expect(managementClient.IncludeProcesses(addresses[:1])).To(Succeed())
+				})
+
+				It("should remove the exclusion and the failed exclusion", func() {
+					Expect(mockFdbClient.mockedKeyValues).NotTo(HaveKey("\xff\xff/management/excluded/192.168.0.1:4500"))
+					Expect(mockFdbClient.mockedKeyValues).NotTo(HaveKey("\xff\xff/management/failed/192.168.0.1:4500"))
+					Expect(mockFdbClient.mockedKeyValues).To(HaveKey("\xff\xff/management/failed/192.168.0.3:4500"))
+				})
+			})
+		})
+
+		When("no addresses are provided", func() {
+			BeforeEach(func() {
+				addresses = nil
+			})
+
+			It("should not update any keys", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockFdbClient.mockedKeyValues).To(BeEmpty())
+			})
+		})
+
+		When("the transaction fails", func() {
+			BeforeEach(func() {
+				mockFdbClient.mockedError = fmt.Errorf("transaction failed")
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	When("managing the maintenance zone", func() {
+		It("should set and reset the maintenance zone without calling fdbcli", func() {
+			zone, err := managementClient.GetMaintenanceZone()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone).To(BeEmpty())
+
+			Expect(managementClient.SetMaintenanceZone("zone-1", 3600)).To(Succeed())
+			Expect(mockFdbClient.mockedKeyValues).To(HaveKeyWithValue("\xff\xff/management/maintenance/zone-1", []byte("3600")))
+			zone, err = managementClient.GetMaintenanceZone()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone).To(Equal("zone-1"))
+
+			Expect(managementClient.ResetMaintenanceMode()).To(Succeed())
+			zone, err = managementClient.GetMaintenanceZone()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone).To(BeEmpty())
+			Expect(mockRunner.receivedBinary).To(BeEmpty())
+		})
+	})
+
+	When("changing the coordinators", func() {
+		var connectionString string
+		var err error
+
+		BeforeEach(func() {
+			mockFdbClient.mockedOutput = []byte("test:abcd@192.168.0.1:4500:tls,192.168.0.2:4500:tls,192.168.0.3:4500:tls")
+		})
+
+		JustBeforeEach(func() {
+			connectionString, err = managementClient.ChangeCoordinators([]fdbv1beta2.ProcessAddress{
+				{IPAddress: net.ParseIP("192.168.0.1"), Port: 4500, Flags: map[string]bool{"tls": true}},
+				{IPAddress: net.ParseIP("192.168.0.2"), Port: 4500, Flags: map[string]bool{"tls": true}},
+				{IPAddress: net.ParseIP("192.168.0.3"), Port: 4500, Flags: map[string]bool{"tls": true}},
+			})
+		})
+
+		It("should set the coordinators key without calling fdbcli", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockFdbClient.mockedKeyValues).To(HaveKeyWithValue(coordinatorsProcessesKey, []byte("192.168.0.1:4500:tls,192.168.0.2:4500:tls,192.168.0.3:4500:tls")))
+			Expect(connectionString).To(Equal("test:abcd@192.168.0.1:4500:tls,192.168.0.2:4500:tls,192.168.0.3:4500:tls"))
+			Expect(mockRunner.receivedBinary).To(BeEmpty())
+		})
+	})
+})
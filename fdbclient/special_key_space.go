@@ -0,0 +1,56 @@
+/*
+ * special_key_space.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fdbclient
+
+// #define FDB_API_VERSION 620
+// #include <foundationdb/fdb_c.h>
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+)
+
+const (
+	// specialKeySpaceEnableWritesOption is the transaction option that allows writes to the special key space.
+	specialKeySpaceEnableWritesOption = 714
+	// minimumManagementAPIVersion is the minimum API version that provides the management module of the special key
+	// space.
+	minimumManagementAPIVersion = 700
+)
+
+// transactionHandle mirrors the layout of the unexported transaction type of the Go bindings.
+type transactionHandle struct {
+	ptr *C.FDBTransaction
+}
+
+// enableSpecialKeySpaceWrites allows the transaction to write to the special key space. The used Go bindings don't
+// generate a setter for this option, so the option is set directly with the C API.
+func enableSpecialKeySpaceWrites(transaction fdb.Transaction) error {
+	handle := *(**transactionHandle)(unsafe.Pointer(&transaction))
+	errorCode := C.fdb_transaction_set_option(handle.ptr, C.FDBTransactionOption(specialKeySpaceEnableWritesOption), nil, 0)
+	if errorCode != 0 {
+		return fdb.Error{Code: int(errorCode)}
+	}
+
+	return nil
+}
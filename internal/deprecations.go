@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
 )
 
 // DeprecationOptions controls how deprecations and changes to defaults
@@ -57,6 +58,13 @@ func NormalizeClusterSpec(cluster *fdbv1beta2.FoundationDBCluster, options Depre
 		}
 	}
 
+	if options.UseFutureDefaults {
+		// The unified image will become the default in the next major version.
+		if cluster.Spec.UseUnifiedImage == nil {
+			cluster.Spec.UseUnifiedImage = pointer.Bool(true)
+		}
+	}
+
 	if !options.OnlyShowChanges {
 		// Set up resource requirements for the main container.
 		updatePodTemplates(&cluster.Spec, func(template *corev1.PodTemplateSpec) {
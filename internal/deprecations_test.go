@@ -281,6 +281,35 @@ var _ = Describe("[internal] deprecations", func() {
 			})
 		})
 
+		Context("with the future defaults", func() {
+			JustBeforeEach(func() {
+				err := NormalizeClusterSpec(cluster, DeprecationOptions{UseFutureDefaults: true, OnlyShowChanges: false})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should have the unified images enabled", func() {
+				Expect(cluster.GetUseUnifiedImage()).To(BeTrue())
+			})
+
+			It("should use the default image config for the unified image", func() {
+				Expect(spec.MainContainer.ImageConfigs).To(ContainElement(fdbv1beta2.ImageConfig{BaseImage: "foundationdb/foundationdb-kubernetes"}))
+			})
+
+			It("should not have any init containers in the process settings", func() {
+				Expect(spec.Processes["general"].PodTemplate.Spec.InitContainers).To(HaveLen(0))
+			})
+
+			When("the unified image is explicitly disabled", func() {
+				BeforeEach(func() {
+					spec.UseUnifiedImage = pointer.Bool(false)
+				})
+
+				It("should have the unified images disabled", func() {
+					Expect(cluster.GetUseUnifiedImage()).To(BeFalse())
+				})
+			})
+		})
+
 		When("adding an image config", func() {
 			When("no image config is set", func() {
 				It("should be added", func() {
@@ -0,0 +1,61 @@
+/*
+ * disaster_recovery_status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var disasterRecoveryLagRegex = regexp.MustCompile(`The DR is (\d+(?:\.\d+)?) seconds behind`)
+
+// ParseDisasterRecoveryStatus parses the output of `fdbdr status` and returns the state of the replication. The
+// cluster names are not part of the output and must be set by the caller.
+func ParseDisasterRecoveryStatus(output string) *fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails {
+	details := &fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{}
+
+	// fdbdr reports a running DR either as complete copy or as NOT complete copy of the primary database, everything
+	// else means that no DR is running.
+	if strings.Contains(output, "is a complete copy") {
+		details.Running = true
+		details.Restorable = true
+	} else if strings.Contains(output, "is NOT a complete copy") {
+		details.Running = true
+	}
+
+	if !details.Running {
+		return details
+	}
+
+	lag := disasterRecoveryLagRegex.FindStringSubmatch(output)
+	if lag != nil {
+		secondsBehind, err := strconv.ParseFloat(lag[1], 64)
+		if err == nil {
+			details.SecondsBehind = int64(math.Ceil(secondsBehind))
+		}
+	}
+
+	return details
+}
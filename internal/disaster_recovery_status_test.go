@@ -0,0 +1,61 @@
+/*
+ * disaster_recovery_status_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("disaster_recovery_status", func() {
+	DescribeTable("parsing the DR status",
+		func(output string, expected *fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails) {
+			Expect(ParseDisasterRecoveryStatus(output)).To(Equal(expected))
+		},
+		Entry("no DR",
+			"No previous DR on tag `default' found.\n",
+			&fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{},
+		),
+		Entry("DR that is not restorable",
+			"The DR on tag `default' is NOT a complete copy of the primary database.\n\nThe DR is 12.500000 seconds behind.\n",
+			&fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+				Running:       true,
+				SecondsBehind: 13,
+			},
+		),
+		Entry("DR that is restorable",
+			"The DR on tag `default' is a complete copy of the primary database.\n\nThe DR is 0.700000 seconds behind.\n",
+			&fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+				Running:       true,
+				Restorable:    true,
+				SecondsBehind: 1,
+			},
+		),
+		Entry("DR that is restorable without lag information",
+			"The DR on tag `default' is a complete copy of the primary database.\n",
+			&fdbv1beta2.FoundationDBDisasterRecoveryStatusDetails{
+				Running:    true,
+				Restorable: true,
+			},
+		),
+	)
+})
@@ -44,6 +44,9 @@ type Info struct {
 	LocalityData map[string]string
 
 	Class fdbv1beta2.ProcessClass
+
+	// Preferred defines if the process matches the preferred Pod labels of the coordinator constraints.
+	Preferred bool
 }
 
 // Sort processes by their priority and their ID.
@@ -53,6 +56,11 @@ type Info struct {
 func sortLocalities(cluster *fdbv1beta2.FoundationDBCluster, processes []Info) {
 	// Sort the processes for ID to ensure we have a stable input
 	sort.SliceStable(processes, func(i, j int) bool {
+		// prefer processes that match the preferred Pod labels
+		if processes[i].Preferred != processes[j].Preferred {
+			return processes[i].Preferred
+		}
+
 		p1 := cluster.GetClassCandidatePriority(processes[i].Class)
 		p2 := cluster.GetClassCandidatePriority(processes[j].Class)
 
@@ -168,6 +176,16 @@ func ChooseDistributedProcesses(cluster *fdbv1beta2.FoundationDBCluster, process
 		for field, limit := range constraint.HardLimits {
 			hardLimits[field] = limit
 		}
+
+		// Hard limits for additional localities, e.g. from the coordinator constraints, must be tracked as well.
+		additionalFields := make([]string, 0, len(constraint.HardLimits))
+		for field := range constraint.HardLimits {
+			if !containsField(fields, field) {
+				additionalFields = append(additionalFields, field)
+			}
+		}
+		sort.Strings(additionalFields)
+		fields = append(append(make([]string, 0, len(fields)+len(additionalFields)), fields...), additionalFields...)
 	}
 
 	for _, field := range fields {
@@ -240,8 +258,38 @@ func ChooseDistributedProcesses(cluster *fdbv1beta2.FoundationDBCluster, process
 	return chosen, nil
 }
 
-// GetHardLimits returns the distribution of localities.
+// containsField returns true if the field is part of the fields.
+func containsField(fields []string, field string) bool {
+	for _, current := range fields {
+		if current == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetHardLimits returns the distribution of localities. The limits of the coordinator constraints will be merged
+// into the limits, if a limit is defined in both the stricter limit will be used.
 func GetHardLimits(cluster *fdbv1beta2.FoundationDBCluster) map[string]int {
+	hardLimits := getDefaultHardLimits(cluster)
+
+	for field, limit := range cluster.Spec.CoordinatorConstraints.MaxCoordinatorsPerLocality {
+		if limit < 1 {
+			continue
+		}
+
+		current, ok := hardLimits[field]
+		if !ok || limit < current {
+			hardLimits[field] = limit
+		}
+	}
+
+	return hardLimits
+}
+
+// getDefaultHardLimits returns the distribution of localities based on the database configuration.
+func getDefaultHardLimits(cluster *fdbv1beta2.FoundationDBCluster) map[string]int {
 	if cluster.Spec.DatabaseConfiguration.UsableRegions <= 1 {
 		// For the three_data_hall redundancy mode we will recruit 9 coordinators and those hard limits are only used
 		// for selecting coordinators. We want to make sure we select coordinators across as many fault domains as possible.
@@ -267,12 +315,15 @@ func GetHardLimits(cluster *fdbv1beta2.FoundationDBCluster) map[string]int {
 // CheckCoordinatorValidity determines if the cluster's current coordinators
 // meet the fault tolerance requirements.
 //
+// The node names contain the node of the Pod for each process group, they are
+// used to check the excluded nodes of the coordinator constraints.
+//
 // The first return value will be whether the coordinators are valid.
 // The second return value will be whether the processes have their TLS flags
 // matching the cluster spec.
 // The third return value will hold any errors encountered when checking the
 // coordinators.
-func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, coordinatorStatus map[string]bool) (bool, bool, error) {
+func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, coordinatorStatus map[string]bool, nodeNames map[fdbv1beta2.ProcessGroupID]string) (bool, bool, error) {
 	if len(coordinatorStatus) == 0 {
 		return false, false, errors.New("unable to get coordinator status")
 	}
@@ -280,6 +331,9 @@ func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.Foundation
 	allAddressesValid := true
 	allEligible := true
 	allUsingCorrectAddress := true
+	allSatisfyConstraints := true
+	missingDataHall := false
+	coordinatorCount := 0
 	hardLimits := GetHardLimits(cluster)
 	coordinatorLocalities := make(map[string]map[string]int)
 	// Track what fields should be validated.
@@ -358,10 +412,28 @@ func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.Foundation
 		}
 
 		if coordinatorAddress != "" {
+			coordinatorCount++
+
+			if cluster.IsExcludedFromCoordinators(nodeNames[fdbv1beta2.ProcessGroupID(processGroupID)], process.Locality) {
+				pLogger.Info("Coordinator is running on an excluded node or zone", "address", coordinatorAddress)
+				allSatisfyConstraints = false
+			}
+
+			if cluster.HasPinnedCoordinators() && !cluster.IsPinnedCoordinator(fdbv1beta2.ProcessGroupID(processGroupID)) {
+				pLogger.Info("Coordinator is not part of the pinned coordinators", "address", coordinatorAddress)
+				allSatisfyConstraints = false
+			}
+
 			for _, field := range fieldsToValidate {
 				locality, ok := process.Locality[field]
 				// If the field is not set ignore it.
 				if !ok {
+					// For the three_data_hall redundancy mode all coordinators must have the data hall locality,
+					// otherwise we are not able to verify that the coordinators are spread across the data halls.
+					if field == fdbv1beta2.FDBLocalityDataHallKey {
+						pLogger.Info("Coordinator has no data hall locality", "address", coordinatorAddress)
+						missingDataHall = true
+					}
 					continue
 				}
 				coordinatorLocalities[field][locality]++
@@ -385,9 +457,14 @@ func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.Foundation
 		}
 	}
 
-	// Check if the coordinators are distributed across the localities based on the hard limit requirements.
+	// Check if the coordinators are distributed across the localities based on the hard limit requirements. Pinned
+	// coordinators are selected by the user, so the distribution is not checked.
 	hasCorrectLocalityDistribution := true
 	for field, maxValue := range hardLimits {
+		if cluster.HasPinnedCoordinators() {
+			break
+		}
+
 		for locality, currentValue := range coordinatorLocalities[field] {
 			if currentValue > maxValue {
 				logger.Info("Cluster does not have coordinators in the correct number of localities", "desiredCount", maxValue, "currentCount", currentValue, "locality", locality)
@@ -407,10 +484,14 @@ func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.Foundation
 	// Verify that enough coordinators are running.
 	desiredCoordinatorCount := cluster.DesiredCoordinatorCount()
 	runningCoordinators := len(coordinatorLocalities[fdbv1beta2.FDBLocalityZoneIDKey])
+	// Pinned coordinators are not required to be in different zones.
+	if cluster.HasPinnedCoordinators() {
+		runningCoordinators = coordinatorCount
+	}
 	hasEnoughCoordinators := runningCoordinators == desiredCoordinatorCount
 	if !hasEnoughCoordinators {
 		logger.Info("Cluster has not enough running coordinators", "runningCoordinators", runningCoordinators, "desiredCount", desiredCoordinatorCount)
 	}
 
-	return hasEnoughCoordinators && hasCorrectLocalityDistribution && allHealthy && allUsingCorrectAddress && allEligible, allAddressesValid, nil
+	return hasEnoughCoordinators && hasCorrectLocalityDistribution && !missingDataHall && allHealthy && allUsingCorrectAddress && allEligible && allSatisfyConstraints, allAddressesValid, nil
 }
@@ -229,6 +229,21 @@ var _ = Describe("Localities", func() {
 				Expect(localities[3].ID).To(Equal("log-1"))
 			})
 		})
+
+		When("some localities are preferred", func() {
+			BeforeEach(func() {
+				localities[1].Preferred = true
+			})
+
+			It("should sort the preferred localities first", func() {
+				sortLocalities(cluster, localities)
+
+				Expect(localities[0].ID).To(Equal("tlog-1"))
+				Expect(localities[1].ID).To(Equal("storage-1"))
+				Expect(localities[2].ID).To(Equal("storage-51"))
+				Expect(localities[3].ID).To(Equal("log-1"))
+			})
+		})
 	})
 
 	Describe("chooseDistributedProcesses", func() {
@@ -330,6 +345,26 @@ var _ = Describe("Localities", func() {
 				})
 			})
 
+			Context("with a hard limit for an additional locality", func() {
+				BeforeEach(func() {
+					for idx := range candidates {
+						candidates[idx].LocalityData["rack"] = "r1"
+					}
+					candidates[7].LocalityData["rack"] = "r2"
+
+					result, err = ChooseDistributedProcesses(cluster, candidates, 2, ProcessSelectionConstraint{
+						HardLimits: map[string]int{"rack": 1},
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should recruit at most one process per rack", func() {
+					Expect(len(result)).To(Equal(2))
+					Expect(result[0].ID).To(Equal("p1"))
+					Expect(result[1].ID).To(Equal("p8"))
+				})
+			})
+
 			Context("when only distributing across data centers", func() {
 				BeforeEach(func() {
 					result, err = ChooseDistributedProcesses(cluster, candidates, 5, ProcessSelectionConstraint{
@@ -442,6 +477,27 @@ var _ = Describe("Localities", func() {
 				fdbv1beta2.FDBLocalityZoneIDKey:   1,
 			},
 		),
+		Entry("cluster with three data hall and coordinator constraints",
+			&fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+						RedundancyMode: fdbv1beta2.RedundancyModeThreeDataHall,
+					},
+					CoordinatorConstraints: fdbv1beta2.CoordinatorConstraints{
+						MaxCoordinatorsPerLocality: map[string]int{
+							fdbv1beta2.FDBLocalityDataHallKey: 2,
+							fdbv1beta2.FDBLocalityZoneIDKey:   2,
+							"rack":                            1,
+						},
+					},
+				},
+			},
+			map[string]int{
+				fdbv1beta2.FDBLocalityDataHallKey: 2,
+				fdbv1beta2.FDBLocalityZoneIDKey:   1,
+				"rack":                            1,
+			},
+		),
 	)
 
 	DescribeTable("when getting the locality info from a process", func(process fdbv1beta2.FoundationDBStatusProcessInfo, mainContainerTLS bool, expected Info, expectedError bool) {
@@ -645,7 +701,7 @@ var _ = Describe("Localities", func() {
 
 		Context("with the default configuration", func() {
 			It("should report the coordinators as valid", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeTrue())
 				Expect(addressesValid).To(BeTrue())
 				Expect(err).NotTo(HaveOccurred())
@@ -654,7 +710,7 @@ var _ = Describe("Localities", func() {
 
 		When("an empty coordinator status is passed down", func() {
 			It("should report the coordinators as invalid", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, nil)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, nil, nil)
 				Expect(coordinatorsValid).To(BeFalse())
 				Expect(addressesValid).To(BeFalse())
 				Expect(err).To(HaveOccurred())
@@ -671,7 +727,7 @@ var _ = Describe("Localities", func() {
 			})
 
 			It("should report the coordinators as invalid", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeFalse())
 				Expect(addressesValid).To(BeTrue())
 				Expect(err).To(BeNil())
@@ -686,7 +742,7 @@ var _ = Describe("Localities", func() {
 			})
 
 			It("should report the coordinators as invalid", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeFalse())
 				Expect(addressesValid).To(BeTrue())
 				Expect(err).NotTo(HaveOccurred())
@@ -699,7 +755,7 @@ var _ = Describe("Localities", func() {
 			})
 
 			It("should ignore the process", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeTrue())
 				Expect(addressesValid).To(BeTrue())
 				Expect(err).To(BeNil())
@@ -712,7 +768,7 @@ var _ = Describe("Localities", func() {
 			})
 
 			It("should report the coordinators as invalid", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeFalse())
 				Expect(addressesValid).To(BeFalse())
 				Expect(err).NotTo(HaveOccurred())
@@ -729,7 +785,7 @@ var _ = Describe("Localities", func() {
 			})
 
 			It("should report that not all addresses and coordinators are valid", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeFalse())
 				Expect(addressesValid).To(BeFalse())
 				Expect(err).To(BeNil())
@@ -747,7 +803,7 @@ var _ = Describe("Localities", func() {
 			})
 
 			It("should be ignored", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeTrue())
 				Expect(addressesValid).To(BeTrue())
 				Expect(err).To(BeNil())
@@ -767,20 +823,82 @@ var _ = Describe("Localities", func() {
 			})
 
 			It("should ignore the test process and report the coordinators as valid", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeTrue())
 				Expect(addressesValid).To(BeTrue())
 				Expect(err).To(BeNil())
 			})
 		})
 
+		When("a coordinator is running in an excluded zone", func() {
+			BeforeEach(func() {
+				cluster.Spec.CoordinatorConstraints.ExcludedZones = []string{"test-1"}
+			})
+
+			It("should report the coordinators as invalid", func() {
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
+				Expect(coordinatorsValid).To(BeFalse())
+				Expect(addressesValid).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("a coordinator is running on an excluded node", func() {
+			BeforeEach(func() {
+				cluster.Spec.CoordinatorConstraints.ExcludedNodes = []string{"node-1"}
+			})
+
+			It("should report the coordinators as invalid", func() {
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, map[fdbv1beta2.ProcessGroupID]string{"test-1": "node-1"})
+				Expect(coordinatorsValid).To(BeFalse())
+				Expect(addressesValid).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not use the machineid locality as node", func() {
+				status.Cluster.Processes["1"].Locality[fdbv1beta2.FDBLocalityMachineIDKey] = "node-1"
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, map[fdbv1beta2.ProcessGroupID]string{"test-1": "node-2"})
+				Expect(coordinatorsValid).To(BeTrue())
+				Expect(addressesValid).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the coordinators are pinned", func() {
+			BeforeEach(func() {
+				cluster.Spec.CoordinatorConstraints.PinnedCoordinators = []fdbv1beta2.ProcessGroupID{"test-1", "test-2", "test-3"}
+				// Pinned coordinators are not required to be distributed across zones.
+				status.Cluster.Processes["2"].Locality[fdbv1beta2.FDBLocalityZoneIDKey] = "test-1"
+			})
+
+			It("should report the coordinators as valid", func() {
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
+				Expect(coordinatorsValid).To(BeTrue())
+				Expect(addressesValid).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			When("a coordinator is not part of the pinned coordinators", func() {
+				BeforeEach(func() {
+					cluster.Spec.CoordinatorConstraints.PinnedCoordinators = []fdbv1beta2.ProcessGroupID{"test-1", "test-2", "test-4"}
+				})
+
+				It("should report the coordinators as invalid", func() {
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
+					Expect(coordinatorsValid).To(BeFalse())
+					Expect(addressesValid).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
 		Context("with too few coordinators", func() {
 			BeforeEach(func() {
 				status.Client.Coordinators.Coordinators = status.Client.Coordinators.Coordinators[0:2]
 			})
 
 			It("should report the coordinators as not valid", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeFalse())
 				Expect(addressesValid).To(BeTrue())
 				Expect(err).To(BeNil())
@@ -803,13 +921,56 @@ var _ = Describe("Localities", func() {
 			})
 
 			It("should report the coordinators as not valid", func() {
-				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 				Expect(coordinatorsValid).To(BeFalse())
 				Expect(addressesValid).To(BeTrue())
 				Expect(err).To(BeNil())
 			})
 		})
 
+		When("using the three_data_hall redundancy mode", func() {
+			BeforeEach(func() {
+				cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeThreeDataHall
+				status.Client.Coordinators.Coordinators = nil
+				status.Cluster.Processes = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{}
+				cluster.Status.ProcessGroups = nil
+
+				for i := 1; i <= 9; i++ {
+					processGroupID := fmt.Sprintf("test-%d", i)
+					process := generateDummyProcessInfo(processGroupID, "dc1", 4501, false)
+					process.Locality[fdbv1beta2.FDBLocalityDataHallKey] = fmt.Sprintf("az%d", (i-1)/3)
+					status.Cluster.Processes[fdbv1beta2.ProcessGroupID(processGroupID)] = process
+					status.Client.Coordinators.Coordinators = append(status.Client.Coordinators.Coordinators, fdbv1beta2.FoundationDBStatusCoordinator{
+						Address:   process.Address,
+						Reachable: true,
+					})
+					cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, &fdbv1beta2.ProcessGroupStatus{
+						ProcessGroupID: fdbv1beta2.ProcessGroupID(processGroupID),
+					})
+				}
+			})
+
+			It("should report the coordinators as valid", func() {
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
+				Expect(coordinatorsValid).To(BeTrue())
+				Expect(addressesValid).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			When("a coordinator has no data hall locality", func() {
+				BeforeEach(func() {
+					delete(status.Cluster.Processes["test-1"].Locality, fdbv1beta2.FDBLocalityDataHallKey)
+				})
+
+				It("should report the coordinators as not valid", func() {
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
+					Expect(coordinatorsValid).To(BeFalse())
+					Expect(addressesValid).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
 		Context("with multiple regions", func() {
 			BeforeEach(func() {
 				cluster.Spec.DatabaseConfiguration.UsableRegions = 2
@@ -903,7 +1064,7 @@ var _ = Describe("Localities", func() {
 
 			Context("with coordinators divided across three DCs", func() {
 				It("should report the coordinators as valid", func() {
-					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 					Expect(coordinatorsValid).To(BeTrue())
 					Expect(addressesValid).To(BeTrue())
 					Expect(err).To(BeNil())
@@ -920,7 +1081,7 @@ var _ = Describe("Localities", func() {
 				})
 
 				It("should report the coordinators as not valid", func() {
-					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 					Expect(coordinatorsValid).To(BeFalse())
 					Expect(addressesValid).To(BeTrue())
 					Expect(err).To(BeNil())
@@ -947,7 +1108,7 @@ var _ = Describe("Localities", func() {
 				})
 
 				It("should report the coordinators addresses as valid", func() {
-					_, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					_, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 					Expect(addressesValid).To(BeTrue())
 					Expect(err).To(BeNil())
 				})
@@ -965,7 +1126,7 @@ var _ = Describe("Localities", func() {
 					})
 
 					It("should report the coordinators addresses as valid", func() {
-						_, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+						_, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 						Expect(addressesValid).To(BeTrue())
 						Expect(err).To(BeNil())
 					})
@@ -985,7 +1146,7 @@ var _ = Describe("Localities", func() {
 				})
 
 				It("should report the coordinators addresses as valid", func() {
-					_, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					_, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 					Expect(addressesValid).To(BeTrue())
 					Expect(err).To(BeNil())
 				})
@@ -1004,7 +1165,7 @@ var _ = Describe("Localities", func() {
 					})
 
 					It("should report the coordinators addresses as valid", func() {
-						_, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+						_, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 						Expect(addressesValid).To(BeTrue())
 						Expect(err).To(BeNil())
 					})
@@ -1020,7 +1181,7 @@ var _ = Describe("Localities", func() {
 
 			When("the pods do not have DNS names assigned", func() {
 				It("should report valid coordinators", func() {
-					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(coordinatorsValid).To(BeTrue())
 					Expect(addressesValid).To(BeTrue())
@@ -1035,7 +1196,7 @@ var _ = Describe("Localities", func() {
 				})
 
 				It("should reject coordinators based on IP addresses", func() {
-					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(coordinatorsValid).To(BeFalse())
 					Expect(addressesValid).To(BeTrue())
@@ -1059,7 +1220,7 @@ var _ = Describe("Localities", func() {
 				})
 
 				It("should return that the coordinators are valid", func() {
-					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 					Expect(coordinatorsValid).To(BeTrue())
 					Expect(addressesValid).To(BeTrue())
 					Expect(err).NotTo(HaveOccurred())
@@ -1085,7 +1246,7 @@ var _ = Describe("Localities", func() {
 				})
 
 				It("should return that the coordinators are invalid", func() {
-					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus, nil)
 					Expect(coordinatorsValid).To(BeFalse())
 					Expect(addressesValid).To(BeTrue())
 					Expect(err).NotTo(HaveOccurred())
@@ -99,7 +99,7 @@ type realFdbPodSidecarClient struct {
 }
 
 // realPodSidecarClient provides a client for use in real environments, using
-// the annotations from the unified Kubernetes image.
+// the annotations and the configuration API from the unified Kubernetes image.
 type realFdbPodAnnotationClient struct {
 	// Cluster is the cluster we are connecting to.
 	Cluster *fdbv1beta2.FoundationDBCluster
@@ -109,12 +109,54 @@ type realFdbPodAnnotationClient struct {
 
 	// logger is used to add common fields to log messages.
 	logger logr.Logger
+
+	// monitorAPIAddress is the address of the configuration API of
+	// fdb-kubernetes-monitor. If empty the configuration API will not be used
+	// and the client relies on the pod annotations.
+	monitorAPIAddress string
+
+	// useTLS indicates whether this is using a TLS connection to the
+	// configuration API.
+	useTLS bool
+
+	// tlsConfig contains the TLS configuration for the connection to the
+	// configuration API.
+	tlsConfig *tls.Config
+
+	// getTimeout defines the timeout for get requests
+	getTimeout time.Duration
+
+	// postTimeout defines the timeout for post requests
+	postTimeout time.Duration
 }
 
 // NewFdbPodClient builds a client for working with an FDB Pod
 func NewFdbPodClient(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, log logr.Logger, getTimeout time.Duration, postTimeout time.Duration) (podclient.FdbPodClient, error) {
 	if GetImageType(pod) == FDBImageTypeUnified {
-		return &realFdbPodAnnotationClient{Cluster: cluster, Pod: pod, logger: log}, nil
+		client := &realFdbPodAnnotationClient{Cluster: cluster, Pod: pod, logger: log, getTimeout: getTimeout, postTimeout: postTimeout}
+		if !podSupportsMonitorAPI(pod) {
+			return client, nil
+		}
+
+		if pod.Status.PodIP == "" {
+			return nil, fmt.Errorf("waiting for pod %s/%s/%s to be assigned an IP", cluster.Namespace, cluster.Name, pod.Name)
+		}
+
+		client.useTLS = podHasMonitorTLS(pod)
+		if client.useTLS {
+			tlsConfig, err := getTLSConfigFromEnvironment()
+			if err != nil {
+				return nil, err
+			}
+			client.tlsConfig = tlsConfig
+		}
+
+		ips := GetPublicIPsForPod(pod, log)
+		if len(ips) > 0 {
+			client.monitorAPIAddress = net.JoinHostPort(ips[0], "8080")
+		}
+
+		return client, nil
 	}
 
 	if pod.Status.PodIP == "" {
@@ -130,37 +172,51 @@ func NewFdbPodClient(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, l
 
 	var tlsConfig = &tls.Config{}
 	if useTLS {
-		certFile := os.Getenv("FDB_TLS_CERTIFICATE_FILE")
-		keyFile := os.Getenv("FDB_TLS_KEY_FILE")
-		caFile := os.Getenv("FDB_TLS_CA_FILE")
-
-		if certFile == "" || keyFile == "" || caFile == "" {
-			return nil, errors.New("missing one or more TLS env vars: FDB_TLS_CERTIFICATE_FILE, FDB_TLS_KEY_FILE or FDB_TLS_CA_FILE")
-		}
-
-		cert, err := tls.LoadX509KeyPair(
-			certFile,
-			keyFile,
-		)
+		var err error
+		tlsConfig, err = getTLSConfigFromEnvironment()
 		if err != nil {
 			return nil, err
 		}
-		tlsConfig.Certificates = []tls.Certificate{cert}
-		if os.Getenv("DISABLE_SIDECAR_TLS_CHECK") == "1" {
-			tlsConfig.InsecureSkipVerify = true
-		}
-		certPool := x509.NewCertPool()
-		caList, err := os.ReadFile(caFile)
-		if err != nil {
-			return nil, err
-		}
-		certPool.AppendCertsFromPEM(caList)
-		tlsConfig.RootCAs = certPool
 	}
 
 	return &realFdbPodSidecarClient{Cluster: cluster, Pod: pod, useTLS: useTLS, tlsConfig: tlsConfig, logger: log, getTimeout: getTimeout, postTimeout: postTimeout}, nil
 }
 
+// getTLSConfigFromEnvironment builds the TLS configuration for the connection to the pods based on the
+// FDB_TLS_CERTIFICATE_FILE, FDB_TLS_KEY_FILE and FDB_TLS_CA_FILE environment variables.
+func getTLSConfigFromEnvironment() (*tls.Config, error) {
+	certFile := os.Getenv("FDB_TLS_CERTIFICATE_FILE")
+	keyFile := os.Getenv("FDB_TLS_KEY_FILE")
+	caFile := os.Getenv("FDB_TLS_CA_FILE")
+
+	if certFile == "" || keyFile == "" || caFile == "" {
+		return nil, errors.New("missing one or more TLS env vars: FDB_TLS_CERTIFICATE_FILE, FDB_TLS_KEY_FILE or FDB_TLS_CA_FILE")
+	}
+
+	cert, err := tls.LoadX509KeyPair(
+		certFile,
+		keyFile,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	if os.Getenv("DISABLE_SIDECAR_TLS_CHECK") == "1" {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	certPool := x509.NewCertPool()
+	caList, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	certPool.AppendCertsFromPEM(caList)
+	tlsConfig.RootCAs = certPool
+
+	return tlsConfig, nil
+}
+
 // getListenIP gets the IP address that a pod listens on.
 func (client *realFdbPodSidecarClient) getListenIP() string {
 	ips := GetPublicIPsForPod(client.Pod, client.logger)
@@ -172,15 +228,15 @@ func (client *realFdbPodSidecarClient) getListenIP() string {
 }
 
 // generateRequest will generate a retryablehttp.Request for the provided parameters or an error if a request cannot be
-// generated.
-func generateRequest(retryClient *retryablehttp.Client, url string, method string, getTimeout time.Duration, postTimeout time.Duration) (*retryablehttp.Request, error) {
+// generated. The body will only be used for post requests.
+func generateRequest(retryClient *retryablehttp.Client, url string, method string, body string, getTimeout time.Duration, postTimeout time.Duration) (*retryablehttp.Request, error) {
 	switch method {
 	case http.MethodGet:
 		retryClient.HTTPClient.Timeout = getTimeout
 		return retryablehttp.NewRequest(http.MethodGet, url, nil)
 	case http.MethodPost:
 		retryClient.HTTPClient.Timeout = postTimeout
-		req, err := retryablehttp.NewRequest(http.MethodPost, url, strings.NewReader(""))
+		req, err := retryablehttp.NewRequest(http.MethodPost, url, strings.NewReader(body))
 		if err != nil {
 			return nil, err
 		}
@@ -193,11 +249,16 @@ func generateRequest(retryClient *retryablehttp.Client, url string, method strin
 
 // makeRequest submits a request to the sidecar.
 func (client *realFdbPodSidecarClient) makeRequest(method, path string) (string, int, error) {
+	return makeHTTPRequest(client.getListenIP()+":8080", client.useTLS, client.tlsConfig, method, path, "", client.getTimeout, client.postTimeout)
+}
+
+// makeHTTPRequest submits a request to the provided host and returns the body of the response and the status code.
+func makeHTTPRequest(host string, useTLS bool, tlsConfig *tls.Config, method string, path string, body string, getTimeout time.Duration, postTimeout time.Duration) (string, int, error) {
 	var err error
 
 	target := url.URL{
 		Scheme: "http",
-		Host:   client.getListenIP() + ":8080",
+		Host:   host,
 		Path:   path,
 	}
 	retryClient := retryablehttp.NewClient()
@@ -207,12 +268,12 @@ func (client *realFdbPodSidecarClient) makeRequest(method, path string) (string,
 	retryClient.Logger = nil
 	retryClient.CheckRetry = retryablehttp.ErrorPropagatedRetryPolicy
 
-	if client.useTLS {
-		retryClient.HTTPClient.Transport = &http.Transport{TLSClientConfig: client.tlsConfig}
+	if useTLS {
+		retryClient.HTTPClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
 		target.Scheme = "https"
 	}
 
-	req, err := generateRequest(retryClient, target.String(), method, client.getTimeout, client.postTimeout)
+	req, err := generateRequest(retryClient, target.String(), method, body, getTimeout, postTimeout)
 	if err != nil {
 		return "", 0, err
 	}
@@ -226,8 +287,8 @@ func (client *realFdbPodSidecarClient) makeRequest(method, path string) (string,
 		return "", 0, err
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	bodyText := string(body)
+	responseBody, err := io.ReadAll(resp.Body)
+	bodyText := string(responseBody)
 
 	if err != nil {
 		return "", resp.StatusCode, err
@@ -300,6 +361,13 @@ func (client *realFdbPodSidecarClient) GetVariableSubstitutions() (map[string]st
 	return substitutions, err
 }
 
+// GetProcessCommandLines returns the command lines of the running fdbserver
+// processes. The sidecar is not able to report the command lines, so this
+// method always returns nil.
+func (client *realFdbPodSidecarClient) GetProcessCommandLines() (map[int]string, error) {
+	return nil, nil
+}
+
 // UpdateFile checks if a file is up-to-date and tries to update it.
 func (client *realFdbPodSidecarClient) UpdateFile(name string, contents string) (bool, error) {
 	if name == "fdbmonitor.conf" {
@@ -361,6 +429,10 @@ func (client *realFdbPodAnnotationClient) UpdateFile(name string, contents strin
 		return true, nil
 	}
 	if name == "fdbmonitor.conf" {
+		if client.monitorAPIAddress != "" {
+			return client.updateConfiguration(contents)
+		}
+
 		desiredConfiguration := monitorapi.ProcessConfiguration{}
 		err := json.Unmarshal([]byte(contents), &desiredConfiguration)
 		if err != nil {
@@ -389,6 +461,69 @@ func (client *realFdbPodAnnotationClient) UpdateFile(name string, contents strin
 	return false, fmt.Errorf("unknown file %s", name)
 }
 
+// updateConfiguration pushes the desired process configuration to the configuration API of fdb-kubernetes-monitor and
+// checks if the acknowledged configuration hash matches the hash of the desired configuration.
+func (client *realFdbPodAnnotationClient) updateConfiguration(contents string) (bool, error) {
+	response, code, err := makeHTTPRequest(client.monitorAPIAddress, client.useTLS, client.tlsConfig, http.MethodPost, podclient.MonitorAPIConfigurationPath, contents, client.getTimeout, client.postTimeout)
+	if err != nil {
+		return false, err
+	}
+
+	if code != http.StatusOK {
+		client.logger.Info("Kubernetes monitor rejected the process configuration", "response_code", code, "response", response)
+		return false, nil
+	}
+
+	configurationResponse := podclient.MonitorConfigurationResponse{}
+	err = json.Unmarshal([]byte(response), &configurationResponse)
+	if err != nil {
+		client.logger.Error(err, "Error parsing configuration response", "response", response)
+		return false, err
+	}
+
+	expectedHash := sha256.Sum256([]byte(contents))
+	expectedHashString := hex.EncodeToString(expectedHash[:])
+	match := configurationResponse.ConfigurationHash == expectedHashString
+	if !match {
+		client.logger.Info("Waiting for Kubernetes monitor config update",
+			"desiredHash", expectedHashString, "currentHash", configurationResponse.ConfigurationHash)
+	}
+
+	return match, nil
+}
+
+// GetProcessCommandLines returns the command lines of the running fdbserver
+// processes reported by the configuration API of fdb-kubernetes-monitor. If
+// the configuration API is not available this method returns nil.
+func (client *realFdbPodAnnotationClient) GetProcessCommandLines() (map[int]string, error) {
+	if client.monitorAPIAddress == "" {
+		return nil, nil
+	}
+
+	response, code, err := makeHTTPRequest(client.monitorAPIAddress, client.useTLS, client.tlsConfig, http.MethodGet, podclient.MonitorAPIProcessesPath, "", client.getTimeout, client.postTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response code %d from Kubernetes monitor: %s", code, response)
+	}
+
+	processes := make([]podclient.MonitorProcessStatus, 0)
+	err = json.Unmarshal([]byte(response), &processes)
+	if err != nil {
+		client.logger.Error(err, "Error deserializing process status", "responseBody", response)
+		return nil, err
+	}
+
+	commandLines := make(map[int]string, len(processes))
+	for _, process := range processes {
+		commandLines[process.ProcessNumber] = process.CommandLine
+	}
+
+	return commandLines, nil
+}
+
 // IsPresent checks whether a file in the sidecar is present.
 // This implementation always returns true, because the unified image handles
 // these checks internally.
@@ -412,6 +547,28 @@ func podHasSidecarTLS(pod *corev1.Pod) bool {
 	return false
 }
 
+// podSupportsMonitorAPI determines whether the fdb-kubernetes-monitor in the
+// pod has advertised a supported version of its configuration API.
+func podSupportsMonitorAPI(pod *corev1.Pod) bool {
+	return pod.Annotations[podclient.MonitorAPIVersionAnnotation] == podclient.MonitorAPIVersionV1
+}
+
+// podHasMonitorTLS determines whether the configuration API of the
+// fdb-kubernetes-monitor in the main container is using TLS.
+func podHasMonitorTLS(pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == fdbv1beta2.MainContainerName {
+			for _, arg := range container.Args {
+				if arg == "--tls" {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
 // GetImageType determines whether a pod is using the unified or the split
 // image.
 func GetImageType(pod *corev1.Pod) FDBImageType {
@@ -476,7 +633,9 @@ func GetSubstitutionsFromClusterAndPod(logger logr.Logger, cluster *fdbv1beta2.F
 		}
 		substitutions["FDB_MACHINE_ID"] = pod.Spec.NodeName
 
-		if faultDomainSource == "spec.nodeName" {
+		if distributionKey, ok := pod.Labels[fdbv1beta2.FDBDistributionKeyLabel]; ok {
+			substitutions["FDB_ZONE_ID"] = distributionKey
+		} else if faultDomainSource == "spec.nodeName" {
 			substitutions["FDB_ZONE_ID"] = pod.Spec.NodeName
 		} else {
 			return nil, fmt.Errorf("unsupported fault domain source %s", faultDomainSource)
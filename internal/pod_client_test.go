@@ -21,14 +21,22 @@
 package internal
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient"
 	"github.com/hashicorp/go-retryablehttp"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var _ = Describe("pod_client", func() {
@@ -81,7 +89,7 @@ var _ = Describe("pod_client", func() {
 
 		When("generating a http get request", func() {
 			It("should generate the request", func() {
-				req, err := generateRequest(retryClient, target.String(), http.MethodGet, getTimeout, postTimeout)
+				req, err := generateRequest(retryClient, target.String(), http.MethodGet, "", getTimeout, postTimeout)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(req.Method).To(Equal(http.MethodGet))
 				Expect(retryClient.HTTPClient.Timeout).To(Equal(getTimeout))
@@ -90,7 +98,7 @@ var _ = Describe("pod_client", func() {
 
 		When("generating a http post request", func() {
 			It("should generate the request", func() {
-				req, err := generateRequest(retryClient, target.String(), http.MethodPost, getTimeout, postTimeout)
+				req, err := generateRequest(retryClient, target.String(), http.MethodPost, "", getTimeout, postTimeout)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(req.Method).To(Equal(http.MethodPost))
 				Expect(retryClient.HTTPClient.Timeout).To(Equal(postTimeout))
@@ -100,10 +108,104 @@ var _ = Describe("pod_client", func() {
 
 		When("generating a http delete request", func() {
 			It("should generate the request", func() {
-				req, err := generateRequest(retryClient, target.String(), http.MethodDelete, getTimeout, postTimeout)
+				req, err := generateRequest(retryClient, target.String(), http.MethodDelete, "", getTimeout, postTimeout)
 				Expect(err).To(HaveOccurred())
 				Expect(req).To(BeNil())
 			})
 		})
 	})
+
+	When("using the configuration API of the unified image", func() {
+		var server *httptest.Server
+		var client *realFdbPodAnnotationClient
+		var acknowledgedHash string
+		var receivedConfiguration string
+
+		BeforeEach(func() {
+			acknowledgedHash = ""
+			receivedConfiguration = ""
+			cluster.Spec.UseUnifiedImage = pointer.Bool(true)
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/" + podclient.MonitorAPIConfigurationPath:
+					body, err := io.ReadAll(r.Body)
+					Expect(err).NotTo(HaveOccurred())
+					receivedConfiguration = string(body)
+					hash := acknowledgedHash
+					if hash == "" {
+						sum := sha256.Sum256(body)
+						hash = hex.EncodeToString(sum[:])
+					}
+					Expect(json.NewEncoder(w).Encode(podclient.MonitorConfigurationResponse{ConfigurationHash: hash})).To(Succeed())
+				case "/" + podclient.MonitorAPIProcessesPath:
+					Expect(json.NewEncoder(w).Encode([]podclient.MonitorProcessStatus{
+						{ProcessNumber: 1, CommandLine: "/usr/bin/fdbserver --class storage"},
+					})).To(Succeed())
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			serverURL, err := url.Parse(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+
+			pod, err := GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+			Expect(err).NotTo(HaveOccurred())
+			pod.Annotations[podclient.MonitorAPIVersionAnnotation] = podclient.MonitorAPIVersionV1
+			Expect(podSupportsMonitorAPI(pod)).To(BeTrue())
+
+			client = &realFdbPodAnnotationClient{
+				Cluster:           cluster,
+				Pod:               pod,
+				logger:            log.Log,
+				monitorAPIAddress: serverURL.Host,
+				getTimeout:        1 * time.Second,
+				postTimeout:       1 * time.Second,
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		When("the monitor acknowledges the configuration", func() {
+			It("should push the configuration and report it as synced", func() {
+				synced, err := client.UpdateFile("fdbmonitor.conf", `{"version":"7.1.26"}`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(synced).To(BeTrue())
+				Expect(receivedConfiguration).To(Equal(`{"version":"7.1.26"}`))
+			})
+		})
+
+		When("the monitor acknowledges a different configuration", func() {
+			BeforeEach(func() {
+				acknowledgedHash = "outdated"
+			})
+
+			It("should report the configuration as not synced", func() {
+				synced, err := client.UpdateFile("fdbmonitor.conf", `{"version":"7.1.26"}`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(synced).To(BeFalse())
+			})
+		})
+
+		It("should report the command lines of the running processes", func() {
+			commandLines, err := client.GetProcessCommandLines()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commandLines).To(Equal(map[int]string{1: "/usr/bin/fdbserver --class storage"}))
+		})
+
+		When("the monitor does not advertise the configuration API", func() {
+			BeforeEach(func() {
+				client.monitorAPIAddress = ""
+			})
+
+			It("should not report any command lines", func() {
+				commandLines, err := client.GetProcessCommandLines()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(commandLines).To(BeNil())
+			})
+		})
+	})
 })
@@ -22,6 +22,7 @@ package internal
 
 import (
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
@@ -34,6 +35,34 @@ import (
 	"k8s.io/utils/pointer"
 )
 
+const (
+	// blobCredentialsVolumeName is the name of the volume that contains the blob credentials for the backup agents.
+	blobCredentialsVolumeName = "blob-credentials"
+	// blobCredentialsMountPath is the path where the blob credentials will be mounted in the backup agents.
+	blobCredentialsMountPath = "/var/secrets/blob-credentials"
+	// blobCredentialsFileName is the name of the blob credentials file.
+	blobCredentialsFileName = "credentials.json"
+	// blobCAVolumeName is the name of the volume that contains the CA bundle for the blob store endpoint.
+	blobCAVolumeName = "blob-ca"
+	// blobCAMountPath is the path where the CA bundle for the blob store endpoint will be mounted in the backup agents.
+	blobCAMountPath = "/var/secrets/blob-ca"
+	// blobCAFileName is the name of the CA bundle file.
+	blobCAFileName = "ca.crt"
+	// blobCABundleContainerName is the name of the init container that combines the CA bundle of the backup agents
+	// with the CA bundle for the blob store endpoint.
+	blobCABundleContainerName = "blob-ca-bundle"
+	// blobCABundleVolumeName is the name of the volume that contains the combined CA bundle.
+	blobCABundleVolumeName = "blob-ca-bundle"
+	// blobCABundleMountPath is the path where the combined CA bundle will be mounted in the backup agents.
+	blobCABundleMountPath = "/var/blob-ca-bundle"
+	// blobCABundleFileName is the name of the combined CA bundle file.
+	blobCABundleFileName = "ca.pem"
+	// disasterRecoverySourceClusterFile is the name of the cluster file of the source cluster in the DR agents.
+	disasterRecoverySourceClusterFile = "source.cluster"
+	// disasterRecoveryDestinationClusterFile is the name of the cluster file of the destination cluster in the DR agents.
+	disasterRecoveryDestinationClusterFile = "destination.cluster"
+)
+
 // GetProcessGroupIDFromPodName returns the process group ID for a given Pod name.
 func GetProcessGroupIDFromPodName(cluster *fdbv1beta2.FoundationDBCluster, podName string) fdbv1beta2.ProcessGroupID {
 	tmpName := strings.ReplaceAll(podName, cluster.Name, "")[1:]
@@ -114,6 +143,9 @@ func GetPod(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.Pr
 
 	metadata := GetPodMetadata(cluster, processGroup.ProcessClass, processGroup.ProcessGroupID, specHash)
 	metadata.Name = processGroup.GetPodName(cluster)
+	if cluster.IsLogicalFaultDomain(processGroup) {
+		metadata.Labels[fdbv1beta2.FDBDistributionKeyLabel] = string(processGroup.FaultDomain)
+	}
 	metadata.OwnerReferences = owner
 
 	return &corev1.Pod{
@@ -258,7 +290,7 @@ func ensureSecurityContextIsPresent(container *corev1.Container) {
 	}
 }
 
-func setAffinityForFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, processClass fdbv1beta2.ProcessClass) {
+func setAffinityForFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, processGroup *fdbv1beta2.ProcessGroupStatus) {
 	faultDomainKey := cluster.Spec.FaultDomain.Key
 	if faultDomainKey == "" {
 		faultDomainKey = corev1.LabelHostname
@@ -278,8 +310,13 @@ func setAffinityForFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, podSpec
 			labelSelectors[key] = value
 		}
 
+		if cluster.IsLogicalFaultDomain(processGroup) {
+			setAffinityForLogicalFaultDomain(podSpec, processGroup, faultDomainKey, labelSelectors)
+			return
+		}
+
 		processClassLabel := cluster.GetProcessClassLabel()
-		labelSelectors[processClassLabel] = string(processClass)
+		labelSelectors[processClassLabel] = string(processGroup.ProcessClass)
 
 		podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
 			corev1.WeightedPodAffinityTerm{
@@ -292,6 +329,74 @@ func setAffinityForFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, podSpec
 	}
 }
 
+// setAffinityForLogicalFaultDomain adds the affinity rules to bin pack process groups with the same logical fault domain
+// into the same physical fault domain and to keep process groups of other logical fault domains out of this physical
+// fault domain. The rules are applied across all process classes, as the logical fault domains are shared by all
+// process classes. The anti affinity is required, otherwise two logical fault domains could end up in the same
+// physical fault domain. The labelSelectors must only contain the match labels of the cluster.
+func setAffinityForLogicalFaultDomain(podSpec *corev1.PodSpec, processGroup *fdbv1beta2.ProcessGroupStatus, faultDomainKey string, labelSelectors map[string]string) {
+	if podSpec.Affinity.PodAffinity == nil {
+		podSpec.Affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+
+	affinityLabels := make(map[string]string, len(labelSelectors)+1)
+	for key, value := range labelSelectors {
+		affinityLabels[key] = value
+	}
+	affinityLabels[fdbv1beta2.FDBDistributionKeyLabel] = string(processGroup.FaultDomain)
+
+	podSpec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(podSpec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.WeightedPodAffinityTerm{
+			Weight: 1,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				TopologyKey:   faultDomainKey,
+				LabelSelector: &metav1.LabelSelector{MatchLabels: affinityLabels},
+			},
+		})
+
+	// Pods without the distribution key label are not assigned to a logical fault domain yet and must not block
+	// the scheduling, otherwise the rollout of the logical fault domains would be stuck.
+	podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+		corev1.PodAffinityTerm{
+			TopologyKey: faultDomainKey,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: labelSelectors,
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      fdbv1beta2.FDBDistributionKeyLabel,
+						Operator: metav1.LabelSelectorOpExists,
+					},
+					{
+						Key:      fdbv1beta2.FDBDistributionKeyLabel,
+						Operator: metav1.LabelSelectorOpNotIn,
+						Values:   []string{string(processGroup.FaultDomain)},
+					},
+				},
+			},
+		})
+}
+
+// setZoneIDForLogicalFaultDomain sets the zone ID of the process group to the logical fault domain, if the process
+// group is assigned to a logical fault domain.
+func setZoneIDForLogicalFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, containers ...*corev1.Container) {
+	if !cluster.IsLogicalFaultDomain(processGroup) {
+		return
+	}
+
+	zoneVariable := "FDB_ZONE_ID"
+	if strings.HasPrefix(cluster.Spec.FaultDomain.ValueFrom, "$") {
+		zoneVariable = cluster.Spec.FaultDomain.ValueFrom[1:]
+	}
+
+	for _, container := range containers {
+		if container == nil {
+			continue
+		}
+
+		setEnv(container, corev1.EnvVar{Name: zoneVariable, Value: string(processGroup.FaultDomain)})
+	}
+}
+
 func configureVolumesForContainers(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, volumeClaimTemplate *corev1.PersistentVolumeClaim, podName string, processClass fdbv1beta2.ProcessClass) {
 	useUnifiedImages := pointer.BoolDeref(cluster.Spec.UseUnifiedImage, false)
 	monitorConfKey := GetConfigMapMonitorConfEntry(processClass, GetDesiredImageType(cluster), cluster.GetDesiredServersPerPod(processClass))
@@ -470,7 +575,12 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 
 	ensureSecurityContextIsPresent(mainContainer)
 	ensureSecurityContextIsPresent(sidecarContainer)
-	setAffinityForFaultDomain(cluster, podSpec, processGroup.ProcessClass)
+	setAffinityForFaultDomain(cluster, podSpec, processGroup)
+	if useUnifiedImages {
+		setZoneIDForLogicalFaultDomain(cluster, processGroup, mainContainer)
+	} else {
+		setZoneIDForLogicalFaultDomain(cluster, processGroup, initContainer, sidecarContainer)
+	}
 	configureVolumesForContainers(cluster, podSpec, processSettings.VolumeClaimTemplate, podName, processGroup.ProcessClass)
 	configureNoSchedule(podSpec, processGroup.ProcessGroupID, cluster.Spec.Buggify.NoSchedule)
 
@@ -501,6 +611,25 @@ func configureSidecarContainerForBackup(backup *fdbv1beta2.FoundationDBBackup, c
 	return configureSidecarContainer(container, true, "", "", backup.Spec.Version, nil, backup.Spec.SidecarContainer.ImageConfigs, pointer.BoolDeref(backup.Spec.AllowTagOverride, false))
 }
 
+// configureSidecarContainerForDisasterRecovery sets up a sidecar container for the DR agents, that copies the cluster
+// files of the source and the destination cluster.
+func configureSidecarContainerForDisasterRecovery(disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery, container *corev1.Container) error {
+	err := configureSidecarContainer(container, true, "", "", disasterRecovery.Spec.Version, nil, disasterRecovery.Spec.SidecarContainer.ImageConfigs, false)
+	if err != nil {
+		return err
+	}
+
+	container.Args = []string{
+		"--copy-file", disasterRecoverySourceClusterFile,
+		"--copy-file", disasterRecoveryDestinationClusterFile,
+		"--require-not-empty", disasterRecoverySourceClusterFile,
+		"--require-not-empty", disasterRecoveryDestinationClusterFile,
+		"--init-mode",
+	}
+
+	return nil
+}
+
 // configureSidecarContainer sets up a foundationdb-kubernetes-sidecar container.
 func configureSidecarContainer(container *corev1.Container, initMode bool, processGroupID fdbv1beta2.ProcessGroupID, podName string, versionString string, optionalCluster *fdbv1beta2.FoundationDBCluster, imageConfigs []fdbv1beta2.ImageConfig, allowTagOverride bool) error {
 	sidecarEnv := make([]corev1.EnvVar, 0, 4)
@@ -812,8 +941,49 @@ func extendEnv(container *corev1.Container, env ...corev1.EnvVar) {
 	}
 }
 
-// GetBackupDeployment builds a deployment for backup agents for a cluster.
-func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deployment, error) {
+// setEnv sets the environment variable for the container and replaces an existing environment variable with the same
+// name.
+func setEnv(container *corev1.Container, env corev1.EnvVar) {
+	for idx, envVar := range container.Env {
+		if envVar.Name == env.Name {
+			container.Env[idx] = env
+			return
+		}
+	}
+
+	container.Env = append(container.Env, env)
+}
+
+// getBlobCABundleContainer returns the init container that writes the combined CA bundle for the backup agents. The
+// container uses the image, environment and volumes of the main container, so it can read the CA file that is defined
+// in FDB_TLS_CA_FILE of the pod template and append the CA bundle for the blob store endpoint.
+func getBlobCABundleContainer(mainContainer *corev1.Container) *corev1.Container {
+	container := &corev1.Container{
+		Name:  blobCABundleContainerName,
+		Image: mainContainer.Image,
+		Command: []string{
+			"/bin/sh",
+			"-c",
+			fmt.Sprintf("cat ${FDB_TLS_CA_FILE:-} %s > %s", path.Join(blobCAMountPath, blobCAFileName), path.Join(blobCABundleMountPath, blobCABundleFileName)),
+		},
+		Env:             append([]corev1.EnvVar{}, mainContainer.Env...),
+		EnvFrom:         append([]corev1.EnvFromSource{}, mainContainer.EnvFrom...),
+		SecurityContext: mainContainer.SecurityContext.DeepCopy(),
+	}
+
+	container.VolumeMounts = append(container.VolumeMounts, mainContainer.VolumeMounts...)
+	container.VolumeMounts = append(container.VolumeMounts,
+		corev1.VolumeMount{Name: blobCAVolumeName, MountPath: blobCAMountPath, ReadOnly: true},
+		corev1.VolumeMount{Name: blobCABundleVolumeName, MountPath: blobCABundleMountPath},
+	)
+
+	return container
+}
+
+// GetBackupDeployment builds a deployment for backup agents for a cluster. The
+// credentialsHash is the hash of the blob credentials secrets and is used to
+// restart the backup agents when the secrets are changed.
+func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup, credentialsHash string) (*appsv1.Deployment, error) {
 	agentCount := int32(backup.GetDesiredAgentCount())
 	if agentCount == 0 {
 		return nil, nil
@@ -898,6 +1068,11 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 		}
 	}
 
+	blobCredentials := backup.Spec.BlobCredentials
+	if blobCredentials != nil {
+		args = append(args, "--blob_credentials", path.Join(blobCredentialsMountPath, blobCredentialsFileName))
+	}
+
 	mainContainer.Args = args
 	if mainContainer.Env == nil {
 		mainContainer.Env = make([]corev1.EnvVar, 0, 1)
@@ -910,6 +1085,35 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 		corev1.VolumeMount{Name: "dynamic-conf", MountPath: "/var/dynamic-conf"},
 	)
 
+	if blobCredentials != nil {
+		mainContainer.VolumeMounts = append(mainContainer.VolumeMounts,
+			corev1.VolumeMount{Name: blobCredentialsVolumeName, MountPath: blobCredentialsMountPath, ReadOnly: true},
+		)
+
+	}
+
+	// The backup agents use the same CA bundle for the connections to the cluster and to the blob store, so the CA
+	// bundle for the blob store is appended to the CA bundle that is defined in the pod template.
+	var blobCABundleContainer *corev1.Container
+	if blobCredentials != nil && blobCredentials.CASecretName != nil {
+		blobCABundleContainer = getBlobCABundleContainer(mainContainer)
+		setEnv(mainContainer, corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: path.Join(blobCABundleMountPath, blobCABundleFileName)})
+		mainContainer.VolumeMounts = append(mainContainer.VolumeMounts,
+			corev1.VolumeMount{Name: blobCABundleVolumeName, MountPath: blobCABundleMountPath, ReadOnly: true},
+		)
+	}
+
+	for _, destination := range backup.Spec.Destinations {
+		if destination.FileSystemConfiguration == nil {
+			continue
+		}
+
+		mainContainer.VolumeMounts = append(mainContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      getBackupDestinationVolumeName(destination.Name),
+			MountPath: fdbv1beta2.GetBackupDestinationMountPath(destination.Name),
+		})
+	}
+
 	if mainContainer.Resources.Requests == nil {
 		mainContainer.Resources.Requests = corev1.ResourceList{
 			"cpu":    resource.MustParse("1"),
@@ -931,6 +1135,11 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 		return nil, err
 	}
 
+	if blobCABundleContainer != nil {
+		blobCABundleContainer.Resources = *mainContainer.Resources.DeepCopy()
+		podTemplate.Spec.InitContainers = append(podTemplate.Spec.InitContainers, *blobCABundleContainer)
+	}
+
 	if podTemplate.ObjectMeta.Labels == nil {
 		podTemplate.ObjectMeta.Labels = make(map[string]string, 1)
 	}
@@ -953,6 +1162,227 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 		},
 	)
 
+	for _, destination := range backup.Spec.Destinations {
+		if destination.FileSystemConfiguration == nil {
+			continue
+		}
+
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+			Name: getBackupDestinationVolumeName(destination.Name),
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: destination.FileSystemConfiguration.PersistentVolumeClaimName,
+			}},
+		})
+	}
+
+	if blobCredentials != nil {
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+			Name: blobCredentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: blobCredentials.SecretName,
+				Items: []corev1.KeyToPath{
+					{Key: blobCredentials.GetCredentialsKey(), Path: blobCredentialsFileName},
+				},
+			}},
+		})
+
+		if blobCredentials.CASecretName != nil {
+			podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes,
+				corev1.Volume{
+					Name: blobCAVolumeName,
+					VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+						SecretName: *blobCredentials.CASecretName,
+						Items: []corev1.KeyToPath{
+							{Key: blobCredentials.GetCAKey(), Path: blobCAFileName},
+						},
+					}},
+				},
+				corev1.Volume{Name: blobCABundleVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			)
+		}
+	}
+
+	if credentialsHash != "" {
+		if podTemplate.ObjectMeta.Annotations == nil {
+			podTemplate.ObjectMeta.Annotations = make(map[string]string, 1)
+		}
+		podTemplate.ObjectMeta.Annotations[fdbv1beta2.LastBlobCredentialsKey] = credentialsHash
+	}
+
+	deployment.Spec.Template = *podTemplate
+
+	specHash, err := GetJSONHash(deployment.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] = specHash
+
+	return deployment, nil
+}
+
+// getBackupDestinationVolumeName returns the name of the volume for a file system destination of a backup.
+func getBackupDestinationVolumeName(destination string) string {
+	return fmt.Sprintf("backup-%s", destination)
+}
+
+// GetDisasterRecoveryDeployment builds a deployment for DR agents for a disaster recovery resource.
+func GetDisasterRecoveryDeployment(disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery) (*appsv1.Deployment, error) {
+	agentCount := int32(disasterRecovery.GetDesiredAgentCount())
+	if agentCount == 0 {
+		return nil, nil
+	}
+	deploymentName := fmt.Sprintf("%s-dr-agents", disasterRecovery.ObjectMeta.Name)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   disasterRecovery.ObjectMeta.Namespace,
+			Name:        deploymentName,
+			Annotations: map[string]string{},
+			Labels:      map[string]string{},
+		},
+	}
+	deployment.Spec.Replicas = &agentCount
+	deployment.ObjectMeta.OwnerReferences = BuildOwnerReference(disasterRecovery.TypeMeta, disasterRecovery.ObjectMeta)
+
+	if disasterRecovery.Spec.DeploymentMetadata != nil {
+		for key, value := range disasterRecovery.Spec.DeploymentMetadata.Labels {
+			deployment.ObjectMeta.Labels[key] = value
+		}
+		for key, value := range disasterRecovery.Spec.DeploymentMetadata.Annotations {
+			deployment.ObjectMeta.Annotations[key] = value
+		}
+	}
+	deployment.ObjectMeta.Labels[fdbv1beta2.DisasterRecoveryDeploymentLabel] = string(disasterRecovery.ObjectMeta.UID)
+
+	var podTemplate *corev1.PodTemplateSpec
+	if disasterRecovery.Spec.PodTemplateSpec != nil {
+		podTemplate = disasterRecovery.Spec.PodTemplateSpec.DeepCopy()
+	} else {
+		podTemplate = &corev1.PodTemplateSpec{}
+	}
+
+	var mainContainer *corev1.Container
+	var initContainer *corev1.Container
+
+	for index, container := range podTemplate.Spec.Containers {
+		if container.Name == fdbv1beta2.MainContainerName {
+			mainContainer = &podTemplate.Spec.Containers[index]
+		}
+	}
+
+	for index, container := range podTemplate.Spec.InitContainers {
+		if container.Name == fdbv1beta2.InitContainerName {
+			initContainer = &podTemplate.Spec.InitContainers[index]
+		}
+	}
+
+	if mainContainer == nil {
+		containers := []corev1.Container{
+			{
+				Name: fdbv1beta2.MainContainerName,
+			},
+		}
+		containers = append(containers, podTemplate.Spec.Containers...)
+		mainContainer = &containers[0]
+		podTemplate.Spec.Containers = containers
+	}
+
+	imageConfigs := disasterRecovery.Spec.MainContainer.ImageConfigs
+	if len(imageConfigs) == 0 {
+		imageConfigs = []fdbv1beta2.ImageConfig{
+			{BaseImage: "foundationdb/foundationdb"},
+		}
+	}
+
+	image, err := GetImage(mainContainer.Image, imageConfigs, disasterRecovery.Spec.Version, false)
+	if err != nil {
+		return nil, err
+	}
+	mainContainer.Image = image
+	mainContainer.Command = []string{"dr_agent"}
+	args := []string{
+		"--log", "--logdir", "/var/log/fdb-trace-logs",
+		"-s", path.Join("/var/dynamic-conf", disasterRecoverySourceClusterFile),
+		"-d", path.Join("/var/dynamic-conf", disasterRecoveryDestinationClusterFile),
+	}
+
+	if len(disasterRecovery.Spec.CustomParameters) > 0 {
+		err := disasterRecovery.Spec.CustomParameters.ValidateCustomParameters()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, customParameter := range disasterRecovery.Spec.CustomParameters {
+			args = append(args, fmt.Sprintf("--%s", customParameter))
+		}
+	}
+
+	mainContainer.Args = args
+
+	mainContainer.VolumeMounts = append(mainContainer.VolumeMounts,
+		corev1.VolumeMount{Name: "logs", MountPath: "/var/log/fdb-trace-logs"},
+		corev1.VolumeMount{Name: "dynamic-conf", MountPath: "/var/dynamic-conf"},
+	)
+
+	if mainContainer.Resources.Requests == nil {
+		mainContainer.Resources.Requests = corev1.ResourceList{
+			"cpu":    resource.MustParse("1"),
+			"memory": resource.MustParse("1Gi"),
+		}
+	}
+
+	if mainContainer.Resources.Limits == nil {
+		mainContainer.Resources.Limits = mainContainer.Resources.Requests
+	}
+
+	if initContainer == nil {
+		podTemplate.Spec.InitContainers = append(podTemplate.Spec.InitContainers, corev1.Container{Name: fdbv1beta2.InitContainerName})
+		initContainer = &podTemplate.Spec.InitContainers[0]
+	}
+
+	err = configureSidecarContainerForDisasterRecovery(disasterRecovery, initContainer)
+	if err != nil {
+		return nil, err
+	}
+
+	if podTemplate.ObjectMeta.Labels == nil {
+		podTemplate.ObjectMeta.Labels = make(map[string]string, 1)
+	}
+	podTemplate.ObjectMeta.Labels[fdbv1beta2.BackupDeploymentPodLabel] = deployment.ObjectMeta.Name
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{
+		fdbv1beta2.BackupDeploymentPodLabel: deployment.ObjectMeta.Name,
+	}}
+
+	// The DR agents must connect to both clusters, so the cluster files of both clusters are combined into a single
+	// volume.
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes,
+		corev1.Volume{Name: "logs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		corev1.Volume{Name: "dynamic-conf", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		corev1.Volume{
+			Name: "config-map",
+			VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-config", disasterRecovery.Spec.SourceClusterName)},
+							Items: []corev1.KeyToPath{
+								{Key: ClusterFileKey, Path: disasterRecoverySourceClusterFile},
+							},
+						},
+					},
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-config", disasterRecovery.Spec.DestinationClusterName)},
+							Items: []corev1.KeyToPath{
+								{Key: ClusterFileKey, Path: disasterRecoveryDestinationClusterFile},
+							},
+						},
+					},
+				},
+			}},
+		},
+	)
+
 	deployment.Spec.Template = *podTemplate
 
 	specHash, err := GetJSONHash(deployment.Spec)
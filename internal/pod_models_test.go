@@ -1810,6 +1810,84 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		When("the fault domain distribution is enabled", func() {
+			var processGroup *fdbv1beta2.ProcessGroupStatus
+
+			BeforeEach(func() {
+				cluster.Spec.FaultDomain = fdbv1beta2.FoundationDBClusterFaultDomain{
+					DistributionConfig: &fdbv1beta2.DistributionConfig{
+						Enabled: pointer.Bool(true),
+					},
+				}
+				processGroup = GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1)
+				processGroup.FaultDomain = fdbv1beta2.FaultDomain(cluster.Name + "-1")
+			})
+
+			JustBeforeEach(func() {
+				spec, err = GetPodSpec(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should set the logical fault domain as zone ID", func() {
+				Expect(spec.InitContainers[0].Env).To(ContainElement(corev1.EnvVar{Name: "FDB_ZONE_ID", Value: cluster.Name + "-1"}))
+				Expect(spec.Containers[1].Env).To(ContainElement(corev1.EnvVar{Name: "FDB_ZONE_ID", Value: cluster.Name + "-1"}))
+			})
+
+			It("should set the pod affinity for the logical fault domain", func() {
+				Expect(spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(ConsistOf(corev1.WeightedPodAffinityTerm{
+					Weight: 1,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						TopologyKey: "kubernetes.io/hostname",
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								fdbv1beta2.FDBClusterLabel:         cluster.Name,
+								fdbv1beta2.FDBDistributionKeyLabel: cluster.Name + "-1",
+							},
+						},
+					},
+				}))
+				Expect(spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+				Expect(spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(ConsistOf(corev1.PodAffinityTerm{
+					TopologyKey: "kubernetes.io/hostname",
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							fdbv1beta2.FDBClusterLabel: cluster.Name,
+						},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      fdbv1beta2.FDBDistributionKeyLabel,
+								Operator: metav1.LabelSelectorOpExists,
+							},
+							{
+								Key:      fdbv1beta2.FDBDistributionKeyLabel,
+								Operator: metav1.LabelSelectorOpNotIn,
+								Values:   []string{cluster.Name + "-1"},
+							},
+						},
+					},
+				}))
+			})
+
+			When("the process group is not assigned to a logical fault domain", func() {
+				BeforeEach(func() {
+					processGroup.FaultDomain = "node-1"
+				})
+
+				It("should use the default fault domain settings", func() {
+					Expect(spec.Affinity.PodAffinity).To(BeNil())
+					Expect(spec.InitContainers[0].Env).NotTo(ContainElement(HaveField("Value", "node-1")))
+				})
+			})
+
+			When("creating the Pod", func() {
+				It("should add the distribution key label", func() {
+					pod, err := GetPod(cluster, processGroup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(pod.Labels).To(HaveKeyWithValue(fdbv1beta2.FDBDistributionKeyLabel, cluster.Name+"-1"))
+				})
+			})
+		})
+
 		Context("with a custom fault domain", func() {
 			BeforeEach(func() {
 
@@ -2998,7 +3076,7 @@ var _ = Describe("pod_models", func() {
 
 		Context("with a basic deployment", func() {
 			BeforeEach(func() {
-				deployment, err = GetBackupDeployment(backup)
+				deployment, err = GetBackupDeployment(backup, "")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(deployment).NotTo(BeNil())
 			})
@@ -3143,7 +3221,7 @@ var _ = Describe("pod_models", func() {
 						},
 					},
 				}
-				deployment, err = GetBackupDeployment(backup)
+				deployment, err = GetBackupDeployment(backup, "")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(deployment).NotTo(BeNil())
 			})
@@ -3193,6 +3271,178 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		When("a file system destination is defined", func() {
+			BeforeEach(func() {
+				backup.Spec.Destinations = []fdbv1beta2.BackupDestination{
+					{
+						Name:                   "primary",
+						BlobStoreConfiguration: backup.Spec.BlobStoreConfiguration,
+					},
+					{
+						Name: "local",
+						FileSystemConfiguration: &fdbv1beta2.BackupFileSystemConfiguration{
+							PersistentVolumeClaimName: "backup-data",
+						},
+					},
+				}
+				deployment, err = GetBackupDeployment(backup, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).NotTo(BeNil())
+			})
+
+			It("should add the volume for the file system destination", func() {
+				Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "backup-local",
+					VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "backup-data",
+					}},
+				}))
+				Expect(deployment.Spec.Template.Spec.Volumes).To(HaveLen(4))
+			})
+
+			It("should mount the volume for the file system destination", func() {
+				Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "backup-local",
+					MountPath: "/var/fdb/backups/local",
+				}))
+			})
+		})
+
+		When("blob credentials are defined", func() {
+			var credentialsHash string
+
+			BeforeEach(func() {
+				credentialsHash = ""
+				backup.Spec.BlobCredentials = &fdbv1beta2.BackupBlobCredentials{
+					SecretName: "blob-credentials",
+				}
+			})
+
+			JustBeforeEach(func() {
+				deployment, err = GetBackupDeployment(backup, credentialsHash)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).NotTo(BeNil())
+			})
+
+			It("should pass the credentials to the backup agents", func() {
+				mainContainer := deployment.Spec.Template.Spec.Containers[0]
+				Expect(mainContainer.Args).To(HaveLen(5))
+				Expect(mainContainer.Args[3:]).To(Equal([]string{"--blob_credentials", "/var/secrets/blob-credentials/credentials.json"}))
+				Expect(mainContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "blob-credentials",
+					MountPath: "/var/secrets/blob-credentials",
+					ReadOnly:  true,
+				}))
+				Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "blob-credentials",
+					VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+						SecretName: "blob-credentials",
+						Items: []corev1.KeyToPath{
+							{Key: "credentials.json", Path: "credentials.json"},
+						},
+					}},
+				}))
+				Expect(mainContainer.Env).NotTo(ContainElement(HaveField("Name", "FDB_TLS_CA_FILE")))
+			})
+
+			It("should not add the credentials hash annotation", func() {
+				Expect(deployment.Spec.Template.ObjectMeta.Annotations).NotTo(HaveKey(fdbv1beta2.LastBlobCredentialsKey))
+			})
+
+			When("a credentials hash is provided", func() {
+				BeforeEach(func() {
+					credentialsHash = "test-hash"
+				})
+
+				It("should add the credentials hash to the pod template", func() {
+					Expect(deployment.Spec.Template.ObjectMeta.Annotations).To(HaveKeyWithValue(fdbv1beta2.LastBlobCredentialsKey, "test-hash"))
+				})
+
+				It("should change the spec hash of the deployment", func() {
+					previousDeployment, err := GetBackupDeployment(backup, "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(deployment.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]).NotTo(Equal(previousDeployment.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]))
+				})
+			})
+
+			When("a CA bundle is defined", func() {
+				BeforeEach(func() {
+					backup.Spec.BlobCredentials.CASecretName = pointer.String("blob-ca")
+					backup.Spec.BlobCredentials.CAKey = pointer.String("bundle.pem")
+				})
+
+				It("should combine the CA bundles in an init container", func() {
+					mainContainer := deployment.Spec.Template.Spec.Containers[0]
+					Expect(mainContainer.Env).To(ContainElement(corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: "/var/blob-ca-bundle/ca.pem"}))
+					Expect(mainContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+						Name:      "blob-ca-bundle",
+						MountPath: "/var/blob-ca-bundle",
+						ReadOnly:  true,
+					}))
+					Expect(mainContainer.VolumeMounts).NotTo(ContainElement(HaveField("Name", "blob-ca")))
+
+					initContainers := deployment.Spec.Template.Spec.InitContainers
+					Expect(initContainers).To(HaveLen(2))
+					bundleContainer := initContainers[1]
+					Expect(bundleContainer.Name).To(Equal("blob-ca-bundle"))
+					Expect(bundleContainer.Image).To(Equal(mainContainer.Image))
+					Expect(bundleContainer.Command).To(Equal([]string{
+						"/bin/sh",
+						"-c",
+						"cat ${FDB_TLS_CA_FILE:-} /var/secrets/blob-ca/ca.crt > /var/blob-ca-bundle/ca.pem",
+					}))
+					Expect(bundleContainer.Env).NotTo(ContainElement(HaveField("Name", "FDB_TLS_CA_FILE")))
+					Expect(bundleContainer.VolumeMounts).To(ContainElements(
+						corev1.VolumeMount{Name: "blob-ca", MountPath: "/var/secrets/blob-ca", ReadOnly: true},
+						corev1.VolumeMount{Name: "blob-ca-bundle", MountPath: "/var/blob-ca-bundle"},
+					))
+
+					Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElements(
+						corev1.Volume{
+							Name: "blob-ca",
+							VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+								SecretName: "blob-ca",
+								Items: []corev1.KeyToPath{
+									{Key: "bundle.pem", Path: "ca.crt"},
+								},
+							}},
+						},
+						corev1.Volume{Name: "blob-ca-bundle", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					))
+				})
+
+				When("the pod template defines a CA file", func() {
+					BeforeEach(func() {
+						backup.Spec.PodTemplateSpec = &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: fdbv1beta2.MainContainerName,
+										Env: []corev1.EnvVar{
+											{Name: "FDB_TLS_CA_FILE", Value: "/var/secrets/fdb-certs/ca.pem"},
+										},
+										VolumeMounts: []corev1.VolumeMount{
+											{Name: "fdb-certs", MountPath: "/var/secrets/fdb-certs"},
+										},
+									},
+								},
+							},
+						}
+					})
+
+					It("should append the CA bundle for the blob store to the CA file", func() {
+						mainContainer := deployment.Spec.Template.Spec.Containers[0]
+						Expect(mainContainer.Env).To(ContainElement(corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: "/var/blob-ca-bundle/ca.pem"}))
+						Expect(mainContainer.Env).NotTo(ContainElement(corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: "/var/secrets/fdb-certs/ca.pem"}))
+
+						bundleContainer := deployment.Spec.Template.Spec.InitContainers[1]
+						Expect(bundleContainer.Env).To(ContainElement(corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: "/var/secrets/fdb-certs/ca.pem"}))
+						Expect(bundleContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "fdb-certs", MountPath: "/var/secrets/fdb-certs"}))
+					})
+				})
+			})
+		})
+
 		Context("with a custom label", func() {
 			BeforeEach(func() {
 				backup.Spec.BackupDeploymentMetadata = &metav1.ObjectMeta{
@@ -3200,7 +3450,7 @@ var _ = Describe("pod_models", func() {
 						"fdb-test": "test-value",
 					},
 				}
-				deployment, err = GetBackupDeployment(backup)
+				deployment, err = GetBackupDeployment(backup, "")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(deployment).NotTo(BeNil())
 			})
@@ -3216,7 +3466,7 @@ var _ = Describe("pod_models", func() {
 		Context("with a nil agent count", func() {
 			BeforeEach(func() {
 				backup.Spec.AgentCount = nil
-				deployment, err = GetBackupDeployment(backup)
+				deployment, err = GetBackupDeployment(backup, "")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -3230,7 +3480,7 @@ var _ = Describe("pod_models", func() {
 			BeforeEach(func() {
 				agentCount := 0
 				backup.Spec.AgentCount = &agentCount
-				deployment, err = GetBackupDeployment(backup)
+				deployment, err = GetBackupDeployment(backup, "")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -3252,7 +3502,7 @@ var _ = Describe("pod_models", func() {
 						}},
 					},
 				}
-				deployment, err = GetBackupDeployment(backup)
+				deployment, err = GetBackupDeployment(backup, "")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -3268,7 +3518,7 @@ var _ = Describe("pod_models", func() {
 		Context("with customParameters", func() {
 			BeforeEach(func() {
 				backup.Spec.CustomParameters = []fdbv1beta2.FoundationDBCustomParameter{"customParameter=1337"}
-				deployment, err = GetBackupDeployment(backup)
+				deployment, err = GetBackupDeployment(backup, "")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(deployment).NotTo(BeNil())
 			})
@@ -3296,7 +3546,7 @@ var _ = Describe("pod_models", func() {
 				backup.Spec.SidecarContainer.ImageConfigs = []fdbv1beta2.ImageConfig{
 					{BaseImage: "foundationdb/foundationdb-kubernetes-sidecar", Tag: "dev-1"},
 				}
-				deployment, err = GetBackupDeployment(backup)
+				deployment, err = GetBackupDeployment(backup, "")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(deployment).NotTo(BeNil())
 			})
@@ -3328,7 +3578,7 @@ var _ = Describe("pod_models", func() {
 				}
 
 				backup.Spec.PodTemplateSpec = &templateSpec
-				deployment, err = GetBackupDeployment(backup)
+				deployment, err = GetBackupDeployment(backup, "")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(deployment).NotTo(BeNil())
 			})
@@ -3340,6 +3590,120 @@ var _ = Describe("pod_models", func() {
 		})
 	})
 
+	Describe("GetDisasterRecoveryDeployment", func() {
+		var disasterRecovery *fdbv1beta2.FoundationDBDisasterRecovery
+		var deployment *appsv1.Deployment
+
+		BeforeEach(func() {
+			destination := cluster.DeepCopy()
+			destination.Name = "operator-test-2"
+			disasterRecovery = CreateDefaultDisasterRecovery(cluster, destination)
+			disasterRecovery.ObjectMeta.UID = "dr-uid"
+		})
+
+		When("using the default configuration", func() {
+			BeforeEach(func() {
+				deployment, err = GetDisasterRecoveryDeployment(disasterRecovery)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).NotTo(BeNil())
+			})
+
+			It("should set the metadata for the deployment", func() {
+				Expect(deployment.ObjectMeta.Name).To(Equal("operator-test-1-dr-agents"))
+				Expect(deployment.ObjectMeta.OwnerReferences).To(HaveLen(1))
+				Expect(deployment.ObjectMeta.OwnerReferences[0].UID).To(Equal(disasterRecovery.ObjectMeta.UID))
+				Expect(deployment.ObjectMeta.Labels).To(Equal(map[string]string{
+					fdbv1beta2.DisasterRecoveryDeploymentLabel: "dr-uid",
+				}))
+				Expect(deployment.ObjectMeta.Annotations).To(HaveKey(fdbv1beta2.LastSpecKey))
+			})
+
+			It("should set the replication factor to the specified agent count", func() {
+				Expect(deployment.Spec.Replicas).To(HaveValue(Equal(int32(3))))
+			})
+
+			It("should combine the cluster files of both clusters", func() {
+				Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "config-map",
+					VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{
+							{
+								ConfigMap: &corev1.ConfigMapProjection{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "operator-test-1-config"},
+									Items:                []corev1.KeyToPath{{Key: ClusterFileKey, Path: "source.cluster"}},
+								},
+							},
+							{
+								ConfigMap: &corev1.ConfigMapProjection{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "operator-test-2-config"},
+									Items:                []corev1.KeyToPath{{Key: ClusterFileKey, Path: "destination.cluster"}},
+								},
+							},
+						},
+					}},
+				}))
+			})
+
+			It("should run the DR agent with both cluster files", func() {
+				container := deployment.Spec.Template.Spec.Containers[0]
+				Expect(container.Name).To(Equal(fdbv1beta2.MainContainerName))
+				Expect(container.Image).To(Equal(fmt.Sprintf("foundationdb/foundationdb:%s", cluster.Spec.Version)))
+				Expect(container.Command).To(Equal([]string{"dr_agent"}))
+				Expect(container.Args).To(Equal([]string{
+					"--log",
+					"--logdir",
+					"/var/log/fdb-trace-logs",
+					"-s",
+					"/var/dynamic-conf/source.cluster",
+					"-d",
+					"/var/dynamic-conf/destination.cluster",
+				}))
+			})
+
+			It("should copy both cluster files in the init container", func() {
+				container := deployment.Spec.Template.Spec.InitContainers[0]
+				Expect(container.Name).To(Equal(fdbv1beta2.InitContainerName))
+				Expect(container.Image).To(Equal(fmt.Sprintf("foundationdb/foundationdb-kubernetes-sidecar:%s-1", cluster.Spec.Version)))
+				Expect(container.Args).To(Equal([]string{
+					"--copy-file",
+					"source.cluster",
+					"--copy-file",
+					"destination.cluster",
+					"--require-not-empty",
+					"source.cluster",
+					"--require-not-empty",
+					"destination.cluster",
+					"--init-mode",
+				}))
+			})
+		})
+
+		When("customParameters are defined", func() {
+			BeforeEach(func() {
+				disasterRecovery.Spec.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{"knob_http_verbose_level=3"}
+				deployment, err = GetDisasterRecoveryDeployment(disasterRecovery)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).NotTo(BeNil())
+			})
+
+			It("should pass the parameters to the DR agent", func() {
+				Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--knob_http_verbose_level=3"))
+			})
+		})
+
+		When("the agent count is 0", func() {
+			BeforeEach(func() {
+				disasterRecovery.Spec.AgentCount = pointer.Int(0)
+				deployment, err = GetDisasterRecoveryDeployment(disasterRecovery)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not create a deployment", func() {
+				Expect(deployment).To(BeNil())
+			})
+		})
+	})
+
 	Context("Get image for container", func() {
 		type testCase struct {
 			imageName     string
@@ -0,0 +1,135 @@
+/*
+ * process_group_helper.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"sort"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetProcessGroupResourceMetadata returns the metadata for the FoundationDBProcessGroup resource of the provided
+// process group.
+func GetProcessGroupResourceMetadata(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) metav1.ObjectMeta {
+	metadata := GetObjectMetadata(cluster, nil, processGroup.ProcessClass, processGroup.ProcessGroupID)
+	metadata.Name = processGroup.GetPodName(cluster)
+	metadata.OwnerReferences = BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+
+	return metadata
+}
+
+// GetProcessGroupResources returns all FoundationDBProcessGroup resources that belong to the provided cluster.
+func GetProcessGroupResources(ctx context.Context, reader client.Reader, cluster *fdbv1beta2.FoundationDBCluster) ([]fdbv1beta2.FoundationDBProcessGroup, error) {
+	processGroupList := &fdbv1beta2.FoundationDBProcessGroupList{}
+	err := reader.List(ctx, processGroupList, client.InNamespace(cluster.Namespace), client.MatchingLabels(cluster.GetMatchLabels()))
+	if err != nil {
+		return nil, err
+	}
+
+	processGroups := make([]fdbv1beta2.FoundationDBProcessGroup, 0, len(processGroupList.Items))
+	for _, processGroup := range processGroupList.Items {
+		// Make sure we only return the process groups of this cluster, the match labels could be shared across
+		// multiple clusters.
+		if processGroup.Spec.ClusterName != cluster.Name {
+			continue
+		}
+
+		processGroups = append(processGroups, processGroup)
+	}
+
+	return processGroups, nil
+}
+
+// GetCluster fetches the FoundationDBCluster with the provided key and loads the process groups from the
+// FoundationDBProcessGroup resources if the cluster makes use of them. Every reader of a FoundationDBCluster should use
+// this method, otherwise the process groups in the cluster status will be empty for clusters that use
+// FoundationDBProcessGroup resources.
+func GetCluster(ctx context.Context, reader client.Reader, key types.NamespacedName) (*fdbv1beta2.FoundationDBCluster, error) {
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := reader.Get(ctx, key, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	err = LoadProcessGroupsFromResources(ctx, reader, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// ListClusters lists the FoundationDBClusters matching the provided options and loads the process groups from the
+// FoundationDBProcessGroup resources for every cluster that makes use of them.
+func ListClusters(ctx context.Context, reader client.Reader, options ...client.ListOption) (*fdbv1beta2.FoundationDBClusterList, error) {
+	clusters := &fdbv1beta2.FoundationDBClusterList{}
+	err := reader.List(ctx, clusters, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range clusters.Items {
+		err = LoadProcessGroupsFromResources(ctx, reader, &clusters.Items[idx])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return clusters, nil
+}
+
+// LoadProcessGroupsFromResources sets the process groups in the cluster status based on the FoundationDBProcessGroup
+// resources of the cluster. The resources are only read if the cluster makes use of FoundationDBProcessGroup resources,
+// so clusters that don't use them will work even if the FoundationDBProcessGroup CRD is not installed. If the cluster
+// status already contains process groups, e.g. because the cluster was created before the usage of
+// FoundationDBProcessGroup resources was enabled, the process groups in the cluster status take precedence and the
+// resources will not be read.
+func LoadProcessGroupsFromResources(ctx context.Context, reader client.Reader, cluster *fdbv1beta2.FoundationDBCluster) error {
+	if !cluster.UseProcessGroupResources() || len(cluster.Status.ProcessGroups) > 0 {
+		return nil
+	}
+
+	processGroupResources, err := GetProcessGroupResources(ctx, reader, cluster)
+	if err != nil {
+		return err
+	}
+
+	if len(processGroupResources) == 0 {
+		return nil
+	}
+
+	processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupResources))
+	for _, processGroup := range processGroupResources {
+		processGroups = append(processGroups, processGroup.GetProcessGroupStatus())
+	}
+
+	sort.Slice(processGroups, func(i, j int) bool {
+		return processGroups[i].ProcessGroupID < processGroups[j].ProcessGroupID
+	})
+
+	cluster.Status.ProcessGroups = processGroups
+
+	return nil
+}
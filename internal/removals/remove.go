@@ -22,8 +22,10 @@ package removals
 
 import (
 	"fmt"
-	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 	"net"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	"github.com/go-logr/logr"
@@ -157,6 +159,90 @@ func GetRemainingMap(logger logr.Logger, adminClient fdbadminclient.AdminClient,
 	return remainingMap, nil
 }
 
+// FilterSuspendedProcessGroups returns the process groups that are allowed to be removed based on the minimum suspension
+// duration of the cluster. If the suspension is disabled, the provided list will be returned. Otherwise only process
+// groups that are suspended for at least the minimum suspension duration will be returned. The returned duration
+// defines the time until the next suspended process group can be removed or 0 if no further process group is suspended.
+func FilterSuspendedProcessGroups(cluster *fdbv1beta2.FoundationDBCluster, processGroupsToRemove []*fdbv1beta2.ProcessGroupStatus) ([]*fdbv1beta2.ProcessGroupStatus, time.Duration) {
+	suspensionDuration := cluster.GetMinimumSuspensionDuration()
+	if suspensionDuration == 0 {
+		return processGroupsToRemove, 0
+	}
+
+	var nextRemoval time.Duration
+	filteredList := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupsToRemove))
+	for _, processGroup := range processGroupsToRemove {
+		if !processGroup.IsSuspended() {
+			continue
+		}
+
+		remaining := suspensionDuration - time.Since(processGroup.SuspensionTimestamp.Time)
+		if remaining > 0 {
+			if nextRemoval == 0 || remaining < nextRemoval {
+				nextRemoval = remaining
+			}
+
+			continue
+		}
+
+		filteredList = append(filteredList, processGroup)
+	}
+
+	return filteredList, nextRemoval
+}
+
+// CheckDataHallRemovals checks for the three_data_hall redundancy mode that the removal of the provided process groups
+// doesn't leave any affected data hall with less than two zones and that none of the process groups is still serving
+// as a coordinator. If one of those requirements is not met, an error will be returned.
+func CheckDataHallRemovals(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, processGroupsToRemove []*fdbv1beta2.ProcessGroupStatus) error {
+	if cluster.Spec.DatabaseConfiguration.RedundancyMode != fdbv1beta2.RedundancyModeThreeDataHall {
+		return nil
+	}
+
+	removals := make(map[string]fdbv1beta2.None, len(processGroupsToRemove))
+	for _, processGroup := range processGroupsToRemove {
+		removals[string(processGroup.ProcessGroupID)] = fdbv1beta2.None{}
+	}
+
+	coordinators := fdbstatus.GetCoordinatorsFromStatus(status)
+	affectedDataHalls := map[string]fdbv1beta2.None{}
+	remainingZones := map[string]map[string]fdbv1beta2.None{}
+	for _, process := range status.Cluster.Processes {
+		processGroupID := process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]
+		dataHall := process.Locality[fdbv1beta2.FDBLocalityDataHallKey]
+
+		if _, ok := removals[processGroupID]; ok {
+			if _, isCoordinator := coordinators[processGroupID]; isCoordinator {
+				return fmt.Errorf("process group %s is still serving as coordinator", processGroupID)
+			}
+
+			if dataHall != "" {
+				affectedDataHalls[dataHall] = fdbv1beta2.None{}
+			}
+
+			continue
+		}
+
+		if process.Excluded || dataHall == "" {
+			continue
+		}
+
+		if _, ok := remainingZones[dataHall]; !ok {
+			remainingZones[dataHall] = map[string]fdbv1beta2.None{}
+		}
+
+		remainingZones[dataHall][process.Locality[fdbv1beta2.FDBLocalityZoneIDKey]] = fdbv1beta2.None{}
+	}
+
+	for dataHall := range affectedDataHalls {
+		if len(remainingZones[dataHall]) < 2 {
+			return fmt.Errorf("removing the process groups would leave data hall %s with %d zones, at least 2 zones are required", dataHall, len(remainingZones[dataHall]))
+		}
+	}
+
+	return nil
+}
+
 // RemovalAllowed returns if we are allowed to remove the process group or if we have to wait to ensure a safe deletion.
 func RemovalAllowed(lastDeletion int64, currentTimestamp int64, waitTime int) (int64, bool) {
 	ts := currentTimestamp - int64(waitTime)
@@ -22,6 +22,10 @@ package removals
 
 import (
 	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
@@ -255,4 +259,147 @@ var _ = Describe("remove", func() {
 				int64(59)),
 		)
 	})
+
+	When("filtering suspended process groups", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var processGroups []*fdbv1beta2.ProcessGroupStatus
+		var filtered []*fdbv1beta2.ProcessGroupStatus
+		var nextRemoval time.Duration
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{}
+			processGroups = []*fdbv1beta2.ProcessGroupStatus{
+				{
+					ProcessGroupID: "storage-1",
+				},
+				{
+					ProcessGroupID:      "storage-2",
+					SuspensionTimestamp: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+				},
+				{
+					ProcessGroupID:      "storage-3",
+					SuspensionTimestamp: &metav1.Time{Time: time.Now().Add(-30 * time.Minute)},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			filtered, nextRemoval = FilterSuspendedProcessGroups(cluster, processGroups)
+		})
+
+		When("the suspension is disabled", func() {
+			It("should return all process groups", func() {
+				Expect(filtered).To(Equal(processGroups))
+				Expect(nextRemoval).To(BeZero())
+			})
+		})
+
+		When("the suspension is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MinimumSuspensionDurationSeconds = pointer.Int(3600)
+			})
+
+			It("should only return the process groups that are suspended long enough", func() {
+				Expect(filtered).To(HaveLen(1))
+				Expect(filtered[0].ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID("storage-2")))
+			})
+
+			It("should return the duration until the next process group can be removed", func() {
+				Expect(nextRemoval).To(BeNumerically("~", 30*time.Minute, time.Minute))
+			})
+		})
+	})
+
+	When("checking the data hall removals", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var status *fdbv1beta2.FoundationDBStatus
+		var processGroupsToRemove []*fdbv1beta2.ProcessGroupStatus
+		var err error
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+						RedundancyMode: fdbv1beta2.RedundancyModeThreeDataHall,
+					},
+				},
+			}
+
+			status = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{},
+				},
+			}
+
+			for _, dataHall := range []string{"az1", "az2", "az3"} {
+				for i := 1; i <= 3; i++ {
+					processGroupID := fmt.Sprintf("%s-storage-%d", dataHall, i)
+					var roles []fdbv1beta2.FoundationDBStatusProcessRoleInfo
+					if i == 1 {
+						roles = append(roles, fdbv1beta2.FoundationDBStatusProcessRoleInfo{Role: string(fdbv1beta2.ProcessRoleCoordinator)})
+					}
+
+					status.Cluster.Processes[fdbv1beta2.ProcessGroupID(processGroupID)] = fdbv1beta2.FoundationDBStatusProcessInfo{
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: processGroupID,
+							fdbv1beta2.FDBLocalityZoneIDKey:     processGroupID,
+							fdbv1beta2.FDBLocalityDataHallKey:   dataHall,
+						},
+						Roles: roles,
+					}
+				}
+			}
+		})
+
+		JustBeforeEach(func() {
+			err = CheckDataHallRemovals(cluster, status, processGroupsToRemove)
+		})
+
+		When("a single process group is removed", func() {
+			BeforeEach(func() {
+				processGroupsToRemove = []*fdbv1beta2.ProcessGroupStatus{
+					{ProcessGroupID: "az1-storage-2"},
+				}
+			})
+
+			It("should allow the removal", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the removal would leave a data hall with a single zone", func() {
+			BeforeEach(func() {
+				processGroupsToRemove = []*fdbv1beta2.ProcessGroupStatus{
+					{ProcessGroupID: "az1-storage-2"},
+					{ProcessGroupID: "az1-storage-3"},
+				}
+			})
+
+			It("should block the removal", func() {
+				Expect(err).To(MatchError("removing the process groups would leave data hall az1 with 1 zones, at least 2 zones are required"))
+			})
+
+			When("the redundancy mode is not three_data_hall", func() {
+				BeforeEach(func() {
+					cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeTriple
+				})
+
+				It("should allow the removal", func() {
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("a coordinator is removed", func() {
+			BeforeEach(func() {
+				processGroupsToRemove = []*fdbv1beta2.ProcessGroupStatus{
+					{ProcessGroupID: "az2-storage-1"},
+				}
+			})
+
+			It("should block the removal", func() {
+				Expect(err).To(MatchError("process group az2-storage-1 is still serving as coordinator"))
+			})
+		})
+	})
 })
@@ -43,6 +43,100 @@ func getMaxReplacements(cluster *fdbv1beta2.FoundationDBCluster, maxReplacements
 	return maxReplacements - removalCount
 }
 
+// replacementBuckets tracks the remaining budget for concurrent automatic replacements per process class and per
+// condition.
+type replacementBuckets struct {
+	// maxReplacements is the remaining global budget for replacements that are counted against the process class
+	// buckets, this ensures that the sum of the process class buckets never exceeds the MaxConcurrentReplacements.
+	maxReplacements int
+	processClasses  map[fdbv1beta2.ProcessClass]int
+	conditions     map[fdbv1beta2.ProcessGroupConditionType]int
+	// conditionOrder defines the order of the conditions with a separate budget, this is used to select the bucket
+	// for ongoing replacements.
+	conditionOrder []fdbv1beta2.ProcessGroupConditionType
+}
+
+// getBucket returns the bucket that should be used for the provided process group and failure condition. If the
+// condition has a separate budget, the condition bucket will be used, otherwise the bucket of the process class or if
+// no specific bucket exists the general bucket.
+func (buckets *replacementBuckets) getBucket(processClass fdbv1beta2.ProcessClass, condition fdbv1beta2.ProcessGroupConditionType) (fdbv1beta2.ProcessClass, fdbv1beta2.ProcessGroupConditionType) {
+	if _, ok := buckets.conditions[condition]; ok {
+		return "", condition
+	}
+
+	if _, ok := buckets.processClasses[processClass]; ok {
+		return processClass, ""
+	}
+
+	return fdbv1beta2.ProcessClassGeneral, ""
+}
+
+// remaining returns the remaining budget of the bucket for the provided process group and failure condition.
+func (buckets *replacementBuckets) remaining(processClass fdbv1beta2.ProcessClass, condition fdbv1beta2.ProcessGroupConditionType) int {
+	bucketClass, bucketCondition := buckets.getBucket(processClass, condition)
+	if bucketCondition != "" {
+		return buckets.conditions[bucketCondition]
+	}
+
+	if buckets.maxReplacements < buckets.processClasses[bucketClass] {
+		return buckets.maxReplacements
+	}
+
+	return buckets.processClasses[bucketClass]
+}
+
+// take reduces the budget of the bucket for the provided process group and failure condition by one.
+func (buckets *replacementBuckets) take(processClass fdbv1beta2.ProcessClass, condition fdbv1beta2.ProcessGroupConditionType) {
+	bucketClass, bucketCondition := buckets.getBucket(processClass, condition)
+	if bucketCondition != "" {
+		buckets.conditions[bucketCondition]--
+		return
+	}
+
+	buckets.maxReplacements--
+	buckets.processClasses[bucketClass]--
+}
+
+// getOngoingReplacementCondition returns the condition with a separate budget that an ongoing replacement should be
+// accounted for. If the process group has no such condition an empty condition will be returned.
+func (buckets *replacementBuckets) getOngoingReplacementCondition(processGroupStatus *fdbv1beta2.ProcessGroupStatus) fdbv1beta2.ProcessGroupConditionType {
+	for _, condition := range buckets.conditionOrder {
+		if processGroupStatus.GetConditionTime(condition) != nil {
+			return condition
+		}
+	}
+
+	return ""
+}
+
+func getReplacementBuckets(cluster *fdbv1beta2.FoundationDBCluster) *replacementBuckets {
+	processClassBuckets, conditionBuckets := cluster.GetReplacementBuckets()
+	buckets := &replacementBuckets{
+		maxReplacements: cluster.GetMaxConcurrentAutomaticReplacements(),
+		processClasses:  processClassBuckets,
+		conditions:      conditionBuckets,
+		conditionOrder:  make([]fdbv1beta2.ProcessGroupConditionType, 0, len(conditionBuckets)),
+	}
+
+	for _, policy := range cluster.Spec.AutomationOptions.Replacements.ConditionPolicies {
+		if _, ok := conditionBuckets[policy.ConditionType]; ok {
+			buckets.conditionOrder = append(buckets.conditionOrder, policy.ConditionType)
+		}
+	}
+
+	// The maximum number of replacements will be the defined number in the cluster spec
+	// minus all currently ongoing replacements e.g. process groups marked for removal but
+	// not fully excluded.
+	for _, processGroupStatus := range cluster.Status.ProcessGroups {
+		if processGroupStatus.IsMarkedForRemoval() && !processGroupStatus.IsExcluded() {
+			// Count all removals that are in-flight.
+			buckets.take(processGroupStatus.ProcessClass, buckets.getOngoingReplacementCondition(processGroupStatus))
+		}
+	}
+
+	return buckets
+}
+
 // ReplaceFailedProcessGroups flags failed processes groups for removal and returns an indicator
 // of whether any processes were thus flagged.
 func ReplaceFailedProcessGroups(log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, hasDesiredFaultTolerance bool) bool {
@@ -51,7 +145,7 @@ func ReplaceFailedProcessGroups(log logr.Logger, cluster *fdbv1beta2.FoundationD
 		return false
 	}
 
-	maxReplacements := getMaxReplacements(cluster, cluster.GetMaxConcurrentAutomaticReplacements())
+	buckets := getReplacementBuckets(cluster)
 	hasReplacement := false
 	crashLoopContainerProcessGroups := cluster.GetCrashLoopContainerProcessGroups()
 
@@ -86,7 +180,7 @@ func ReplaceFailedProcessGroups(log logr.Logger, cluster *fdbv1beta2.FoundationD
 			continue
 		}
 
-		failureCondition, failureTime := processGroupStatus.NeedsReplacement(cluster.GetFailureDetectionTimeSeconds(), cluster.GetTaintReplacementTimeSeconds())
+		failureCondition, failureTime := cluster.GetProcessGroupFailureCondition(processGroupStatus)
 		if failureTime == 0 {
 			continue
 		}
@@ -112,7 +206,7 @@ func ReplaceFailedProcessGroups(log logr.Logger, cluster *fdbv1beta2.FoundationD
 		}
 
 		// We are not allowed to replace additional process groups.
-		if maxReplacements <= 0 {
+		if buckets.remaining(processGroupStatus.ProcessClass, failureCondition) <= 0 {
 			log.Info("Detected replace process group but cannot replace it because we hit the replacement limit",
 				"processGroupID", processGroupStatus.ProcessGroupID,
 				"failureCondition", failureCondition,
@@ -130,7 +224,7 @@ func ReplaceFailedProcessGroups(log logr.Logger, cluster *fdbv1beta2.FoundationD
 		processGroupStatus.MarkForRemoval()
 		hasReplacement = true
 		processGroupStatus.ExclusionSkipped = skipExclusion
-		maxReplacements--
+		buckets.take(processGroupStatus.ProcessClass, failureCondition)
 	}
 
 	return hasReplacement
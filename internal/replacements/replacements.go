@@ -42,6 +42,11 @@ import (
 func ReplaceMisconfiguredProcessGroups(ctx context.Context, podManager podmanager.PodLifecycleManager, client client.Client, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim) (bool, error) {
 	hasReplacements := false
 
+	distributionReplacements, err := getFaultDomainDistributionReplacements(cluster)
+	if err != nil {
+		return hasReplacements, err
+	}
+
 	maxReplacements := getMaxReplacements(cluster, cluster.GetMaxConcurrentReplacements())
 	for _, processGroup := range cluster.Status.ProcessGroups {
 		if maxReplacements <= 0 {
@@ -53,6 +58,19 @@ func ReplaceMisconfiguredProcessGroups(ctx context.Context, podManager podmanage
 			continue
 		}
 
+		if reason, ok := distributionReplacements[processGroup.ProcessGroupID]; ok {
+			log.Info("Replace process group",
+				"namespace", cluster.Namespace,
+				"cluster", cluster.Name,
+				"processGroupID", processGroup.ProcessGroupID,
+				"faultDomain", processGroup.FaultDomain,
+				"reason", reason)
+			processGroup.MarkForRemoval()
+			hasReplacements = true
+			maxReplacements--
+			continue
+		}
+
 		// TODO(johscheuer): Fix how we fetch the pvc to make better use of the controller runtime cache.
 		pvc, hasPVC := pvcMap[processGroup.ProcessGroupID]
 		pod, podErr := podManager.GetPod(ctx, client, cluster, processGroup.GetPodName(cluster))
@@ -94,6 +112,70 @@ func ReplaceMisconfiguredProcessGroups(ctx context.Context, podManager podmanage
 	return hasReplacements, nil
 }
 
+// getFaultDomainDistributionReplacements returns the process groups that must be replaced to distribute the process
+// groups equally across the logical fault domains. The value of the map contains the reason for the replacement.
+func getFaultDomainDistributionReplacements(cluster *fdbv1beta2.FoundationDBCluster) (map[fdbv1beta2.ProcessGroupID]string, error) {
+	replacements := map[fdbv1beta2.ProcessGroupID]string{}
+	if !cluster.UseFaultDomainDistribution() {
+		return replacements, nil
+	}
+
+	desiredCountStruct, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+	desiredCounts := desiredCountStruct.Map()
+	desiredFaultDomains := cluster.GetDesiredLogicalFaultDomains()
+
+	// If no process group is assigned to a logical fault domain, the fault domain distribution was just enabled. In
+	// this case the update status reconciler will assign the existing process groups in place and no process group
+	// must be replaced.
+	hasAssignedProcessGroups := false
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if cluster.IsLogicalFaultDomain(processGroup) {
+			hasAssignedProcessGroups = true
+			break
+		}
+	}
+
+	if !hasAssignedProcessGroups {
+		return replacements, nil
+	}
+
+	processGroupsPerFaultDomain := map[fdbv1beta2.ProcessClass]map[fdbv1beta2.FaultDomain]int{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if !cluster.IsLogicalFaultDomain(processGroup) {
+			replacements[processGroup.ProcessGroupID] = fmt.Sprintf("process group is not assigned to a logical fault domain, current fault domain: %s", processGroup.FaultDomain)
+			continue
+		}
+
+		// Process groups of a process class that is not desired anymore will be removed by the shrink logic.
+		desiredCount := desiredCounts[processGroup.ProcessClass]
+		if desiredCount <= 0 {
+			continue
+		}
+
+		if _, ok := processGroupsPerFaultDomain[processGroup.ProcessClass]; !ok {
+			processGroupsPerFaultDomain[processGroup.ProcessClass] = map[fdbv1beta2.FaultDomain]int{}
+		}
+
+		// The maximum number of process groups per logical fault domain is ceil(desired process groups / desired fault domains).
+		maxPerFaultDomain := (desiredCount + desiredFaultDomains - 1) / desiredFaultDomains
+		if processGroupsPerFaultDomain[processGroup.ProcessClass][processGroup.FaultDomain] >= maxPerFaultDomain {
+			replacements[processGroup.ProcessGroupID] = fmt.Sprintf("logical fault domain %s has more than %d process groups", processGroup.FaultDomain, maxPerFaultDomain)
+			continue
+		}
+
+		processGroupsPerFaultDomain[processGroup.ProcessClass][processGroup.FaultDomain]++
+	}
+
+	return replacements, nil
+}
+
 func processGroupNeedsRemovalForPVC(cluster *fdbv1beta2.FoundationDBCluster, pvc corev1.PersistentVolumeClaim, log logr.Logger, processGroup *fdbv1beta2.ProcessGroupStatus) (bool, error) {
 	processGroupID := internal.GetProcessGroupIDFromMeta(cluster, pvc.ObjectMeta)
 	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "pvc", pvc.Name, "processGroupID", processGroupID, "reconciler", "replaceMisconfiguredProcessGroups")
@@ -743,4 +743,77 @@ var _ = Describe("replace_misconfigured_pods", func() {
 			})
 		})
 	})
+
+	When("the fault domain distribution is enabled", func() {
+		var replacements map[fdbv1beta2.ProcessGroupID]string
+
+		BeforeEach(func() {
+			cluster.Spec.ProcessCounts.Storage = 4
+			cluster.Spec.FaultDomain = fdbv1beta2.FoundationDBClusterFaultDomain{
+				Key: corev1.LabelHostname,
+				DistributionConfig: &fdbv1beta2.DistributionConfig{
+					Enabled: pointer.Bool(true),
+				},
+			}
+
+			faultDomains := []string{"0", "0", "0", "host-1", "3", "1"}
+			cluster.Status.ProcessGroups = make([]*fdbv1beta2.ProcessGroupStatus, 0, len(faultDomains))
+			for idx, faultDomain := range faultDomains {
+				processGroup := fdbv1beta2.NewProcessGroupStatus(fdbv1beta2.ProcessGroupID(fmt.Sprintf("storage-%d", idx+1)), fdbv1beta2.ProcessClassStorage, nil)
+				processGroup.FaultDomain = fdbv1beta2.FaultDomain(faultDomain)
+				if faultDomain != "host-1" {
+					processGroup.FaultDomain = fdbv1beta2.FaultDomain(cluster.Name + "-" + faultDomain)
+				}
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+			cluster.Status.ProcessGroups[5].MarkForRemoval()
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			replacements, err = getFaultDomainDistributionReplacements(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should replace the process groups that are not distributed correctly", func() {
+			Expect(replacements).To(HaveLen(3))
+			Expect(replacements).To(HaveKey(fdbv1beta2.ProcessGroupID("storage-3")))
+			Expect(replacements).To(HaveKey(fdbv1beta2.ProcessGroupID("storage-4")))
+			Expect(replacements).To(HaveKey(fdbv1beta2.ProcessGroupID("storage-5")))
+		})
+
+		When("the fault domain distribution is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.FaultDomain.DistributionConfig.Enabled = pointer.Bool(false)
+			})
+
+			It("should not replace any process groups", func() {
+				Expect(replacements).To(BeEmpty())
+			})
+		})
+
+		When("no process group is assigned to a logical fault domain", func() {
+			BeforeEach(func() {
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					processGroup.FaultDomain = "host-1"
+				}
+			})
+
+			It("should not replace any process groups", func() {
+				Expect(replacements).To(BeEmpty())
+			})
+		})
+
+		When("no storage process groups are desired", func() {
+			BeforeEach(func() {
+				cluster.Spec.ProcessCounts.Storage = -1
+			})
+
+			It("should only replace the process groups that are not assigned to a logical fault domain", func() {
+				Expect(replacements).To(HaveLen(2))
+				Expect(replacements).To(HaveKey(fdbv1beta2.ProcessGroupID("storage-4")))
+				Expect(replacements).To(HaveKey(fdbv1beta2.ProcessGroupID("storage-5")))
+			})
+		})
+	})
 })
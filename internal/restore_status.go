@@ -0,0 +1,114 @@
+/*
+ * restore_status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var (
+	restoreStateRegex       = regexp.MustCompile(`State:\s+(\S+)`)
+	restoreBlocksRegex      = regexp.MustCompile(`Blocks:\s+(\d+)/(\d+)`)
+	restoreLastErrorRegex   = regexp.MustCompile(`LastError:\s+(.*?)(?:\s{2}URL:|$)`)
+	restoreNumericKeysRegex = regexp.MustCompile(`(BlocksInProgress|Files|BytesWritten|CurrentVersion|FirstConsistentVersion|ApplyVersionLag):\s+(-?\d+)`)
+)
+
+// ParseRestoreStatus parses the output of `fdbrestore status` and returns the phase and the progress of the restore.
+// If the output contains no restore an empty phase and a nil progress will be returned.
+func ParseRestoreStatus(output string) (fdbv1beta2.RestorePhase, *fdbv1beta2.FoundationDBRestoreProgress) {
+	// The status will only contain multiple restores if multiple tags are used, the operator only uses the default tag
+	// so only the first line with a state will be parsed.
+	var line string
+	for _, currentLine := range strings.Split(output, "\n") {
+		if restoreStateRegex.MatchString(currentLine) {
+			line = currentLine
+			break
+		}
+	}
+
+	if line == "" {
+		return "", nil
+	}
+
+	phase := getRestorePhase(restoreStateRegex.FindStringSubmatch(line)[1])
+	progress := &fdbv1beta2.FoundationDBRestoreProgress{}
+
+	blocks := restoreBlocksRegex.FindStringSubmatch(line)
+	if blocks != nil {
+		progress.BlocksCompleted, _ = strconv.ParseInt(blocks[1], 10, 64)
+		progress.BlocksTotal, _ = strconv.ParseInt(blocks[2], 10, 64)
+	}
+
+	for _, match := range restoreNumericKeysRegex.FindAllStringSubmatch(line, -1) {
+		value, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch match[1] {
+		case "BlocksInProgress":
+			progress.BlocksInProgress = value
+		case "Files":
+			progress.Files = value
+		case "BytesWritten":
+			progress.BytesWritten = value
+		case "CurrentVersion":
+			progress.CurrentVersion = value
+		case "FirstConsistentVersion":
+			progress.FirstConsistentVersion = value
+		case "ApplyVersionLag":
+			progress.ApplyVersionLag = value
+		}
+	}
+
+	lastError := restoreLastErrorRegex.FindStringSubmatch(line)
+	if lastError != nil {
+		// fdbrestore reports None if the restore has not encountered any error.
+		progress.LastError = strings.TrimSpace(lastError[1])
+		if progress.LastError == "None" {
+			progress.LastError = ""
+		}
+	}
+
+	return phase, progress
+}
+
+// getRestorePhase maps the state reported by fdbrestore to the restore phase.
+func getRestorePhase(state string) fdbv1beta2.RestorePhase {
+	switch strings.ToLower(state) {
+	case "queued":
+		return fdbv1beta2.RestorePhaseQueued
+	case "starting":
+		return fdbv1beta2.RestorePhaseStarting
+	case "running":
+		return fdbv1beta2.RestorePhaseRunning
+	case "completed":
+		return fdbv1beta2.RestorePhaseCompleted
+	case "aborted":
+		return fdbv1beta2.RestorePhaseAborted
+	}
+
+	return fdbv1beta2.RestorePhaseUnknown
+}
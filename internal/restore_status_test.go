@@ -0,0 +1,93 @@
+/*
+ * restore_status_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("restore_status", func() {
+	DescribeTable("parsing the restore status",
+		func(fixture string, expectedPhase fdbv1beta2.RestorePhase, expectedProgress *fdbv1beta2.FoundationDBRestoreProgress) {
+			output, err := os.ReadFile(filepath.Join("testdata", fixture))
+			Expect(err).NotTo(HaveOccurred())
+
+			phase, progress := ParseRestoreStatus(string(output))
+			Expect(phase).To(Equal(expectedPhase))
+			Expect(progress).To(Equal(expectedProgress))
+		},
+		Entry("no restore",
+			"fdbrestore_status_empty.txt",
+			fdbv1beta2.RestorePhase(""),
+			nil,
+		),
+		Entry("running restore",
+			"fdbrestore_status_running.txt",
+			fdbv1beta2.RestorePhaseRunning,
+			&fdbv1beta2.FoundationDBRestoreProgress{
+				BlocksCompleted:        42,
+				BlocksTotal:            100,
+				BlocksInProgress:       3,
+				Files:                  12,
+				BytesWritten:           1048576,
+				CurrentVersion:         2000,
+				FirstConsistentVersion: 1500,
+				ApplyVersionLag:        10,
+			},
+		),
+		Entry("completed restore with key ranges and prefixes",
+			"fdbrestore_status_completed.txt",
+			fdbv1beta2.RestorePhaseCompleted,
+			&fdbv1beta2.FoundationDBRestoreProgress{
+				BlocksCompleted:        100,
+				BlocksTotal:            100,
+				Files:                  12,
+				BytesWritten:           2097152,
+				CurrentVersion:         2500,
+				FirstConsistentVersion: 1500,
+			},
+		),
+		Entry("aborted restore with an error",
+			"fdbrestore_status_aborted.txt",
+			fdbv1beta2.RestorePhaseAborted,
+			&fdbv1beta2.FoundationDBRestoreProgress{
+				BlocksCompleted:        10,
+				BlocksTotal:            100,
+				Files:                  12,
+				BytesWritten:           4096,
+				CurrentVersion:         1000,
+				FirstConsistentVersion: -1,
+				LastError:              "'restore_missing_data' 20s ago.",
+			},
+		),
+	)
+
+	It("should report an unknown state", func() {
+		phase, progress := ParseRestoreStatus("Tag: default  UID: 3b5c8e0f9a7d4c21b6e2f1a0d9c8b7a6  State: unitialized  Blocks: 0/0  BlocksInProgress: 0  Files: 0  BytesWritten: 0  CurrentVersion: 0 FirstConsistentVersion: 0  ApplyVersionLag: 0  LastError: None  URL: blobstore://test@test-service/test-backup  Range: ''-'\\xff'  AddPrefix: ''  RemovePrefix: ''  Version: 0\n\n\n")
+		Expect(phase).To(Equal(fdbv1beta2.RestorePhaseUnknown))
+		Expect(progress).To(Equal(&fdbv1beta2.FoundationDBRestoreProgress{}))
+	})
+})
@@ -0,0 +1,146 @@
+/*
+ * restore_validation.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// restoreValidationClusterFileMountPath is the directory in which the cluster file of the temporary cluster is mounted
+// in the verification job.
+const restoreValidationClusterFileMountPath = "/var/dynamic-conf"
+
+// GetRestoreValidationName returns the name of the resources that are created to validate the backup.
+func GetRestoreValidationName(backup *fdbv1beta2.FoundationDBBackup) string {
+	return fmt.Sprintf("%s-validation", backup.Name)
+}
+
+// getRestoreValidationMetadata returns the metadata for the resources that are created to validate the backup. The
+// labels of the backup are copied, so the resources are picked up by an operator that uses a label selector.
+func getRestoreValidationMetadata(backup *fdbv1beta2.FoundationDBBackup) metav1.ObjectMeta {
+	labels := make(map[string]string, len(backup.Labels)+1)
+	for key, value := range backup.Labels {
+		labels[key] = value
+	}
+	labels[fdbv1beta2.RestoreValidationLabel] = string(backup.UID)
+
+	return metav1.ObjectMeta{
+		Name:            GetRestoreValidationName(backup),
+		Namespace:       backup.Namespace,
+		Labels:          labels,
+		OwnerReferences: BuildOwnerReference(backup.TypeMeta, backup.ObjectMeta),
+	}
+}
+
+// GetRestoreValidationCluster returns the temporary cluster into which the backup will be restored.
+func GetRestoreValidationCluster(backup *fdbv1beta2.FoundationDBBackup) *fdbv1beta2.FoundationDBCluster {
+	cluster := &fdbv1beta2.FoundationDBCluster{
+		ObjectMeta: getRestoreValidationMetadata(backup),
+		Spec:       *backup.Spec.RestoreValidation.ClusterTemplate.DeepCopy(),
+	}
+
+	if cluster.Spec.Version == "" {
+		cluster.Spec.Version = backup.Spec.Version
+	}
+
+	return cluster
+}
+
+// GetRestoreValidationBackup returns a stopped backup for the temporary cluster. The backup will never be started,
+// it only runs the backup agents that are required to restore the backup into the temporary cluster.
+func GetRestoreValidationBackup(backup *fdbv1beta2.FoundationDBBackup, destination fdbv1beta2.BackupDestination) *fdbv1beta2.FoundationDBBackup {
+	spec := backup.Spec.DeepCopy()
+	spec.ClusterName = GetRestoreValidationName(backup)
+	spec.BackupState = fdbv1beta2.BackupStateStopped
+	spec.BlobStoreConfiguration = destination.BlobStoreConfiguration.DeepCopy()
+	spec.Destinations = nil
+	spec.AgentAutoscaling = nil
+	spec.SnapshotSchedule = nil
+	spec.RetentionPolicy = nil
+	spec.RestoreValidation = nil
+	// The temporary backup shares the destination with the backup, so the backup data must never be deleted.
+	deletionPolicy := fdbv1beta2.BackupDeletionPolicyRetain
+	spec.DeletionPolicy = &deletionPolicy
+
+	return &fdbv1beta2.FoundationDBBackup{
+		ObjectMeta: getRestoreValidationMetadata(backup),
+		Spec:       *spec,
+	}
+}
+
+// GetRestoreValidationRestore returns the restore of the backup into the temporary cluster.
+func GetRestoreValidationRestore(backup *fdbv1beta2.FoundationDBBackup, destination fdbv1beta2.BackupDestination) *fdbv1beta2.FoundationDBRestore {
+	blobStoreConfiguration := destination.BlobStoreConfiguration.DeepCopy()
+	if blobStoreConfiguration.BackupName == "" {
+		blobStoreConfiguration.BackupName = backup.Name
+	}
+
+	return &fdbv1beta2.FoundationDBRestore{
+		ObjectMeta: getRestoreValidationMetadata(backup),
+		Spec: fdbv1beta2.FoundationDBRestoreSpec{
+			DestinationClusterName: GetRestoreValidationName(backup),
+			BlobStoreConfiguration: blobStoreConfiguration,
+			CustomParameters:       backup.Spec.CustomParameters,
+		},
+	}
+}
+
+// GetRestoreValidationJob returns the job that verifies the restored data. If the backup defines no verification job
+// nil will be returned.
+func GetRestoreValidationJob(backup *fdbv1beta2.FoundationDBBackup) *batchv1.Job {
+	if backup.Spec.RestoreValidation.VerificationJob == nil {
+		return nil
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: getRestoreValidationMetadata(backup),
+		Spec:       *backup.Spec.RestoreValidation.VerificationJob.DeepCopy(),
+	}
+
+	podSpec := &job.Spec.Template.Spec
+	if podSpec.RestartPolicy == "" {
+		podSpec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "fdb-cluster-file",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-config", GetRestoreValidationName(backup))},
+			Items: []corev1.KeyToPath{
+				{Key: ClusterFileKey, Path: "fdb.cluster"},
+			},
+		}},
+	})
+
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for idx := range containers {
+			containers[idx].VolumeMounts = append(containers[idx].VolumeMounts, corev1.VolumeMount{Name: "fdb-cluster-file", MountPath: restoreValidationClusterFileMountPath})
+			extendEnv(&containers[idx], corev1.EnvVar{Name: "FDB_CLUSTER_FILE", Value: fmt.Sprintf("%s/fdb.cluster", restoreValidationClusterFileMountPath)})
+		}
+	}
+
+	return job
+}
@@ -0,0 +1,144 @@
+/*
+ * restore_validation_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package internal
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("restore_validation", func() {
+	var backup *fdbv1beta2.FoundationDBBackup
+	var destination fdbv1beta2.BackupDestination
+
+	BeforeEach(func() {
+		backup = CreateDefaultBackup(CreateDefaultCluster())
+		backup.ObjectMeta.UID = types.UID("backup-uid")
+		backup.ObjectMeta.Labels = map[string]string{"fdb-operator": "main"}
+		backup.Spec.RestoreValidation = &fdbv1beta2.BackupRestoreValidation{
+			Schedule: "0 4 * * 0",
+		}
+
+		var err error
+		destination, err = backup.GetRestoreValidationDestination()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("getting the temporary cluster", func() {
+		It("should use the version of the backup", func() {
+			cluster := GetRestoreValidationCluster(backup)
+			Expect(cluster.Name).To(Equal("operator-test-1-validation"))
+			Expect(cluster.Spec.Version).To(Equal(backup.Spec.Version))
+			Expect(cluster.Labels).To(Equal(map[string]string{
+				"fdb-operator":                    "main",
+				fdbv1beta2.RestoreValidationLabel: "backup-uid",
+			}))
+			Expect(cluster.OwnerReferences).To(HaveLen(1))
+		})
+
+		It("should use the version of the template", func() {
+			backup.Spec.RestoreValidation.ClusterTemplate.Version = "7.1.27"
+			Expect(GetRestoreValidationCluster(backup).Spec.Version).To(Equal("7.1.27"))
+		})
+	})
+
+	When("getting the backup for the temporary cluster", func() {
+		It("should run stopped backup agents for the temporary cluster", func() {
+			validationBackup := GetRestoreValidationBackup(backup, destination)
+			Expect(validationBackup.Spec.ClusterName).To(Equal("operator-test-1-validation"))
+			Expect(validationBackup.Spec.BackupState).To(Equal(fdbv1beta2.BackupStateStopped))
+			Expect(validationBackup.Spec.BlobStoreConfiguration).To(Equal(backup.Spec.BlobStoreConfiguration))
+			Expect(validationBackup.Spec.RestoreValidation).To(BeNil())
+			Expect(validationBackup.Spec.AgentCount).To(Equal(backup.Spec.AgentCount))
+		})
+
+		When("the backup data is deleted with the backup", func() {
+			BeforeEach(func() {
+				deletionPolicy := fdbv1beta2.BackupDeletionPolicyDelete
+				backup.Spec.DeletionPolicy = &deletionPolicy
+			})
+
+			It("should retain the backup data when the temporary backup is deleted", func() {
+				validationBackup := GetRestoreValidationBackup(backup, destination)
+				Expect(validationBackup.GetDeletionPolicy()).To(Equal(fdbv1beta2.BackupDeletionPolicyRetain))
+			})
+		})
+	})
+
+	When("getting the restore", func() {
+		It("should restore the destination into the temporary cluster", func() {
+			restore := GetRestoreValidationRestore(backup, destination)
+			Expect(restore.Spec.DestinationClusterName).To(Equal("operator-test-1-validation"))
+			Expect(restore.BackupURL()).To(Equal(backup.BackupURL()))
+		})
+
+		When("the backup name is not defined", func() {
+			BeforeEach(func() {
+				backup.Spec.BlobStoreConfiguration.BackupName = ""
+			})
+
+			It("should use the name of the backup", func() {
+				restore := GetRestoreValidationRestore(backup, destination)
+				Expect(restore.BackupURL()).To(Equal(backup.BackupURL()))
+			})
+		})
+	})
+
+	When("getting the verification job", func() {
+		It("should return nil without a verification job", func() {
+			Expect(GetRestoreValidationJob(backup)).To(BeNil())
+		})
+
+		When("a verification job is defined", func() {
+			BeforeEach(func() {
+				backup.Spec.RestoreValidation.VerificationJob = &batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "verify", Image: "verify:latest"}},
+						},
+					},
+				}
+			})
+
+			It("should mount the cluster file of the temporary cluster", func() {
+				job := GetRestoreValidationJob(backup)
+				Expect(job).NotTo(BeNil())
+				Expect(job.Name).To(Equal("operator-test-1-validation"))
+				Expect(job.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+				Expect(job.Spec.Template.Spec.Volumes).To(ConsistOf(corev1.Volume{
+					Name: "fdb-cluster-file",
+					VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "operator-test-1-validation-config"},
+						Items:                []corev1.KeyToPath{{Key: ClusterFileKey, Path: "fdb.cluster"}},
+					}},
+				}))
+
+				container := job.Spec.Template.Spec.Containers[0]
+				Expect(container.VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: "fdb-cluster-file", MountPath: "/var/dynamic-conf"}))
+				Expect(container.Env).To(ConsistOf(corev1.EnvVar{Name: "FDB_CLUSTER_FILE", Value: "/var/dynamic-conf/fdb.cluster"}))
+				Expect(backup.Spec.RestoreValidation.VerificationJob.Template.Spec.Containers[0].Env).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -95,6 +95,26 @@ func CreateDefaultBackup(cluster *fdbv1beta2.FoundationDBCluster) *fdbv1beta2.Fo
 	}
 }
 
+// CreateDefaultDisasterRecovery creates a disaster recovery resource that replicates the source cluster into the
+// destination cluster.
+func CreateDefaultDisasterRecovery(source *fdbv1beta2.FoundationDBCluster, destination *fdbv1beta2.FoundationDBCluster) *fdbv1beta2.FoundationDBDisasterRecovery {
+	agentCount := 3
+	return &fdbv1beta2.FoundationDBDisasterRecovery{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      source.Name,
+			Namespace: source.Namespace,
+		},
+		Spec: fdbv1beta2.FoundationDBDisasterRecoverySpec{
+			DisasterRecoveryState:  fdbv1beta2.DisasterRecoveryStateRunning,
+			Version:                source.Spec.Version,
+			SourceClusterName:      source.Name,
+			DestinationClusterName: destination.Name,
+			AgentCount:             &agentCount,
+		},
+		Status: fdbv1beta2.FoundationDBDisasterRecoveryStatus{},
+	}
+}
+
 // GetProcessGroup is a helper method that creates a ProcessGroup based on the provided process class and id number.
 func GetProcessGroup(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, idNum int) *fdbv1beta2.ProcessGroupStatus {
 	_, processGroupID := cluster.GetProcessGroupID(processClass, idNum)
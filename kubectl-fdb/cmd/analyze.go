@@ -126,7 +126,13 @@ func newAnalyzeCmd(streams genericclioptions.IOStreams) *cobra.Command {
 				clusters = args
 			}
 
+			kubeContexts, err := getKubeContexts(cmd, o)
+			if err != nil {
+				return err
+			}
+
 			var errs []error
+			analyzed := map[string]fdbv1beta2.None{}
 			for _, clusterName := range clusters {
 				cluster, err := loadCluster(kubeClient, namespace, clusterName)
 				if err != nil {
@@ -134,14 +140,27 @@ func newAnalyzeCmd(streams genericclioptions.IOStreams) *cobra.Command {
 					continue
 				}
 
-				err = analyzeCluster(cmd, kubeClient, cluster, autoFix, wait, ignoreConditions, ignoreRemovals)
-				if err != nil {
-					errs = append(errs, err)
+				if len(kubeContexts) == 0 {
+					errs = append(errs, analyzeClusterAndStatus(cmd, config, clientSet, kubeClient, cluster, autoFix, wait, ignoreConditions, ignoreRemovals)...)
+					continue
 				}
 
-				err = analyzeStatus(cmd, config, clientSet, kubeClient, cluster, autoFix)
+				discoveredClusters, err := discoverClusters(kubeContexts, cluster)
 				if err != nil {
 					errs = append(errs, err)
+					continue
+				}
+
+				for _, discoveredCluster := range discoveredClusters {
+					// Clusters that are part of the same FDB cluster will be discovered multiple times if all clusters
+					// are analyzed.
+					if _, ok := analyzed[discoveredCluster.String()]; ok {
+						continue
+					}
+					analyzed[discoveredCluster.String()] = fdbv1beta2.None{}
+
+					cmd.Printf("Analyzing cluster %s\n", discoveredCluster.String())
+					errs = append(errs, analyzeClusterAndStatus(cmd, discoveredCluster.kubeContext.restConfig, discoveredCluster.kubeContext.clientSet, discoveredCluster.kubeContext.kubeClient, discoveredCluster.cluster, autoFix, wait, ignoreConditions, ignoreRemovals)...)
 				}
 			}
 
@@ -171,6 +190,9 @@ kubectl fdb analyze --auto-fix sample-cluster-1
 # Analyze the cluster "sample-cluster-1" in the current namespace and ignore the IncorrectCommandLine and IncorrectPodSpec condition
 kubectl fdb analyze --ignore-condition=IncorrectCommandLine --ignore-condition=IncorrectPodSpec sample-cluster-1
 
+# Analyze all clusters that share the connection string with the cluster "sample-cluster-1" in the contexts "dc1", "dc2" and "dc3"
+kubectl fdb analyze --contexts=dc1,dc2,dc3 sample-cluster-1
+
 # Per default the plugin will print out how many process groups are marked for removal instead of printing out each process group.
 # This can be disabled by using the ignore-removals flag to print out the details about process groups that are marked for removal.
 kubectl fdb analyze --ignore-removals=false sample-cluster-1
@@ -192,6 +214,22 @@ kubectl fdb analyze --ignore-removals=false sample-cluster-1
 	return cmd
 }
 
+// analyzeClusterAndStatus analyzes the cluster resource and the machine-readable status of the provided cluster.
+func analyzeClusterAndStatus(cmd *cobra.Command, restConfig *rest.Config, clientSet *kubernetes.Clientset, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, autoFix bool, wait bool, ignoreConditions []string, ignoreRemovals bool) []error {
+	var errs []error
+	err := analyzeCluster(cmd, kubeClient, cluster, autoFix, wait, ignoreConditions, ignoreRemovals)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	err = analyzeStatus(cmd, restConfig, clientSet, kubeClient, cluster, autoFix)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
 func allConditionsValid(conditions []string) error {
 	conditionMap := map[string]fdbv1beta2.None{}
 
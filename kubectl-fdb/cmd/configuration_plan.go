@@ -0,0 +1,201 @@
+/*
+ * configuration_plan.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"fmt"
+	"strings"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newConfigurationPlanCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "configuration-plan",
+		Short: "Get the pending configuration plan of the cluster.",
+		Long:  "Get the pending configuration plan of the cluster. The plan lists all configuration steps the operator will execute once the plan is approved.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeClient, err := getKubeClient(o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			for _, clusterName := range args {
+				plan, err := getConfigurationPlan(kubeClient, clusterName, namespace)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(plan)
+			}
+
+			return nil
+		},
+		Example: `
+# Get the pending configuration plan from cluster c1
+kubectl fdb get configuration-plan c1
+
+# Get the pending configuration plan from cluster c1 in the namespace default
+kubectl fdb -n default get configuration-plan c1
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newApproveConfigurationPlanCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "configuration-plan",
+		Short: "Approve the pending configuration plan of the cluster.",
+		Long:  "Approve the pending configuration plan of the cluster. The operator will only execute the plan if the approved hash matches the hash of the plan.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			hash, err := cmd.Flags().GetString("hash")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			for _, clusterName := range args {
+				err = approveConfigurationPlan(kubeClient, clusterName, namespace, hash, wait)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Example: `
+# Approve the pending configuration plan of cluster c1
+kubectl fdb approve configuration-plan c1
+
+# Approve the pending configuration plan of cluster c1 only if the plan has not changed since it was reviewed
+kubectl fdb approve configuration-plan --hash <hash> c1
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().String("hash", "", "the hash of the reviewed configuration plan, the approval fails if the pending plan has a different hash")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getConfigurationPlan returns a human readable representation of the pending configuration plan.
+func getConfigurationPlan(kubeClient client.Client, clusterName string, namespace string) (string, error) {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	plan := cluster.Status.ConfigurationPlan
+	if plan == nil {
+		return fmt.Sprintf("No pending configuration plan for cluster %s/%s", namespace, clusterName), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Configuration plan: %s\n", plan.Hash))
+	sb.WriteString(fmt.Sprintf("Approved: %t\n", cluster.IsConfigurationPlanApproved()))
+	if plan.CreationTimestamp > 0 {
+		sb.WriteString(fmt.Sprintf("Created: %s\n", time.Unix(plan.CreationTimestamp, 0).UTC().Format(time.RFC3339)))
+	}
+	sb.WriteString("Steps:")
+	for idx, step := range plan.Steps {
+		state := "pending"
+		if idx < plan.CompletedSteps {
+			state = "completed"
+		}
+
+		sb.WriteString(fmt.Sprintf("\n%d. [%s] %s", idx+1, state, step))
+	}
+
+	return sb.String(), nil
+}
+
+// approveConfigurationPlan sets the approval annotation to the hash of the pending configuration plan.
+func approveConfigurationPlan(kubeClient client.Client, clusterName string, namespace string, hash string, wait bool) error {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return err
+	}
+
+	plan := cluster.Status.ConfigurationPlan
+	if plan == nil || plan.Hash == "" {
+		return fmt.Errorf("cluster %s/%s has no pending configuration plan", namespace, clusterName)
+	}
+
+	if hash != "" && hash != plan.Hash {
+		return fmt.Errorf("the pending configuration plan %s of cluster %s/%s doesn't match the provided hash %s", plan.Hash, namespace, clusterName, hash)
+	}
+
+	if cluster.IsConfigurationPlanApproved() {
+		return nil
+	}
+
+	if wait {
+		confirmed := confirmAction(fmt.Sprintf("Approve configuration plan %s of cluster %s/%s with the following steps:\n%s", plan.Hash, namespace, clusterName, strings.Join(plan.Steps, "\n")))
+		if !confirmed {
+			return fmt.Errorf("user aborted the approval")
+		}
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[fdbv1beta2.ApprovedConfigurationPlanAnnotation] = plan.Hash
+
+	return kubeClient.Patch(ctx.TODO(), cluster, patch)
+}
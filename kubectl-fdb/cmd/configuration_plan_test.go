@@ -0,0 +1,108 @@
+/*
+ * configuration_plan_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] configuration plan command", func() {
+	When("the cluster has no pending configuration plan", func() {
+		It("should report that no plan is pending", func() {
+			plan, err := getConfigurationPlan(k8sClient, clusterName, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan).To(Equal("No pending configuration plan for cluster test/test"))
+		})
+
+		It("should fail to approve the plan", func() {
+			Expect(approveConfigurationPlan(k8sClient, clusterName, namespace, "", false)).To(HaveOccurred())
+		})
+	})
+
+	When("the cluster has a pending configuration plan", func() {
+		BeforeEach(func() {
+			cluster.Status.ConfigurationPlan = &fdbv1beta2.ConfigurationPlan{
+				Hash: "abc",
+				Steps: []string{
+					"double ssd-2 usable_regions=1",
+					"double ssd-2 usable_regions=2",
+				},
+				CompletedSteps: 1,
+			}
+		})
+
+		It("should print the plan", func() {
+			plan, err := getConfigurationPlan(k8sClient, clusterName, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan).To(Equal(`Configuration plan: abc
+Approved: false
+Steps:
+1. [completed] double ssd-2 usable_regions=1
+2. [pending] double ssd-2 usable_regions=2`))
+		})
+
+		When("approving the plan", func() {
+			var hash string
+
+			BeforeEach(func() {
+				hash = ""
+			})
+
+			JustBeforeEach(func() {
+				Expect(approveConfigurationPlan(k8sClient, clusterName, namespace, hash, false)).To(Succeed())
+			})
+
+			It("should set the approval annotation", func() {
+				fetchedCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), fetchedCluster)).To(Succeed())
+				Expect(fetchedCluster.Annotations).To(HaveKeyWithValue(fdbv1beta2.ApprovedConfigurationPlanAnnotation, "abc"))
+				Expect(fetchedCluster.IsConfigurationPlanApproved()).To(BeTrue())
+			})
+
+			When("the hash matches the plan", func() {
+				BeforeEach(func() {
+					hash = "abc"
+				})
+
+				It("should set the approval annotation", func() {
+					fetchedCluster := &fdbv1beta2.FoundationDBCluster{}
+					Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), fetchedCluster)).To(Succeed())
+					Expect(fetchedCluster.IsConfigurationPlanApproved()).To(BeTrue())
+				})
+			})
+		})
+
+		When("the hash doesn't match the plan", func() {
+			It("should not approve the plan", func() {
+				Expect(approveConfigurationPlan(k8sClient, clusterName, namespace, "def", false)).To(HaveOccurred())
+
+				fetchedCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), fetchedCluster)).To(Succeed())
+				Expect(fetchedCluster.IsConfigurationPlanApproved()).To(BeFalse())
+			})
+		})
+	})
+})
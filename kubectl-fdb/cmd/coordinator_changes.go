@@ -0,0 +1,342 @@
+/*
+ * coordinator_changes.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newCoordinatorChangesCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "coordinator-changes",
+		Short: "Get the recent coordinator changes of the cluster.",
+		Long:  "Get the recent coordinator changes of the cluster, the newest change is listed last.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeClient, err := getKubeClient(o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			for _, clusterName := range args {
+				changes, err := getCoordinatorChanges(kubeClient, clusterName, namespace)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(changes)
+			}
+
+			return nil
+		},
+		Example: `
+# Get the recent coordinator changes of cluster c1
+kubectl fdb get coordinator-changes c1
+
+# Get the recent coordinator changes of cluster c1 in the namespace default
+kubectl fdb -n default get coordinator-changes c1
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newRollbackCoordinatorsCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "coordinators",
+		Short: "Roll back the coordinators of the cluster to a previous coordinator set.",
+		Long:  "Roll back the coordinators of the cluster to the coordinators that were used before the selected coordinator change. The rollback is only performed if all previous coordinators are reachable. The previous coordinators are pinned in the coordinator constraints of the cluster, so the operator doesn't select different coordinators afterwards.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			change, err := cmd.Flags().GetInt("change")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			connectionString, err := getRollbackConnectionString(cluster, change)
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			clientSet, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			pod, err := getRunningPod(kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			status, err := getStatus(config, clientSet, pod)
+			if err != nil {
+				return err
+			}
+
+			addresses, processGroupIDs, err := getReachableCoordinators(status, connectionString)
+			if err != nil {
+				return err
+			}
+
+			if wait {
+				if !confirmAction(fmt.Sprintf("Change the coordinators of cluster %s/%s to %s and pin them to the process groups %v", namespace, clusterName, strings.Join(addresses, " "), processGroupIDs)) {
+					return fmt.Errorf("user aborted the rollback")
+				}
+			}
+
+			// The coordinators are pinned before they are changed, otherwise the operator could select different
+			// coordinators in the meantime.
+			err = pinCoordinators(kubeClient, cluster, processGroupIDs)
+			if err != nil {
+				return err
+			}
+
+			_, stderr, err := executeCmd(config, clientSet, pod.Name, namespace, fmt.Sprintf("fdbcli --exec 'coordinators %s'", strings.Join(addresses, " ")))
+			if err != nil {
+				return fmt.Errorf("could not change coordinators: %s, %w", stderr.String(), err)
+			}
+
+			status, err = getStatus(config, clientSet, pod)
+			if err != nil {
+				return err
+			}
+
+			err = recordCoordinatorRollback(kubeClient, cluster, status.Cluster.ConnectionString, connectionString)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Changed coordinators of cluster %s/%s to %s\n", namespace, clusterName, strings.Join(addresses, " "))
+			cmd.Printf("The coordinators are pinned to the process groups %v, remove spec.coordinatorConstraints.pinnedCoordinators once the operator should select the coordinators again\n", processGroupIDs)
+
+			return nil
+		},
+		Example: `
+# Roll back the last coordinator change of cluster c1
+kubectl fdb rollback coordinators -c c1
+
+# Roll back to the coordinators before the second coordinator change listed by "kubectl fdb get coordinator-changes c1"
+kubectl fdb rollback coordinators -c c1 --change 2
+`,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "roll back the coordinators of the provided cluster.")
+	cmd.Flags().Int("change", 0, "the number of the coordinator change to roll back, as listed by \"kubectl fdb get coordinator-changes\". Defaults to the last change.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getCoordinatorChanges returns a human readable representation of the coordinator changes.
+func getCoordinatorChanges(kubeClient client.Client, clusterName string, namespace string) (string, error) {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	if len(cluster.Status.CoordinatorChanges) == 0 {
+		return fmt.Sprintf("No coordinator changes recorded for cluster %s/%s", namespace, clusterName), nil
+	}
+
+	changes := make([]string, 0, len(cluster.Status.CoordinatorChanges))
+	for idx, change := range cluster.Status.CoordinatorChanges {
+		changes = append(changes, fmt.Sprintf("%d. %s: %s -> %s (%s)", idx+1, time.Unix(change.Timestamp, 0).UTC().Format(time.RFC3339), change.PreviousConnectionString, change.ConnectionString, change.Reason))
+	}
+
+	return strings.Join(changes, "\n"), nil
+}
+
+// getRollbackConnectionString returns the connection string that was used before the provided coordinator change.
+// The changes are numbered starting with 1, if the change is 0 the last change will be used.
+func getRollbackConnectionString(cluster *fdbv1beta2.FoundationDBCluster, change int) (string, error) {
+	changes := cluster.Status.CoordinatorChanges
+	if len(changes) == 0 {
+		return "", fmt.Errorf("cluster %s/%s has no recorded coordinator changes", cluster.Namespace, cluster.Name)
+	}
+
+	if change == 0 {
+		change = len(changes)
+	}
+
+	if change < 0 || change > len(changes) {
+		return "", fmt.Errorf("coordinator change %d doesn't exist, cluster %s/%s has %d recorded coordinator changes", change, cluster.Namespace, cluster.Name, len(changes))
+	}
+
+	connectionString := changes[change-1].PreviousConnectionString
+	if connectionString == "" {
+		return "", fmt.Errorf("coordinator change %d has no previous connection string", change)
+	}
+
+	if connectionString == cluster.Status.ConnectionString {
+		return "", fmt.Errorf("cluster %s/%s is already using the connection string %s", cluster.Namespace, cluster.Name, connectionString)
+	}
+
+	return connectionString, nil
+}
+
+// getReachableCoordinators returns the coordinator addresses of the connection string and the process groups of those
+// coordinators if all of them are used by a reachable process that is not excluded.
+func getReachableCoordinators(status *fdbv1beta2.FoundationDBStatus, connectionString string) ([]string, []fdbv1beta2.ProcessGroupID, error) {
+	parsed, err := fdbv1beta2.ParseConnectionString(connectionString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reachable := map[string]fdbv1beta2.ProcessGroupID{}
+	for _, process := range status.Cluster.Processes {
+		if process.Excluded {
+			continue
+		}
+
+		addresses, err := fdbv1beta2.ParseProcessAddressesFromCmdline(process.CommandLine)
+		if err != nil {
+			continue
+		}
+
+		processGroupID := fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+		dnsName := process.Locality[fdbv1beta2.FDBLocalityDNSNameKey]
+		for _, address := range addresses {
+			reachable[address.String()] = processGroupID
+			if dnsName != "" {
+				reachable[fdbv1beta2.ProcessAddress{StringAddress: dnsName, Port: address.Port, Flags: address.Flags}.String()] = processGroupID
+			}
+		}
+	}
+
+	var unreachable []string
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(parsed.Coordinators))
+	for _, coordinator := range parsed.Coordinators {
+		processGroupID, ok := reachable[coordinator]
+		if !ok {
+			unreachable = append(unreachable, coordinator)
+			continue
+		}
+
+		if processGroupID == "" {
+			return nil, nil, fmt.Errorf("coordinator %s has no %s locality", coordinator, fdbv1beta2.FDBLocalityInstanceIDKey)
+		}
+
+		processGroupIDs = append(processGroupIDs, processGroupID)
+	}
+
+	if len(unreachable) > 0 {
+		return nil, nil, fmt.Errorf("coordinators are not reachable: %s", strings.Join(unreachable, ", "))
+	}
+
+	return parsed.Coordinators, processGroupIDs, nil
+}
+
+// pinCoordinators pins the coordinators of the cluster to the provided process groups, so the operator keeps those
+// coordinators.
+func pinCoordinators(kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, processGroupIDs []fdbv1beta2.ProcessGroupID) error {
+	if len(processGroupIDs) != cluster.DesiredCoordinatorCount() {
+		return fmt.Errorf("cannot pin %d coordinators, cluster %s/%s requires %d coordinators", len(processGroupIDs), cluster.Namespace, cluster.Name, cluster.DesiredCoordinatorCount())
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Spec.CoordinatorConstraints.PinnedCoordinators = processGroupIDs
+
+	return kubeClient.Patch(ctx.TODO(), cluster, patch)
+}
+
+// recordCoordinatorRollback records the rollback in the coordinator changes and updates the connection string of the
+// cluster. If the operator already recorded the new connection string, the rollback will not be recorded again.
+func recordCoordinatorRollback(kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, newConnectionString string, rollbackConnectionString string) error {
+	err := kubeClient.Get(ctx.TODO(), client.ObjectKeyFromObject(cluster), cluster)
+	if err != nil {
+		return err
+	}
+
+	if cluster.Status.ConnectionString == newConnectionString {
+		return nil
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Status.AddCoordinatorChange(fdbv1beta2.CoordinatorChange{
+		Timestamp:                time.Now().Unix(),
+		PreviousConnectionString: cluster.Status.ConnectionString,
+		ConnectionString:         newConnectionString,
+		Reason:                   fmt.Sprintf("Rollback to the coordinators of %s", rollbackConnectionString),
+	})
+	cluster.Status.ConnectionString = newConnectionString
+
+	return kubeClient.Status().Patch(ctx.TODO(), cluster, patch)
+}
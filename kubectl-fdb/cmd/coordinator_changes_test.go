@@ -0,0 +1,190 @@
+/*
+ * coordinator_changes_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] coordinator changes command", func() {
+	When("the cluster has no coordinator changes", func() {
+		It("should report that no changes are recorded", func() {
+			changes, err := getCoordinatorChanges(k8sClient, clusterName, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal("No coordinator changes recorded for cluster test/test"))
+		})
+
+		It("should not return a connection string for the rollback", func() {
+			_, err := getRollbackConnectionString(cluster, 0)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the cluster has coordinator changes", func() {
+		BeforeEach(func() {
+			cluster.Status.ConnectionString = "test:gen3@1.1.1.3:4501"
+			cluster.Status.CoordinatorChanges = []fdbv1beta2.CoordinatorChange{
+				{
+					Timestamp:                0,
+					PreviousConnectionString: "test:gen1@1.1.1.1:4501",
+					ConnectionString:         "test:gen2@1.1.1.2:4501",
+					Reason:                   "Coordinators are unhealthy or excluded: 1.1.1.1:4501",
+				},
+				{
+					Timestamp:                60,
+					PreviousConnectionString: "test:gen2@1.1.1.2:4501",
+					ConnectionString:         "test:gen3@1.1.1.3:4501",
+					Reason:                   "Coordinators are unhealthy or excluded: 1.1.1.2:4501",
+				},
+			}
+		})
+
+		It("should print the changes", func() {
+			changes, err := getCoordinatorChanges(k8sClient, clusterName, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal(`1. 1970-01-01T00:00:00Z: test:gen1@1.1.1.1:4501 -> test:gen2@1.1.1.2:4501 (Coordinators are unhealthy or excluded: 1.1.1.1:4501)
+2. 1970-01-01T00:01:00Z: test:gen2@1.1.1.2:4501 -> test:gen3@1.1.1.3:4501 (Coordinators are unhealthy or excluded: 1.1.1.2:4501)`))
+		})
+
+		DescribeTable("getting the connection string for the rollback", func(change int, expected string, expectedErr bool) {
+			connectionString, err := getRollbackConnectionString(cluster, change)
+			if expectedErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(connectionString).To(Equal(expected))
+		},
+			Entry("the last change", 0, "test:gen2@1.1.1.2:4501", false),
+			Entry("the first change", 1, "test:gen1@1.1.1.1:4501", false),
+			Entry("a change that doesn't exist", 3, "", true),
+			Entry("a negative change", -1, "", true),
+		)
+
+		When("pinning the coordinators", func() {
+			It("should pin the coordinators in the cluster spec", func() {
+				processGroupIDs := []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "storage-3"}
+				Expect(pinCoordinators(k8sClient, cluster, processGroupIDs)).To(Succeed())
+
+				fetchedCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), fetchedCluster)).To(Succeed())
+				Expect(fetchedCluster.Spec.CoordinatorConstraints.PinnedCoordinators).To(Equal(processGroupIDs))
+			})
+
+			It("should not pin a different number of coordinators", func() {
+				Expect(pinCoordinators(k8sClient, cluster, []fdbv1beta2.ProcessGroupID{"storage-1"})).To(MatchError("cannot pin 1 coordinators, cluster test/test requires 3 coordinators"))
+			})
+		})
+
+		When("recording a rollback", func() {
+			JustBeforeEach(func() {
+				Expect(recordCoordinatorRollback(k8sClient, cluster, "test:gen4@1.1.1.2:4501", "test:gen2@1.1.1.2:4501")).To(Succeed())
+			})
+
+			It("should update the connection string and record the change", func() {
+				fetchedCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), fetchedCluster)).To(Succeed())
+				Expect(fetchedCluster.Status.ConnectionString).To(Equal("test:gen4@1.1.1.2:4501"))
+				Expect(fetchedCluster.Status.CoordinatorChanges).To(HaveLen(3))
+				change := fetchedCluster.Status.CoordinatorChanges[2]
+				Expect(change.PreviousConnectionString).To(Equal("test:gen3@1.1.1.3:4501"))
+				Expect(change.ConnectionString).To(Equal("test:gen4@1.1.1.2:4501"))
+				Expect(change.Reason).To(Equal("Rollback to the coordinators of test:gen2@1.1.1.2:4501"))
+			})
+
+			When("the operator already recorded the new connection string", func() {
+				BeforeEach(func() {
+					cluster.Status.ConnectionString = "test:gen4@1.1.1.2:4501"
+				})
+
+				It("should not record the change again", func() {
+					fetchedCluster := &fdbv1beta2.FoundationDBCluster{}
+					Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), fetchedCluster)).To(Succeed())
+					Expect(fetchedCluster.Status.CoordinatorChanges).To(HaveLen(2))
+				})
+			})
+		})
+	})
+
+	When("checking if the coordinators are reachable", func() {
+		var status *fdbv1beta2.FoundationDBStatus
+
+		BeforeEach(func() {
+			status = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": {
+							CommandLine: "/usr/bin/fdbserver --public_address=1.1.1.1:4501",
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+							},
+						},
+						"2": {
+							CommandLine: "/usr/bin/fdbserver --public_address=1.1.1.2:4501",
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-2",
+								fdbv1beta2.FDBLocalityDNSNameKey:    "storage-2.test.svc.cluster.local",
+							},
+						},
+						"3": {
+							CommandLine: "/usr/bin/fdbserver --public_address=1.1.1.3:4501",
+							Excluded:    true,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-3",
+							},
+						},
+						"4": {
+							CommandLine: "/usr/bin/fdbserver --public_address=1.1.1.4:4501",
+						},
+					},
+				},
+			}
+		})
+
+		It("should return the addresses and process groups if all coordinators are reachable", func() {
+			addresses, processGroupIDs, err := getReachableCoordinators(status, "test:gen1@1.1.1.1:4501,storage-2.test.svc.cluster.local:4501")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addresses).To(ConsistOf("1.1.1.1:4501", "storage-2.test.svc.cluster.local:4501"))
+			Expect(processGroupIDs).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1"), fdbv1beta2.ProcessGroupID("storage-2")))
+		})
+
+		It("should return an error if a coordinator is excluded", func() {
+			_, _, err := getReachableCoordinators(status, "test:gen1@1.1.1.1:4501,1.1.1.3:4501")
+			Expect(err).To(MatchError("coordinators are not reachable: 1.1.1.3:4501"))
+		})
+
+		It("should return an error if a coordinator is missing", func() {
+			_, _, err := getReachableCoordinators(status, "test:gen1@1.1.1.1:4501,1.1.1.5:4501")
+			Expect(err).To(MatchError("coordinators are not reachable: 1.1.1.5:4501"))
+		})
+
+		It("should return an error if a coordinator has no process group ID", func() {
+			_, _, err := getReachableCoordinators(status, "test:gen1@1.1.1.1:4501,1.1.1.4:4501")
+			Expect(err).To(MatchError("coordinator 1.1.1.4:4501 has no instance_id locality"))
+		})
+	})
+})
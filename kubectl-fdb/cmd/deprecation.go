@@ -25,7 +25,6 @@ import (
 	"fmt"
 	"strings"
 
-	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/cobra"
@@ -111,9 +110,7 @@ kubectl fdb deprecation --show-cluster-spec`,
 }
 
 func checkDeprecation(cmd *cobra.Command, kubeClient client.Client, inputClusters []string, namespace string, deprecationOptions internal.DeprecationOptions, showClusterSpec bool) error {
-	clusters := &fdbv1beta2.FoundationDBClusterList{}
-
-	err := kubeClient.List(context.Background(), clusters, client.InNamespace(namespace))
+	clusters, err := internal.ListClusters(context.Background(), kubeClient, client.InNamespace(namespace))
 	if err != nil {
 		return err
 	}
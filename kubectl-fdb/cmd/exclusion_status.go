@@ -87,8 +87,25 @@ func newExclusionStatusCmd(streams genericclioptions.IOStreams) *cobra.Command {
 				return fmt.Errorf("no running Pods are found for cluster: %s/%s", cluster.Namespace, cluster.Name)
 			}
 
+			kubeContexts, err := getKubeContexts(cmd, o)
+			if err != nil {
+				return err
+			}
+
+			// The machine-readable status contains the processes of all clusters sharing the connection string, so
+			// the contexts are only used to show which cluster a process belongs to.
+			var owners map[string]string
+			if len(kubeContexts) > 0 {
+				discoveredClusters, err := discoverClusters(kubeContexts, cluster)
+				if err != nil {
+					return err
+				}
+
+				owners = getProcessGroupOwners(discoveredClusters)
+			}
+
 			// TODO get the pod randomly
-			err = getExclusionStatus(cmd, config, clientSet, pods.Items[0].Name, namespace, ignoreFullyExcluded, interval)
+			err = getExclusionStatus(cmd, config, clientSet, pods.Items[0].Name, namespace, ignoreFullyExcluded, interval, owners)
 			if err != nil {
 				return err
 			}
@@ -110,6 +127,9 @@ kubectl fdb get exclusion-status c1 --ignore-fully-excluded=false
 
 # Get the exclusion status for cluster c1 and updates the data every 5 minutes
 kubectl fdb get exclusion-status c1 --interval=5m
+
+# Get the exclusion status for cluster c1 and show the cluster and context of every process for all clusters that share the connection string with c1 in the contexts "dc1", "dc2" and "dc3"
+kubectl fdb get exclusion-status c1 --contexts=dc1,dc2,dc3
 `,
 	}
 	cmd.SetOut(o.Out)
@@ -130,7 +150,21 @@ type exclusionResult struct {
 	estimate    string
 }
 
-func getExclusionStatus(cmd *cobra.Command, restConfig *rest.Config, kubeClient *kubernetes.Clientset, clientPod string, namespace string, ignoreFullyExcluded bool, interval time.Duration) error {
+// getProcessGroupOwners returns the cluster and context for every process group of the provided clusters.
+func getProcessGroupOwners(clusters []clusterInContext) map[string]string {
+	owners := map[string]string{}
+	for _, discoveredCluster := range clusters {
+		for _, processGroup := range discoveredCluster.cluster.Status.ProcessGroups {
+			owners[string(processGroup.ProcessGroupID)] = discoveredCluster.String()
+		}
+	}
+
+	return owners
+}
+
+// getExclusionStatus prints the exclusion status until all processes are fully excluded. If owners is not empty the
+// cluster and context of a process will be printed too.
+func getExclusionStatus(cmd *cobra.Command, restConfig *rest.Config, kubeClient *kubernetes.Clientset, clientPod string, namespace string, ignoreFullyExcluded bool, interval time.Duration, owners map[string]string) error {
 	timer := time.NewTicker(interval)
 	previousRun := map[string]int{}
 
@@ -167,11 +201,15 @@ func getExclusionStatus(cmd *cobra.Command, restConfig *rest.Config, kubeClient
 				continue
 			}
 
+			instance := process.Locality["instance_id"]
+			if owner, ok := owners[instance]; ok {
+				instance = fmt.Sprintf("%s (%s)", instance, owner)
+			}
+
 			if !ignoreFullyExcluded && len(process.Roles) == 0 {
-				cmd.Println(process.Locality["instance_id"], "is fully excluded")
+				cmd.Println(instance, "is fully excluded")
 			}
 
-			instance := process.Locality["instance_id"]
 			// TODO: Add estimate when an exclusion is done
 			// TODO: Add progress bars
 			for _, role := range process.Roles {
@@ -28,6 +28,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -162,7 +163,8 @@ func buildClusterFileUpdateCommands(cluster *fdbv1beta2.FoundationDBCluster, kub
 }
 
 // updateIPsInConnectionString updates the connection string in the cluster
-// status by replacing old coordinator IPs with the latest IPs.
+// status by replacing old coordinator IPs with the latest IPs. A changed
+// connection string is recorded in the coordinator changes.
 func updateIPsInConnectionString(cluster *fdbv1beta2.FoundationDBCluster) error {
 	connectionString, err := fdbv1beta2.ParseConnectionString(cluster.Status.ConnectionString)
 	if err != nil {
@@ -188,6 +190,16 @@ func updateIPsInConnectionString(cluster *fdbv1beta2.FoundationDBCluster) error
 		}
 	}
 	connectionString.Coordinators = newCoordinators
+	if connectionString.String() == cluster.Status.ConnectionString {
+		return nil
+	}
+
+	cluster.Status.AddCoordinatorChange(fdbv1beta2.CoordinatorChange{
+		Timestamp:                time.Now().Unix(),
+		PreviousConnectionString: cluster.Status.ConnectionString,
+		ConnectionString:         connectionString.String(),
+		Reason:                   "Updated the coordinator IP addresses with kubectl fdb fix-coordinator-ips",
+	})
 	cluster.Status.ConnectionString = connectionString.String()
 
 	return nil
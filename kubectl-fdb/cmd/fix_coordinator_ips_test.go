@@ -156,6 +156,7 @@ var _ = Describe("[plugin] fix-coordinator-ips command", func() {
 						}
 					}
 				}
+				originalConnectionString := cluster.Status.ConnectionString
 				err := updateIPsInConnectionString(cluster)
 
 				if input.ExpectedError != "" {
@@ -164,6 +165,13 @@ var _ = Describe("[plugin] fix-coordinator-ips command", func() {
 				} else {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(cluster.Status.ConnectionString).To(Equal(input.ExpectedConnectionString))
+					if input.ExpectedConnectionString == originalConnectionString {
+						Expect(cluster.Status.CoordinatorChanges).To(BeEmpty())
+					} else {
+						Expect(cluster.Status.CoordinatorChanges).To(HaveLen(1))
+						Expect(cluster.Status.CoordinatorChanges[0].PreviousConnectionString).To(Equal(originalConnectionString))
+						Expect(cluster.Status.CoordinatorChanges[0].ConnectionString).To(Equal(input.ExpectedConnectionString))
+					}
 				}
 			},
 			Entry("healthy cluster",
@@ -42,6 +42,15 @@ kubectl fdb get configuration c1
 
 # Get the configuration string from cluster c1 in the namespace default
 kubectl fdb -n default get configuration c1
+
+# Get the process groups from cluster c1
+kubectl fdb get process-groups c1
+
+# Get the pending configuration plan from cluster c1
+kubectl fdb get configuration-plan c1
+
+# Get the recent coordinator changes from cluster c1
+kubectl fdb get coordinator-changes c1
 `,
 	}
 	cmd.SetOut(o.Out)
@@ -49,7 +58,10 @@ kubectl fdb -n default get configuration c1
 	cmd.SetIn(o.In)
 
 	cmd.AddCommand(newConfigurationCmd(streams))
+	cmd.AddCommand(newConfigurationPlanCmd(streams))
+	cmd.AddCommand(newCoordinatorChangesCmd(streams))
 	cmd.AddCommand(newExclusionStatusCmd(streams))
+	cmd.AddCommand(newProcessGroupsCmd(streams))
 	o.configFlags.AddFlags(cmd.Flags())
 
 	return cmd
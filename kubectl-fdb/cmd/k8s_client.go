@@ -51,6 +51,11 @@ func getKubeClient(o *fdbBOptions) (client.Client, error) {
 		return nil, err
 	}
 
+	return getKubeClientForConfig(config)
+}
+
+// getKubeClientForConfig returns a client for the provided rest config.
+func getKubeClientForConfig(config *rest.Config) (client.Client, error) {
 	scheme := runtime.NewScheme()
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(fdbv1beta1.AddToScheme(scheme))
@@ -85,8 +90,7 @@ func getOperator(kubeClient client.Client, operatorName string, namespace string
 }
 
 func loadCluster(kubeClient client.Client, namespace string, clusterName string) (*fdbv1beta2.FoundationDBCluster, error) {
-	cluster := &fdbv1beta2.FoundationDBCluster{}
-	err := kubeClient.Get(ctx.Background(), types.NamespacedName{Namespace: namespace, Name: clusterName}, cluster)
+	cluster, err := internal.GetCluster(ctx.Background(), kubeClient, types.NamespacedName{Namespace: namespace, Name: clusterName})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,299 @@
+/*
+ * multi_cluster.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"fmt"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// kubeContext contains the clients to interact with a single Kubernetes context.
+type kubeContext struct {
+	name       string
+	kubeClient client.Client
+	restConfig *rest.Config
+	clientSet  *kubernetes.Clientset
+}
+
+// clusterInContext represents a FoundationDBCluster resource in a specific Kubernetes context.
+type clusterInContext struct {
+	kubeContext *kubeContext
+	cluster     *fdbv1beta2.FoundationDBCluster
+}
+
+// String returns a human readable representation of the cluster and its context.
+func (c clusterInContext) String() string {
+	return fmt.Sprintf("%s/%s in context %s", c.cluster.Namespace, c.cluster.Name, c.kubeContext.name)
+}
+
+// getKubeContexts returns the Kubernetes contexts that are defined with the contexts flag. The current context will
+// always be the first context. If the contexts flag is not set, nil will be returned.
+func getKubeContexts(cmd *cobra.Command, o *fdbBOptions) ([]*kubeContext, error) {
+	contextNames, err := cmd.Root().Flags().GetStringSlice("contexts")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contextNames) == 0 {
+		return nil, nil
+	}
+
+	currentContext := pointer.StringDeref(o.configFlags.Context, "")
+	if currentContext == "" {
+		rawConfig, err := o.configFlags.ToRawKubeConfigLoader().RawConfig()
+		if err != nil {
+			return nil, err
+		}
+		currentContext = rawConfig.CurrentContext
+	}
+
+	contextSet := map[string]fdbv1beta2.None{}
+	kubeContexts := make([]*kubeContext, 0, len(contextNames)+1)
+	for _, contextName := range append([]string{currentContext}, contextNames...) {
+		if _, ok := contextSet[contextName]; ok {
+			continue
+		}
+		contextSet[contextName] = fdbv1beta2.None{}
+
+		kubeCtx, err := getKubeContext(o, contextName)
+		if err != nil {
+			return nil, fmt.Errorf("could not create clients for context %s: %w", contextName, err)
+		}
+
+		kubeContexts = append(kubeContexts, kubeCtx)
+	}
+
+	return kubeContexts, nil
+}
+
+// getKubeContext creates the clients for the provided Kubernetes context.
+func getKubeContext(o *fdbBOptions, contextName string) (*kubeContext, error) {
+	configFlags := genericclioptions.NewConfigFlags(true)
+	configFlags.KubeConfig = o.configFlags.KubeConfig
+	configFlags.Context = &contextName
+
+	restConfig, err := configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClient, err := getKubeClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubeContext{
+		name:       contextName,
+		kubeClient: kubeClient,
+		restConfig: restConfig,
+		clientSet:  clientSet,
+	}, nil
+}
+
+// getConnectionStringIDs returns the description and ID of the connection strings of the cluster. The coordinators are
+// ignored as they can differ if a cluster is not yet updated.
+func getConnectionStringIDs(cluster *fdbv1beta2.FoundationDBCluster) map[string]fdbv1beta2.None {
+	ids := map[string]fdbv1beta2.None{}
+	for _, connectionString := range []string{cluster.Status.ConnectionString, cluster.Spec.SeedConnectionString} {
+		if connectionString == "" {
+			continue
+		}
+
+		ids[strings.Split(connectionString, "@")[0]] = fdbv1beta2.None{}
+	}
+
+	return ids
+}
+
+// discoverClusters returns all FoundationDBCluster resources in the provided contexts that share a connection string
+// with the provided cluster and are therefore part of the same FDB cluster.
+func discoverClusters(kubeContexts []*kubeContext, reference *fdbv1beta2.FoundationDBCluster) ([]clusterInContext, error) {
+	referenceIDs := getConnectionStringIDs(reference)
+	if len(referenceIDs) == 0 {
+		return nil, fmt.Errorf("cluster %s/%s has no connection string", reference.Namespace, reference.Name)
+	}
+
+	var clusters []clusterInContext
+	for _, kubeCtx := range kubeContexts {
+		clusterList := &fdbv1beta2.FoundationDBClusterList{}
+		err := kubeCtx.kubeClient.List(ctx.Background(), clusterList)
+		if err != nil {
+			return nil, fmt.Errorf("could not list clusters in context %s: %w", kubeCtx.name, err)
+		}
+
+		for _, candidate := range clusterList.Items {
+			var shared bool
+			for id := range getConnectionStringIDs(&candidate) {
+				if _, ok := referenceIDs[id]; ok {
+					shared = true
+					break
+				}
+			}
+
+			if !shared {
+				continue
+			}
+
+			cluster, err := loadCluster(kubeCtx.kubeClient, candidate.Namespace, candidate.Name)
+			if err != nil {
+				return nil, fmt.Errorf("could not load cluster %s/%s in context %s: %w", candidate.Namespace, candidate.Name, kubeCtx.name, err)
+			}
+
+			clusters = append(clusters, clusterInContext{kubeContext: kubeCtx, cluster: cluster})
+		}
+	}
+
+	if len(clusters) == 0 {
+		return nil, fmt.Errorf("could not find any cluster sharing the connection string of %s/%s", reference.Namespace, reference.Name)
+	}
+
+	return clusters, nil
+}
+
+// getProcessGroupIDsForCluster returns the IDs that belong to the provided cluster. If useProcessGroupID is false, the
+// IDs are interpreted as Pod names.
+func getProcessGroupIDsForCluster(cluster *fdbv1beta2.FoundationDBCluster, ids []string, useProcessGroupID bool) []string {
+	matching := make([]string, 0, len(ids))
+	for _, id := range ids {
+		processGroupID := fdbv1beta2.ProcessGroupID(id)
+		if !useProcessGroupID {
+			if !strings.HasPrefix(id, cluster.Name+"-") {
+				continue
+			}
+
+			processGroupIDs, err := getProcessGroupIDsFromPodName(cluster, []string{id})
+			if err != nil || len(processGroupIDs) == 0 {
+				continue
+			}
+
+			processGroupID = processGroupIDs[0]
+		}
+
+		if fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID) == nil {
+			continue
+		}
+
+		matching = append(matching, id)
+	}
+
+	return matching
+}
+
+// getPodNamesForCluster returns the Pod names that belong to the provided cluster.
+func getPodNamesForCluster(kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, podNames []string) ([]string, error) {
+	pods, err := getPodsForCluster(kubeClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	podSet := make(map[string]fdbv1beta2.None, len(pods.Items))
+	for _, pod := range pods.Items {
+		podSet[pod.Name] = fdbv1beta2.None{}
+	}
+
+	matching := make([]string, 0, len(podNames))
+	for _, podName := range podNames {
+		if _, ok := podSet[podName]; ok {
+			matching = append(matching, podName)
+		}
+	}
+
+	return matching, nil
+}
+
+// splitQualifiedID splits an ID of the form <context or cluster name>/<ID> into the qualifier and the ID. If the ID
+// is not qualified the qualifier will be empty. Process group IDs and Pod names never contain a slash, so the last
+// slash separates the qualifier from the ID.
+func splitQualifiedID(id string) (string, string) {
+	idx := strings.LastIndex(id, "/")
+	if idx == -1 {
+		return "", id
+	}
+
+	return id[:idx], id[idx+1:]
+}
+
+// assignIDsToClusters returns the IDs that belong to each of the provided clusters, using the match function to
+// filter the IDs for a cluster. The returned IDs are not qualified. An error will be returned if an ID doesn't belong to
+// any cluster or if an unqualified ID belongs to multiple clusters. IDs can be qualified with the context or the name
+// of the cluster, e.g. dc1/storage-1.
+func assignIDsToClusters(clusters []clusterInContext, ids []string, reference string, match func(clusterInContext, []string) ([]string, error)) ([][]string, error) {
+	owners := make(map[string][]string, len(ids))
+	idsPerCluster := make([][]string, len(clusters))
+	for idx, discoveredCluster := range clusters {
+		candidates := map[string]string{}
+		unqualifiedIDs := make([]string, 0, len(ids))
+		for _, id := range ids {
+			qualifier, unqualifiedID := splitQualifiedID(id)
+			if qualifier != "" && qualifier != discoveredCluster.kubeContext.name && qualifier != discoveredCluster.cluster.Name {
+				continue
+			}
+
+			candidates[unqualifiedID] = id
+			unqualifiedIDs = append(unqualifiedIDs, unqualifiedID)
+		}
+
+		matching, err := match(discoveredCluster, unqualifiedIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, unqualifiedID := range matching {
+			id := candidates[unqualifiedID]
+			owners[id] = append(owners[id], discoveredCluster.String())
+		}
+
+		idsPerCluster[idx] = matching
+	}
+
+	var unmatched []string
+	for _, id := range ids {
+		clusterOwners := owners[id]
+		if len(clusterOwners) > 1 {
+			return nil, fmt.Errorf("%s matches multiple clusters: %s, please prefix it with the context or the cluster name, e.g. <context>/%s", id, strings.Join(clusterOwners, ", "), id)
+		}
+
+		if len(clusterOwners) == 0 {
+			unmatched = append(unmatched, id)
+		}
+	}
+
+	// Make sure all provided IDs are known before changing any cluster.
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("could not find %v in any cluster sharing the connection string of %s", unmatched, reference)
+	}
+
+	return idsPerCluster, nil
+}
@@ -0,0 +1,194 @@
+/*
+ * multi_cluster_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	mockclient "github.com/FoundationDB/fdb-kubernetes-operator/mock-kubernetes-client/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("[plugin] multi cluster support", func() {
+	var secondClient *mockclient.MockClient
+	var kubeContexts []*kubeContext
+
+	BeforeEach(func() {
+		cluster.Status.ConnectionString = "test:abc@127.0.0.1:4501"
+		secondClient = mockclient.NewMockClientWithHooksAndIndexes(scheme.Scheme, nil, nil, true)
+		kubeContexts = []*kubeContext{
+			{name: "dc1", kubeClient: k8sClient},
+			{name: "dc2", kubeClient: secondClient},
+		}
+	})
+
+	JustBeforeEach(func() {
+		secondCluster := generateClusterStruct("test-dc2", "dc2")
+		secondCluster.Spec.ProcessGroupIDPrefix = "dc2"
+		secondCluster.Spec.SeedConnectionString = "test:abc@127.0.0.2:4501"
+		secondCluster.Status.ProcessGroups = []*fdbv1beta2.ProcessGroupStatus{
+			{ProcessGroupID: "dc2-storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage},
+		}
+		Expect(secondClient.Create(context.TODO(), secondCluster)).To(Succeed())
+
+		otherCluster := generateClusterStruct("other", "dc2")
+		otherCluster.Status.ConnectionString = "other:xyz@127.0.0.3:4501"
+		Expect(secondClient.Create(context.TODO(), otherCluster)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		secondClient.Clear()
+	})
+
+	When("discovering the clusters", func() {
+		It("should return all clusters sharing the connection string", func() {
+			clusters, err := discoverClusters(kubeContexts, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters).To(HaveLen(2))
+			Expect(clusters[0].String()).To(Equal("test/test in context dc1"))
+			Expect(clusters[1].String()).To(Equal("dc2/test-dc2 in context dc2"))
+		})
+
+		When("the cluster has no connection string", func() {
+			BeforeEach(func() {
+				cluster.Status.ConnectionString = ""
+			})
+
+			It("should return an error", func() {
+				_, err := discoverClusters(kubeContexts, cluster)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	When("getting the process groups for a cluster", func() {
+		var testCluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			testCluster = &fdbv1beta2.FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-dc2"},
+				Spec:       fdbv1beta2.FoundationDBClusterSpec{ProcessGroupIDPrefix: "dc2"},
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+						{ProcessGroupID: "dc2-storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage},
+					},
+				},
+			}
+		})
+
+		It("should return the matching process group IDs", func() {
+			Expect(getProcessGroupIDsForCluster(testCluster, []string{"dc2-storage-1", "dc1-storage-1"}, true)).To(ConsistOf("dc2-storage-1"))
+		})
+
+		It("should return the matching Pod names", func() {
+			Expect(getProcessGroupIDsForCluster(testCluster, []string{"test-dc2-storage-1", "test-storage-1"}, false)).To(ConsistOf("test-dc2-storage-1"))
+		})
+	})
+
+	When("getting the owners of the process groups", func() {
+		It("should return the cluster and context of every process group", func() {
+			clusters, err := discoverClusters(kubeContexts, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			owners := getProcessGroupOwners(clusters)
+			Expect(owners).To(HaveKeyWithValue("dc2-storage-1", "dc2/test-dc2 in context dc2"))
+			Expect(owners).To(HaveKeyWithValue(clusterName+"-instance-1", "test/test in context dc1"))
+		})
+	})
+
+	When("removing process groups across contexts", func() {
+		var out *bytes.Buffer
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+			cmd = &cobra.Command{}
+			cmd.SetOut(out)
+		})
+
+		It("should remove the process groups from the matching clusters", func() {
+			Expect(replaceProcessGroupsInContexts(cmd, k8sClient, kubeContexts, clusterName, []string{clusterName + "-instance-1", "dc2-storage-1"}, namespace, true, false, false, true)).To(Succeed())
+
+			updated, err := loadCluster(k8sClient, namespace, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.ProcessGroupsToRemove).To(ConsistOf(fdbv1beta2.ProcessGroupID(clusterName + "-instance-1")))
+
+			updated, err = loadCluster(secondClient, "dc2", "test-dc2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.ProcessGroupsToRemove).To(ConsistOf(fdbv1beta2.ProcessGroupID("dc2-storage-1")))
+			Expect(out.String()).To(ContainSubstring("in context dc2"))
+		})
+
+		When("the process group ID exists in multiple clusters", func() {
+			JustBeforeEach(func() {
+				thirdCluster := generateClusterStruct("test-dc3", "dc2")
+				thirdCluster.Spec.ProcessGroupIDPrefix = "dc2"
+				thirdCluster.Spec.SeedConnectionString = "test:abc@127.0.0.2:4501"
+				thirdCluster.Status.ProcessGroups = []*fdbv1beta2.ProcessGroupStatus{
+					{ProcessGroupID: "dc2-storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage},
+				}
+				Expect(secondClient.Create(context.TODO(), thirdCluster)).To(Succeed())
+			})
+
+			It("should return an error and not change any cluster", func() {
+				err := replaceProcessGroupsInContexts(cmd, k8sClient, kubeContexts, clusterName, []string{"dc2-storage-1"}, namespace, true, false, false, true)
+				Expect(err).To(MatchError(ContainSubstring("matches multiple clusters")))
+
+				updated, err := loadCluster(secondClient, "dc2", "test-dc2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.Spec.ProcessGroupsToRemove).To(BeEmpty())
+			})
+
+			It("should remove the process group from the cluster defined by the prefix", func() {
+				Expect(replaceProcessGroupsInContexts(cmd, k8sClient, kubeContexts, clusterName, []string{"test-dc3/dc2-storage-1"}, namespace, true, false, false, true)).To(Succeed())
+
+				updated, err := loadCluster(secondClient, "dc2", "test-dc2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.Spec.ProcessGroupsToRemove).To(BeEmpty())
+
+				updated, err = loadCluster(secondClient, "dc2", "test-dc3")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.Spec.ProcessGroupsToRemove).To(ConsistOf(fdbv1beta2.ProcessGroupID("dc2-storage-1")))
+			})
+		})
+
+		It("should remove the process group from the cluster in the context defined by the prefix", func() {
+			Expect(replaceProcessGroupsInContexts(cmd, k8sClient, kubeContexts, clusterName, []string{"dc2/dc2-storage-1"}, namespace, true, false, false, true)).To(Succeed())
+
+			updated, err := loadCluster(secondClient, "dc2", "test-dc2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.ProcessGroupsToRemove).To(ConsistOf(fdbv1beta2.ProcessGroupID("dc2-storage-1")))
+		})
+
+		It("should return an error if a process group is unknown", func() {
+			Expect(replaceProcessGroupsInContexts(cmd, k8sClient, kubeContexts, clusterName, []string{"dc3-storage-1"}, namespace, true, false, false, true)).NotTo(Succeed())
+
+			updated, err := loadCluster(k8sClient, namespace, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.ProcessGroupsToRemove).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,157 @@
+/*
+ * process_groups.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func newProcessGroupsCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:     "process-groups",
+		Aliases: []string{"process-group"},
+		Short:   "Get the process groups of a cluster.",
+		Long:    "Get the process groups of a cluster. If process group IDs are provided the details of those process groups will be printed.",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeClient, err := getKubeClient(o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			processGroups, err := getProcessGroups(kubeClient, args[0], namespace, args[1:])
+			if err != nil {
+				return err
+			}
+
+			if len(args) > 1 {
+				return describeProcessGroups(cmd.OutOrStdout(), processGroups)
+			}
+
+			return printProcessGroups(cmd.OutOrStdout(), processGroups)
+		},
+		Example: `
+# List all process groups of cluster c1
+kubectl fdb get process-groups c1
+
+# List all process groups of cluster c1 in the namespace default
+kubectl fdb -n default get process-groups c1
+
+# Show the details of the process groups storage-1 and storage-2 of cluster c1
+kubectl fdb get process-groups c1 storage-1 storage-2
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getProcessGroups returns the process groups of the cluster. If processGroupIDs are provided only the matching process
+// groups will be returned and an error is returned if any of those process groups doesn't exist.
+func getProcessGroups(kubeClient client.Client, clusterName string, namespace string, processGroupIDs []string) ([]*fdbv1beta2.ProcessGroupStatus, error) {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(processGroupIDs) == 0 {
+		return cluster.Status.ProcessGroups, nil
+	}
+
+	processGroupMap := make(map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.ProcessGroupStatus, len(cluster.Status.ProcessGroups))
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		processGroupMap[processGroup.ProcessGroupID] = processGroup
+	}
+
+	processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupIDs))
+	for _, id := range processGroupIDs {
+		processGroup, ok := processGroupMap[fdbv1beta2.ProcessGroupID(id)]
+		if !ok {
+			return nil, fmt.Errorf("could not find process group %s in cluster %s/%s", id, namespace, clusterName)
+		}
+
+		processGroups = append(processGroups, processGroup)
+	}
+
+	return processGroups, nil
+}
+
+// printProcessGroups prints a summary of the provided process groups as a table.
+func printProcessGroups(out io.Writer, processGroups []*fdbv1beta2.ProcessGroupStatus) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, err := fmt.Fprintln(writer, "ID\tCLASS\tFAULT DOMAIN\tADDRESSES\tMARKED FOR REMOVAL\tCONDITIONS")
+	if err != nil {
+		return err
+	}
+
+	for _, processGroup := range processGroups {
+		conditions := make([]string, 0, len(processGroup.ProcessGroupConditions))
+		for _, condition := range processGroup.ProcessGroupConditions {
+			conditions = append(conditions, string(condition.ProcessGroupConditionType))
+		}
+
+		_, err = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%t\t%s\n",
+			processGroup.ProcessGroupID,
+			processGroup.ProcessClass,
+			processGroup.FaultDomain,
+			strings.Join(processGroup.Addresses, ","),
+			processGroup.IsMarkedForRemoval(),
+			strings.Join(conditions, ","),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// describeProcessGroups prints the full information of the provided process groups in YAML.
+func describeProcessGroups(out io.Writer, processGroups []*fdbv1beta2.ProcessGroupStatus) error {
+	output, err := yaml.Marshal(processGroups)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(output)
+
+	return err
+}
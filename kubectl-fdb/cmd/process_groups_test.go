@@ -0,0 +1,131 @@
+/*
+ * process_groups_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("[plugin] process groups command", func() {
+	When("the process groups are stored in the cluster status", func() {
+		It("should return all process groups", func() {
+			processGroups, err := getProcessGroups(k8sClient, clusterName, namespace, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processGroups).To(HaveLen(2))
+		})
+
+		It("should return the requested process group", func() {
+			processGroups, err := getProcessGroups(k8sClient, clusterName, namespace, []string{clusterName + "-instance-2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processGroups).To(HaveLen(1))
+			Expect(processGroups[0].ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID(clusterName + "-instance-2")))
+		})
+
+		It("should return an error if the process group doesn't exist", func() {
+			_, err := getProcessGroups(k8sClient, clusterName, namespace, []string{"missing"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the process groups are stored in FoundationDBProcessGroup resources", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.UseProcessGroupResources = pointer.Bool(true)
+			cluster.Status.ProcessGroups = nil
+			processGroups := []*fdbv1beta2.ProcessGroupStatus{
+				fdbv1beta2.NewProcessGroupStatus("storage-1", fdbv1beta2.ProcessClassStorage, nil),
+				fdbv1beta2.NewProcessGroupStatus("storage-2", fdbv1beta2.ProcessClassStorage, nil),
+			}
+			processGroups[1].UpdateCondition(fdbv1beta2.PodFailing, true)
+
+			for _, processGroup := range processGroups {
+				resource := fdbv1beta2.NewFoundationDBProcessGroup(cluster, processGroup)
+				resource.ObjectMeta = internal.GetProcessGroupResourceMetadata(cluster, processGroup)
+				Expect(k8sClient.Create(context.TODO(), resource)).To(Succeed())
+			}
+		})
+
+		It("should return all process groups", func() {
+			processGroups, err := getProcessGroups(k8sClient, clusterName, namespace, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processGroups).To(HaveLen(2))
+		})
+
+		It("should make the process groups available to the other commands", func() {
+			loadedCluster, err := loadCluster(k8sClient, namespace, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loadedCluster.Status.ProcessGroups).To(HaveLen(2))
+			Expect(fdbv1beta2.FilterByCondition(loadedCluster.Status.ProcessGroups, fdbv1beta2.PodFailing, true)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-2")))
+		})
+
+		When("the cluster doesn't use process group resources", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.UseProcessGroupResources = nil
+			})
+
+			It("should not read the process group resources", func() {
+				processGroups, err := getProcessGroups(k8sClient, clusterName, namespace, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(processGroups).To(BeEmpty())
+			})
+		})
+	})
+
+	When("printing the process groups", func() {
+		var out *bytes.Buffer
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+		})
+
+		It("should print a table with all process groups", func() {
+			Expect(printProcessGroups(out, []*fdbv1beta2.ProcessGroupStatus{
+				{
+					ProcessGroupID: "storage-1",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+					Addresses:      []string{"1.1.1.1"},
+					FaultDomain:    "node-1",
+					ProcessGroupConditions: []*fdbv1beta2.ProcessGroupCondition{
+						fdbv1beta2.NewProcessGroupCondition(fdbv1beta2.MissingProcesses),
+					},
+				},
+			})).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("ID"))
+			Expect(out.String()).To(MatchRegexp(`storage-1\s+storage\s+node-1\s+1.1.1.1\s+false\s+MissingProcesses`))
+		})
+
+		It("should print the details of the process groups", func() {
+			Expect(describeProcessGroups(out, []*fdbv1beta2.ProcessGroupStatus{
+				{
+					ProcessGroupID: "storage-1",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				},
+			})).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("processGroupID: storage-1"))
+		})
+	})
+})
@@ -0,0 +1,383 @@
+/*
+ * recover_process_groups.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newRecoverProcessGroupsCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "process-groups",
+		Short: "Recovers suspended process groups of the given cluster",
+		Long:  "Recovers suspended process groups of the given cluster by removing the removal and suspension information and including the processes again",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, addresses, err := recoverProcessGroups(kubeClient, clusterName, namespace, args, wait)
+			if err != nil {
+				return err
+			}
+
+			if len(addresses) == 0 {
+				return nil
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			clientSet, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			pod, err := getRunningPod(kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			includeAddresses := make([]string, 0, len(addresses))
+			for _, address := range addresses {
+				includeAddresses = append(includeAddresses, address.String())
+			}
+
+			_, stderr, err := executeCmd(config, clientSet, pod.Name, namespace, fmt.Sprintf("fdbcli --exec 'include %s'", strings.Join(includeAddresses, " ")))
+			if err != nil {
+				return fmt.Errorf("could not include processes: %s, %w", stderr.String(), err)
+			}
+
+			cmd.Printf("Recovered process groups %v and included %v\n", args, includeAddresses)
+
+			return nil
+		},
+		Example: `
+# Recover the suspended process groups storage-1 and storage-2 of cluster c1
+kubectl fdb recover process-groups -c c1 storage-1 storage-2
+
+# Recover the suspended process groups storage-1 and storage-2 of cluster c1 in the namespace default
+kubectl fdb -n default recover process-groups -c c1 storage-1 storage-2
+`,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "recover process groups of the provided cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// recoverProcessGroups resets the removal and suspension information of the provided suspended process groups and
+// removes them from the removal lists of the cluster. If the operator already created replacements for the recovered
+// process groups, the surplus process groups will be added to the removal list. The returned addresses must be
+// included again.
+func recoverProcessGroups(kubeClient client.Client, clusterName string, namespace string, ids []string, wait bool) (*fdbv1beta2.FoundationDBCluster, []fdbv1beta2.ProcessAddress, error) {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(ids))
+	recoverSet := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(ids))
+	for _, id := range ids {
+		processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, fdbv1beta2.ProcessGroupID(id))
+		if processGroup == nil {
+			return nil, nil, fmt.Errorf("could not find process group %s in cluster %s/%s", id, namespace, clusterName)
+		}
+
+		if !processGroup.IsSuspended() {
+			return nil, nil, fmt.Errorf("process group %s in cluster %s/%s is not suspended", id, namespace, clusterName)
+		}
+
+		processGroups = append(processGroups, processGroup)
+		recoverSet[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+	}
+
+	surplus, err := getSurplusProcessGroups(kubeClient, cluster, recoverSet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if wait {
+		message := fmt.Sprintf("Recover %v from cluster %s/%s", ids, namespace, clusterName)
+		if len(surplus) > 0 {
+			message += fmt.Sprintf(" and remove the surplus process groups %v", surplus)
+		}
+
+		if !confirmAction(message) {
+			return nil, nil, fmt.Errorf("user aborted the recovery")
+		}
+	}
+
+	// Remove the process groups from the removal lists first, otherwise the operator would mark the process groups
+	// for removal again.
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Spec.ProcessGroupsToRemove = append(filterProcessGroupIDs(cluster.Spec.ProcessGroupsToRemove, recoverSet), surplus...)
+	cluster.Spec.ProcessGroupsToRemoveWithoutExclusion = filterProcessGroupIDs(cluster.Spec.ProcessGroupsToRemoveWithoutExclusion, recoverSet)
+
+	err = kubeClient.Patch(ctx.Background(), cluster, patch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addresses := make([]fdbv1beta2.ProcessAddress, 0, len(processGroups))
+	for _, processGroup := range processGroups {
+		if cluster.UseLocalitiesForExclusion() {
+			addresses = append(addresses, fdbv1beta2.ProcessAddress{StringAddress: processGroup.GetExclusionString()})
+		}
+
+		for _, address := range processGroup.Addresses {
+			addresses = append(addresses, fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP(address)})
+		}
+
+		err = removeSuspensionFromPVC(kubeClient, cluster, processGroup)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = recoverProcessGroupStatus(kubeClient, cluster, recoverSet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cluster, addresses, nil
+}
+
+// recoverProcessGroupStatus resets the removal and suspension information of the provided process groups. Depending
+// on the cluster setting the information is updated in the FoundationDBProcessGroup resources or in the cluster status.
+// Only the changes are patched, the patch of the cluster status will fail if the status was modified concurrently.
+func recoverProcessGroupStatus(kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, recoverSet map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None) error {
+	if cluster.UseProcessGroupResources() {
+		processGroupResources, err := internal.GetProcessGroupResources(ctx.Background(), kubeClient, cluster)
+		if err != nil {
+			return err
+		}
+
+		for _, processGroupResource := range processGroupResources {
+			if _, ok := recoverSet[processGroupResource.Spec.ProcessGroupID]; !ok {
+				continue
+			}
+
+			patch := client.MergeFrom(processGroupResource.DeepCopy())
+			processGroup := processGroupResource.GetProcessGroupStatus()
+			processGroup.Recover()
+			processGroupResource.SetFromProcessGroupStatus(processGroup)
+			err = kubeClient.Status().Patch(ctx.Background(), &processGroupResource, patch)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// The spec patch updated the cluster with the state from the API server, so the process groups must be recovered
+	// in the latest state.
+	patch := client.MergeFromWithOptions(cluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if _, ok := recoverSet[processGroup.ProcessGroupID]; !ok {
+			continue
+		}
+
+		processGroup.Recover()
+	}
+
+	return kubeClient.Status().Patch(ctx.Background(), cluster, patch)
+}
+
+// getSurplusProcessGroups returns the process groups that will exceed the desired process counts after the recovery of
+// the provided process groups, e.g. because the operator already created replacements for them. Process groups
+// without a Pod and process groups with the most recently created Pods will be chosen first as they are most likely the
+// replacements.
+func getSurplusProcessGroups(kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, recoverSet map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None) ([]fdbv1beta2.ProcessGroupID, error) {
+	desiredCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := getPodsForCluster(kubeClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	podCreation := make(map[string]time.Time, len(pods.Items))
+	for _, pod := range pods.Items {
+		podCreation[pod.Labels[cluster.GetProcessGroupIDLabel()]] = pod.CreationTimestamp.Time
+	}
+
+	removals := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(cluster.Spec.ProcessGroupsToRemove))
+	for _, processGroupID := range cluster.Spec.ProcessGroupsToRemove {
+		removals[processGroupID] = fdbv1beta2.None{}
+	}
+
+	recoveredCounts := map[fdbv1beta2.ProcessClass]int{}
+	activeProcessGroups := map[fdbv1beta2.ProcessClass][]*fdbv1beta2.ProcessGroupStatus{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if _, ok := recoverSet[processGroup.ProcessGroupID]; ok {
+			recoveredCounts[processGroup.ProcessClass]++
+			continue
+		}
+
+		if _, ok := removals[processGroup.ProcessGroupID]; ok || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		activeProcessGroups[processGroup.ProcessClass] = append(activeProcessGroups[processGroup.ProcessClass], processGroup)
+	}
+
+	desiredCountsMap := desiredCounts.Map()
+	var surplus []fdbv1beta2.ProcessGroupID
+	for processClass, recoveredCount := range recoveredCounts {
+		candidates := activeProcessGroups[processClass]
+		surplusCount := len(candidates) + recoveredCount - desiredCountsMap[processClass]
+		if surplusCount <= 0 {
+			continue
+		}
+
+		if surplusCount > len(candidates) {
+			surplusCount = len(candidates)
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			createdI, hasPodI := podCreation[string(candidates[i].ProcessGroupID)]
+			createdJ, hasPodJ := podCreation[string(candidates[j].ProcessGroupID)]
+			if hasPodI != hasPodJ {
+				return !hasPodI
+			}
+
+			if !createdI.Equal(createdJ) {
+				return createdI.After(createdJ)
+			}
+
+			return candidates[i].ProcessGroupID > candidates[j].ProcessGroupID
+		})
+
+		for _, processGroup := range candidates[:surplusCount] {
+			surplus = append(surplus, processGroup.ProcessGroupID)
+		}
+	}
+
+	sort.Slice(surplus, func(i, j int) bool {
+		return surplus[i] < surplus[j]
+	})
+
+	return surplus, nil
+}
+
+// removeSuspensionFromPVC removes the suspension label and annotation from the PVC of the process group if present.
+func removeSuspensionFromPVC(kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) error {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	err := kubeClient.List(ctx.Background(), pvcs, internal.GetSinglePodListOptions(cluster, processGroup.ProcessGroupID)...)
+	if err != nil {
+		return err
+	}
+
+	for _, pvc := range pvcs.Items {
+		_, hasLabel := pvc.Labels[fdbv1beta2.SuspendedLabel]
+		_, hasAnnotation := pvc.Annotations[fdbv1beta2.SuspensionExpiryAnnotation]
+		if !hasLabel && !hasAnnotation {
+			continue
+		}
+
+		patch := client.MergeFrom(pvc.DeepCopy())
+		delete(pvc.Labels, fdbv1beta2.SuspendedLabel)
+		delete(pvc.Annotations, fdbv1beta2.SuspensionExpiryAnnotation)
+		err = kubeClient.Patch(ctx.Background(), pvc.DeepCopy(), patch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filterProcessGroupIDs returns the provided process group IDs without the IDs in the filter set.
+func filterProcessGroupIDs(processGroupIDs []fdbv1beta2.ProcessGroupID, filter map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None) []fdbv1beta2.ProcessGroupID {
+	result := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		if _, ok := filter[processGroupID]; ok {
+			continue
+		}
+
+		result = append(result, processGroupID)
+	}
+
+	return result
+}
+
+// getRunningPod returns a running Pod of the cluster that can be used to run fdbcli commands.
+func getRunningPod(kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster) (*corev1.Pod, error) {
+	pods, err := getPodsForCluster(kubeClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp.IsZero() {
+			return pod.DeepCopy(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("no running Pods are found for cluster: %s/%s", cluster.Namespace, cluster.Name)
+}
@@ -0,0 +1,153 @@
+/*
+ * recover_process_groups_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] recover process groups command", func() {
+	var suspendedProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+	BeforeEach(func() {
+		suspendedProcessGroup = fdbv1beta2.NewProcessGroupStatus("storage-1", fdbv1beta2.ProcessClassStorage, []string{"1.1.1.1"})
+		suspendedProcessGroup.MarkForRemoval()
+		suspendedProcessGroup.SetExclude()
+		suspendedProcessGroup.SetSuspended()
+
+		cluster.Spec.Version = fdbv1beta2.Versions.Default.String()
+
+		cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{suspendedProcessGroup.ProcessGroupID, fdbv1beta2.ProcessGroupID(clusterName + "-instance-1")}
+		cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, suspendedProcessGroup)
+	})
+
+	When("the process group is suspended", func() {
+		var addresses []fdbv1beta2.ProcessAddress
+
+		JustBeforeEach(func() {
+			pvc := &corev1.PersistentVolumeClaim{}
+			pvc.ObjectMeta = internal.GetPvcMetadata(cluster, fdbv1beta2.ProcessClassStorage, suspendedProcessGroup.ProcessGroupID)
+			pvc.Name = suspendedProcessGroup.GetPodName(cluster) + "-data"
+			pvc.Labels[fdbv1beta2.SuspendedLabel] = "true"
+			pvc.Annotations = map[string]string{
+				fdbv1beta2.SuspensionExpiryAnnotation: "2023-01-01T00:00:00Z",
+			}
+			Expect(k8sClient.Create(context.TODO(), pvc)).To(Succeed())
+
+			var err error
+			_, addresses, err = recoverProcessGroups(k8sClient, clusterName, namespace, []string{string(suspendedProcessGroup.ProcessGroupID)}, false)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should recover the process group", func() {
+			Expect(addresses).To(ConsistOf(fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP("1.1.1.1")}))
+
+			updated, err := loadCluster(k8sClient, namespace, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.ProcessGroupsToRemove).To(ConsistOf(fdbv1beta2.ProcessGroupID(clusterName + "-instance-1")))
+
+			processGroup := fdbv1beta2.FindProcessGroupByID(updated.Status.ProcessGroups, suspendedProcessGroup.ProcessGroupID)
+			Expect(processGroup).NotTo(BeNil())
+			Expect(processGroup.IsSuspended()).To(BeFalse())
+			Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+			Expect(processGroup.IsExcluded()).To(BeFalse())
+		})
+
+		It("should remove the suspension information from the PVC", func() {
+			pvcs := &corev1.PersistentVolumeClaimList{}
+			Expect(k8sClient.List(context.TODO(), pvcs, client.InNamespace(namespace))).To(Succeed())
+			Expect(pvcs.Items).To(HaveLen(1))
+			Expect(pvcs.Items[0].Labels).NotTo(HaveKey(fdbv1beta2.SuspendedLabel))
+			Expect(pvcs.Items[0].Annotations).NotTo(HaveKey(fdbv1beta2.SuspensionExpiryAnnotation))
+		})
+	})
+
+	When("the operator already created a replacement for the suspended process group", func() {
+		BeforeEach(func() {
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus("storage-2", fdbv1beta2.ProcessClassStorage, []string{"1.1.1.2"}))
+		})
+
+		JustBeforeEach(func() {
+			_, _, err := recoverProcessGroups(k8sClient, clusterName, namespace, []string{string(suspendedProcessGroup.ProcessGroupID)}, false)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should remove the surplus process group", func() {
+			updated, err := loadCluster(k8sClient, namespace, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.ProcessGroupsToRemove).To(ConsistOf(fdbv1beta2.ProcessGroupID(clusterName+"-instance-1"), fdbv1beta2.ProcessGroupID("storage-2")))
+		})
+	})
+
+	When("the process groups are stored in FoundationDBProcessGroup resources", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.UseProcessGroupResources = pointer.Bool(true)
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				resource := fdbv1beta2.NewFoundationDBProcessGroup(cluster, processGroup)
+				resource.ObjectMeta = internal.GetProcessGroupResourceMetadata(cluster, processGroup)
+				Expect(k8sClient.Create(context.TODO(), resource)).To(Succeed())
+				Expect(k8sClient.Status().Update(context.TODO(), resource)).To(Succeed())
+			}
+			cluster.Status.ProcessGroups = nil
+		})
+
+		JustBeforeEach(func() {
+			_, _, err := recoverProcessGroups(k8sClient, clusterName, namespace, []string{string(suspendedProcessGroup.ProcessGroupID)}, false)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should recover the process group in the resource and not in the cluster status", func() {
+			updated := &fdbv1beta2.FoundationDBCluster{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), updated)).To(Succeed())
+			Expect(updated.Status.ProcessGroups).To(BeEmpty())
+
+			loaded, err := loadCluster(k8sClient, namespace, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			processGroup := fdbv1beta2.FindProcessGroupByID(loaded.Status.ProcessGroups, suspendedProcessGroup.ProcessGroupID)
+			Expect(processGroup).NotTo(BeNil())
+			Expect(processGroup.IsSuspended()).To(BeFalse())
+			Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+		})
+	})
+
+	When("the process group is not suspended", func() {
+		It("should return an error", func() {
+			_, _, err := recoverProcessGroups(k8sClient, clusterName, namespace, []string{clusterName + "-instance-1"}, false)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the process group doesn't exist", func() {
+		It("should return an error", func() {
+			_, _, err := recoverProcessGroups(k8sClient, clusterName, namespace, []string{"missing"}, false)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -70,7 +70,16 @@ func newRemoveProcessGroupCmd(streams genericclioptions.IOStreams) *cobra.Comman
 				return err
 			}
 
-			return replaceProcessGroups(kubeClient, cluster, args, namespace, withExclusion, wait, removeAllFailed, useProcessGroupID)
+			kubeContexts, err := getKubeContexts(cmd, o)
+			if err != nil {
+				return err
+			}
+
+			if len(kubeContexts) == 0 {
+				return replaceProcessGroups(kubeClient, cluster, args, namespace, withExclusion, wait, removeAllFailed, useProcessGroupID)
+			}
+
+			return replaceProcessGroupsInContexts(cmd, kubeClient, kubeContexts, cluster, args, namespace, withExclusion, wait, removeAllFailed, useProcessGroupID)
 		},
 		Example: `
 # Remove process groups for a cluster in the current namespace
@@ -85,6 +94,9 @@ kubectl fdb -n default remove process-group --use-process-group-id -c cluster st
 
 # Remove all failed process groups for a cluster (all process groups that have a missing process)
 kubectl fdb -n default remove process-group -c cluster --remove-all-failed
+
+# Remove process groups from all clusters that share the connection string with the cluster in the contexts "dc1", "dc2" and "dc3"
+kubectl fdb -n default remove process-group --contexts=dc1,dc2,dc3 --use-process-group-id -c cluster dc1-storage-1 dc2-storage-1
 `,
 	}
 
@@ -168,3 +180,41 @@ func replaceProcessGroups(kubeClient client.Client, clusterName string, ids []st
 
 	return kubeClient.Patch(ctx.TODO(), cluster, patch)
 }
+
+// replaceProcessGroupsInContexts adds the process groups to the removal lists of all the clusters that share the
+// connection string with the provided cluster.
+func replaceProcessGroupsInContexts(cmd *cobra.Command, kubeClient client.Client, kubeContexts []*kubeContext, clusterName string, ids []string, namespace string, withExclusion bool, wait bool, removeAllFailed bool, useProcessGroupID bool) error {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("could not get cluster: %s/%s", namespace, clusterName)
+		}
+		return err
+	}
+
+	discoveredClusters, err := discoverClusters(kubeContexts, cluster)
+	if err != nil {
+		return err
+	}
+
+	idsPerCluster, err := assignIDsToClusters(discoveredClusters, ids, fmt.Sprintf("%s/%s", namespace, clusterName), func(discoveredCluster clusterInContext, candidates []string) ([]string, error) {
+		return getProcessGroupIDsForCluster(discoveredCluster.cluster, candidates, useProcessGroupID), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for idx, discoveredCluster := range discoveredClusters {
+		if len(idsPerCluster[idx]) == 0 && !removeAllFailed {
+			continue
+		}
+
+		cmd.Printf("Removing process groups %v from cluster %s\n", idsPerCluster[idx], discoveredCluster.String())
+		err = replaceProcessGroups(discoveredCluster.kubeContext.kubeClient, discoveredCluster.cluster.Name, idsPerCluster[idx], discoveredCluster.cluster.Namespace, withExclusion, wait, removeAllFailed, useProcessGroupID)
+		if err != nil {
+			return fmt.Errorf("could not remove process groups from cluster %s: %w", discoveredCluster.String(), err)
+		}
+	}
+
+	return nil
+}
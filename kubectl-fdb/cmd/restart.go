@@ -30,6 +30,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func newRestartCmd(streams genericclioptions.IOStreams) *cobra.Command {
@@ -89,6 +90,15 @@ func newRestartCmd(streams genericclioptions.IOStreams) *cobra.Command {
 				return err
 			}
 
+			kubeContexts, err := getKubeContexts(cmd, o)
+			if err != nil {
+				return err
+			}
+
+			if len(kubeContexts) > 0 {
+				return restartProcessesInContexts(cmd, kubeClient, kubeContexts, clusterName, namespace, args, allProcesses, conditions, wait, sleep)
+			}
+
 			cluster, err := loadCluster(kubeClient, namespace, clusterName)
 			if err != nil {
 				return err
@@ -127,6 +137,9 @@ kubectl fdb restart -c cluster --all-processes
 
 # Restart all processes for a cluster that have the given condition
 kubectl fdb restart -c cluster --process-condition=MissingProcesses
+
+# Restart all processes of all clusters that share the connection string with the cluster in the contexts "dc1", "dc2" and "dc3"
+kubectl fdb restart --contexts=dc1,dc2,dc3 -c cluster --all-processes
 `,
 	}
 
@@ -161,6 +174,72 @@ func convertConditions(inputConditions []string) ([]fdbv1beta2.ProcessGroupCondi
 	return res, nil
 }
 
+// restartProcessesInContexts restarts the processes of all clusters that share the connection string with the
+// provided cluster.
+func restartProcessesInContexts(cmd *cobra.Command, kubeClient client.Client, kubeContexts []*kubeContext, clusterName string, namespace string, podNames []string, allProcesses bool, conditions []fdbv1beta2.ProcessGroupConditionType, wait bool, sleep uint16) error {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return err
+	}
+
+	discoveredClusters, err := discoverClusters(kubeContexts, cluster)
+	if err != nil {
+		return err
+	}
+
+	if !allProcesses && len(conditions) == 0 {
+		processesPerCluster, err := assignIDsToClusters(discoveredClusters, podNames, fmt.Sprintf("%s/%s", namespace, clusterName), func(discoveredCluster clusterInContext, candidates []string) ([]string, error) {
+			return getPodNamesForCluster(discoveredCluster.kubeContext.kubeClient, discoveredCluster.cluster, candidates)
+		})
+		if err != nil {
+			return err
+		}
+
+		return restartProcessesPerCluster(cmd, discoveredClusters, processesPerCluster, wait, sleep)
+	}
+
+	processesPerCluster := make([][]string, len(discoveredClusters))
+	for idx, discoveredCluster := range discoveredClusters {
+		var processes []string
+		if allProcesses {
+			pods, err := getPodsForCluster(discoveredCluster.kubeContext.kubeClient, discoveredCluster.cluster)
+			if err != nil {
+				return err
+			}
+
+			for _, pod := range pods.Items {
+				processes = append(processes, pod.Name)
+			}
+		} else {
+			processes, err = getAllPodsFromClusterWithCondition(cmd.ErrOrStderr(), discoveredCluster.kubeContext.kubeClient, discoveredCluster.cluster.Name, discoveredCluster.cluster.Namespace, conditions)
+			if err != nil {
+				return err
+			}
+		}
+
+		processesPerCluster[idx] = processes
+	}
+
+	return restartProcessesPerCluster(cmd, discoveredClusters, processesPerCluster, wait, sleep)
+}
+
+// restartProcessesPerCluster restarts the provided processes of each cluster.
+func restartProcessesPerCluster(cmd *cobra.Command, discoveredClusters []clusterInContext, processesPerCluster [][]string, wait bool, sleep uint16) error {
+	for idx, discoveredCluster := range discoveredClusters {
+		if len(processesPerCluster[idx]) == 0 {
+			continue
+		}
+
+		cmd.Printf("Restarting processes in cluster %s\n", discoveredCluster.String())
+		err := restartProcesses(cmd, discoveredCluster.kubeContext.restConfig, discoveredCluster.kubeContext.clientSet, processesPerCluster[idx], discoveredCluster.cluster.Namespace, discoveredCluster.cluster.Name, wait, sleep)
+		if err != nil {
+			return fmt.Errorf("could not restart processes in cluster %s: %w", discoveredCluster.String(), err)
+		}
+	}
+
+	return nil
+}
+
 //nolint:interfacer // golint has a false-positive here -> `cmd` can be `github.com/hashicorp/go-retryablehttp.Logger`
 func restartProcesses(cmd *cobra.Command, restConfig *rest.Config, kubeClient *kubernetes.Clientset, processes []string, namespace string, clusterName string, wait bool, sleep uint16) error {
 	if wait {
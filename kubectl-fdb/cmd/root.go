@@ -76,11 +76,13 @@ func NewRootCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd.PersistentFlags().StringP("operator-name", "o", "fdb-kubernetes-operator-controller-manager", "Name of the Deployment for the operator.")
 	cmd.PersistentFlags().BoolP("wait", "w", true, "If the plugin should wait for confirmation before executing any action")
 	cmd.PersistentFlags().Uint16P("sleep", "z", 0, "The plugin should sleep between sequential operations for the defined time in seconds (default 0)")
+	cmd.PersistentFlags().StringSlice("contexts", nil, "Kubernetes contexts to discover all FoundationDBCluster resources that share the connection string with the provided cluster. The current context is always included. Supported by the analyze, exclusion-status, remove and restart commands.")
 	o.configFlags.AddFlags(cmd.Flags())
 
 	cmd.AddCommand(
 		newVersionCmd(streams),
 		newRemoveCmd(streams),
+		newRecoverCmd(streams),
 		newExecCmd(streams),
 		newCordonCmd(streams),
 		newRestartCmd(streams),
@@ -89,6 +91,8 @@ func NewRootCmd(streams genericclioptions.IOStreams) *cobra.Command {
 		newFixCoordinatorIPsCmd(streams),
 		newGetCmd(streams),
 		newBuggifyCmd(streams),
+		newApproveCmd(streams),
+		newRollbackCmd(streams),
 	)
 
 	return cmd
@@ -66,6 +66,7 @@ func main() {
 		),
 		&controllers.FoundationDBBackupReconciler{},
 		&controllers.FoundationDBRestoreReconciler{},
+		&controllers.FoundationDBDisasterRecoveryReconciler{},
 		ctrl.Log)
 
 	if file != nil {
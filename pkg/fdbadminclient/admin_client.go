@@ -21,6 +21,8 @@
 package fdbadminclient
 
 import (
+	"time"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
 
@@ -33,6 +35,11 @@ type AdminClient interface {
 	// ConfigureDatabase sets the database configuration.
 	ConfigureDatabase(configuration fdbv1beta2.DatabaseConfiguration, newDatabase bool, version string) error
 
+	// ConfigureStorageMigration sets the storage migration type and the
+	// perpetual storage wiggle, which are used to migrate the storage servers
+	// to a new storage engine.
+	ConfigureStorageMigration(migrationType fdbv1beta2.StorageMigrationType, perpetualStorageWiggle int) error
+
 	// ExcludeProcesses starts evacuating processes so that they can be removed
 	// from the database.
 	ExcludeProcesses(addresses []fdbv1beta2.ProcessAddress) error
@@ -69,11 +76,11 @@ type AdminClient interface {
 	// version of FDB.
 	GetProtocolVersion(version string) (string, error)
 
-	// StartBackup starts a new backup.
-	StartBackup(url string, snapshotPeriodSeconds int) error
+	// StartBackup starts a new backup with the provided tag.
+	StartBackup(tag string, url string, snapshotPeriodSeconds int) error
 
-	// StopBackup stops a backup.
-	StopBackup(url string) error
+	// StopBackup stops the backup with the provided tag.
+	StopBackup(tag string) error
 
 	// PauseBackups pauses the backups.
 	PauseBackups() error
@@ -81,18 +88,57 @@ type AdminClient interface {
 	// ResumeBackups resumes the backups.
 	ResumeBackups() error
 
-	// ModifyBackup modifies the configuration of the backup.
-	ModifyBackup(int) error
+	// ModifyBackup modifies the configuration of the backup with the provided
+	// tag.
+	ModifyBackup(tag string, snapshotPeriodSeconds int) error
+
+	// GetBackupStatus gets the status of the backup with the provided tag.
+	GetBackupStatus(tag string) (*fdbv1beta2.FoundationDBLiveBackupStatus, error)
+
+	// ForceSnapshot modifies the interval of the active snapshot of the
+	// backup with the provided tag, so that the snapshot will be completed in
+	// the provided duration.
+	ForceSnapshot(tag string, snapshotDurationSeconds int) error
+
+	// DescribeBackup describes the data of the backup in the destination.
+	DescribeBackup(url string) (*fdbv1beta2.FoundationDBBackupDescription, error)
 
-	// GetBackupStatus gets the status of the current backup.
-	GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackupStatus, error)
+	// ExpireBackup deletes all backup data that is only needed to restore
+	// to a version before the provided version.
+	ExpireBackup(url string, version int64) error
+
+	// DeleteBackup deletes all backup data in the destination.
+	DeleteBackup(url string) error
 
 	// StartRestore starts a new restore.
-	StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange) error
+	StartRestore(url string, options RestoreOptions) error
 
 	// GetRestoreStatus gets the status of the current restore.
 	GetRestoreStatus() (string, error)
 
+	// ClearKeyRanges clears all keys in the provided key ranges.
+	ClearKeyRanges(keyRanges []fdbv1beta2.FoundationDBKeyRange) error
+
+	// IsKeyRangeEmpty checks if the provided key range contains any keys.
+	IsKeyRangeEmpty(keyRange fdbv1beta2.FoundationDBKeyRange) (bool, error)
+
+	// StartDisasterRecovery starts replicating the provided source cluster
+	// into the cluster of this client.
+	StartDisasterRecovery(source *fdbv1beta2.FoundationDBCluster) error
+
+	// AbortDisasterRecovery stops replicating the provided source cluster
+	// into the cluster of this client.
+	AbortDisasterRecovery(source *fdbv1beta2.FoundationDBCluster) error
+
+	// SwitchDisasterRecovery switches the replication from the provided
+	// source cluster, afterwards the cluster of this client will be the
+	// source of the replication.
+	SwitchDisasterRecovery(source *fdbv1beta2.FoundationDBCluster) error
+
+	// GetDisasterRecoveryStatus gets the status of the replication from the
+	// provided source cluster into the cluster of this client.
+	GetDisasterRecoveryStatus(source *fdbv1beta2.FoundationDBCluster) (string, error)
+
 	// Close shuts down any resources for the client once it is no longer
 	// needed.
 	Close() error
@@ -103,6 +149,10 @@ type AdminClient interface {
 	// SetKnobs sets the Knobs that should be used for the commandline call.
 	SetKnobs([]string)
 
+	// SetBlobCredentials sets the blob credentials that should be used by
+	// the commands that access the backup data in the destination.
+	SetBlobCredentials(credentials []byte) error
+
 	// GetMaintenanceZone gets current maintenance zone, if any.
 	GetMaintenanceZone() (string, error)
 
@@ -116,3 +166,29 @@ type AdminClient interface {
 	// arguments must be even.
 	WithValues(keysAndValues ...interface{})
 }
+
+// RestoreOptions defines the options for starting a new restore.
+type RestoreOptions struct {
+	// KeyRanges defines the key ranges to restore. If empty the whole database
+	// will be restored.
+	KeyRanges []fdbv1beta2.FoundationDBKeyRange
+
+	// TargetVersion defines the version to restore to. If neither the
+	// TargetVersion nor the TargetTimestamp is set, the restore will restore
+	// to the end of the backup.
+	TargetVersion *int64
+
+	// TargetTimestamp defines the point in time to restore to.
+	TargetTimestamp *time.Time
+
+	// AddPrefix defines the prefix that will be added to the restored keys.
+	AddPrefix string
+
+	// RemovePrefix defines the prefix that will be removed from the restored
+	// keys.
+	RemovePrefix string
+
+	// SourceCluster is the cluster that was backed up. The cluster file of
+	// this cluster is used to convert the TargetTimestamp into a version.
+	SourceCluster *fdbv1beta2.FoundationDBCluster
+}
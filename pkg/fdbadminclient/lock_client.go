@@ -53,4 +53,19 @@ type LockClient interface {
 
 	// UpdateDenyList updates the deny list to match a list of entries.
 	UpdateDenyList(locks []fdbv1beta2.LockDenyListEntry) error
+
+	// AddPendingAction registers that the operator instance for the data
+	// center of this cluster is ready to perform the provided global action.
+	// The value describes the state the instance wants to reach, e.g. the
+	// next database configuration.
+	AddPendingAction(action fdbv1beta2.GlobalCoordinationAction, value string) error
+
+	// GetPendingActions returns the stored values for the provided global
+	// action, keyed by the data center of the operator instance that
+	// registered them.
+	GetPendingActions(action fdbv1beta2.GlobalCoordinationAction) (map[string]string, error)
+
+	// ClearPendingActions removes the values stored by the operator
+	// instances of all data centers for the provided global action.
+	ClearPendingActions(action fdbv1beta2.GlobalCoordinationAction) error
 }
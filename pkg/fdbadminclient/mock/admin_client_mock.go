@@ -37,6 +37,7 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -45,14 +46,21 @@ type AdminClient struct {
 	Cluster                                  *fdbv1beta2.FoundationDBCluster
 	KubeClient                               client.Client
 	DatabaseConfiguration                    *fdbv1beta2.DatabaseConfiguration
+	storageEngines                           map[fdbv1beta2.ProcessGroupID]fdbv1beta2.StorageEngine
 	ExcludedAddresses                        map[string]fdbv1beta2.None
 	KilledAddresses                          map[string]fdbv1beta2.None
 	Knobs                                    map[string]fdbv1beta2.None
+	BlobCredentials                          []byte
 	missingLocalities                        map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
 	missingProcessGroups                     map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
 	incorrectCommandLines                    map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
 	FrozenStatus                             *fdbv1beta2.FoundationDBStatus
 	Backups                                  map[string]fdbv1beta2.FoundationDBBackupStatusBackupDetails
+	BackupDescription                        *fdbv1beta2.FoundationDBBackupDescription
+	ExpiredBackupVersions                    map[string]int64
+	DeletedBackups                           map[string]fdbv1beta2.None
+	ForcedSnapshots                          map[string]int
+	backupProgress                           map[string]fdbv1beta2.FoundationDBLiveBackupStatus
 	clientVersions                           map[string][]string
 	currentCommandLines                      map[string]string
 	VersionProcessGroups                     map[fdbv1beta2.ProcessGroupID]string
@@ -63,11 +71,20 @@ type AdminClient struct {
 	MaxZoneFailuresWithoutLosingAvailability *int
 	MaintenanceZone                          fdbv1beta2.FaultDomain
 	restoreURL                               string
+	restoreState                             string
+	restoreProgress                          fdbv1beta2.FoundationDBRestoreProgress
+	disasterRecoverySource                   string
+	disasterRecoveryRestorable               bool
+	disasterRecoverySecondsBehind            float64
+	RestoreOptions                           *fdbadminclient.RestoreOptions
+	ClearedKeyRanges                         []fdbv1beta2.FoundationDBKeyRange
+	nonEmptyKeyRanges                        []fdbv1beta2.FoundationDBKeyRange
 	maintenanceZoneStartTimestamp            time.Time
 	uptimeSecondsForMaintenanceZone          float64
 	TeamTracker                              []fdbv1beta2.FoundationDBStatusTeamTracker
 	Logs                                     []fdbv1beta2.FoundationDBStatusLogInfo
 	mockError                                error
+	mockedConnectionString                   string
 	LagInfo                                  map[string]fdbv1beta2.FoundationDBStatusLagInfo
 }
 
@@ -107,6 +124,10 @@ func NewMockAdminClientUncast(cluster *fdbv1beta2.FoundationDBCluster, kubeClien
 		}
 		adminClientCache[cluster.Name] = cachedClient
 		cachedClient.Backups = make(map[string]fdbv1beta2.FoundationDBBackupStatusBackupDetails)
+		cachedClient.ExpiredBackupVersions = make(map[string]int64)
+		cachedClient.DeletedBackups = make(map[string]fdbv1beta2.None)
+		cachedClient.storageEngines = make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.StorageEngine)
+		cachedClient.ForcedSnapshots = make(map[string]int)
 	} else {
 		cachedClient.Cluster = cluster.DeepCopy()
 	}
@@ -210,13 +231,18 @@ func (client *AdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
 				command += " --locality_incorrect=1"
 			}
 
+			zoneID := pod.Name
+			if distributionKey, ok := pod.Labels[fdbv1beta2.FDBDistributionKeyLabel]; ok {
+				zoneID = distributionKey
+			}
+
 			var locality map[string]string
 			if _, ok := client.missingLocalities[processGroupID]; ok {
 				locality = map[string]string{}
 			} else {
 				locality = map[string]string{
 					fdbv1beta2.FDBLocalityInstanceIDKey: string(processGroupID),
-					fdbv1beta2.FDBLocalityZoneIDKey:     pod.Name,
+					fdbv1beta2.FDBLocalityZoneIDKey:     zoneID,
 					fdbv1beta2.FDBLocalityDCIDKey:       client.Cluster.Spec.DataCenter,
 				}
 
@@ -261,6 +287,21 @@ func (client *AdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
 				fdbRoles = append(fdbRoles, fdbv1beta2.FoundationDBStatusProcessRoleInfo{Role: string(fdbv1beta2.ProcessRoleCoordinator)})
 			}
 
+			// Storage servers keep the storage engine they were created with, like in a real cluster the storage
+			// engine only changes if the storage server is recreated.
+			if pClass == fdbv1beta2.ProcessClassStorage && !excluded && client.DatabaseConfiguration != nil {
+				storageEngine, ok := client.storageEngines[processGroupID]
+				if !ok {
+					storageEngine = client.DatabaseConfiguration.StorageEngine.GetStorageServerStorageEngine()
+					client.storageEngines[processGroupID] = storageEngine
+				}
+
+				fdbRoles = append(fdbRoles, fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+					Role:            string(fdbv1beta2.ProcessRoleStorage),
+					StorageMetadata: fdbv1beta2.FoundationDBStatusStorageMetadata{StorageEngine: storageEngine},
+				})
+			}
+
 			version, ok := client.VersionProcessGroups[processGroupID]
 			if !ok {
 				if client.Cluster.VersionCompatibleUpgradeInProgress() {
@@ -491,8 +532,22 @@ func (client *AdminClient) ConfigureDatabase(configuration fdbv1beta2.DatabaseCo
 		return client.mockError
 	}
 
+	previousConfiguration := client.DatabaseConfiguration
 	client.DatabaseConfiguration = configuration.DeepCopy()
 
+	// Settings that are not part of the configure command keep their current value.
+	if previousConfiguration != nil {
+		if client.DatabaseConfiguration.PerpetualStorageWiggle == nil {
+			client.DatabaseConfiguration.PerpetualStorageWiggle = previousConfiguration.PerpetualStorageWiggle
+		}
+		if client.DatabaseConfiguration.PerpetualStorageWiggleLocality == nil {
+			client.DatabaseConfiguration.PerpetualStorageWiggleLocality = previousConfiguration.PerpetualStorageWiggleLocality
+		}
+		if client.DatabaseConfiguration.StorageMigrationType == nil {
+			client.DatabaseConfiguration.StorageMigrationType = previousConfiguration.StorageMigrationType
+		}
+	}
+
 	ver, err := fdbv1beta2.ParseFdbVersion(version)
 	if err != nil {
 		return err
@@ -506,6 +561,25 @@ func (client *AdminClient) ConfigureDatabase(configuration fdbv1beta2.DatabaseCo
 	return nil
 }
 
+// ConfigureStorageMigration sets the storage migration type and the perpetual storage wiggle.
+func (client *AdminClient) ConfigureStorageMigration(migrationType fdbv1beta2.StorageMigrationType, perpetualStorageWiggle int) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	if client.DatabaseConfiguration == nil {
+		client.DatabaseConfiguration = &fdbv1beta2.DatabaseConfiguration{}
+	}
+
+	client.DatabaseConfiguration.StorageMigrationType = &migrationType
+	client.DatabaseConfiguration.PerpetualStorageWiggle = &perpetualStorageWiggle
+
+	return nil
+}
+
 // ExcludeProcesses starts evacuating processes so that they can be removed
 // from the database.
 func (client *AdminClient) ExcludeProcesses(addresses []fdbv1beta2.ProcessAddress) error {
@@ -668,6 +742,10 @@ func (client *AdminClient) GetConnectionString() (string, error) {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
+	if client.mockedConnectionString != "" {
+		return client.mockedConnectionString, nil
+	}
+
 	return client.Cluster.Status.ConnectionString, nil
 }
 
@@ -701,7 +779,7 @@ func (client *AdminClient) GetProtocolVersion(version string) (string, error) {
 }
 
 // StartBackup starts a new backup.
-func (client *AdminClient) StartBackup(url string, snapshotPeriodSeconds int) error {
+func (client *AdminClient) StartBackup(tag string, url string, snapshotPeriodSeconds int) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -709,7 +787,7 @@ func (client *AdminClient) StartBackup(url string, snapshotPeriodSeconds int) er
 		return client.mockError
 	}
 
-	client.Backups["default"] = fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+	client.Backups[tag] = fdbv1beta2.FoundationDBBackupStatusBackupDetails{
 		URL:                   url,
 		Running:               true,
 		SnapshotPeriodSeconds: snapshotPeriodSeconds,
@@ -749,8 +827,8 @@ func (client *AdminClient) ResumeBackups() error {
 	return nil
 }
 
-// ModifyBackup reconfigures the backup.
-func (client *AdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
+// ModifyBackup reconfigures the backup with the provided tag.
+func (client *AdminClient) ModifyBackup(tag string, snapshotPeriodSeconds int) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -758,14 +836,14 @@ func (client *AdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
 		return client.mockError
 	}
 
-	backup := client.Backups["default"]
+	backup := client.Backups[tag]
 	backup.SnapshotPeriodSeconds = snapshotPeriodSeconds
-	client.Backups["default"] = backup
+	client.Backups[tag] = backup
 	return nil
 }
 
-// StopBackup stops a backup.
-func (client *AdminClient) StopBackup(url string) error {
+// StopBackup stops the backup with the provided tag.
+func (client *AdminClient) StopBackup(tag string) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -773,18 +851,18 @@ func (client *AdminClient) StopBackup(url string) error {
 		return client.mockError
 	}
 
-	for tag, backup := range client.Backups {
-		if backup.URL == url {
-			backup.Running = false
-			client.Backups[tag] = backup
-			return nil
-		}
+	backup, ok := client.Backups[tag]
+	if !ok {
+		return fmt.Errorf("no backup found for tag %s", tag)
 	}
-	return fmt.Errorf("no backup found for URL %s", url)
+
+	backup.Running = false
+	client.Backups[tag] = backup
+	return nil
 }
 
-// GetBackupStatus gets the status of the current backup.
-func (client *AdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
+// GetBackupStatus gets the status of the backup with the provided tag.
+func (client *AdminClient) GetBackupStatus(tag string) (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -794,20 +872,101 @@ func (client *AdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackup
 
 	status := &fdbv1beta2.FoundationDBLiveBackupStatus{}
 
-	tag := "default"
 	backup, present := client.Backups[tag]
 	if present {
 		status.DestinationURL = backup.URL
 		status.Status.Running = backup.Running
 		status.BackupAgentsPaused = backup.Paused
 		status.SnapshotIntervalSeconds = backup.SnapshotPeriodSeconds
+
+		if progress, ok := client.backupProgress[tag]; ok && backup.Running {
+			status.LogBytesWritten = progress.LogBytesWritten
+			status.RangeBytesWritten = progress.RangeBytesWritten
+			status.LatestRestorablePoint = progress.LatestRestorablePoint
+		}
 	}
 
 	return status, nil
 }
 
+// ForceSnapshot modifies the interval of the active snapshot of the backup with the provided tag.
+func (client *AdminClient) ForceSnapshot(tag string, snapshotDurationSeconds int) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	client.ForcedSnapshots[tag] = snapshotDurationSeconds
+	return nil
+}
+
+// DescribeBackup describes the data of the backup in the destination.
+func (client *AdminClient) DescribeBackup(url string) (*fdbv1beta2.FoundationDBBackupDescription, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return nil, client.mockError
+	}
+
+	if client.BackupDescription == nil {
+		return &fdbv1beta2.FoundationDBBackupDescription{URL: url}, nil
+	}
+
+	description := client.BackupDescription.DeepCopy()
+	description.URL = url
+
+	return description, nil
+}
+
+// ExpireBackup deletes all backup data that is only needed to restore to a version before the provided version.
+func (client *AdminClient) ExpireBackup(url string, version int64) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	client.ExpiredBackupVersions[url] = version
+	if client.BackupDescription == nil {
+		return nil
+	}
+
+	snapshots := make([]fdbv1beta2.FoundationDBBackupSnapshot, 0, len(client.BackupDescription.Snapshots))
+	for _, snapshot := range client.BackupDescription.Snapshots {
+		if snapshot.BeginVersion.Version < version {
+			continue
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+	client.BackupDescription.Snapshots = snapshots
+
+	if client.BackupDescription.MinRestorablePoint != nil && client.BackupDescription.MinRestorablePoint.Version < version && len(snapshots) > 0 {
+		client.BackupDescription.MinRestorablePoint = snapshots[0].EndVersion.DeepCopy()
+	}
+
+	return nil
+}
+
+// DeleteBackup deletes all backup data in the destination.
+func (client *AdminClient) DeleteBackup(url string) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	client.DeletedBackups[url] = fdbv1beta2.None{}
+	return nil
+}
+
 // StartRestore starts a new restore.
-func (client *AdminClient) StartRestore(url string, _ []fdbv1beta2.FoundationDBKeyRange) error {
+func (client *AdminClient) StartRestore(url string, options fdbadminclient.RestoreOptions) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -816,6 +975,7 @@ func (client *AdminClient) StartRestore(url string, _ []fdbv1beta2.FoundationDBK
 	}
 
 	client.restoreURL = url
+	client.RestoreOptions = &options
 	return nil
 }
 
@@ -828,7 +988,255 @@ func (client *AdminClient) GetRestoreStatus() (string, error) {
 		return "", client.mockError
 	}
 
-	return fmt.Sprintf("%s\n", client.restoreURL), nil
+	if client.restoreURL == "" {
+		return "\n", nil
+	}
+
+	state := client.restoreState
+	if state == "" {
+		state = "running"
+	}
+
+	// The error is followed by a line break in the output of fdbrestore.
+	lastError := "None"
+	if client.restoreProgress.LastError != "" {
+		lastError = client.restoreProgress.LastError + "\n"
+	}
+
+	addPrefix, removePrefix := "", ""
+	var ranges []fdbv1beta2.FoundationDBKeyRange
+	var restoreVersion int64
+	if client.RestoreOptions != nil {
+		addPrefix = client.RestoreOptions.AddPrefix
+		removePrefix = client.RestoreOptions.RemovePrefix
+		ranges = client.RestoreOptions.KeyRanges
+		restoreVersion = pointer.Int64Deref(client.RestoreOptions.TargetVersion, 0)
+	}
+
+	if len(ranges) == 0 {
+		ranges = []fdbv1beta2.FoundationDBKeyRange{{Start: "", End: "\\xff"}}
+	}
+
+	var rangeOutput strings.Builder
+	for _, keyRange := range ranges {
+		rangeOutput.WriteString(fmt.Sprintf("  Range: '%s'-'%s'", keyRange.Start, keyRange.End))
+	}
+
+	// This is the same format that fdbrestore uses for every restore tag.
+	return fmt.Sprintf("Tag: default  UID: 6f6e0bd1c3a84e01bd2a4c1f0c6bd7e2  State: %s  Blocks: %d/%d  BlocksInProgress: %d  Files: %d  BytesWritten: %d  CurrentVersion: %d FirstConsistentVersion: %d  ApplyVersionLag: %d  LastError: %s  URL: %s%s  AddPrefix: '%s'  RemovePrefix: '%s'  Version: %d\n\n\n",
+		state,
+		client.restoreProgress.BlocksCompleted,
+		client.restoreProgress.BlocksTotal,
+		client.restoreProgress.BlocksInProgress,
+		client.restoreProgress.Files,
+		client.restoreProgress.BytesWritten,
+		client.restoreProgress.CurrentVersion,
+		client.restoreProgress.FirstConsistentVersion,
+		client.restoreProgress.ApplyVersionLag,
+		lastError,
+		client.restoreURL,
+		rangeOutput.String(),
+		addPrefix,
+		removePrefix,
+		restoreVersion,
+	), nil
+}
+
+// ClearKeyRanges clears all keys in the provided key ranges.
+func (client *AdminClient) ClearKeyRanges(keyRanges []fdbv1beta2.FoundationDBKeyRange) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	client.ClearedKeyRanges = append(client.ClearedKeyRanges, keyRanges...)
+
+	remaining := make([]fdbv1beta2.FoundationDBKeyRange, 0, len(client.nonEmptyKeyRanges))
+	for _, nonEmptyKeyRange := range client.nonEmptyKeyRanges {
+		cleared := false
+		for _, keyRange := range keyRanges {
+			if keyRange.Start <= nonEmptyKeyRange.Start && nonEmptyKeyRange.End <= keyRange.End {
+				cleared = true
+				break
+			}
+		}
+
+		if !cleared {
+			remaining = append(remaining, nonEmptyKeyRange)
+		}
+	}
+	client.nonEmptyKeyRanges = remaining
+
+	return nil
+}
+
+// IsKeyRangeEmpty checks if the provided key range contains any keys.
+func (client *AdminClient) IsKeyRangeEmpty(keyRange fdbv1beta2.FoundationDBKeyRange) (bool, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return false, client.mockError
+	}
+
+	for _, nonEmptyKeyRange := range client.nonEmptyKeyRanges {
+		if nonEmptyKeyRange.Start < keyRange.End && keyRange.Start < nonEmptyKeyRange.End {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// MockNonEmptyKeyRange mocks that the provided key range contains data.
+func (client *AdminClient) MockNonEmptyKeyRange(keyRange fdbv1beta2.FoundationDBKeyRange) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	client.nonEmptyKeyRanges = append(client.nonEmptyKeyRanges, keyRange)
+}
+
+// MockRestoreStatus mocks the state and the progress of the current restore, the state must be one of the states
+// reported by fdbrestore, e.g. running or completed.
+func (client *AdminClient) MockRestoreStatus(state string, progress fdbv1beta2.FoundationDBRestoreProgress) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	client.restoreState = state
+	client.restoreProgress = progress
+}
+
+// StartDisasterRecovery starts replicating the provided source cluster into the cluster of this client.
+func (client *AdminClient) StartDisasterRecovery(source *fdbv1beta2.FoundationDBCluster) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	if client.disasterRecoverySource != "" {
+		return fmt.Errorf("a DR from %s is already running", client.disasterRecoverySource)
+	}
+
+	client.disasterRecoverySource = source.Name
+	return nil
+}
+
+// AbortDisasterRecovery stops replicating the provided source cluster into the cluster of this client.
+func (client *AdminClient) AbortDisasterRecovery(source *fdbv1beta2.FoundationDBCluster) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	if client.disasterRecoverySource != source.Name {
+		return fmt.Errorf("no DR from %s is running", source.Name)
+	}
+
+	client.disasterRecoverySource = ""
+	client.disasterRecoveryRestorable = false
+	client.disasterRecoverySecondsBehind = 0
+	return nil
+}
+
+// SwitchDisasterRecovery switches the replication from the provided source cluster, afterwards the cluster of this
+// client will be the source of the replication.
+func (client *AdminClient) SwitchDisasterRecovery(source *fdbv1beta2.FoundationDBCluster) error {
+	err := client.switchDisasterRecoverySource(source)
+	if err != nil {
+		return err
+	}
+
+	sourceClient, err := NewMockAdminClientUncast(source, client.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	sourceClient.disasterRecoverySource = client.Cluster.Name
+	sourceClient.disasterRecoveryRestorable = true
+	return nil
+}
+
+// switchDisasterRecoverySource validates that the replication can be switched and removes it from this client.
+func (client *AdminClient) switchDisasterRecoverySource(source *fdbv1beta2.FoundationDBCluster) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	if client.disasterRecoverySource != source.Name {
+		return fmt.Errorf("no DR from %s is running", source.Name)
+	}
+
+	if !client.disasterRecoveryRestorable {
+		return fmt.Errorf("the DR from %s is not restorable", source.Name)
+	}
+
+	client.disasterRecoverySource = ""
+	client.disasterRecoveryRestorable = false
+	client.disasterRecoverySecondsBehind = 0
+	return nil
+}
+
+// GetDisasterRecoveryStatus gets the status of the replication from the provided source cluster into the cluster of
+// this client.
+func (client *AdminClient) GetDisasterRecoveryStatus(source *fdbv1beta2.FoundationDBCluster) (string, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return "", client.mockError
+	}
+
+	if client.disasterRecoverySource != source.Name {
+		return "No previous DR on tag `default' found.\n", nil
+	}
+
+	completeCopy := "is NOT a complete copy"
+	if client.disasterRecoveryRestorable {
+		completeCopy = "is a complete copy"
+	}
+
+	return fmt.Sprintf("The DR on tag `default' %s of the primary database.\n\nThe DR is %f seconds behind.\n",
+		completeCopy,
+		client.disasterRecoverySecondsBehind,
+	), nil
+}
+
+// MockBackupProgress mocks the progress of the running backup with the provided tag.
+func (client *AdminClient) MockBackupProgress(tag string, bytesWritten int64, lagSeconds float64) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.backupProgress == nil {
+		client.backupProgress = make(map[string]fdbv1beta2.FoundationDBLiveBackupStatus)
+	}
+
+	client.backupProgress[tag] = fdbv1beta2.FoundationDBLiveBackupStatus{
+		LogBytesWritten: bytesWritten,
+		LatestRestorablePoint: &fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{
+			LagSeconds: lagSeconds,
+		},
+	}
+}
+
+// MockDisasterRecoveryStatus mocks the progress of the running replication into the cluster of this client.
+func (client *AdminClient) MockDisasterRecoveryStatus(restorable bool, secondsBehind float64) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	client.disasterRecoveryRestorable = restorable
+	client.disasterRecoverySecondsBehind = secondsBehind
 }
 
 // MockClientVersion returns a mocked client version
@@ -866,6 +1274,14 @@ func (client *AdminClient) MockLocalityInfo(processGroupID fdbv1beta2.ProcessGro
 	client.localityInfo[processGroupID] = locality
 }
 
+// MockStorageEngine sets the storage engine that the storage servers of the process group report.
+func (client *AdminClient) MockStorageEngine(processGroupID fdbv1beta2.ProcessGroupID, storageEngine fdbv1beta2.StorageEngine) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	client.storageEngines[processGroupID] = storageEngine
+}
+
 // MockIncorrectCommandLine updates the mock for whether a process group should
 // be have an incorrect command-line.
 func (client *AdminClient) MockIncorrectCommandLine(processGroupID fdbv1beta2.ProcessGroupID, incorrect bool) {
@@ -936,6 +1352,13 @@ func (client *AdminClient) GetCoordinatorSet() (map[string]fdbv1beta2.None, erro
 	return fdbstatus.GetCoordinatorsFromStatus(status), nil
 }
 
+// SetBlobCredentials sets the blob credentials that should be used by the commands that access the backup data in the
+// destination.
+func (client *AdminClient) SetBlobCredentials(credentials []byte) error {
+	client.BlobCredentials = credentials
+	return nil
+}
+
 // SetKnobs sets the knobs that should be used for the commandline call.
 func (client *AdminClient) SetKnobs(knobs []string) {
 	client.Knobs = make(map[string]fdbv1beta2.None, len(knobs))
@@ -979,6 +1402,15 @@ func (client *AdminClient) MockUptimeSecondsForMaintenanceZone(seconds float64)
 	client.uptimeSecondsForMaintenanceZone = seconds
 }
 
+// MockConnectionString mocks the connection string that is reported by the cluster, e.g. to simulate a change of the
+// coordinators that was done outside of the operator. This can be reset by passing an empty string to this method.
+func (client *AdminClient) MockConnectionString(connectionString string) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	client.mockedConnectionString = connectionString
+}
+
 // MockError mocks an error that will be returned when making any calls to the mock client. This can be reset by passing
 // a nil value to this method.
 func (client *AdminClient) MockError(err error) {
@@ -40,6 +40,10 @@ type LockClient struct {
 	// pendingUpgrades stores data about process groups that have a pending
 	// upgrade.
 	pendingUpgrades map[fdbv1beta2.Version]map[fdbv1beta2.ProcessGroupID]bool
+
+	// pendingActions stores the values registered by the different data
+	// centers for global actions.
+	pendingActions map[fdbv1beta2.GlobalCoordinationAction]map[string]string
 }
 
 // TakeLock attempts to acquire a lock.
@@ -107,6 +111,41 @@ func (client *LockClient) UpdateDenyList(locks []fdbv1beta2.LockDenyListEntry) e
 	return nil
 }
 
+// AddPendingAction registers that the operator instance for the data
+// center of this cluster is ready to perform the provided global action.
+func (client *LockClient) AddPendingAction(action fdbv1beta2.GlobalCoordinationAction, value string) error {
+	return client.AddPendingActionForDataCenter(action, client.cluster.Spec.DataCenter, value)
+}
+
+// AddPendingActionForDataCenter registers the value for the provided global
+// action on behalf of the operator instance in another data center. This is
+// only available in the mock client.
+func (client *LockClient) AddPendingActionForDataCenter(action fdbv1beta2.GlobalCoordinationAction, dataCenter string, value string) error {
+	if client.pendingActions[action] == nil {
+		client.pendingActions[action] = make(map[string]string)
+	}
+	client.pendingActions[action][dataCenter] = value
+	return nil
+}
+
+// GetPendingActions returns the stored values for the provided global
+// action, keyed by the data center of the operator instance that registered
+// them.
+func (client *LockClient) GetPendingActions(action fdbv1beta2.GlobalCoordinationAction) (map[string]string, error) {
+	actions := make(map[string]string, len(client.pendingActions[action]))
+	for dataCenter, value := range client.pendingActions[action] {
+		actions[dataCenter] = value
+	}
+	return actions, nil
+}
+
+// ClearPendingActions removes the values stored by the operator instances
+// of all data centers for the provided global action.
+func (client *LockClient) ClearPendingActions(action fdbv1beta2.GlobalCoordinationAction) error {
+	delete(client.pendingActions, action)
+	return nil
+}
+
 // ReleaseLock will release the current lock. The method will only release the lock if the current
 // operator is the lock holder.
 func (client *LockClient) ReleaseLock() error {
@@ -129,7 +168,11 @@ func NewMockLockClientUncast(cluster *fdbv1beta2.FoundationDBCluster) *LockClien
 
 	client := lockClientCache[cluster.Name]
 	if client == nil {
-		client = &LockClient{cluster: cluster, pendingUpgrades: make(map[fdbv1beta2.Version]map[fdbv1beta2.ProcessGroupID]bool)}
+		client = &LockClient{
+			cluster:         cluster,
+			pendingUpgrades: make(map[fdbv1beta2.Version]map[fdbv1beta2.ProcessGroupID]bool),
+			pendingActions:  make(map[fdbv1beta2.GlobalCoordinationAction]map[string]string),
+		}
 		lockClientCache[cluster.Name] = client
 	}
 	return client
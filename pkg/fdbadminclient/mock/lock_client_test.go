@@ -107,4 +107,49 @@ var _ = Describe("lock_client_test", func() {
 			})
 		})
 	})
+
+	Describe("pending actions", func() {
+		BeforeEach(func() {
+			cluster := internal.CreateDefaultCluster()
+			cluster.Name = "pending-actions"
+			cluster.Spec.DataCenter = "primary"
+			lockClient = NewMockLockClientUncast(cluster)
+			err = lockClient.AddPendingAction(fdbv1beta2.GlobalCoordinationActionConfigureDatabase, "double ssd")
+			Expect(err).NotTo(HaveOccurred())
+			err = lockClient.AddPendingActionForDataCenter(fdbv1beta2.GlobalCoordinationActionConfigureDatabase, "remote", "triple ssd")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			ClearMockLockClients()
+		})
+
+		It("returns the values for the action", func() {
+			actions, err := lockClient.GetPendingActions(fdbv1beta2.GlobalCoordinationActionConfigureDatabase)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actions).To(Equal(map[string]string{
+				"primary": "double ssd",
+				"remote":  "triple ssd",
+			}))
+		})
+
+		It("returns no values for a different action", func() {
+			actions, err := lockClient.GetPendingActions(fdbv1beta2.GlobalCoordinationActionChangeCoordinators)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actions).To(BeEmpty())
+		})
+
+		When("clearing the action", func() {
+			BeforeEach(func() {
+				err = lockClient.ClearPendingActions(fdbv1beta2.GlobalCoordinationActionConfigureDatabase)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("removes the values of all data centers", func() {
+				actions, err := lockClient.GetPendingActions(fdbv1beta2.GlobalCoordinationActionConfigureDatabase)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actions).To(BeEmpty())
+			})
+		})
+	})
 })
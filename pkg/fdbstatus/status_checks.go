@@ -413,6 +413,90 @@ func DoCoordinatorFaultDomainCheckOnStatus(status *fdbv1beta2.FoundationDBStatus
 	return nil
 }
 
+// minimumZonesPerDataHall defines the minimum number of zones that must be available in every data hall for the
+// three_data_hall redundancy mode.
+const minimumZonesPerDataHall = 2
+
+// minimumDataHalls defines the minimum number of data halls for the three_data_hall redundancy mode.
+const minimumDataHalls = 3
+
+// DoDataHallFaultDomainCheckOnStatus does a data hall related fault domain check over the given status object. The
+// check will only be performed if the database is configured with the three_data_hall redundancy mode. The check
+// makes sure that the cluster has processes in at least 3 data halls with at least 2 zones per data hall, that the
+// coordinators still have a quorum if a single data hall fails and that log and storage servers are spread across the
+// data halls.
+func DoDataHallFaultDomainCheckOnStatus(status *fdbv1beta2.FoundationDBStatus) error {
+	if status.Cluster.DatabaseConfiguration.RedundancyMode != fdbv1beta2.RedundancyModeThreeDataHall {
+		return nil
+	}
+
+	zonesPerDataHall := map[string]map[string]fdbv1beta2.None{}
+	coordinatorsPerDataHall := map[string]int{}
+	logDataHalls := map[string]fdbv1beta2.None{}
+	storageDataHalls := map[string]fdbv1beta2.None{}
+	var coordinators int
+
+	for _, process := range status.Cluster.Processes {
+		dataHall := process.Locality[fdbv1beta2.FDBLocalityDataHallKey]
+
+		for _, role := range process.Roles {
+			switch fdbv1beta2.ProcessRole(role.Role) {
+			case fdbv1beta2.ProcessRoleCoordinator:
+				coordinators++
+				coordinatorsPerDataHall[dataHall]++
+			case fdbv1beta2.ProcessRoleLog:
+				logDataHalls[dataHall] = fdbv1beta2.None{}
+			case fdbv1beta2.ProcessRoleStorage:
+				storageDataHalls[dataHall] = fdbv1beta2.None{}
+			}
+		}
+
+		if process.Excluded || dataHall == "" {
+			continue
+		}
+
+		if _, ok := zonesPerDataHall[dataHall]; !ok {
+			zonesPerDataHall[dataHall] = map[string]fdbv1beta2.None{}
+		}
+
+		zonesPerDataHall[dataHall][process.Locality[fdbv1beta2.FDBLocalityZoneIDKey]] = fdbv1beta2.None{}
+	}
+
+	if len(zonesPerDataHall) < minimumDataHalls {
+		return fmt.Errorf("data hall check is not satisfied, expected processes in at least %d data halls, found %d data halls", minimumDataHalls, len(zonesPerDataHall))
+	}
+
+	for dataHall, zones := range zonesPerDataHall {
+		if len(zones) < minimumZonesPerDataHall {
+			return fmt.Errorf("data hall check is not satisfied, data hall %s has %d zones, expected at least %d zones", dataHall, len(zones), minimumZonesPerDataHall)
+		}
+	}
+
+	if _, ok := coordinatorsPerDataHall[""]; ok {
+		return fmt.Errorf("data hall check is not satisfied, %d coordinators have no data hall locality", coordinatorsPerDataHall[""])
+	}
+
+	// Make sure that the coordinators still have a quorum if the data hall with the most coordinators fails.
+	quorum := coordinators/2 + 1
+	for dataHall, count := range coordinatorsPerDataHall {
+		if coordinators-count < quorum {
+			return fmt.Errorf("data hall check is not satisfied, losing data hall %s would leave %d of %d coordinators, a quorum requires %d coordinators", dataHall, coordinators-count, coordinators, quorum)
+		}
+	}
+
+	delete(logDataHalls, "")
+	if len(logDataHalls) < 2 {
+		return fmt.Errorf("data hall check is not satisfied, expected log servers in at least 2 data halls, found %d data halls", len(logDataHalls))
+	}
+
+	delete(storageDataHalls, "")
+	if len(storageDataHalls) < minimumDataHalls {
+		return fmt.Errorf("data hall check is not satisfied, expected storage servers in at least %d data halls, found %d data halls", minimumDataHalls, len(storageDataHalls))
+	}
+
+	return nil
+}
+
 // DoFaultDomainChecksOnStatus does the specified fault domain check(s) over the given status object.
 // @note this is a wrapper over the above fault domain related functions.
 func DoFaultDomainChecksOnStatus(status *fdbv1beta2.FoundationDBStatus, storageServerCheck bool, logServerCheck bool, coordinatorCheck bool) error {
@@ -467,5 +551,11 @@ func HasDesiredFaultToleranceFromStatus(log logr.Logger, status *fdbv1beta2.Foun
 		return false
 	}
 
+	err = DoDataHallFaultDomainCheckOnStatus(status)
+	if err != nil {
+		log.Info("Fault domain check for data halls failed", "error", err)
+		return false
+	}
+
 	return true
 }
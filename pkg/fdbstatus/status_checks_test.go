@@ -21,6 +21,7 @@
 package fdbstatus
 
 import (
+	"fmt"
 	"github.com/go-logr/logr"
 	"net"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -1214,6 +1215,126 @@ var _ = Describe("status_checks", func() {
 			})
 		})
 
+		Context("data hall fault domain check", func() {
+			BeforeEach(func() {
+				processes := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{}
+				for _, dataHall := range []string{"az1", "az2", "az3"} {
+					for i := 1; i <= 3; i++ {
+						roles := []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleStorage)},
+							{Role: string(fdbv1beta2.ProcessRoleCoordinator)},
+						}
+
+						if i == 1 {
+							roles = append(roles, fdbv1beta2.FoundationDBStatusProcessRoleInfo{Role: string(fdbv1beta2.ProcessRoleLog)})
+						}
+
+						processGroupID := fmt.Sprintf("%s-storage-%d", dataHall, i)
+						processes[fdbv1beta2.ProcessGroupID(processGroupID)] = fdbv1beta2.FoundationDBStatusProcessInfo{
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: processGroupID,
+								fdbv1beta2.FDBLocalityZoneIDKey:     processGroupID,
+								fdbv1beta2.FDBLocalityDataHallKey:   dataHall,
+							},
+							Roles: roles,
+						}
+					}
+				}
+
+				status = &fdbv1beta2.FoundationDBStatus{
+					Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+						DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+							RedundancyMode: fdbv1beta2.RedundancyModeThreeDataHall,
+						},
+						Processes: processes,
+					},
+				}
+			})
+
+			When("the processes are spread across all data halls", func() {
+				It("should report no error", func() {
+					Expect(DoDataHallFaultDomainCheckOnStatus(status)).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the redundancy mode is not three_data_hall", func() {
+				BeforeEach(func() {
+					status.Cluster.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeTriple
+					delete(status.Cluster.Processes, "az1-storage-1")
+					delete(status.Cluster.Processes, "az1-storage-2")
+					delete(status.Cluster.Processes, "az1-storage-3")
+				})
+
+				It("should report no error", func() {
+					Expect(DoDataHallFaultDomainCheckOnStatus(status)).NotTo(HaveOccurred())
+				})
+			})
+
+			When("a data hall has no processes", func() {
+				BeforeEach(func() {
+					delete(status.Cluster.Processes, "az1-storage-1")
+					delete(status.Cluster.Processes, "az1-storage-2")
+					delete(status.Cluster.Processes, "az1-storage-3")
+				})
+
+				It("should report an error", func() {
+					err := DoDataHallFaultDomainCheckOnStatus(status)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("data hall check is not satisfied, expected processes in at least 3 data halls, found 2 data halls"))
+				})
+			})
+
+			When("a data hall has only one zone left", func() {
+				BeforeEach(func() {
+					for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"az2-storage-2", "az2-storage-3"} {
+						process := status.Cluster.Processes[processGroupID]
+						process.Excluded = true
+						status.Cluster.Processes[processGroupID] = process
+					}
+				})
+
+				It("should report an error", func() {
+					err := DoDataHallFaultDomainCheckOnStatus(status)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("data hall check is not satisfied, data hall az2 has 1 zones, expected at least 2 zones"))
+				})
+			})
+
+			When("too many coordinators are in the same data hall", func() {
+				BeforeEach(func() {
+					for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"az2-storage-1", "az2-storage-2", "az3-storage-1", "az3-storage-2"} {
+						process := status.Cluster.Processes[processGroupID]
+						process.Roles = []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleStorage)},
+						}
+						status.Cluster.Processes[processGroupID] = process
+					}
+				})
+
+				It("should report an error", func() {
+					err := DoDataHallFaultDomainCheckOnStatus(status)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("data hall check is not satisfied, losing data hall az1 would leave 2 of 5 coordinators, a quorum requires 3 coordinators"))
+				})
+			})
+
+			When("the log servers are only in one data hall", func() {
+				BeforeEach(func() {
+					for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"az2-storage-1", "az3-storage-1"} {
+						process := status.Cluster.Processes[processGroupID]
+						process.Roles = process.Roles[:2]
+						status.Cluster.Processes[processGroupID] = process
+					}
+				})
+
+				It("should report an error", func() {
+					err := DoDataHallFaultDomainCheckOnStatus(status)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("data hall check is not satisfied, expected log servers in at least 2 data halls, found 1 data halls"))
+				})
+			})
+		})
+
 		Context("multiple fault domain checks", func() {
 			BeforeEach(func() {
 				status = &fdbv1beta2.FoundationDBStatus{
@@ -21,6 +21,8 @@
 package mock
 
 import (
+	"sync"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient"
@@ -56,3 +58,43 @@ func (client *FdbPodClient) IsPresent(_ string) (bool, error) {
 func (client *FdbPodClient) GetVariableSubstitutions() (map[string]string, error) {
 	return internal.GetSubstitutionsFromClusterAndPod(client.logger, client.Cluster, client.Pod)
 }
+
+// processCommandLines contains the command lines that the mock clients will
+// report, keyed by the namespace and name of the pod.
+var processCommandLines = make(map[string]map[int]string)
+var processCommandLinesMutex sync.Mutex
+
+// MockProcessCommandLines sets the command lines that the mock client will
+// report for the running processes of the provided pod.
+func MockProcessCommandLines(pod *corev1.Pod, commandLines map[int]string) {
+	processCommandLinesMutex.Lock()
+	defer processCommandLinesMutex.Unlock()
+	processCommandLines[pod.Namespace+"/"+pod.Name] = commandLines
+}
+
+// ClearMockProcessCommandLines removes all the mocked command lines.
+func ClearMockProcessCommandLines() {
+	processCommandLinesMutex.Lock()
+	defer processCommandLinesMutex.Unlock()
+	processCommandLines = make(map[string]map[int]string)
+}
+
+// GetProcessCommandLines returns the command lines of the running fdbserver
+// processes. If no command lines were mocked for the pod, the result will be
+// nil.
+func (client *FdbPodClient) GetProcessCommandLines() (map[int]string, error) {
+	processCommandLinesMutex.Lock()
+	defer processCommandLinesMutex.Unlock()
+
+	commandLines, ok := processCommandLines[client.Pod.Namespace+"/"+client.Pod.Name]
+	if !ok {
+		return nil, nil
+	}
+
+	result := make(map[int]string, len(commandLines))
+	for processNumber, commandLine := range commandLines {
+		result[processNumber] = commandLine
+	}
+
+	return result, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * monitor_api.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podclient
+
+const (
+	// MonitorAPIVersionAnnotation is the annotation that fdb-kubernetes-monitor
+	// sets on the pod to advertise the version of its configuration API.
+	MonitorAPIVersionAnnotation = "foundationdb.org/launcher-api-version"
+
+	// MonitorAPIVersionV1 is the first version of the configuration API of
+	// fdb-kubernetes-monitor.
+	MonitorAPIVersionV1 = "v1"
+
+	// MonitorAPIConfigurationPath is the path to push a new process
+	// configuration to fdb-kubernetes-monitor.
+	MonitorAPIConfigurationPath = "api/v1/configuration"
+
+	// MonitorAPIProcessesPath is the path to read the state of the running
+	// processes from fdb-kubernetes-monitor.
+	MonitorAPIProcessesPath = "api/v1/processes"
+)
+
+// MonitorConfigurationResponse is the response of fdb-kubernetes-monitor after
+// a new process configuration was pushed.
+type MonitorConfigurationResponse struct {
+	// ConfigurationHash is the hex encoded SHA256 hash of the configuration that
+	// fdb-kubernetes-monitor has accepted as its active configuration.
+	ConfigurationHash string `json:"configurationHash"`
+
+	// Processes contains the state of the running processes.
+	Processes []MonitorProcessStatus `json:"processes,omitempty"`
+}
+
+// MonitorProcessStatus represents the state of a single fdbserver process
+// started by fdb-kubernetes-monitor.
+type MonitorProcessStatus struct {
+	// ProcessNumber is the number of the process in the pod, starting with 1.
+	ProcessNumber int `json:"processNumber"`
+
+	// CommandLine is the command line that was used to start the process.
+	CommandLine string `json:"commandLine"`
+}
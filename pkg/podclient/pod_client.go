@@ -31,4 +31,9 @@ type FdbPodClient interface {
 	// GetVariableSubstitutions gets the current keys and values that this
 	// process group will substitute into its monitor conf.
 	GetVariableSubstitutions() (map[string]string, error)
+
+	// GetProcessCommandLines returns the command lines of the running fdbserver
+	// processes, keyed by the process number. If the pod is not able to report
+	// the command lines, the result will be nil.
+	GetProcessCommandLines() (map[int]string, error)
 }
@@ -39,6 +39,7 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/fdbclient"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"gopkg.in/natefinch/lumberjack.v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
@@ -76,6 +77,7 @@ type Options struct {
 	LogFileMinAge                      time.Duration
 	GetTimeout                         time.Duration
 	PostTimeout                        time.Duration
+	BackupStatusRefreshInterval        time.Duration
 	DeprecationOptions                 internal.DeprecationOptions
 }
 
@@ -106,6 +108,7 @@ func (o *Options) BindFlags(fs *flag.FlagSet) {
 	fs.StringVar(&o.WatchNamespace, "watch-namespace", os.Getenv("WATCH_NAMESPACE"), "Defines which namespace the operator should watch.")
 	fs.DurationVar(&o.GetTimeout, "get-timeout", 5*time.Second, "http timeout for get requests to the FDB sidecar.")
 	fs.DurationVar(&o.PostTimeout, "post-timeout", 10*time.Second, "http timeout for post requests to the FDB sidecar.")
+	fs.DurationVar(&o.BackupStatusRefreshInterval, "backup-status-refresh-interval", 5*time.Minute, "Defines how often the status of a running backup is refreshed for the backup metrics. A value of 0 disables the periodic refresh.")
 	fs.BoolVar(&o.EnableRestartIncompatibleProcesses, "enable-restart-incompatible-processes", true, "This flag enables/disables in the operator to restart incompatible fdbserver processes.")
 	fs.BoolVar(&o.ServerSideApply, "server-side-apply", false, "This flag enables server side apply.")
 	fs.BoolVar(&o.EnableRecoveryState, "enable-recovery-state", true, "This flag enables the use of the recovery state for the minimum uptime between bounced if the FDB version supports it.")
@@ -123,6 +126,7 @@ func StartManager(
 	clusterReconciler *controllers.FoundationDBClusterReconciler,
 	backupReconciler *controllers.FoundationDBBackupReconciler,
 	restoreReconciler *controllers.FoundationDBRestoreReconciler,
+	disasterRecoveryReconciler *controllers.FoundationDBDisasterRecoveryReconciler,
 	logr logr.Logger,
 	watchedObjects ...client.Object) (manager.Manager, *os.File) {
 	if operatorOpts.PrintVersion {
@@ -153,6 +157,9 @@ func StartManager(
 		LeaderElection:     operatorOpts.EnableLeaderElection,
 		LeaderElectionID:   operatorOpts.LeaderElectionID,
 		Port:               9443,
+		// The backup controller only reads the secrets that are referenced by a backup, so secrets are read
+		// directly from the API server instead of caching the data of all secrets.
+		ClientDisableCacheFor: []client.Object{&corev1.Secret{}},
 	}
 
 	if operatorOpts.WatchNamespace != "" {
@@ -208,11 +215,16 @@ func StartManager(
 		backupReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
 		backupReconciler.Log = logr.WithName("controllers").WithName("FoundationDBBackup")
 		backupReconciler.ServerSideApply = operatorOpts.ServerSideApply
+		backupReconciler.StatusRefreshInterval = operatorOpts.BackupStatusRefreshInterval
 
 		if err := backupReconciler.SetupWithManager(mgr, operatorOpts.MaxConcurrentReconciles, *labelSelector); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBBackup")
 			os.Exit(1)
 		}
+
+		if operatorOpts.MetricsAddr != "0" {
+			controllers.InitBackupMetrics(backupReconciler)
+		}
 	}
 
 	if restoreReconciler != nil {
@@ -226,6 +238,27 @@ func StartManager(
 			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBRestore")
 			os.Exit(1)
 		}
+
+		if operatorOpts.MetricsAddr != "0" {
+			controllers.InitRestoreMetrics(restoreReconciler)
+		}
+	}
+
+	if disasterRecoveryReconciler != nil {
+		disasterRecoveryReconciler.Client = mgr.GetClient()
+		disasterRecoveryReconciler.Recorder = mgr.GetEventRecorderFor("foundationdbdisasterrecovery-controller")
+		disasterRecoveryReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
+		disasterRecoveryReconciler.Log = logr.WithName("controllers").WithName("FoundationDBDisasterRecovery")
+		disasterRecoveryReconciler.ServerSideApply = operatorOpts.ServerSideApply
+
+		if err := disasterRecoveryReconciler.SetupWithManager(mgr, operatorOpts.MaxConcurrentReconciles, *labelSelector); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBDisasterRecovery")
+			os.Exit(1)
+		}
+
+		if operatorOpts.MetricsAddr != "0" {
+			controllers.InitDisasterRecoveryMetrics(disasterRecoveryReconciler)
+		}
 	}
 
 	if operatorOpts.CleanUpOldLogFile {